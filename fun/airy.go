@@ -0,0 +1,80 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// constants for Airy functions
+const (
+	airyC1 = 0.355028053887817239260 // Ai(0)
+	airyC2 = 0.258819403792806798405 // -Ai'(0)
+)
+
+// AiryAi computes the Airy function Ai(x); the solution of y'' - x y = 0 that decays as x → ∞
+//   NOTE: the Maclaurin series is used for |x| ≤ 1 and the relations with the Bessel functions of
+//         order 1/3 are used otherwise; e.g. Ai(x) = sqrt(x/3) K_{1/3}(ζ) / π with ζ = 2 x^{3/2} / 3
+//   Reference:
+//   [1] Abramowitz M, Stegun IA (1972) Handbook of Mathematical Functions with Formulas, Graphs,
+//       and Mathematical Tables. U.S. Department of Commerce, NIST. Chapter 10
+func AiryAi(x float64) float64 {
+	ai, _ := airy(x)
+	return ai
+}
+
+// AiryBi computes the Airy function Bi(x); the solution of y'' - x y = 0 that grows as x → ∞.
+// See AiryAi for the methods
+func AiryBi(x float64) float64 {
+	_, bi := airy(x)
+	return bi
+}
+
+// airy computes Ai(x) and Bi(x)
+func airy(x float64) (ai, bi float64) {
+	if math.IsNaN(x) {
+		return x, x
+	}
+	if math.IsInf(x, 1) {
+		return 0, x
+	}
+	if math.IsInf(x, -1) {
+		return 0, 0
+	}
+
+	// Maclaurin series
+	if math.Abs(x) <= 1 {
+		x3 := x * x * x
+		f, g := 1.0, x
+		tf, tg := 1.0, x
+		for k := 1; k < 100; k++ {
+			fk := float64(3 * k)
+			tf *= x3 / ((fk - 1.0) * fk)
+			tg *= x3 / (fk * (fk + 1.0))
+			f += tf
+			g += tg
+			if math.Abs(tf) < 1e-17*math.Abs(f) && math.Abs(tg) < 1e-17*math.Abs(g) {
+				break
+			}
+		}
+		return airyC1*f - airyC2*g, math.Sqrt(3) * (airyC1*f + airyC2*g)
+	}
+
+	// positive x: modified Bessel functions
+	ax := math.Abs(x)
+	ζ := 2.0 * ax * math.Sqrt(ax) / 3.0
+	if x > 0 {
+		I, K := besselIK(1.0/3.0, complex(ζ, 0))
+		r := math.Sqrt(x / 3.0)
+		ai = r * real(K) / math.Pi
+		bi = r * (2.0*real(I) + 2.0/math.Pi*math.Sin(math.Pi/3.0)*real(K))
+		return
+	}
+
+	// negative x: Bessel functions
+	jp := real(BesselJ(1.0/3.0, complex(ζ, 0)))
+	jm := real(BesselJ(-1.0/3.0, complex(ζ, 0)))
+	ai = math.Sqrt(ax) / 3.0 * (jp + jm)
+	bi = math.Sqrt(ax/3.0) * (jm - jp)
+	return
+}
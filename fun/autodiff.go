@@ -0,0 +1,173 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"github.com/cpmech/gosl/la"
+)
+
+// DualSs defines a scalar function f(s) of a scalar dual argument s. Also returns error
+type DualSs func(s Dual) (Dual, error)
+
+// DualSv defines a scalar function f(v) of a vector dual argument v. Also returns error
+type DualSv func(v []Dual) (Dual, error)
+
+// DualVv defines a vector function f(v) of a vector dual argument v. Also returns error
+type DualVv func(f, v []Dual) error
+
+// HyperDualSv defines a scalar function f(v) of a vector hyper-dual argument v. Also returns error
+type HyperDualSv func(v []HyperDual) (HyperDual, error)
+
+// DualDeriv returns the (exact) derivative df/ds of f(s) as a scalar function
+func DualDeriv(f DualSs) Ss {
+	return func(s float64) (float64, error) {
+		res, err := f(DualVar(s))
+		return res.D, err
+	}
+}
+
+// DualResidual returns the vector function f(v) computed with the real parts of the dual numbers only
+//  INPUT:
+//   m    -- length of f
+//   ffcn -- function written with dual numbers
+//  NOTE: the returned function holds workspaces and must not be called concurrently
+func DualResidual(m int, ffcn DualVv) Vv {
+	var fd, vd []Dual
+	return func(f, v []float64) (err error) {
+		fd, vd = dualResize(fd, m), dualResize(vd, len(v))
+		for j := 0; j < len(v); j++ {
+			vd[j] = DualCte(v[j])
+		}
+		err = ffcn(fd, vd)
+		if err != nil {
+			return
+		}
+		for i := 0; i < m; i++ {
+			f[i] = fd[i].V
+		}
+		return
+	}
+}
+
+// DualJacobian returns the (exact) Jacobian dfdv of f(v) as a triplet function; e.g. to be used
+// with num.NlSolver instead of finite differences. The Jacobian is computed column by column
+// with one evaluation of ffcn per column and only the non-zero entries are put into the triplet
+//  INPUT:
+//   m    -- length of f
+//   ffcn -- function written with dual numbers
+//  NOTE: (1) the triplet must be initialised by the caller with enough room for the non-zeros
+//        (2) the returned function holds workspaces and must not be called concurrently
+func DualJacobian(m int, ffcn DualVv) Tv {
+	var fd, vd []Dual
+	return func(dfdv *la.Triplet, v []float64) (err error) {
+		fd, vd = dualResize(fd, m), dualResize(vd, len(v))
+		for j := 0; j < len(v); j++ {
+			vd[j] = DualCte(v[j])
+		}
+		dfdv.Start()
+		for j := 0; j < len(v); j++ {
+			vd[j].D = 1
+			err = ffcn(fd, vd)
+			if err != nil {
+				return
+			}
+			vd[j].D = 0
+			for i := 0; i < m; i++ {
+				if fd[i].D != 0 {
+					dfdv.Put(i, j, fd[i].D)
+				}
+			}
+		}
+		return
+	}
+}
+
+// DualJacobianDense returns the (exact) Jacobian dfdv of f(v) as a dense matrix function
+//  INPUT:
+//   m    -- length of f
+//   ffcn -- function written with dual numbers
+//  NOTE: the returned function holds workspaces and must not be called concurrently
+func DualJacobianDense(m int, ffcn DualVv) Mv {
+	var fd, vd []Dual
+	return func(dfdv [][]float64, v []float64) (err error) {
+		fd, vd = dualResize(fd, m), dualResize(vd, len(v))
+		for j := 0; j < len(v); j++ {
+			vd[j] = DualCte(v[j])
+		}
+		for j := 0; j < len(v); j++ {
+			vd[j].D = 1
+			err = ffcn(fd, vd)
+			if err != nil {
+				return
+			}
+			vd[j].D = 0
+			for i := 0; i < m; i++ {
+				dfdv[i][j] = fd[i].D
+			}
+		}
+		return
+	}
+}
+
+// DualGradient returns the (exact) gradient df/dv of the scalar function f(v) as a vector function
+//  NOTE: the returned function holds workspaces and must not be called concurrently
+func DualGradient(f DualSv) Vv {
+	var vd []Dual
+	return func(g, v []float64) (err error) {
+		vd = dualResize(vd, len(v))
+		for j := 0; j < len(v); j++ {
+			vd[j] = DualCte(v[j])
+		}
+		var res Dual
+		for j := 0; j < len(v); j++ {
+			vd[j].D = 1
+			res, err = f(vd)
+			if err != nil {
+				return
+			}
+			vd[j].D = 0
+			g[j] = res.D
+		}
+		return
+	}
+}
+
+// HyperDualHessian returns the (exact) Hessian d²f/dv² of the scalar function f(v) as a dense
+// matrix function. The symmetry of the Hessian is used, thus n(n+1)/2 evaluations of f are needed
+//  NOTE: the returned function holds workspaces and must not be called concurrently
+func HyperDualHessian(f HyperDualSv) Mv {
+	var vd []HyperDual
+	return func(H [][]float64, v []float64) (err error) {
+		if len(vd) != len(v) {
+			vd = make([]HyperDual, len(v))
+		}
+		for j := 0; j < len(v); j++ {
+			vd[j] = HyperDualCte(v[j])
+		}
+		var res HyperDual
+		for i := 0; i < len(v); i++ {
+			vd[i].D1 = 1
+			for j := i; j < len(v); j++ {
+				vd[j].D2 = 1
+				res, err = f(vd)
+				if err != nil {
+					return
+				}
+				vd[j].D2 = 0
+				H[i][j], H[j][i] = res.D12, res.D12
+			}
+			vd[i].D1 = 0
+		}
+		return
+	}
+}
+
+// dualResize returns a slice of dual numbers with length n, reusing x if possible
+func dualResize(x []Dual, n int) []Dual {
+	if len(x) != n {
+		return make([]Dual, n)
+	}
+	return x
+}
@@ -4,6 +4,8 @@
 
 package fun
 
+import "math"
+
 // max returns the max between two floats
 func max(a, b float64) float64 {
 	if a > b {
@@ -43,3 +45,78 @@ func iabs(a int) int {
 	}
 	return a
 }
+
+// gaussLegendre01 computes the n nodes and weights of the Gauss-Legendre quadrature on [0, 1]
+func gaussLegendre01(n int) (x, w []float64) {
+	x = make([]float64, n)
+	w = make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var pp float64
+		for it := 0; it < 100; it++ {
+			p1, p2 := 1.0, 0.0
+			for j := 0; j < n; j++ {
+				p1, p2 = ((2.0*float64(j)+1.0)*z*p1-float64(j)*p2)/float64(j+1), p1
+			}
+			pp = float64(n) * (z*p1 - p2) / (z*z - 1.0)
+			z1 := z
+			z = z1 - p1/pp
+			if math.Abs(z-z1) < 1e-15 {
+				break
+			}
+		}
+		x[i], x[n-1-i] = (1.0-z)/2.0, (1.0+z)/2.0
+		w[i] = 1.0 / ((1.0 - z*z) * pp * pp)
+		w[n-1-i] = w[i]
+	}
+	return
+}
+
+// sinpi computes sin(π x) with exact zeros at the integers
+func sinpi(x float64) float64 {
+	r := math.Mod(x, 2.0) // r ∈ (-2, 2)
+	switch {
+	case r == 0 || r == 1 || r == -1:
+		return 0
+	case r == 0.5 || r == -1.5:
+		return 1
+	case r == -0.5 || r == 1.5:
+		return -1
+	}
+	return math.Sin(math.Pi * r)
+}
+
+// cospi computes cos(π x) with exact zeros at the half-integers
+func cospi(x float64) float64 {
+	return sinpi(x + 0.5)
+}
+
+// isNonPosInt tells whether x is zero or a negative integer
+func isNonPosInt(x float64) bool {
+	return x <= 0 && x == math.Floor(x)
+}
+
+// gammaRatio computes Π Γ(num[i]) / Π Γ(den[i]) using logarithms. Poles in the denominator
+// yield zero whereas poles in the numerator yield ±Inf
+func gammaRatio(num, den []float64) float64 {
+	for _, d := range den {
+		if isNonPosInt(d) {
+			return 0
+		}
+	}
+	s, sgn := 0.0, 1.0
+	for _, a := range num {
+		if isNonPosInt(a) {
+			return math.Inf(1)
+		}
+		l, g := math.Lgamma(a)
+		s += l
+		sgn *= float64(g)
+	}
+	for _, d := range den {
+		l, g := math.Lgamma(d)
+		s -= l
+		sgn *= float64(g)
+	}
+	return sgn * math.Exp(s)
+}
@@ -0,0 +1,336 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// 20-point Gauss-Legendre rule on [0, 1] used by the integral representations
+var besselX, besselW = gaussLegendre01(20)
+
+// BesselJ computes the Bessel function of the first kind J_ν(z) of real order ν and complex
+// argument z (principal branch: -π < arg z ≤ π)
+//   NOTE: the following methods are employed (after mapping Re z < 0 to Re z > 0 and ν < 0 to ν > 0)
+//     (1) power series for |z| ≤ max(4, ν)
+//     (2) Hankel's asymptotic expansion for |z| ≥ max(20, ν²)
+//     (3) Schläfli's integral representations for |arg z| ≤ π/4
+//     (4) integral representations of the modified Bessel functions I_ν and K_ν otherwise
+//   The absolute accuracy of (3) is about 1e-16 exp(|Im z|); thus, the relative accuracy is
+//   reduced if |Im z| is large and the result is much smaller than exp(|Im z|)
+//   Reference:
+//   [1] Abramowitz M, Stegun IA (1972) Handbook of Mathematical Functions with Formulas, Graphs,
+//       and Mathematical Tables. U.S. Department of Commerce, NIST. Chapter 9
+func BesselJ(ν float64, z complex128) complex128 {
+	J, _ := besselJY(ν, z)
+	return J
+}
+
+// BesselY computes the Bessel function of the second kind Y_ν(z) of real order ν and complex
+// argument z (principal branch: -π < arg z ≤ π). See BesselJ for the methods
+//   NOTE: Y_ν(0) = -Inf
+func BesselY(ν float64, z complex128) complex128 {
+	_, Y := besselJY(ν, z)
+	return Y
+}
+
+// SphBesselJ computes the spherical Bessel function of the first kind
+//
+//      jₙ(x) = sqrt(π / (2x)) J_{n+1/2}(x)
+//
+//   NOTE: returns NaN if n < 0
+func SphBesselJ(n int, x float64) float64 {
+	if n < 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	if x < 0 {
+		if n%2 == 0 {
+			return SphBesselJ(n, -x)
+		}
+		return -SphBesselJ(n, -x)
+	}
+	return math.Sqrt(math.Pi/(2.0*x)) * real(BesselJ(float64(n)+0.5, complex(x, 0)))
+}
+
+// SphBesselY computes the spherical Bessel function of the second kind
+//
+//      yₙ(x) = sqrt(π / (2x)) Y_{n+1/2}(x)
+//
+//   NOTE: returns NaN if n < 0 and -Inf if x = 0
+func SphBesselY(n int, x float64) float64 {
+	if n < 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		return math.Inf(-1)
+	}
+	if x < 0 {
+		if n%2 == 0 {
+			return -SphBesselY(n, -x)
+		}
+		return SphBesselY(n, -x)
+	}
+	return math.Sqrt(math.Pi/(2.0*x)) * real(BesselY(float64(n)+0.5, complex(x, 0)))
+}
+
+// besselJY computes J_ν(z) and Y_ν(z) for any real ν and complex z
+func besselJY(ν float64, z complex128) (J, Y complex128) {
+	if math.IsNaN(ν) || cmplx.IsNaN(z) {
+		return cmplx.NaN(), cmplx.NaN()
+	}
+
+	// negative order: J_{-μ} = cos(μπ) J_μ - sin(μπ) Y_μ  and  Y_{-μ} = sin(μπ) J_μ + cos(μπ) Y_μ
+	if ν < 0 {
+		J, Y = besselJY(-ν, z)
+		c, s := complex(cospi(-ν), 0), complex(sinpi(-ν), 0)
+		if s == 0 {
+			return c * J, c * Y
+		}
+		return c*J - s*Y, s*J + c*Y
+	}
+
+	// zero argument
+	if z == 0 {
+		if ν == 0 {
+			return 1, complex(math.Inf(-1), 0)
+		}
+		return 0, complex(math.Inf(-1), 0)
+	}
+
+	// left half-plane: z = ζ exp(±iπ) with Re ζ > 0
+	if real(z) < 0 {
+		J, Y = besselJYright(ν, -z)
+		c := complex(0, 2.0*cospi(ν))
+		if imag(z) >= 0 {
+			e := complex(cospi(ν), sinpi(ν)) // exp(iνπ)
+			return e * J, Y/e + c*J
+		}
+		e := complex(cospi(ν), -sinpi(ν)) // exp(-iνπ)
+		return e * J, Y/e - c*J
+	}
+	return besselJYright(ν, z)
+}
+
+// besselJYright computes J_ν(z) and Y_ν(z) for ν ≥ 0 and Re z ≥ 0
+func besselJYright(ν float64, z complex128) (J, Y complex128) {
+	az := cmplx.Abs(z)
+	if az >= math.Max(20, ν*ν) {
+		return besselHankel(ν, z)
+	}
+	θ := cmplx.Phase(z)
+	if math.Abs(θ) <= math.Pi/4 {
+		J, Y = besselJYint(ν, z)
+		if az <= math.Max(4, ν) {
+			J = besselJser(ν, z)
+		}
+		return
+	}
+	if θ > 0 { // z = i w
+		I, K := besselIK(ν, complex(imag(z), -real(z)))
+		J = complex(cospi(ν/2), sinpi(ν/2)) * I
+		H1 := complex(0, -2.0/math.Pi) * complex(cospi(ν/2), -sinpi(ν/2)) * K
+		return J, (H1 - J) / complex(0, 1)
+	}
+	I, K := besselIK(ν, complex(-imag(z), real(z))) // z = -i w
+	J = complex(cospi(ν/2), -sinpi(ν/2)) * I
+	H2 := complex(0, 2.0/math.Pi) * complex(cospi(ν/2), sinpi(ν/2)) * K
+	return J, (J - H2) / complex(0, 1)
+}
+
+// besselJser computes J_ν(z) by the power series
+func besselJser(ν float64, z complex128) complex128 {
+	lg, sg := math.Lgamma(ν + 1.0)
+	t := complex(float64(sg), 0) * cmplx.Exp(complex(ν, 0)*cmplx.Log(z/2)-complex(lg, 0))
+	q := -z * z / 4
+	sum := t
+	for k := 1; k < 1000; k++ {
+		t *= q / complex(float64(k)*(ν+float64(k)), 0)
+		sum += t
+		if cmplx.Abs(t) < 1e-17*cmplx.Abs(sum) {
+			break
+		}
+	}
+	return sum
+}
+
+// besselJYint computes J_ν(z) and Y_ν(z) for ν ≥ 0 and |arg z| ≤ π/4 by Schläfli's integrals
+//
+//      J_ν(z) = (1/π) ∫₀^π cos(νθ - z sinθ) dθ - (sin νπ / π) ∫₀^∞ exp(-z sinh t - νt) dt
+//      Y_ν(z) = (1/π) ∫₀^π sin(z sinθ - νθ) dθ - (1/π) ∫₀^∞ (exp(νt) + exp(-νt) cos νπ) exp(-z sinh t) dt
+//
+func besselJYint(ν float64, z complex128) (J, Y complex128) {
+	az := cmplx.Abs(z)
+	var c1, s1 complex128
+	n := int((az+ν)/2.0) + 1
+	h := math.Pi / float64(n)
+	for p := 0; p < n; p++ {
+		for j, x := range besselX {
+			θ := h * (float64(p) + x)
+			φ := z*complex(math.Sin(θ), 0) - complex(ν*θ, 0)
+			c1 += complex(h*besselW[j], 0) * cmplx.Cos(φ)
+			s1 += complex(h*besselW[j], 0) * cmplx.Sin(φ)
+		}
+	}
+	cosνπ := cospi(ν)
+	tj, ty := besselTail(func(t float64) (complex128, complex128) {
+		e := cmplx.Exp(-z * complex(math.Sinh(t), 0))
+		ep, em := math.Exp(ν*t), math.Exp(-ν*t)
+		return e * complex(em, 0), e * complex(ep+em*cosνπ, 0)
+	}, func(t float64) float64 {
+		return ν*t - real(z)*math.Sinh(t)
+	}, func(t float64) float64 {
+		return az*math.Cosh(t) + ν
+	})
+	J = (c1 - complex(sinpi(ν), 0)*tj) / math.Pi
+	Y = (s1 - ty) / math.Pi
+	return
+}
+
+// besselIK computes the modified Bessel functions I_ν(w) and K_ν(w) for ν ≥ 0 and |arg w| ≤ π/4
+//
+//      I_ν(w) = (1/π) ∫₀^π exp(w cosθ) cos νθ dθ - (sin νπ / π) ∫₀^∞ exp(-w cosh t - νt) dt
+//      K_ν(w) = ∫₀^∞ exp(-w cosh t) cosh νt dt
+//
+func besselIK(ν float64, w complex128) (I, K complex128) {
+	aw := cmplx.Abs(w)
+
+	// asymptotic expansions
+	if aw >= math.Max(20, ν*ν) {
+		var si, sk complex128
+		t := complex(1, 0)
+		prev := math.Inf(1)
+		for k := 0; k < 200; k++ {
+			if k > 0 {
+				f := float64(2*k - 1)
+				t *= complex((4.0*ν*ν-f*f)/(8.0*float64(k)), 0) / w
+			}
+			at := cmplx.Abs(t)
+			if at > prev {
+				break
+			}
+			prev = at
+			sk += t
+			if k%2 == 0 {
+				si += t
+			} else {
+				si -= t
+			}
+			if at < 1e-17 {
+				break
+			}
+		}
+		I = cmplx.Exp(w) / cmplx.Sqrt(2*math.Pi*w) * si
+		K = cmplx.Sqrt(math.Pi/(2*w)) * cmplx.Exp(-w) * sk
+		return
+	}
+
+	// I: power series or integral representation
+	if aw <= math.Max(8, ν) {
+		lg, sg := math.Lgamma(ν + 1.0)
+		t := complex(float64(sg), 0) * cmplx.Exp(complex(ν, 0)*cmplx.Log(w/2)-complex(lg, 0))
+		q := w * w / 4
+		I = t
+		for k := 1; k < 1000; k++ {
+			t *= q / complex(float64(k)*(ν+float64(k)), 0)
+			I += t
+			if cmplx.Abs(t) < 1e-17*cmplx.Abs(I) {
+				break
+			}
+		}
+	} else {
+		n := int((aw+ν)/2.0) + 1
+		h := math.Pi / float64(n)
+		for p := 0; p < n; p++ {
+			for j, x := range besselX {
+				θ := h * (float64(p) + x)
+				I += complex(h*besselW[j]*math.Cos(ν*θ), 0) * cmplx.Exp(w*complex(math.Cos(θ), 0))
+			}
+		}
+		var ti complex128
+		if sinpi(ν) != 0 {
+			ti, _ = besselTail(func(t float64) (complex128, complex128) {
+				return cmplx.Exp(-w*complex(math.Cosh(t), 0) - complex(ν*t, 0)), 0
+			}, func(t float64) float64 {
+				return -ν*t - real(w)*math.Cosh(t)
+			}, func(t float64) float64 {
+				return aw*math.Sinh(t) + ν
+			})
+		}
+		I = (I - complex(sinpi(ν), 0)*ti) / math.Pi
+	}
+
+	// K: integral representation
+	K, _ = besselTail(func(t float64) (complex128, complex128) {
+		return cmplx.Exp(-w*complex(math.Cosh(t), 0)) * complex(math.Cosh(ν*t), 0), 0
+	}, func(t float64) float64 {
+		return ν*t - real(w)*math.Cosh(t)
+	}, func(t float64) float64 {
+		return aw*math.Sinh(t) + ν
+	})
+	return
+}
+
+// besselHankel computes J_ν(z) and Y_ν(z) for ν ≥ 0, Re z ≥ 0 and large |z| by Hankel's
+// asymptotic expansions of H¹_ν(z) and H²_ν(z)
+func besselHankel(ν float64, z complex128) (J, Y complex128) {
+	var s1, s2 complex128
+	t := complex(1, 0)
+	ik := complex(1, 0) // iᵏ
+	prev := math.Inf(1)
+	for k := 0; k < 200; k++ {
+		if k > 0 {
+			f := float64(2*k - 1)
+			t *= complex((4.0*ν*ν-f*f)/(8.0*float64(k)), 0) / z
+			ik *= complex(0, 1)
+		}
+		at := cmplx.Abs(t)
+		if at > prev {
+			break
+		}
+		prev = at
+		s1 += ik * t
+		s2 += cmplx.Conj(ik) * t
+		if at < 1e-17 {
+			break
+		}
+	}
+	ω := z - complex((ν/2.0+0.25)*math.Pi, 0)
+	f := cmplx.Sqrt(2.0 / (math.Pi * z))
+	H1 := f * cmplx.Exp(complex(0, 1)*ω) * s1
+	H2 := f * cmplx.Exp(complex(0, -1)*ω) * s2
+	return (H1 + H2) / 2, (H1 - H2) / complex(0, 2)
+}
+
+// besselTail integrates two functions f(t) = (f1, f2) on [0, ∞) by composite Gauss-Legendre
+// quadrature. The size of the panels is controlled by rate(t), an estimate of the rate of change
+// of the integrand, and the integration stops when logmag(t), an estimate of the logarithm of the
+// magnitude of the integrand, has decreased enough beyond its maximum
+func besselTail(f func(t float64) (complex128, complex128), logmag, rate func(t float64) float64) (sum1, sum2 complex128) {
+	t := 0.0
+	mmax := logmag(0)
+	for it := 0; it < 100000; it++ {
+		h := math.Min(0.5, 2.0/rate(t))
+		for j, x := range besselX {
+			f1, f2 := f(t + h*x)
+			sum1 += complex(h*besselW[j], 0) * f1
+			sum2 += complex(h*besselW[j], 0) * f2
+		}
+		t += h
+		m := logmag(t)
+		if m > mmax {
+			mmax = m
+		} else if m < mmax-45 {
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,450 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fft"
+	"github.com/cpmech/gosl/la"
+)
+
+// Chebfun holds a Chebyshev approximation of a function on the interval [A, B]
+//
+//                 N
+//          f(x) = Σ  cₖ Tₖ(t)     with     t = (2 x - A - B) / (B - A)  ∈  [-1, 1]
+//                k=0
+//
+//   The approximation is computed by interpolation at the Chebyshev-Gauss-Lobatto points
+//   tⱼ = cos(π j / N) with the coefficients obtained by a discrete cosine transform (type I). The
+//   adaptive constructor doubles N until the trailing coefficients fall below the tolerance
+//   (relative to the magnitude of f); thus, smooth functions are approximated to machine precision.
+//   The approximations can then be differentiated, integrated, combined and their roots, maxima
+//   and minima are computed
+//
+//   Reference:
+//   [1] Trefethen LN (2013) Approximation Theory and Approximation Practice. SIAM. 305p
+//   [2] Battles Z, Trefethen LN (2004) An extension of MATLAB to continuous functions and
+//       operators. SIAM Journal on Scientific Computing, 25(5):1743-1770
+type Chebfun struct {
+	A float64   // left end of interval
+	B float64   // right end of interval
+	C []float64 // [N+1] Chebyshev coefficients
+}
+
+// ChebfunTol is the default tolerance of the adaptive construction of Chebfuns
+var ChebfunTol = 1e-15
+
+// ChebfunNmax is the maximum degree in the adaptive construction of Chebfuns
+var ChebfunNmax = 65536
+
+// NewChebfun computes the Chebyshev approximation of f on [a, b] adaptively; i.e. with N = 16,
+// 32, 64, ... until the approximation converges to the tolerance ChebfunTol
+func NewChebfun(f Ss, a, b float64) (o *Chebfun, err error) {
+	if b <= a {
+		return nil, chk.Err("interval must have b > a. [%g, %g] is invalid\n", a, b)
+	}
+	for N := 16; N <= ChebfunNmax; N *= 2 {
+		var vals []float64
+		vals, err = chebfunSample(f, a, b, N)
+		if err != nil {
+			return
+		}
+		var c []float64
+		c, err = chebfunCoefs(vals)
+		if err != nil {
+			return
+		}
+		vscale := 0.0
+		for _, v := range vals {
+			vscale = math.Max(vscale, math.Abs(v))
+		}
+		tail := imax(4, N/16)
+		converged := true
+		for k := N - tail + 1; k <= N; k++ {
+			if math.Abs(c[k]) > ChebfunTol*vscale {
+				converged = false
+				break
+			}
+		}
+		if converged {
+			o = &Chebfun{a, b, c}
+			o.chop(ChebfunTol * vscale)
+			return
+		}
+	}
+	return nil, chk.Err("Chebyshev approximation did not converge with N=%d on [%g, %g]\n", ChebfunNmax, a, b)
+}
+
+// NewChebfunDeg computes the Chebyshev interpolant of f on [a, b] with degree N (i.e. with N+1
+// Chebyshev-Gauss-Lobatto points)
+func NewChebfunDeg(f Ss, a, b float64, N int) (o *Chebfun, err error) {
+	if b <= a {
+		return nil, chk.Err("interval must have b > a. [%g, %g] is invalid\n", a, b)
+	}
+	if N < 1 {
+		return nil, chk.Err("degree must be at least 1. N=%d is invalid\n", N)
+	}
+	vals, err := chebfunSample(f, a, b, N)
+	if err != nil {
+		return
+	}
+	c, err := chebfunCoefs(vals)
+	if err != nil {
+		return
+	}
+	return &Chebfun{a, b, c}, nil
+}
+
+// NewChebfunCoef allocates a Chebfun with given coefficients
+func NewChebfunCoef(a, b float64, c []float64) (o *Chebfun) {
+	if b <= a {
+		chk.Panic("interval must have b > a. [%g, %g] is invalid\n", a, b)
+	}
+	if len(c) < 1 {
+		c = []float64{0}
+	}
+	o = &Chebfun{a, b, make([]float64, len(c))}
+	copy(o.C, c)
+	return
+}
+
+// Degree returns the degree N of the approximation
+func (o *Chebfun) Degree() int {
+	return len(o.C) - 1
+}
+
+// F evaluates the approximation at x using Clenshaw's algorithm
+func (o *Chebfun) F(x float64) float64 {
+	t := (2.0*x - o.A - o.B) / (o.B - o.A)
+	var b1, b2 float64
+	for k := len(o.C) - 1; k >= 1; k-- {
+		b1, b2 = 2.0*t*b1-b2+o.C[k], b1
+	}
+	return t*b1 - b2 + o.C[0]
+}
+
+// Ss returns the approximation as a scalar function of a scalar (e.g. for composition)
+func (o *Chebfun) Ss() Ss {
+	return func(x float64) (float64, error) {
+		return o.F(x), nil
+	}
+}
+
+// Deriv returns the derivative df/dx
+func (o *Chebfun) Deriv() *Chebfun {
+	N := len(o.C) - 1
+	if N == 0 {
+		return NewChebfunCoef(o.A, o.B, []float64{0})
+	}
+	d := make([]float64, N+1) // d[N] = 0
+	for k := N; k >= 1; k-- {
+		d[k-1] = 2.0 * float64(k) * o.C[k]
+		if k+1 <= N {
+			d[k-1] += d[k+1]
+		}
+	}
+	d[0] /= 2.0
+	scale := 2.0 / (o.B - o.A)
+	for k := 0; k < N; k++ {
+		d[k] *= scale
+	}
+	return &Chebfun{o.A, o.B, d[:N]}
+}
+
+// Integral returns the indefinite integral F(x) = ∫_A^x f(s) ds
+func (o *Chebfun) Integral() *Chebfun {
+	N := len(o.C) - 1
+	c := make([]float64, N+3) // padded with zeros
+	copy(c, o.C)
+	r := make([]float64, N+2)
+	for k := 1; k <= N+1; k++ {
+		if k == 1 {
+			r[1] = c[0] - c[2]/2.0
+		} else {
+			r[k] = (c[k-1] - c[k+1]) / (2.0 * float64(k))
+		}
+	}
+	sgn := -1.0
+	for k := 1; k <= N+1; k++ { // F(A) = Σ rₖ (-1)ᵏ = 0
+		r[0] -= sgn * r[k]
+		sgn = -sgn
+	}
+	scale := (o.B - o.A) / 2.0
+	for k := 0; k <= N+1; k++ {
+		r[k] *= scale
+	}
+	return &Chebfun{o.A, o.B, r}
+}
+
+// Sum returns the definite integral ∫_A^B f(x) dx (Clenshaw-Curtis)
+func (o *Chebfun) Sum() (res float64) {
+	for k := 0; k < len(o.C); k += 2 {
+		res += 2.0 * o.C[k] / (1.0 - float64(k*k))
+	}
+	return res * (o.B - o.A) / 2.0
+}
+
+// Roots returns the (sorted) real roots of f in [A, B]. The roots are the eigenvalues of the
+// colleague matrix; for degrees greater than 50, the interval is recursively subdivided
+//   Reference:
+//   [1] Boyd JP (2002) Computing zeros on a real interval through Chebyshev expansion and
+//       polynomial rootfinding. SIAM Journal on Numerical Analysis, 40(5):1666-1682
+func (o *Chebfun) Roots() (roots []float64, err error) {
+	roots, err = o.roots()
+	if err != nil {
+		return
+	}
+	sort.Float64s(roots)
+	tol := 1e-12 * (o.B - o.A)
+	unique := roots[:0]
+	for i, r := range roots {
+		if i == 0 || r-unique[len(unique)-1] > tol {
+			unique = append(unique, r)
+		}
+	}
+	return unique, nil
+}
+
+// Max returns the location and value of the global maximum of f in [A, B]
+func (o *Chebfun) Max() (xmax, fmax float64, err error) {
+	return o.extremum(1)
+}
+
+// Min returns the location and value of the global minimum of f in [A, B]
+func (o *Chebfun) Min() (xmin, fmin float64, err error) {
+	xmin, fmin, err = o.extremum(-1)
+	return
+}
+
+// Scale returns α⋅f
+func (o *Chebfun) Scale(α float64) *Chebfun {
+	r := NewChebfunCoef(o.A, o.B, o.C)
+	for k := range r.C {
+		r.C[k] *= α
+	}
+	return r
+}
+
+// Add returns f + g
+func (o *Chebfun) Add(g *Chebfun) (r *Chebfun, err error) {
+	return o.combine(g, 1)
+}
+
+// Sub returns f - g
+func (o *Chebfun) Sub(g *Chebfun) (r *Chebfun, err error) {
+	return o.combine(g, -1)
+}
+
+// Mul returns f⋅g computed exactly with Tₘ Tₙ = (Tₘ₊ₙ + T|ₘ₋ₙ|) / 2
+func (o *Chebfun) Mul(g *Chebfun) (r *Chebfun, err error) {
+	err = o.checkInterval(g)
+	if err != nil {
+		return
+	}
+	r = &Chebfun{o.A, o.B, make([]float64, len(o.C)+len(g.C)-1)}
+	for m, cm := range o.C {
+		for n, cn := range g.C {
+			r.C[m+n] += cm * cn / 2.0
+			r.C[iabs(m-n)] += cm * cn / 2.0
+		}
+	}
+	r.chop(ChebfunTol * r.vscale())
+	return
+}
+
+// Div returns f/g computed adaptively
+func (o *Chebfun) Div(g *Chebfun) (r *Chebfun, err error) {
+	err = o.checkInterval(g)
+	if err != nil {
+		return
+	}
+	return NewChebfun(func(x float64) (float64, error) {
+		return o.F(x) / g.F(x), nil
+	}, o.A, o.B)
+}
+
+// Compose returns g(f(x)) computed adaptively. g may be another Chebfun; e.g. h.Ss()
+func (o *Chebfun) Compose(g Ss) (r *Chebfun, err error) {
+	return NewChebfun(func(x float64) (float64, error) {
+		return g(o.F(x))
+	}, o.A, o.B)
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// chebfunSample computes the values of f at the N+1 Chebyshev-Gauss-Lobatto points xⱼ mapped from
+// tⱼ = cos(π j / N)
+func chebfunSample(f Ss, a, b float64, N int) (vals []float64, err error) {
+	vals = make([]float64, N+1)
+	for j := 0; j <= N; j++ {
+		t := math.Cos(math.Pi * float64(j) / float64(N))
+		vals[j], err = f((a+b)/2.0 + (b-a)/2.0*t)
+		if err != nil {
+			return
+		}
+		if math.IsNaN(vals[j]) || math.IsInf(vals[j], 0) {
+			return nil, chk.Err("function value at x=%g is not finite\n", (a+b)/2.0+(b-a)/2.0*t)
+		}
+	}
+	return
+}
+
+// chebfunCoefs computes the Chebyshev coefficients from the values at the Chebyshev-Gauss-Lobatto
+// points using the DCT-I
+//                        N
+//          cₖ = (2/N)  Σ''  fⱼ cos(π j k / N)   (c₀ and c_N halved;  Σ'' => first and last halved)
+//                       j=0
+//   The DCT-I is computed with fft.Dct; thus N may be any number ≥ 1
+func chebfunCoefs(vals []float64) (c []float64, err error) {
+	N := len(vals) - 1
+	c, err = fft.Dct(vals, 1)
+	if err != nil {
+		return
+	}
+	for k := 0; k <= N; k++ {
+		c[k] /= float64(N)
+	}
+	c[0] /= 2.0
+	c[N] /= 2.0
+	return
+}
+
+// chop removes trailing coefficients smaller than tol
+func (o *Chebfun) chop(tol float64) {
+	n := len(o.C)
+	for n > 1 && math.Abs(o.C[n-1]) <= tol {
+		n--
+	}
+	o.C = o.C[:n]
+}
+
+// vscale returns an estimate of max|f|
+func (o *Chebfun) vscale() (res float64) {
+	for _, c := range o.C {
+		res += math.Abs(c)
+	}
+	return
+}
+
+// checkInterval checks whether f and g are defined on the same interval
+func (o *Chebfun) checkInterval(g *Chebfun) (err error) {
+	if o.A != g.A || o.B != g.B {
+		return chk.Err("intervals must be equal. [%g, %g] != [%g, %g]\n", o.A, o.B, g.A, g.B)
+	}
+	return
+}
+
+// combine returns f + α⋅g
+func (o *Chebfun) combine(g *Chebfun, α float64) (r *Chebfun, err error) {
+	err = o.checkInterval(g)
+	if err != nil {
+		return
+	}
+	r = &Chebfun{o.A, o.B, make([]float64, imax(len(o.C), len(g.C)))}
+	copy(r.C, o.C)
+	for k, c := range g.C {
+		r.C[k] += α * c
+	}
+	r.chop(ChebfunTol * math.Max(o.vscale(), g.vscale()))
+	return
+}
+
+// extremum returns the global maximum (sign = 1) or minimum (sign = -1)
+func (o *Chebfun) extremum(sign float64) (xext, fext float64, err error) {
+	candidates, err := o.Deriv().Roots()
+	if err != nil {
+		return
+	}
+	candidates = append(candidates, o.A, o.B)
+	fext = math.Inf(-1)
+	for _, x := range candidates {
+		if fx := sign * o.F(x); fx > fext {
+			xext, fext = x, fx
+		}
+	}
+	fext *= sign
+	return
+}
+
+// roots computes the roots in [A, B] (unsorted)
+func (o *Chebfun) roots() (roots []float64, err error) {
+
+	// trim tiny leading coefficients
+	c := o.C
+	vs := o.vscale()
+	n := len(c) - 1
+	for n > 0 && math.Abs(c[n]) <= 1e-15*vs {
+		n--
+	}
+	if n == 0 {
+		return
+	}
+
+	// subdivide
+	if n > 50 {
+		mid := o.A + 0.4917*(o.B-o.A) // slightly off-centre to avoid roots at the midpoint
+		for _, ab := range [][]float64{{o.A, mid}, {mid, o.B}} {
+			var sub *Chebfun
+			sub, err = NewChebfunDeg(o.Ss(), ab[0], ab[1], n)
+			if err != nil {
+				return
+			}
+			sub.chop(1e-15 * vs)
+			var r []float64
+			r, err = sub.roots()
+			if err != nil {
+				return
+			}
+			roots = append(roots, r...)
+		}
+		return
+	}
+
+	// linear
+	var ts []float64
+	if n == 1 {
+		ts = []float64{-c[0] / c[1]}
+	} else {
+
+		// colleague matrix (transposed; upper Hessenberg)
+		H := make([][]float64, n)
+		for i := 0; i < n; i++ {
+			H[i] = make([]float64, n)
+		}
+		H[1][0] = 1
+		for k := 1; k < n-1; k++ {
+			H[k-1][k] = 0.5
+			H[k+1][k] = 0.5
+		}
+		H[n-2][n-1] = 0.5
+		for k := 0; k < n; k++ {
+			H[k][n-1] -= c[k] / (2.0 * c[n])
+		}
+
+		// eigenvalues
+		var λ []complex128
+		λ, err = la.HessenbergEigen(H)
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			if math.Abs(imag(λ[i])) < 1e-8 {
+				ts = append(ts, real(λ[i]))
+			}
+		}
+	}
+
+	// map to [A, B]
+	for _, t := range ts {
+		if t >= -1.0-1e-8 && t <= 1.0+1e-8 {
+			t = math.Max(-1, math.Min(1, t))
+			roots = append(roots, (o.A+o.B)/2.0+(o.B-o.A)/2.0*t)
+		}
+	}
+	return
+}
@@ -0,0 +1,569 @@
+# Copyright 2016 The Gosl Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+# Generates high-precision reference tables (hp-*.cmp) for the special functions in fun.
+#
+# Only Python's standard library is used: all values are computed with the decimal module using
+# convergent series (or Euler-Maclaurin and Stirling expansions) with 50 to 150 significant digits,
+# depending on the cancellation of each series. Thus, the tables are accurate to all printed digits.
+#
+# Reference:
+# [1] Abramowitz M, Stegun IA (1972) Handbook of Mathematical Functions with Formulas, Graphs,
+#     and Mathematical Tables. U.S. Department of Commerce, NIST
+# [2] Olver FWJ et al. (2010) NIST Handbook of Mathematical Functions. Cambridge University Press
+
+from decimal import Decimal as D, getcontext, localcontext
+from fractions import Fraction as F
+
+PREC = 60
+getcontext().prec = PREC
+
+# constants and elementary functions ##################################################################
+
+def pi():
+    with localcontext() as ctx:
+        ctx.prec += 5
+        three = D(3)
+        lasts, t, s, n, na, d, da = 0, three, 3, 1, 0, 0, 24
+        while s != lasts:
+            lasts = s
+            n, na = n + na, na + 8
+            d, da = d + da, da + 32
+            t = (t * n) / d
+            s += t
+    return +s
+
+def sin_cos(x):
+    with localcontext() as ctx:
+        ctx.prec += 10
+        p = pi()
+        k = (x / (2 * p)).to_integral_value()
+        x = x - k * 2 * p
+        s, c, t, i = D(0), D(0), D(1), 0
+        while True: # t = xⁱ/i!
+            if i % 4 == 0: c += t
+            if i % 4 == 1: s += t
+            if i % 4 == 2: c -= t
+            if i % 4 == 3: s -= t
+            i += 1
+            t = t * x / i
+            if abs(t) < D(10) ** (-ctx.prec - 5):
+                break
+    return +s, +c
+
+def sin(x): return sin_cos(x)[0]
+def cos(x): return sin_cos(x)[1]
+
+def atan(x):
+    with localcontext() as ctx:
+        ctx.prec += 10
+        if x < 0:
+            return -atan(-x)
+        if x > 1:
+            return pi() / 2 - atan(1 / x)
+        k = 0
+        while x > D('0.1'):
+            x = x / (1 + (1 + x * x).sqrt())
+            k += 1
+        s, t, i, x2 = D(0), x, 1, x * x
+        while abs(t) > D(10) ** (-ctx.prec - 5):
+            s += t / i
+            t = -t * x2
+            i += 2
+        s = s * 2 ** k
+    return +s
+
+def atan2(y, x):
+    if x > 0: return atan(y / x)
+    if x < 0 and y >= 0: return atan(y / x) + pi()
+    if x < 0 and y < 0: return atan(y / x) - pi()
+    if y > 0: return pi() / 2
+    if y < 0: return -pi() / 2
+    return D(0)
+
+# complex numbers with Decimal components ############################################################
+
+class C:
+    def __init__(self, re, im=0):
+        self.re, self.im = D(re), D(im)
+    def __add__(a, b):
+        b = b if isinstance(b, C) else C(b)
+        return C(a.re + b.re, a.im + b.im)
+    __radd__ = __add__
+    def __sub__(a, b):
+        b = b if isinstance(b, C) else C(b)
+        return C(a.re - b.re, a.im - b.im)
+    def __rsub__(a, b):
+        return C(b) - a
+    def __neg__(a):
+        return C(-a.re, -a.im)
+    def __mul__(a, b):
+        b = b if isinstance(b, C) else C(b)
+        return C(a.re * b.re - a.im * b.im, a.re * b.im + a.im * b.re)
+    __rmul__ = __mul__
+    def __truediv__(a, b):
+        b = b if isinstance(b, C) else C(b)
+        d = b.re * b.re + b.im * b.im
+        return C((a.re * b.re + a.im * b.im) / d, (a.im * b.re - a.re * b.im) / d)
+    def __rtruediv__(a, b):
+        return C(b) / a
+    def abs(a):
+        return (a.re * a.re + a.im * a.im).sqrt()
+
+def cexp(z):
+    s, c = sin_cos(z.im)
+    e = z.re.exp()
+    return C(e * c, e * s)
+
+def clog(z):
+    return C(z.abs().ln(), atan2(z.im, z.re))
+
+def cpow(z, nu):
+    return cexp(clog(z) * nu)
+
+# Bernoulli numbers, gamma and digamma functions ######################################################
+
+def bernoulli(nmax):
+    B, A = [], [F(0)] * (nmax + 1)
+    for m in range(nmax + 1): # Akiyama-Tanigawa algorithm
+        A[m] = F(1, m + 1)
+        for j in range(m, 0, -1):
+            A[j - 1] = j * (A[j - 1] - A[j])
+        B.append(A[0])
+    B[1] = -B[1] # B₁ = -1/2
+    return B
+
+BERN = bernoulli(100)
+
+def frac(q):
+    return D(q.numerator) / D(q.denominator)
+
+def lngamma(x): # x > 0
+    with localcontext() as ctx:
+        ctx.prec += 10
+        shift = D(0)
+        while x < 2 * ctx.prec:
+            shift += x.ln()
+            x += 1
+        s = (x - D('0.5')) * x.ln() - x + (2 * pi()).ln() / 2
+        for k in range(1, 45):
+            s += frac(BERN[2 * k]) / (2 * k * (2 * k - 1) * x ** (2 * k - 1))
+        s -= shift
+    return +s
+
+def isnonposint(x):
+    return x <= 0 and x == x.to_integral_value()
+
+def gamma(x):
+    if isnonposint(x):
+        raise ValueError('gamma: pole')
+    with localcontext() as ctx:
+        ctx.prec += 10
+        if x > 0:
+            g = lngamma(x).exp()
+        else:
+            g = pi() / (sin(pi() * x) * lngamma(1 - x).exp())
+    return +g
+
+def rgamma(x): # 1/Γ(x)
+    if isnonposint(x):
+        return D(0)
+    return 1 / gamma(x)
+
+def digamma(x):
+    with localcontext() as ctx:
+        ctx.prec += 10
+        if x <= 0:
+            s, c = sin_cos(pi() * x)
+            return digamma(1 - x) - pi() * c / s
+        shift = D(0)
+        while x < 2 * ctx.prec:
+            shift += 1 / x
+            x += 1
+        s = x.ln() - 1 / (2 * x)
+        for k in range(1, 45):
+            s -= frac(BERN[2 * k]) / (2 * k * x ** (2 * k))
+        s -= shift
+    return +s
+
+EULER = -digamma(D(1))
+
+# zeta functions ######################################################################################
+
+def hurwitz(s, q): # Euler-Maclaurin
+    with localcontext() as ctx:
+        ctx.prec += 20
+        N = 80
+        r = D(0)
+        for k in range(N):
+            r += (-s * (q + k).ln()).exp()
+        a = q + N
+        r += ((1 - s) * a.ln()).exp() / (s - 1) + (-s * a.ln()).exp() / 2
+        fact, poch = D(1), s # (2j)!, s(s+1)...(s+2j-2)
+        for j in range(1, 45):
+            fact *= (2 * j - 1) * (2 * j)
+            r += frac(BERN[2 * j]) / fact * poch * (-(s + 2 * j - 1) * a.ln()).exp()
+            poch *= (s + 2 * j - 1) * (s + 2 * j)
+    return +r
+
+def polygamma(n, x):
+    f = 1
+    for k in range(2, n + 1):
+        f *= k
+    return (-1) ** (n + 1) * f * hurwitz(D(n + 1), x)
+
+# incomplete gamma and beta functions ################################################################
+
+def gammainc(a, x): # regularised P(a, x) and Q(a, x)
+    with localcontext() as ctx:
+        ctx.prec = 150
+        s, t, k = D(0), 1 / a, 0
+        while t > D(10) ** -160 * s:
+            s += t
+            k += 1
+            t = t * x / (a + k)
+        P = s * (a * x.ln() - x - lngamma(a)).exp()
+        Q = 1 - P
+    return +P, +Q
+
+def betainc(a, b, x): # regularised I_x(a, b)
+    with localcontext() as ctx:
+        ctx.prec = 150
+        if x > (a + 1) / (a + b + 2):
+            return 1 - betainc(b, a, 1 - x)
+        s, t, n = D(0), D(1), 0
+        while t > D(10) ** -160 * s:
+            s += t
+            t = t * (a + b + n) / (a + 1 + n) * x
+            n += 1
+        lnB = lngamma(a) + lngamma(b) - lngamma(a + b)
+        r = s * (a * x.ln() + b * (1 - x).ln() - lnB).exp() / a
+    return +r
+
+# error functions #####################################################################################
+
+def cerf(z, prec):
+    with localcontext() as ctx:
+        ctx.prec = prec
+        s, t, k, z2 = C(0), z, 0, z * z # t = (-1)ᵏ z²ᵏ⁺¹ / k!
+        while t.abs() > D(10) ** (-prec - 5):
+            s = s + t / (2 * k + 1)
+            k += 1
+            t = -t * z2 / k
+        return s * (2 / pi().sqrt())
+
+def erf(x):
+    prec = 40 + int(2 * float(x) ** 2 / 2.3)
+    with localcontext() as ctx:
+        ctx.prec = prec
+        e = cerf(C(x), prec).re
+        return +e, +(1 - e)
+
+def faddeeva(z): # w(z) = exp(-z²) erfc(-iz)
+    prec = 40 + int(float(z.abs()) ** 2 / 2.3) + 20
+    with localcontext() as ctx:
+        ctx.prec = prec
+        iz = C(-z.im, z.re)
+        return cexp(-(z * z)) * (1 + cerf(iz, prec))
+
+# Bessel functions ####################################################################################
+
+def besselj(nu, z):
+    prec = 50 + int(float(z.abs()) / 2.3) + 10
+    with localcontext() as ctx:
+        ctx.prec = prec
+        q = -(z * z) / 4
+        s, t, k = C(0), C(rgamma(nu + 1)), 0 # t = qᵏ / (k! Γ(ν+k+1))
+        if isnonposint(nu + 1): # J_{-n} with n > 0
+            n = int(-nu)
+            t = C(1)
+            for i in range(1, n + 1):
+                t = t * q / i
+            k = n # first non-zero term: k = n
+            t = t * rgamma(nu + k + 1)
+        while True:
+            s = s + t
+            k += 1
+            t = t * q / (k * (nu + k))
+            if t.abs() < D(10) ** (-prec - 5) * s.abs() and k > 3:
+                break
+        return cpow(z / 2, nu) * s
+
+def bessely(nu, z):
+    prec = 50 + int(float(z.abs()) / 2.3) + 20
+    with localcontext() as ctx:
+        ctx.prec = prec
+        if nu != nu.to_integral_value():
+            s, c = sin_cos(pi() * nu)
+            return (besselj(nu, z) * c - besselj(-nu, z)) / s
+        n = int(nu)
+        if n < 0:
+            return bessely(-nu, z) * (-1) ** n
+        h = z / 2
+        q = h * h
+        s1, t = C(0), C(1)
+        fk = [1]
+        for k in range(1, n + 1):
+            fk.append(fk[-1] * k)
+        for k in range(n):
+            s1 = s1 + C(D(fk[n - k - 1]) / D(fk[k])) * t
+            t = t * q
+        s1 = s1 / cpow(h, D(n)) / pi()
+        s2, t, k = C(0), C(D(1) / D(fk[n])), 0 # t = (-q)ᵏ / (k! (n+k)!)
+        while True:
+            s2 = s2 + t * (digamma(D(k + 1)) + digamma(D(n + k + 1)))
+            k += 1
+            t = -(t * q) / (k * (n + k))
+            if t.abs() < D(10) ** (-prec - 5) and k > 3:
+                break
+        s2 = s2 * cpow(h, D(n)) / pi()
+        return -s1 + clog(h) * besselj(nu, z) * (2 / pi()) - s2
+
+# Airy functions ######################################################################################
+
+def airy(x):
+    prec = 50 + int(abs(float(x)) ** 1.5 / 2.3) + 10
+    with localcontext() as ctx:
+        ctx.prec = prec
+        c1 = 1 / ((D(2) / 3 * D(3).ln()).exp() * gamma(D(2) / 3))
+        c2 = 1 / ((D(1) / 3 * D(3).ln()).exp() * gamma(D(1) / 3))
+        x3 = x * x * x
+        f, t, k = D(0), D(1), 0
+        while True:
+            f += t
+            k += 1
+            t = t * x3 / ((3 * k - 1) * (3 * k))
+            if abs(t) < D(10) ** (-prec - 5) and k > 3:
+                break
+        g, t, k = D(0), x, 0
+        while True:
+            g += t
+            k += 1
+            t = t * x3 / ((3 * k) * (3 * k + 1))
+            if abs(t) < D(10) ** (-prec - 5) and k > 3:
+                break
+        return +(c1 * f - c2 * g), +(D(3).sqrt() * (c1 * f + c2 * g))
+
+# exponential integrals ###############################################################################
+
+def expint(n, x): # Eₙ(x), x > 0
+    prec = 50 + int(2 * float(x) / 2.3)
+    with localcontext() as ctx:
+        ctx.prec = prec
+        if n == 0:
+            return +((-x).exp() / x)
+        if x > 2: # continued fraction evaluated backwards; avoids the cancellation of the series
+            prev, m = None, 100
+            while True:
+                r = D(0)
+                for i in range(m, 0, -1):
+                    r = D(i * (n - 1 + i)) / (x + n + 2 * i - r)
+                r = (-x).exp() / (x + n - r)
+                if prev is not None and abs(r - prev) < D(10) ** (-PREC - 5) * abs(r):
+                    return +r
+                prev, m = r, 2 * m
+        psi = -EULER + sum(D(1) / m for m in range(1, n))
+        fact = D(1)
+        for m in range(1, n):
+            fact *= m
+        r = (-x) ** (n - 1) / fact * (-x.ln() + psi)
+        t, k = D(1), 0 # t = (-x)ᵏ / k!
+        while True:
+            if k != n - 1:
+                r -= t / (k - n + 1)
+            k += 1
+            t = -t * x / k
+            if abs(t) < D(10) ** (-prec - 5) and k > n:
+                break
+        return +r
+
+def expinti(x): # Ei(x)
+    prec = 50 + int(abs(float(x)) / 2.3) + 10
+    with localcontext() as ctx:
+        ctx.prec = prec
+        r = EULER + abs(x).ln()
+        t, k = x, 1 # t = xᵏ / k!
+        while abs(t) > D(10) ** (-prec - 5):
+            r += t / k
+            k += 1
+            t = t * x / k
+        return +r
+
+# Lambert W function ##################################################################################
+
+def lambertw(x, w0):
+    with localcontext() as ctx:
+        ctx.prec = PREC + 10
+        w = D(w0)
+        for it in range(200):
+            e = w.exp()
+            f = w * e - x
+            wn = w - f / (e * (w + 1) - (w + 2) * f / (2 * w + 2)) # Halley
+            if abs(wn - w) < D(10) ** (-PREC - 5) * (1 + abs(w)):
+                w = wn
+                break
+            w = wn
+    return +w
+
+# Gauss hypergeometric function #######################################################################
+
+def hyp2f1(a, b, c, x):
+    with localcontext() as ctx:
+        ctx.prec = 80
+        if x < 0: # Pfaff transformation: y = x/(x-1) in (0, 1)
+            y = x / (x - 1)
+            return +((1 - x) ** (-a) * hyp2f1(a, c - b, c, y))
+        s, t, k = D(0), D(1), 0
+        while True:
+            s += t
+            t = t * (a + k) * (b + k) / ((c + k) * (k + 1)) * x
+            k += 1
+            if t == 0 or (abs(t) < D(10) ** -90 * abs(s) and k > 5):
+                break
+        return +s
+
+# output ##############################################################################################
+
+def fmt(v):
+    if D(v) == 0:
+        return '%25s' % '0'
+    return '%25s' % format(D(v), '.17e')
+
+def savefile(fn, keys, rows):
+    l = '# generated by genSpecialFunctions.py (Python decimal; see header of script)\n'
+    l += ''.join(['%25s' % k for k in keys]) + '\n'
+    for r in rows:
+        l += ''.join([fmt(v) for v in r]) + '\n'
+    open(fn, 'w').write(l)
+    print('file <%s> written' % fn)
+
+def dd(s): # exact value of the float64 nearest to s; i.e. the argument as read by Go
+    return D(float(s))
+
+if __name__ == '__main__':
+
+    # incomplete gamma
+    rows = []
+    for a in ['0.1', '0.5', '1', '2.5', '10', '45.5', '150']:
+        for x in ['1e-15', '1e-10', '0.01', '0.5', '1', '3', '10', '30', '60', '120', '200']:
+            P, Q = gammainc(dd(a), dd(x))
+            if Q > D('1e-100') and P > D('1e-100'):
+                rows.append([dd(a), dd(x), P, Q])
+    savefile('hp-gamma-incomplete.cmp', ['a', 'x', 'P', 'Q'], rows)
+
+    # incomplete beta
+    rows = []
+    for a, b in [('0.5', '0.5'), ('1', '3'), ('2.5', '0.7'), ('5', '5'), ('0.1', '20'), ('30', '45'), ('200', '150')]:
+        for x in ['1e-15', '1e-10', '0.001', '0.1', '0.3', '0.5', '0.57', '0.7', '0.9', '0.999']:
+            I = betainc(dd(a), dd(b), dd(x))
+            if I > D('1e-100') and 1 - I > D('1e-100'):
+                rows.append([dd(a), dd(b), dd(x), I, betainc(dd(b), dd(a), 1 - dd(x))])
+    savefile('hp-beta-incomplete.cmp', ['a', 'b', 'x', 'I', 'Ic'], rows)
+
+    # digamma
+    rows = []
+    for x in ['-10.3', '-2.5', '-0.9', '-0.1', '1e-8', '0.25', '1', '1.4616321449683623', '2', '3.7', '10', '100.5', '1e6']:
+        rows.append([dd(x), digamma(dd(x))])
+    savefile('hp-digamma.cmp', ['x', 'psi'], rows)
+
+    # polygamma
+    rows = []
+    for n in [1, 2, 3, 5, 10]:
+        for x in ['0.01', '0.5', '1', '2.5', '10', '100']:
+            rows.append([D(n), dd(x), polygamma(n, dd(x))])
+    savefile('hp-polygamma.cmp', ['n', 'x', 'psin'], rows)
+
+    # zeta functions
+    rows = []
+    for s in ['-20.5', '-7', '-3.5', '-2', '-1', '-0.5', '0', '0.5', '0.999', '1.001', '1.5', '2', '3', '4.5', '10', '30', '60']:
+        rows.append([dd(s), hurwitz(dd(s), D(1))])
+    savefile('hp-zeta.cmp', ['s', 'zeta'], rows)
+    rows = []
+    for s in ['-2.5', '0.5', '1.5', '2', '7.3']:
+        for q in ['0.1', '0.5', '1', '3.3', '25']:
+            rows.append([dd(s), dd(q), hurwitz(dd(s), dd(q))])
+    savefile('hp-hurwitz.cmp', ['s', 'q', 'zeta'], rows)
+
+    # error functions
+    rows = []
+    for x in ['1e-10', '1e-3', '0.1', '0.4', '0.5', '0.8', '1', '1.5', '2', '3', '4', '5', '6', '8', '10', '15', '20', '26']:
+        e, ec = erf(dd(x))
+        rows.append([dd(x), e, ec])
+    savefile('hp-erf.cmp', ['x', 'erf', 'erfc'], rows)
+
+    # Faddeeva function
+    rows = []
+    for xr, xi in [('0', '0'), ('1', '0'), ('3', '0'), ('10', '0'), ('0', '1'), ('0', '5'), ('0', '-1'),
+                   ('0.5', '0.5'), ('1', '2'), ('2', '1'), ('-3', '0.5'), ('5', '5'), ('-5', '0.01'),
+                   ('6', '0.1'), ('8', '-0.5'), ('-1', '-1'), ('0.2', '-2'), ('12', '3'), ('1e-5', '1e-5'),
+                   ('0.001', '4'), ('4', '0.001'), ('30', '1'), ('-2', '8')]:
+        z = C(dd(xr), dd(xi))
+        w = faddeeva(z)
+        rows.append([z.re, z.im, w.re, w.im])
+    savefile('hp-faddeeva.cmp', ['xr', 'xi', 'wr', 'wi'], rows)
+
+    # Bessel functions
+    rows = []
+    for nu in ['0', '1', '2', '0.5', '1.3', '2.999', '3', '7.25', '20', '-0.7', '-2']:
+        for zr, zi in [('0.1', '0'), ('1', '0'), ('5', '0'), ('12.5', '0'), ('30', '0'), ('2', '1'), ('-3', '2'),
+                       ('0.5', '-4'), ('-6', '-0.5'), ('10', '10'), ('0', '3'), ('-8', '0'), ('25', '-2')]:
+            z = C(dd(zr), dd(zi))
+            J = besselj(dd(nu), z)
+            Y = bessely(dd(nu), z)
+            rows.append([dd(nu), z.re, z.im, J.re, J.im, Y.re, Y.im])
+    savefile('hp-bessel-complex.cmp', ['nu', 'zr', 'zi', 'Jr', 'Ji', 'Yr', 'Yi'], rows)
+
+    # spherical Bessel functions
+    rows = []
+    for n in [0, 1, 2, 5, 10]:
+        for x in ['0.01', '0.5', '1', '3', '10', '25', '60']:
+            x_ = dd(x)
+            f = (pi() / (2 * x_)).sqrt()
+            rows.append([D(n), x_, f * besselj(D(n) + D('0.5'), C(x_)).re, f * bessely(D(n) + D('0.5'), C(x_)).re])
+    savefile('hp-bessel-spherical.cmp', ['n', 'x', 'j', 'y'], rows)
+
+    # Airy functions
+    rows = []
+    for x in ['-20', '-10', '-5.5', '-2', '-1', '-0.1', '0', '0.1', '1', '2', '3.5', '5', '8', '12']:
+        ai, bi = airy(dd(x))
+        rows.append([dd(x), ai, bi])
+    savefile('hp-airy.cmp', ['x', 'Ai', 'Bi'], rows)
+
+    # exponential integrals
+    rows = []
+    for n in [0, 1, 2, 5, 20]:
+        for x in ['1e-3', '0.1', '0.5', '1', '2', '5', '10', '30', '80']:
+            rows.append([D(n), dd(x), expint(n, dd(x))])
+    savefile('hp-expint.cmp', ['n', 'x', 'En'], rows)
+    rows = []
+    for x in ['-50', '-10', '-1', '-0.01', '1e-5', '0.1', '0.3725074107813666', '1', '2', '5', '10', '40', '80']:
+        rows.append([dd(x), expinti(dd(x))])
+    savefile('hp-expint-ei.cmp', ['x', 'Ei'], rows)
+
+    # Lambert W function
+    import math
+    rows = []
+    e1 = -1 / D(1).exp()
+    for x in [e1 + D('1e-12'), e1 + D('1e-4'), D('-0.3'), D('-0.1'), D('-1e-5'), D('1e-10'), D('0.5'), D(1), D('2.718281828459045'), D(10), D(1000), D('1e20'), D('1e300')]:
+        xf = float(x)
+        w0 = math.log(1 + xf) if xf < 3 else math.log(xf) - math.log(math.log(xf))
+        if xf < -0.25:
+            w0 = -1 + math.sqrt(2 * (math.e * xf + 1))
+        rows.append([dd(x), lambertw(dd(x), w0)])
+    savefile('hp-lambertw.cmp', ['x', 'W'], rows)
+    rows = []
+    for x in [e1 + D('1e-12'), e1 + D('1e-4'), D('-0.3'), D('-0.1'), D('-1e-5'), D('-1e-100')]:
+        xf = float(x)
+        w0 = math.log(-xf) - math.log(-math.log(-xf)) if xf > -0.25 else -1 - math.sqrt(2 * (math.e * xf + 1))
+        rows.append([dd(x), lambertw(dd(x), w0)])
+    savefile('hp-lambertwm1.cmp', ['x', 'W'], rows)
+
+    # Gauss hypergeometric function
+    rows = []
+    for a, b, c in [('1', '1', '2'), ('0.5', '0.5', '1.5'), ('1.5', '2.25', '3.1'), ('-3', '2', '0.5'),
+                    ('0.3', '0.7', '1'), ('1', '2', '3'), ('1', '2', '5'), ('2', '3', '4'), ('0.5', '1', '1.2'),
+                    ('-2.5', '1.5', '3.5'), ('3', '4', '2')]:
+        for x in ['-5', '-1', '-0.5', '0', '0.3', '0.5', '0.7', '0.9', '0.99']:
+            rows.append([dd(a), dd(b), dd(c), dd(x), hyp2f1(dd(a), dd(b), dd(c), dd(x))])
+    savefile('hp-hyp2f1.cmp', ['a', 'b', 'c', 'x', 'F'], rows)
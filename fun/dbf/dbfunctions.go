@@ -30,8 +30,11 @@ type T interface {
 var allocators = map[string]func() T{} // type => function allocator
 
 // New allocates function by name
+//  NOTE: the definition of the function (name and parameters) is recorded; thus, the function can
+//        be saved in JSON (see Definition)
 func New(name string, prms Params) (T, error) {
 	if name == "zero" {
+		define(&Zero, name, nil)
 		return &Zero, nil
 	}
 	allocator, ok := allocators[name]
@@ -43,6 +46,7 @@ func New(name string, prms Params) (T, error) {
 	if err != nil {
 		return nil, err
 	}
+	define(o, name, prms)
 	return o, nil
 }
 
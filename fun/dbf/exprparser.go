@@ -0,0 +1,644 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// exprNode holds a node of the expression tree
+type exprNode struct {
+	op   string      // "num", "t", "x", "prm", "+", "-", "*", "/", "^", "neg", "cond" or function name
+	val  float64     // value of "num"
+	idx  int         // index of "x" or "prm"
+	args []*exprNode // operands or arguments of functions
+}
+
+// exprFunc defines an evaluator of a compiled expression
+type exprFunc func(t float64, x []float64) float64
+
+// exprFunctions holds the number of arguments of available functions
+var exprFunctions = map[string]int{
+	"sin": 1, "cos": 1, "tan": 1, "asin": 1, "acos": 1, "atan": 1,
+	"sinh": 1, "cosh": 1, "tanh": 1, "exp": 1, "log": 1, "ln": 1, "sqrt": 1,
+	"abs": 1, "sign": 1, "heav": 1, "ramp": 1, "pow": 2, "min": 2, "max": 2,
+}
+
+// exprConstants holds the named constants
+var exprConstants = map[string]float64{"pi": math.Pi, "e": math.E}
+
+// parser ////////////////////////////////////////////////////////////////////////////////////////
+
+// exprToken holds a token: number, identifier or symbol
+type exprToken struct {
+	kind byte    // 'n' number, 'i' identifier, 's' symbol, 0 end
+	str  string  // identifier or symbol
+	val  float64 // number
+	pos  int     // position in string
+}
+
+// exprParser implements a recursive descent parser
+//
+//   expr    := term { ("+" | "-") term }
+//   term    := unary { ("*" | "/") unary }
+//   unary   := ("-" | "+") unary | power
+//   power   := primary [ ("^" | "**") unary ]
+//   primary := number | "t" | "x[" integer "]" | constant | parameter | function "(" args ")" | "(" expr ")"
+type exprParser struct {
+	str    string      // expression
+	tokens []exprToken // tokens
+	pos    int         // current token
+	prms   []string    // names of parameters found in expression
+}
+
+// parseExpr parses the expression and returns the tree. The names of the parameters are collected
+// in the order they appear
+func parseExpr(str string) (root *exprNode, prms []string, err error) {
+	p := &exprParser{str: str}
+	err = p.tokenise()
+	if err != nil {
+		return
+	}
+	root, err = p.expr()
+	if err != nil {
+		return
+	}
+	if tk := p.peek(); tk.kind != 0 {
+		return nil, nil, p.error(tk, "unexpected %q", tk.str)
+	}
+	return root, p.prms, nil
+}
+
+// tokenise splits the expression into tokens
+func (o *exprParser) tokenise() (err error) {
+	s := o.str
+	i := 0
+	for i < len(s) {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(s) && unicode.IsDigit(rune(s[i+1]))):
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.') {
+				j++
+			}
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && unicode.IsDigit(rune(s[k])) {
+					for k < len(s) && unicode.IsDigit(rune(s[k])) {
+						k++
+					}
+					j = k
+				}
+			}
+			v, e := strconv.ParseFloat(s[i:j], 64)
+			if e != nil {
+				return chk.Err("expression %q: invalid number %q at position %d\n", s, s[i:j], i)
+			}
+			o.tokens = append(o.tokens, exprToken{kind: 'n', str: s[i:j], val: v, pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			o.tokens = append(o.tokens, exprToken{kind: 'i', str: s[i:j], pos: i})
+			i = j
+		case strings.ContainsRune("+-*/^()[],", c):
+			if c == '*' && i+1 < len(s) && s[i+1] == '*' {
+				o.tokens = append(o.tokens, exprToken{kind: 's', str: "^", pos: i})
+				i += 2
+				continue
+			}
+			o.tokens = append(o.tokens, exprToken{kind: 's', str: string(c), pos: i})
+			i++
+		default:
+			return chk.Err("expression %q: invalid character %q at position %d\n", s, c, i)
+		}
+	}
+	return
+}
+
+// peek returns the current token
+func (o *exprParser) peek() exprToken {
+	if o.pos < len(o.tokens) {
+		return o.tokens[o.pos]
+	}
+	return exprToken{pos: len(o.str)}
+}
+
+// accept advances if the current token is the symbol sym
+func (o *exprParser) accept(sym string) bool {
+	if tk := o.peek(); tk.kind == 's' && tk.str == sym {
+		o.pos++
+		return true
+	}
+	return false
+}
+
+// expect advances if the current token is the symbol sym or returns an error
+func (o *exprParser) expect(sym string) error {
+	if !o.accept(sym) {
+		tk := o.peek()
+		return o.error(tk, "%q expected", sym)
+	}
+	return nil
+}
+
+// error returns an error message indicating the position of tk
+func (o *exprParser) error(tk exprToken, msg string, args ...interface{}) error {
+	return chk.Err("expression %q: %s at position %d\n", o.str, io.Sf(msg, args...), tk.pos)
+}
+
+func (o *exprParser) expr() (n *exprNode, err error) {
+	n, err = o.term()
+	for err == nil {
+		switch {
+		case o.accept("+"):
+			var r *exprNode
+			r, err = o.term()
+			n = &exprNode{op: "+", args: []*exprNode{n, r}}
+		case o.accept("-"):
+			var r *exprNode
+			r, err = o.term()
+			n = &exprNode{op: "-", args: []*exprNode{n, r}}
+		default:
+			return
+		}
+	}
+	return
+}
+
+func (o *exprParser) term() (n *exprNode, err error) {
+	n, err = o.unary()
+	for err == nil {
+		switch {
+		case o.accept("*"):
+			var r *exprNode
+			r, err = o.unary()
+			n = &exprNode{op: "*", args: []*exprNode{n, r}}
+		case o.accept("/"):
+			var r *exprNode
+			r, err = o.unary()
+			n = &exprNode{op: "/", args: []*exprNode{n, r}}
+		default:
+			return
+		}
+	}
+	return
+}
+
+func (o *exprParser) unary() (n *exprNode, err error) {
+	if o.accept("-") {
+		n, err = o.unary()
+		return &exprNode{op: "neg", args: []*exprNode{n}}, err
+	}
+	if o.accept("+") {
+		return o.unary()
+	}
+	return o.power()
+}
+
+func (o *exprParser) power() (n *exprNode, err error) {
+	n, err = o.primary()
+	if err == nil && o.accept("^") {
+		var r *exprNode
+		r, err = o.unary()
+		n = &exprNode{op: "^", args: []*exprNode{n, r}}
+	}
+	return
+}
+
+func (o *exprParser) primary() (n *exprNode, err error) {
+	tk := o.peek()
+	switch tk.kind {
+	case 'n':
+		o.pos++
+		return &exprNode{op: "num", val: tk.val}, nil
+	case 'i':
+		o.pos++
+		name := tk.str
+		if nargs, ok := exprFunctions[name]; ok && o.accept("(") {
+			n = &exprNode{op: name}
+			if name == "ln" {
+				n.op = "log"
+			}
+			for i := 0; i < nargs; i++ {
+				if i > 0 {
+					if err = o.expect(","); err != nil {
+						return
+					}
+				}
+				var a *exprNode
+				if a, err = o.expr(); err != nil {
+					return
+				}
+				n.args = append(n.args, a)
+			}
+			err = o.expect(")")
+			return
+		}
+		switch name {
+		case "t":
+			return &exprNode{op: "t"}, nil
+		case "x":
+			if err = o.expect("["); err != nil {
+				return
+			}
+			itk := o.peek()
+			if itk.kind != 'n' || itk.val != math.Floor(itk.val) || itk.val < 0 {
+				return nil, o.error(itk, "non-negative integer index of x expected")
+			}
+			o.pos++
+			if err = o.expect("]"); err != nil {
+				return
+			}
+			return &exprNode{op: "x", idx: int(itk.val)}, nil
+		}
+		if v, ok := exprConstants[name]; ok {
+			return &exprNode{op: "num", val: v}, nil
+		}
+		for i, p := range o.prms {
+			if p == name {
+				return &exprNode{op: "prm", idx: i}, nil
+			}
+		}
+		o.prms = append(o.prms, name)
+		return &exprNode{op: "prm", idx: len(o.prms) - 1}, nil
+	case 's':
+		if o.accept("(") {
+			if n, err = o.expr(); err != nil {
+				return
+			}
+			err = o.expect(")")
+			return
+		}
+		return nil, o.error(tk, "unexpected %q", tk.str)
+	}
+	return nil, o.error(tk, "unexpected end of expression")
+}
+
+// symbolic differentiation ///////////////////////////////////////////////////////////////////////
+
+// exprVar identifies the variable of differentiation: t (if idx < 0) or x[idx]
+type exprVar struct {
+	idx int
+}
+
+// is tells whether n is the variable v
+func (v exprVar) is(n *exprNode) bool {
+	return (n.op == "t" && v.idx < 0) || (n.op == "x" && n.idx == v.idx)
+}
+
+// depends tells whether n depends on v
+func (v exprVar) depends(n *exprNode) bool {
+	if v.is(n) {
+		return true
+	}
+	for _, a := range n.args {
+		if v.depends(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// diff returns the derivative of n with respect to v (simplified)
+func (v exprVar) diff(n *exprNode) *exprNode {
+	if !v.depends(n) {
+		return num(0)
+	}
+	if v.is(n) {
+		return num(1)
+	}
+	var a, b, da, db *exprNode
+	a, da = n.args[0], v.diff(n.args[0])
+	if len(n.args) > 1 {
+		b, db = n.args[1], v.diff(n.args[1])
+	}
+	switch n.op {
+	case "+":
+		return add(da, db)
+	case "-":
+		return sub(da, db)
+	case "neg":
+		return neg(da)
+	case "*":
+		return add(mul(da, b), mul(a, db))
+	case "/":
+		return sub(div(da, b), div(mul(a, db), pow(b, num(2))))
+	case "^", "pow":
+		if !v.depends(b) { // (aᵇ)' = b aᵇ⁻¹ a'
+			return mul(mul(b, pow(a, sub(b, num(1)))), da)
+		} // (aᵇ)' = aᵇ (b' ln(a) + b a'/a)
+		return mul(n, add(mul(db, fcn("log", a)), div(mul(b, da), a)))
+	case "sin":
+		return mul(fcn("cos", a), da)
+	case "cos":
+		return neg(mul(fcn("sin", a), da))
+	case "tan":
+		return div(da, pow(fcn("cos", a), num(2)))
+	case "asin":
+		return div(da, fcn("sqrt", sub(num(1), pow(a, num(2)))))
+	case "acos":
+		return neg(div(da, fcn("sqrt", sub(num(1), pow(a, num(2))))))
+	case "atan":
+		return div(da, add(num(1), pow(a, num(2))))
+	case "sinh":
+		return mul(fcn("cosh", a), da)
+	case "cosh":
+		return mul(fcn("sinh", a), da)
+	case "tanh":
+		return div(da, pow(fcn("cosh", a), num(2)))
+	case "exp":
+		return mul(n, da)
+	case "log":
+		return div(da, a)
+	case "sqrt":
+		return div(da, mul(num(2), n))
+	case "abs":
+		return mul(fcn("sign", a), da)
+	case "sign", "heav":
+		return num(0)
+	case "ramp":
+		return mul(fcn("heav", a), da)
+	case "min":
+		return &exprNode{op: "cond", args: []*exprNode{sub(a, b), da, db}}
+	case "max":
+		return &exprNode{op: "cond", args: []*exprNode{sub(a, b), db, da}}
+	case "cond":
+		return &exprNode{op: "cond", args: []*exprNode{a, db, v.diff(n.args[2])}}
+	}
+	chk.Panic("cannot differentiate %q", n.op) // should not happen
+	return nil
+}
+
+// constructors with simplification //////////////////////////////////////////////////////////////
+
+func num(v float64) *exprNode { return &exprNode{op: "num", val: v} }
+
+func isNum(n *exprNode, v float64) bool { return n.op == "num" && n.val == v }
+
+func fcn(name string, a *exprNode) *exprNode {
+	return fold(&exprNode{op: name, args: []*exprNode{a}})
+}
+
+func add(a, b *exprNode) *exprNode {
+	switch {
+	case isNum(a, 0):
+		return b
+	case isNum(b, 0):
+		return a
+	case b.op == "neg":
+		return sub(a, b.args[0])
+	}
+	return fold(&exprNode{op: "+", args: []*exprNode{a, b}})
+}
+
+func sub(a, b *exprNode) *exprNode {
+	switch {
+	case isNum(b, 0):
+		return a
+	case isNum(a, 0):
+		return neg(b)
+	case b.op == "neg":
+		return add(a, b.args[0])
+	}
+	return fold(&exprNode{op: "-", args: []*exprNode{a, b}})
+}
+
+func neg(a *exprNode) *exprNode {
+	switch {
+	case a.op == "num":
+		return num(-a.val)
+	case a.op == "neg":
+		return a.args[0]
+	}
+	return &exprNode{op: "neg", args: []*exprNode{a}}
+}
+
+func mul(a, b *exprNode) *exprNode {
+	switch {
+	case isNum(a, 0) || isNum(b, 0):
+		return num(0)
+	case isNum(a, 1):
+		return b
+	case isNum(b, 1):
+		return a
+	case isNum(a, -1):
+		return neg(b)
+	case isNum(b, -1):
+		return neg(a)
+	case a.op == "neg":
+		return neg(mul(a.args[0], b))
+	case b.op == "neg":
+		return neg(mul(a, b.args[0]))
+	case b.op == "num" && a.op != "num":
+		return mul(b, a) // constants first
+	case a.op == "num" && b.op == "*" && b.args[0].op == "num":
+		return mul(num(a.val*b.args[0].val), b.args[1])
+	}
+	return fold(&exprNode{op: "*", args: []*exprNode{a, b}})
+}
+
+func div(a, b *exprNode) *exprNode {
+	switch {
+	case isNum(a, 0):
+		return num(0)
+	case isNum(b, 1):
+		return a
+	case a.op == "neg":
+		return neg(div(a.args[0], b))
+	}
+	return fold(&exprNode{op: "/", args: []*exprNode{a, b}})
+}
+
+func pow(a, b *exprNode) *exprNode {
+	switch {
+	case isNum(b, 0):
+		return num(1)
+	case isNum(b, 1):
+		return a
+	case a.op == "^" && a.args[1].op == "num" && b.op == "num":
+		return pow(a.args[0], num(a.args[1].val*b.val))
+	}
+	return fold(&exprNode{op: "^", args: []*exprNode{a, b}})
+}
+
+// fold evaluates n if all arguments are numbers
+func fold(n *exprNode) *exprNode {
+	for _, a := range n.args {
+		if a.op != "num" {
+			return n
+		}
+	}
+	return num(compileExpr(n, nil)(0, nil))
+}
+
+// compilation ///////////////////////////////////////////////////////////////////////////////////
+
+// compileExpr converts the tree into a tree of closures. prms holds the (connected) values of
+// parameters; thus, changes in the parameters are taken into account by the compiled function
+func compileExpr(n *exprNode, prms []float64) exprFunc {
+	switch n.op {
+	case "num":
+		v := n.val
+		return func(t float64, x []float64) float64 { return v }
+	case "t":
+		return func(t float64, x []float64) float64 { return t }
+	case "x":
+		i := n.idx
+		return func(t float64, x []float64) float64 { return x[i] }
+	case "prm":
+		p := &prms[n.idx]
+		return func(t float64, x []float64) float64 { return *p }
+	}
+	args := make([]exprFunc, len(n.args))
+	for i, a := range n.args {
+		args[i] = compileExpr(a, prms)
+	}
+	a := args[0]
+	var b exprFunc
+	if len(args) > 1 {
+		b = args[1]
+	}
+	switch n.op {
+	case "+":
+		return func(t float64, x []float64) float64 { return a(t, x) + b(t, x) }
+	case "-":
+		return func(t float64, x []float64) float64 { return a(t, x) - b(t, x) }
+	case "*":
+		if n.args[0].op == "num" {
+			c := n.args[0].val
+			return func(t float64, x []float64) float64 { return c * b(t, x) }
+		}
+		return func(t float64, x []float64) float64 { return a(t, x) * b(t, x) }
+	case "/":
+		return func(t float64, x []float64) float64 { return a(t, x) / b(t, x) }
+	case "neg":
+		return func(t float64, x []float64) float64 { return -a(t, x) }
+	case "^", "pow":
+		if n.args[1].op == "num" {
+			switch c := n.args[1].val; c {
+			case 2:
+				return func(t float64, x []float64) float64 { v := a(t, x); return v * v }
+			case 3:
+				return func(t float64, x []float64) float64 { v := a(t, x); return v * v * v }
+			case 0.5:
+				return func(t float64, x []float64) float64 { return math.Sqrt(a(t, x)) }
+			case -1:
+				return func(t float64, x []float64) float64 { return 1.0 / a(t, x) }
+			default:
+				return func(t float64, x []float64) float64 { return math.Pow(a(t, x), c) }
+			}
+		}
+		return func(t float64, x []float64) float64 { return math.Pow(a(t, x), b(t, x)) }
+	case "cond":
+		c := args[2]
+		return func(t float64, x []float64) float64 {
+			if a(t, x) <= 0 {
+				return b(t, x)
+			}
+			return c(t, x)
+		}
+	case "min":
+		return func(t float64, x []float64) float64 { return math.Min(a(t, x), b(t, x)) }
+	case "max":
+		return func(t float64, x []float64) float64 { return math.Max(a(t, x), b(t, x)) }
+	}
+	var f func(float64) float64
+	switch n.op {
+	case "sin":
+		f = math.Sin
+	case "cos":
+		f = math.Cos
+	case "tan":
+		f = math.Tan
+	case "asin":
+		f = math.Asin
+	case "acos":
+		f = math.Acos
+	case "atan":
+		f = math.Atan
+	case "sinh":
+		f = math.Sinh
+	case "cosh":
+		f = math.Cosh
+	case "tanh":
+		f = math.Tanh
+	case "exp":
+		f = math.Exp
+	case "log":
+		f = math.Log
+	case "sqrt":
+		f = math.Sqrt
+	case "abs":
+		f = math.Abs
+	case "sign":
+		f = fun.Sign
+	case "heav":
+		f = fun.Heav
+	case "ramp":
+		f = fun.Ramp
+	default:
+		chk.Panic("cannot compile %q", n.op) // should not happen
+	}
+	return func(t float64, x []float64) float64 { return f(a(t, x)) }
+}
+
+// printing //////////////////////////////////////////////////////////////////////////////////////
+
+// exprPrecedence returns the precedence of operators
+var exprPrecedence = map[string]int{"+": 1, "-": 1, "*": 2, "/": 2, "neg": 3, "^": 4}
+
+// exprString converts the tree to a string. prms holds the names of parameters
+func exprString(n *exprNode, prms []string) string {
+	switch n.op {
+	case "num":
+		return strconv.FormatFloat(n.val, 'g', -1, 64)
+	case "t":
+		return "t"
+	case "x":
+		return io.Sf("x[%d]", n.idx)
+	case "prm":
+		return prms[n.idx]
+	case "cond":
+		return io.Sf("cond(%s, %s, %s)", exprString(n.args[0], prms), exprString(n.args[1], prms), exprString(n.args[2], prms))
+	}
+	prec, isOp := exprPrecedence[n.op]
+	if !isOp {
+		l := make([]string, len(n.args))
+		for i, a := range n.args {
+			l[i] = exprString(a, prms)
+		}
+		return n.op + "(" + strings.Join(l, ", ") + ")"
+	}
+	operand := func(a *exprNode, right bool) string {
+		s := exprString(a, prms)
+		p, ok := exprPrecedence[a.op]
+		if (a.op == "num" && a.val < 0) || (ok && (p < prec || (p == prec && (n.op == "^" || (right && (n.op == "-" || n.op == "/")))))) {
+			return "(" + s + ")"
+		}
+		return s
+	}
+	if n.op == "neg" { // -a*b = (-a)*b
+		if p, ok := exprPrecedence[n.args[0].op]; ok && p == 1 {
+			return "-(" + exprString(n.args[0], prms) + ")"
+		}
+		return "-" + exprString(n.args[0], prms)
+	}
+	return operand(n.args[0], false) + n.op + operand(n.args[1], true)
+}
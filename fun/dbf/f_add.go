@@ -63,5 +63,13 @@ func (o Add) H(t float64, x []float64) float64 {
 // Grad returns ∇F = ∂y/∂x = Grad(t, x)
 func (o Add) Grad(v []float64, t float64, x []float64) {
 	setvzero(v)
+	if o.Fa != nil && o.Fb != nil {
+		gb := make([]float64, len(v))
+		o.Fa.Grad(v, t, x)
+		o.Fb.Grad(gb, t, x)
+		for i := range v {
+			v[i] = o.A*v[i] + o.B*gb[i]
+		}
+	}
 	return
 }
@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import "github.com/cpmech/gosl/chk"
+
+// Clamp implements a function with values limited to [ymin, ymax]
+//  F(t, x) := min(max(Fa(t,x), ymin), ymax)
+//  NOTE: the derivatives are zero where the values are clamped
+type Clamp struct {
+	Fa   T
+	Ymin float64
+	Ymax float64
+}
+
+// set allocators database
+func init() {
+	allocators["clamp"] = func() T { return new(Clamp) }
+}
+
+// Init initialises the function
+func (o *Clamp) Init(prms Params) (err error) {
+	e := prms.Connect(&o.Ymin, "ymin", "clamp function")
+	e += prms.Connect(&o.Ymax, "ymax", "clamp function")
+	if e != "" {
+		return chk.Err("%v\n", e)
+	}
+	if o.Fa = getFa(prms); o.Fa == nil {
+		return chk.Err("clamp: function \"fa\" must be given\n")
+	}
+	if o.Ymin > o.Ymax {
+		return chk.Err("clamp: ymin must not be greater than ymax. %g > %g is invalid\n", o.Ymin, o.Ymax)
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Clamp) F(t float64, x []float64) float64 {
+	y := o.Fa.F(t, x)
+	if y < o.Ymin {
+		return o.Ymin
+	}
+	if y > o.Ymax {
+		return o.Ymax
+	}
+	return y
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Clamp) G(t float64, x []float64) float64 {
+	if o.clamped(t, x) {
+		return 0
+	}
+	return o.Fa.G(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Clamp) H(t float64, x []float64) float64 {
+	if o.clamped(t, x) {
+		return 0
+	}
+	return o.Fa.H(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Clamp) Grad(v []float64, t float64, x []float64) {
+	if o.clamped(t, x) {
+		setvzero(v)
+		return
+	}
+	o.Fa.Grad(v, t, x)
+	return
+}
+
+// clamped tells whether the value of Fa is outside [ymin, ymax]
+func (o Clamp) clamped(t float64, x []float64) bool {
+	y := o.Fa.F(t, x)
+	return y < o.Ymin || y > o.Ymax
+}
+
+// getFa returns the function in the parameter named "fa" or nil if not found
+func getFa(prms Params) T {
+	if p := prms.Find("fa"); p != nil {
+		return p.Fcn
+	}
+	return nil
+}
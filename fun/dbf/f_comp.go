@@ -0,0 +1,70 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import "github.com/cpmech/gosl/chk"
+
+// Comp implements the composition of two functions: the output of Fb replaces the time argument
+// of Fa
+//  F(t, x) := Fa(Fb(t,x), x)
+type Comp struct {
+	Fa, Fb T
+}
+
+// set allocators database
+func init() {
+	allocators["comp"] = func() T { return new(Comp) }
+}
+
+// Init initialises the function
+func (o *Comp) Init(prms Params) (err error) {
+	o.Fa, o.Fb, err = getFaFb(prms, "comp")
+	return
+}
+
+// F returns y = F(t, x)
+func (o Comp) F(t float64, x []float64) float64 {
+	return o.Fa.F(o.Fb.F(t, x), x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Comp) G(t float64, x []float64) float64 {
+	return o.Fa.G(o.Fb.F(t, x), x) * o.Fb.G(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Comp) H(t float64, x []float64) float64 {
+	u, gb := o.Fb.F(t, x), o.Fb.G(t, x)
+	return o.Fa.H(u, x)*gb*gb + o.Fa.G(u, x)*o.Fb.H(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Comp) Grad(v []float64, t float64, x []float64) {
+	u := o.Fb.F(t, x)
+	gb := make([]float64, len(v))
+	o.Fb.Grad(gb, t, x)
+	o.Fa.Grad(v, u, x)
+	ga := o.Fa.G(u, x)
+	for i := range v {
+		v[i] += ga * gb[i]
+	}
+	return
+}
+
+// getFaFb returns the functions in the parameters named "fa" and "fb"
+func getFaFb(prms Params, caller string) (fa, fb T, err error) {
+	for _, p := range prms {
+		switch p.N {
+		case "fa":
+			fa = p.Fcn
+		case "fb":
+			fb = p.Fcn
+		}
+	}
+	if fa == nil || fb == nil {
+		return nil, nil, chk.Err("%s: functions \"fa\" and \"fb\" must be given\n", caller)
+	}
+	return
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import "github.com/cpmech/gosl/chk"
+
+// Expr implements a function defined by a mathematical expression; e.g.
+//
+//   F(t, x) = A*sin(w*t)*exp(-x[0]^2)
+//
+//  The expression may contain:
+//   numbers       -- e.g. 2, 0.5, 1e-3
+//   variables     -- t and x[i] (coordinate i)
+//   constants     -- pi and e
+//   operators     -- + - * / ^ (or **) and parentheses. ^ is right-associative and has higher
+//                    precedence than the unary minus; e.g. -x[0]^2 = -(x[0]^2)
+//   functions     -- sin cos tan asin acos atan sinh cosh tanh exp log (or ln) sqrt abs sign heav
+//                    ramp pow(a,b) min(a,b) max(a,b)
+//   parameters    -- any other name; e.g. A and w. Their values are connected to prms
+//
+//  The derivatives G, H and Grad are obtained by symbolic differentiation. All expressions are
+//  compiled into trees of closures for fast evaluation.
+//
+//  With New, the expression is given by the Extra field of the parameter named "expr"; e.g.
+//   dbf.New("expr", dbf.Params{{N: "expr", Extra: "A*sin(w*t)"}, {N: "A", V: 2}, {N: "w", V: 3}})
+type Expr struct {
+	Str string // the expression
+
+	// derived
+	names []string    // names of parameters
+	vals  []float64   // values of parameters (connected)
+	root  *exprNode   // expression tree
+	dt    *exprNode   // ∂F/∂t
+	dtt   *exprNode   // ∂²F/∂t²
+	dx    []*exprNode // ∂F/∂x[i]
+	f     exprFunc    // compiled F
+	g     exprFunc    // compiled G
+	h     exprFunc    // compiled H
+	grad  []exprFunc  // compiled gradient
+}
+
+// set allocators database
+func init() {
+	allocators["expr"] = func() T { return new(Expr) }
+}
+
+// NewExpr parses and compiles the expression str with parameters prms
+func NewExpr(str string, prms Params) (o *Expr, err error) {
+	o = &Expr{Str: str}
+	err = o.Init(prms)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Init initialises the function. If prms has a parameter named "expr", its Extra field replaces
+// the expression in Str
+func (o *Expr) Init(prms Params) (err error) {
+	if p := prms.Find("expr"); p != nil {
+		o.Str = p.Extra
+	}
+	o.root, o.names, err = parseExpr(o.Str)
+	if err != nil {
+		return
+	}
+	o.vals = make([]float64, len(o.names))
+	e := ""
+	for i, name := range o.names {
+		e += prms.Connect(&o.vals[i], name, "expr function")
+	}
+	if e != "" {
+		return chk.Err("%v\n", e)
+	}
+
+	// derivatives
+	o.dt = exprVar{-1}.diff(o.root)
+	o.dtt = exprVar{-1}.diff(o.dt)
+	o.dx = make([]*exprNode, exprMaxIndex(o.root)+1)
+	for i := range o.dx {
+		o.dx[i] = exprVar{i}.diff(o.root)
+	}
+
+	// compile
+	o.f = compileExpr(o.root, o.vals)
+	o.g = compileExpr(o.dt, o.vals)
+	o.h = compileExpr(o.dtt, o.vals)
+	o.grad = make([]exprFunc, len(o.dx))
+	for i, d := range o.dx {
+		o.grad[i] = compileExpr(d, o.vals)
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Expr) F(t float64, x []float64) float64 {
+	return o.f(t, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Expr) G(t float64, x []float64) float64 {
+	return o.g(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Expr) H(t float64, x []float64) float64 {
+	return o.h(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Expr) Grad(v []float64, t float64, x []float64) {
+	for i := range v {
+		if i < len(o.grad) {
+			v[i] = o.grad[i](t, x)
+		} else {
+			v[i] = 0
+		}
+	}
+	return
+}
+
+// Derivatives returns the (simplified) symbolic expressions of G, H and Grad
+func (o Expr) Derivatives() (g, h string, grad []string) {
+	g = exprString(o.dt, o.names)
+	h = exprString(o.dtt, o.names)
+	grad = make([]string, len(o.dx))
+	for i, d := range o.dx {
+		grad[i] = exprString(d, o.names)
+	}
+	return
+}
+
+// exprMaxIndex returns the largest index i of x[i] in the expression or -1 if x is absent
+func exprMaxIndex(n *exprNode) (imax int) {
+	imax = -1
+	if n.op == "x" {
+		imax = n.idx
+	}
+	for _, a := range n.args {
+		if i := exprMaxIndex(a); i > imax {
+			imax = i
+		}
+	}
+	return
+}
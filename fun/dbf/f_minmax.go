@@ -0,0 +1,95 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+// Min implements the minimum of two functions
+//  F(t, x) := min(Fa(t,x), Fb(t,x))
+//  NOTE: the derivatives are the ones of the active function (Fa if Fa = Fb)
+type Min struct {
+	Fa, Fb T
+}
+
+// Max implements the maximum of two functions
+//  F(t, x) := max(Fa(t,x), Fb(t,x))
+//  NOTE: the derivatives are the ones of the active function (Fa if Fa = Fb)
+type Max struct {
+	Fa, Fb T
+}
+
+// set allocators database
+func init() {
+	allocators["min"] = func() T { return new(Min) }
+	allocators["max"] = func() T { return new(Max) }
+}
+
+// Init initialises the function
+func (o *Min) Init(prms Params) (err error) {
+	o.Fa, o.Fb, err = getFaFb(prms, "min")
+	return
+}
+
+// F returns y = F(t, x)
+func (o Min) F(t float64, x []float64) float64 {
+	return o.active(t, x).F(t, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Min) G(t float64, x []float64) float64 {
+	return o.active(t, x).G(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Min) H(t float64, x []float64) float64 {
+	return o.active(t, x).H(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Min) Grad(v []float64, t float64, x []float64) {
+	o.active(t, x).Grad(v, t, x)
+	return
+}
+
+// active returns the function with the smallest value
+func (o Min) active(t float64, x []float64) T {
+	if o.Fb.F(t, x) < o.Fa.F(t, x) {
+		return o.Fb
+	}
+	return o.Fa
+}
+
+// Init initialises the function
+func (o *Max) Init(prms Params) (err error) {
+	o.Fa, o.Fb, err = getFaFb(prms, "max")
+	return
+}
+
+// F returns y = F(t, x)
+func (o Max) F(t float64, x []float64) float64 {
+	return o.active(t, x).F(t, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Max) G(t float64, x []float64) float64 {
+	return o.active(t, x).G(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Max) H(t float64, x []float64) float64 {
+	return o.active(t, x).H(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Max) Grad(v []float64, t float64, x []float64) {
+	o.active(t, x).Grad(v, t, x)
+	return
+}
+
+// active returns the function with the largest value
+func (o Max) active(t float64, x []float64) T {
+	if o.Fb.F(t, x) > o.Fa.F(t, x) {
+		return o.Fb
+	}
+	return o.Fa
+}
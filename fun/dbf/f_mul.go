@@ -61,7 +61,15 @@ func (o Mul) H(t float64, x []float64) float64 {
 
 // Grad returns ∇F = ∂y/∂x = Grad(t, x)
 func (o Mul) Grad(v []float64, t float64, x []float64) {
-	chk.Panic("mul: Grad is not implemented yet")
-	setvzero(v)
-	return
+	if o.Fa != nil && o.Fb != nil {
+		gb := make([]float64, len(v))
+		o.Fa.Grad(v, t, x)
+		o.Fb.Grad(gb, t, x)
+		fa, fb := o.Fa.F(t, x), o.Fb.F(t, x)
+		for i := range v {
+			v[i] = fb*v[i] + fa*gb[i]
+		}
+		return
+	}
+	chk.Panic("mul: fa and fb functions are <nil>\n")
 }
@@ -0,0 +1,74 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Periodic implements the periodic extension of a function defined over one period
+//  F(t, x) := Fa(t0 + mod(t - t0, T), x)
+//  where T is the period and t0 is the start of the period (default = 0)
+type Periodic struct {
+	Fa     T
+	Period float64
+	T0     float64
+}
+
+// set allocators database
+func init() {
+	allocators["periodic"] = func() T { return new(Periodic) }
+}
+
+// Init initialises the function
+func (o *Periodic) Init(prms Params) (err error) {
+	o.T0 = 0
+	if prms.Find("t0") != nil {
+		prms.Connect(&o.T0, "t0", "periodic function")
+	}
+	e := prms.Connect(&o.Period, "period", "periodic function")
+	if e != "" {
+		return chk.Err("%v\n", e)
+	}
+	if o.Fa = getFa(prms); o.Fa == nil {
+		return chk.Err("periodic: function \"fa\" must be given\n")
+	}
+	if o.Period <= 0 {
+		return chk.Err("periodic: period must be positive. %g is invalid\n", o.Period)
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Periodic) F(t float64, x []float64) float64 {
+	return o.Fa.F(o.local(t), x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Periodic) G(t float64, x []float64) float64 {
+	return o.Fa.G(o.local(t), x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Periodic) H(t float64, x []float64) float64 {
+	return o.Fa.H(o.local(t), x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Periodic) Grad(v []float64, t float64, x []float64) {
+	o.Fa.Grad(v, o.local(t), x)
+	return
+}
+
+// local returns the time within the first period [t0, t0+T)
+func (o Periodic) local(t float64) float64 {
+	τ := math.Mod(t-o.T0, o.Period)
+	if τ < 0 {
+		τ += o.Period
+	}
+	return o.T0 + τ
+}
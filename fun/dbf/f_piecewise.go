@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Piecewise implements a function defined by other functions in consecutive time intervals
+//
+//              │ F0(t,x)  if        t < t1
+//    F(t, x) = ┤ F1(t,x)  if t1 ≤ t < t2
+//              │ ...
+//              │ Fn(t,x)  if tn ≤ t
+//
+//  The parameters are given in sequence: functions (named "f") alternating with the switching
+//  times (named "t"); e.g. {f: F0}, {t: t1}, {f: F1}, {t: t2}, {f: F2}
+//  NOTE: (1) the functions are evaluated with the global time t
+//        (2) the function may be discontinuous at the switching times
+type Piecewise struct {
+	Fs []T       // functions
+	Ts []float64 // switching times
+}
+
+// set allocators database
+func init() {
+	allocators["piecewise"] = func() T { return new(Piecewise) }
+}
+
+// Init initialises the function
+func (o *Piecewise) Init(prms Params) (err error) {
+	o.Fs, o.Ts = nil, nil
+	for _, p := range prms {
+		switch p.N {
+		case "f":
+			if p.Fcn == nil {
+				return chk.Err("piecewise: function of parameter \"f\" must not be <nil>\n")
+			}
+			if len(o.Fs) != len(o.Ts) {
+				return chk.Err("piecewise: functions and switching times must alternate\n")
+			}
+			o.Fs = append(o.Fs, p.Fcn)
+		case "t":
+			if len(o.Fs) != len(o.Ts)+1 {
+				return chk.Err("piecewise: functions and switching times must alternate\n")
+			}
+			if len(o.Ts) > 0 && p.V <= o.Ts[len(o.Ts)-1] {
+				return chk.Err("piecewise: switching times must be increasing. %g is invalid\n", p.V)
+			}
+			o.Ts = append(o.Ts, p.V)
+		default:
+			return chk.Err("piecewise: parameter named %q is invalid\n", p.N)
+		}
+	}
+	if len(o.Fs) == 0 || len(o.Fs) != len(o.Ts)+1 {
+		return chk.Err("piecewise: the first and last parameters must be functions\n")
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Piecewise) F(t float64, x []float64) float64 {
+	return o.active(t).F(t, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Piecewise) G(t float64, x []float64) float64 {
+	return o.active(t).G(t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Piecewise) H(t float64, x []float64) float64 {
+	return o.active(t).H(t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Piecewise) Grad(v []float64, t float64, x []float64) {
+	o.active(t).Grad(v, t, x)
+	return
+}
+
+// active returns the function in the interval containing t
+func (o Piecewise) active(t float64) T {
+	return o.Fs[sort.Search(len(o.Ts), func(i int) bool { return o.Ts[i] > t })]
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import "github.com/cpmech/gosl/chk"
+
+// Tshift implements a function shifted in time (delayed if ts > 0)
+//  F(t, x) := Fa(t - ts, x)
+type Tshift struct {
+	Fa T
+	Ts float64
+}
+
+// Tscale implements a function with scaled time (faster if s > 1)
+//  F(t, x) := Fa(s⋅t, x)
+type Tscale struct {
+	Fa T
+	S  float64
+}
+
+// set allocators database
+func init() {
+	allocators["tshift"] = func() T { return new(Tshift) }
+	allocators["tscale"] = func() T { return new(Tscale) }
+}
+
+// Init initialises the function
+func (o *Tshift) Init(prms Params) (err error) {
+	e := prms.Connect(&o.Ts, "ts", "tshift function")
+	if e != "" {
+		return chk.Err("%v\n", e)
+	}
+	if o.Fa = getFa(prms); o.Fa == nil {
+		return chk.Err("tshift: function \"fa\" must be given\n")
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Tshift) F(t float64, x []float64) float64 {
+	return o.Fa.F(t-o.Ts, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Tshift) G(t float64, x []float64) float64 {
+	return o.Fa.G(t-o.Ts, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Tshift) H(t float64, x []float64) float64 {
+	return o.Fa.H(t-o.Ts, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Tshift) Grad(v []float64, t float64, x []float64) {
+	o.Fa.Grad(v, t-o.Ts, x)
+	return
+}
+
+// Init initialises the function
+func (o *Tscale) Init(prms Params) (err error) {
+	e := prms.Connect(&o.S, "s", "tscale function")
+	if e != "" {
+		return chk.Err("%v\n", e)
+	}
+	if o.Fa = getFa(prms); o.Fa == nil {
+		return chk.Err("tscale: function \"fa\" must be given\n")
+	}
+	return
+}
+
+// F returns y = F(t, x)
+func (o Tscale) F(t float64, x []float64) float64 {
+	return o.Fa.F(o.S*t, x)
+}
+
+// G returns ∂y/∂t_cteX = G(t, x)
+func (o Tscale) G(t float64, x []float64) float64 {
+	return o.S * o.Fa.G(o.S*t, x)
+}
+
+// H returns ∂²y/∂t²_cteX = H(t, x)
+func (o Tscale) H(t float64, x []float64) float64 {
+	return o.S * o.S * o.Fa.H(o.S*t, x)
+}
+
+// Grad returns ∇F = ∂y/∂x = Grad(t, x)
+func (o Tscale) Grad(v []float64, t float64, x []float64) {
+	o.Fa.Grad(v, o.S*t, x)
+	return
+}
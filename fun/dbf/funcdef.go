@@ -0,0 +1,144 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Func holds a function together with its definition; i.e. the name of the function type and the
+// parameters. Func implements T and can be marshalled to and unmarshalled from JSON. Nested
+// functions (e.g. of combinators such as "add" or "comp") are saved in the "fcn" field of the
+// corresponding parameters. For example:
+//
+//   {"type": "add", "prms": [
+//       {"n": "a", "v": 1}, {"n": "b", "v": 2},
+//       {"n": "fa", "fcn": {"type": "cos", "prms": [{"n": "a", "v": 1}, ...]}},
+//       {"n": "fb", "fcn": {"type": "lin", "prms": [{"n": "m", "v": 0.5}, ...]}}
+//   ]}
+//
+//  NOTE: functions allocated by New can also be marshalled because New records their definitions
+//        (see Definition); e.g. Params with functions allocated by New in the "fcn" field
+type Func struct {
+	Type string     `json:"type"` // function type; e.g. "cos", "pts", "add"
+	Prms Params     `json:"prms"` // parameters
+	T    `json:"-"` // the allocated function
+}
+
+// NewFunc allocates function by name and keeps its definition; thus, it can be saved in JSON
+func NewFunc(name string, prms Params) (o *Func, err error) {
+	o = &Func{Type: name}
+	err = o.Init(prms)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// NewFromJSON allocates a function from its JSON definition
+func NewFromJSON(data []byte) (o *Func, err error) {
+	o = new(Func)
+	err = json.Unmarshal(data, o)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Init (re-)allocates and initialises the function with the given parameters
+func (o *Func) Init(prms Params) (err error) {
+	o.Prms = prms
+	o.T, err = New(o.Type, prms)
+	return
+}
+
+// UnmarshalJSON reads the definition and allocates the function
+func (o *Func) UnmarshalJSON(data []byte) (err error) {
+	var def struct {
+		Type string `json:"type"`
+		Prms Params `json:"prms"`
+	}
+	err = json.Unmarshal(data, &def)
+	if err != nil {
+		return
+	}
+	o.Type = def.Type
+	return o.Init(def.Prms)
+}
+
+// definitions maps functions allocated by New to their definitions
+var definitions = struct {
+	sync.Mutex
+	m map[T]*Func
+}{m: map[T]*Func{}}
+
+// define records the definition of a function allocated by New
+func define(o T, name string, prms Params) {
+	definitions.Lock()
+	definitions.m[o] = &Func{Type: name, Prms: prms, T: o}
+	definitions.Unlock()
+}
+
+// Definition returns the definition of a function allocated by New or NewFunc; e.g. to save in JSON
+// a function allocated by New:
+//   def, ok := dbf.Definition(f)
+//   b, err := json.Marshal(def)
+//  NOTE: (1) ok = false means that the function was not allocated by New or NewFunc
+//        (2) the definition holds the parameters given to New; if the function is re-initialised
+//            with other parameters by calling its Init method, use NewFunc and Func.Init instead
+//        (3) New keeps a reference to the function and its definition in a package-level map;
+//            thus, these functions are never garbage collected. This is fine for functions
+//            allocated when setting up a problem but not for functions allocated in a loop
+func Definition(o T) (def *Func, ok bool) {
+	if def, ok = o.(*Func); ok {
+		return
+	}
+	if o == nil || !reflect.TypeOf(o).Comparable() {
+		return nil, false
+	}
+	definitions.Lock()
+	def, ok = definitions.m[o]
+	definitions.Unlock()
+	return
+}
+
+// plainP has the same fields as P but not its methods (to avoid recursion in MarshalJSON)
+type plainP P
+
+// MarshalJSON writes the parameter, including its function (if any)
+func (o *P) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		*plainP
+		Fcn *Func `json:"fcn,omitempty"`
+	}{plainP: (*plainP)(o)}
+	if o.Fcn != nil {
+		f, ok := Definition(o.Fcn)
+		if !ok {
+			return nil, chk.Err("function of parameter %q cannot be marshalled because it has no definition; use dbf.New or dbf.NewFunc to allocate it\n", o.N)
+		}
+		aux.Fcn = f
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON reads the parameter, including its function (if any)
+func (o *P) UnmarshalJSON(data []byte) (err error) {
+	aux := struct {
+		*plainP
+		Fcn *Func `json:"fcn,omitempty"`
+	}{plainP: (*plainP)(o)}
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return
+	}
+	if aux.Fcn != nil {
+		o.Fcn = aux.Fcn
+	}
+	return
+}
@@ -27,8 +27,8 @@ type P struct {
 	SetDef bool    `json:"setdef"` // tells model to use a default value
 
 	// auxiliary
-	Fcn   T  // a function y=f(t,x)
-	Other *P // dependency: connected parameter
+	Fcn   T  `json:"-"` // a function y=f(t,x); saved as "fcn" if allocated by NewFunc (see Func)
+	Other *P `json:"-"` // dependency: connected parameter
 
 	// derived
 	conn []*float64 // connected variables to V
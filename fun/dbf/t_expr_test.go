@@ -0,0 +1,149 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_expr01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expr01. parser and evaluator")
+
+	x := []float64{0.7, -1.3, 2.1}
+	t := 0.9
+	for _, c := range []struct {
+		str string
+		ref float64
+	}{
+		{"1 + 2*3", 7},
+		{"(1 + 2)*3", 9},
+		{"2^3^2", 512},
+		{"2**3", 8},
+		{"-2^2", -4},
+		{"2^-1", 0.5},
+		{"8/4/2", 1},
+		{"1 - 2 - 3", -4},
+		{"1.5e1 + .5 + 2E-1", 15.7},
+		{"+3 - -2", 5},
+		{"pi + e", math.Pi + math.E},
+		{"t*x[0] - x[2]", t*x[0] - x[2]},
+		{"sin(t)^2 + cos(t)^2", 1},
+		{"ln(exp(x[2]))", x[2]},
+		{"sqrt(abs(x[1]))*sign(x[1])", -math.Sqrt(1.3)},
+		{"pow(x[0], 3) + min(t, x[0]) + max(t, x[0])", math.Pow(0.7, 3) + 0.7 + 0.9},
+		{"heav(x[1]) + ramp(x[0]) + heav(0)", 1.2},
+		{"atan(1)*4 + asin(1)*2 + acos(1)", 2 * math.Pi},
+		{"tanh(x[0]) - sinh(x[0])/cosh(x[0]) + tan(t)", math.Tan(t)},
+	} {
+		o, err := NewExpr(c.str, nil)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Scalar(tst, c.str, 1e-14, o.F(t, x), c.ref)
+	}
+
+	// errors
+	for _, str := range []string{"", "1 +", "(1", "1)", "sin(1", "pow(1)", "x", "x[-1]", "x[1.5]", "2 # 3", "1 2", "a", "sin"} {
+		_, err := NewExpr(str, Params{&P{N: "b", V: 1}})
+		if err == nil {
+			tst.Errorf("expression %q should have failed\n", str)
+			return
+		}
+		io.Pfyel("%v", err)
+	}
+}
+
+func Test_expr02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expr02. parameters and New")
+
+	prms := Params{
+		&P{N: "expr", Extra: "A*sin(w*t)*exp(-x[0]^2)"},
+		&P{N: "A", V: 2},
+		&P{N: "w", V: 3},
+	}
+	f, err := New("expr", prms)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	t, x := 0.4, []float64{0.5, 1.0}
+	chk.Scalar(tst, "F", 1e-15, f.F(t, x), 2*math.Sin(1.2)*math.Exp(-0.25))
+	chk.Scalar(tst, "G", 1e-14, f.G(t, x), 6*math.Cos(1.2)*math.Exp(-0.25))
+	chk.Scalar(tst, "H", 1e-14, f.H(t, x), -18*math.Sin(1.2)*math.Exp(-0.25))
+	v := make([]float64, 2)
+	f.Grad(v, t, x)
+	chk.Vector(tst, "Grad", 1e-15, v, []float64{-2 * 2 * math.Sin(1.2) * math.Exp(-0.25) * 0.5, 0})
+
+	// parameters are connected
+	prms.Find("w").Set(1)
+	chk.Scalar(tst, "F (w=1)", 1e-15, f.F(t, x), 2*math.Sin(0.4)*math.Exp(-0.25))
+	chk.Scalar(tst, "G (w=1)", 1e-15, f.G(t, x), 2*math.Cos(0.4)*math.Exp(-0.25))
+
+	// symbolic derivatives
+	g, h, grad := f.(*Expr).Derivatives()
+	io.Pforan("G    = %v\nH    = %v\nGrad = %v\n", g, h, grad)
+	chk.String(tst, g, "A*cos(w*t)*w*exp(-x[0]^2)")
+	chk.String(tst, grad[0], "-A*sin(w*t)*exp(-x[0]^2)*2*x[0]")
+
+	// missing parameter
+	_, err = NewExpr("A*t + B", Params{&P{N: "A", V: 1}})
+	if err == nil {
+		tst.Errorf("missing parameter B should have failed\n")
+	}
+}
+
+func Test_expr03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expr03. symbolic derivatives")
+
+	prms := Params{&P{N: "a", V: 1.5}, &P{N: "b", V: 0.3}}
+	for _, str := range []string{
+		"a*t^3 - b*t^2 + t - 1",
+		"a*sin(b*t)*cos(x[0]*t) + x[1]^2/t",
+		"exp(-b*t)*sqrt(1 + t^2) + log(t*x[0]^2 + x[1]^2)",
+		"t^x[0] + x[0]^t + pow(x[1], 2.5)",
+		"tan(b*t) + atan(t*x[0]) + asin(x[1]/3) - acos(x[0]/2)",
+		"tanh(t - x[0]) + sinh(b*t)*cosh(x[1]) + abs(t - x[1])",
+		"min(t^2, x[0]) + max(b*t, x[1]) + ramp(t - x[0])",
+		"-(t - a)/(t + a) + 1/x[0]",
+	} {
+		o, err := NewExpr(str, prms)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("\n%s\n", str)
+		CheckDerivT(tst, o, 0.5, 2.5, []float64{0.7, 1.3}, 7, []float64{0.7, 1.3}, 1e-2, 1e-6, 1e-6, chk.Verbose)
+		CheckDerivX(tst, o, 1.1, []float64{0.2, 0.3}, []float64{1.5, 2.0}, 5, [][]float64{{1.1, 0}}, 1e-2, 1e-6, chk.Verbose)
+
+		// the printed derivatives give the same values when parsed again
+		g, h, grad := o.Derivatives()
+		t, x, v := 1.7, []float64{0.4, 1.6}, make([]float64, 2)
+		o.Grad(v, t, x)
+		for i, s := range append([]string{g, h}, grad...) {
+			if strings.Contains(s, "cond") {
+				continue
+			}
+			d, err := NewExpr(s, prms)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			ref := []float64{o.G(t, x), o.H(t, x), v[0], v[1]}[i]
+			chk.Scalar(tst, "reparsed "+s, 1e-13, d.F(t, x), ref)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// checkSameT checks that two functions give the same values
+func checkSameT(tst *testing.T, msg string, a, b T, ts []float64, x []float64) {
+	va, vb := make([]float64, len(x)), make([]float64, len(x))
+	for _, t := range ts {
+		chk.Scalar(tst, io.Sf("%s: F(%g)", msg, t), 1e-15, a.F(t, x), b.F(t, x))
+		chk.Scalar(tst, io.Sf("%s: G(%g)", msg, t), 1e-15, a.G(t, x), b.G(t, x))
+		chk.Scalar(tst, io.Sf("%s: H(%g)", msg, t), 1e-15, a.H(t, x), b.H(t, x))
+		a.Grad(va, t, x)
+		b.Grad(vb, t, x)
+		chk.Vector(tst, io.Sf("%s: Grad(%g)", msg, t), 1e-15, va, vb)
+	}
+}
+
+func Test_funcdef01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("funcdef01. JSON")
+
+	cos, err := NewFunc("cos", Params{&P{N: "a", V: 1}, &P{N: "b/pi", V: 2}, &P{N: "c", V: 1}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	lin, err := NewFunc("lin", Params{&P{N: "m", V: 0.5}, &P{N: "ts", V: 0}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	pts, err := NewFunc("pts", Params{
+		&P{N: "t0", V: 0.0}, &P{N: "y0", V: 0.50},
+		&P{N: "dy", Extra: "-0.3  0  -0.15  -0.04  -0.01"},
+	})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	expr, err := NewFunc("expr", Params{&P{N: "expr", Extra: "A*t^2*x[0] + x[1]"}, &P{N: "A", V: 3}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	add, err := NewFunc("add", Params{&P{N: "a", V: 2}, &P{N: "b", V: -1}, &P{N: "fa", Fcn: cos}, &P{N: "fb", Fcn: lin}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	mul, err := NewFunc("mul", Params{&P{N: "fa", Fcn: add}, &P{N: "fb", Fcn: pts}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	pw, err := NewFunc("piecewise", Params{&P{N: "f", Fcn: mul}, &P{N: "t", V: 0.5}, &P{N: "f", Fcn: expr}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// save and load
+	b, err := json.Marshal(pw)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%s\n", b)
+	res, err := NewFromJSON(b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, res.Type, "piecewise")
+	checkSameT(tst, "piecewise", res, pw, []float64{0, 0.2, 0.45, 0.5, 0.8, 2}, []float64{0.3, -0.4})
+
+	// same JSON when saved again
+	b2, err := json.Marshal(res)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, string(b2), string(b))
+
+	// slices of functions
+	var fcns []*Func
+	err = json.Unmarshal([]byte(`[
+		{"type": "cte", "prms": [{"n": "c", "v": 3}]},
+		{"type": "zero", "prms": []},
+		{"type": "tshift", "prms": [{"n": "ts", "v": 1}, {"n": "fa", "fcn": {"type": "rmp", "prms": [
+			{"n": "ca", "v": 0}, {"n": "cb", "v": 1}, {"n": "ta", "v": 0}, {"n": "tb", "v": 1}]}}]}
+	]`), &fcns)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "cte", 1e-15, fcns[0].F(0, nil), 3)
+	chk.Scalar(tst, "zero", 1e-15, fcns[1].F(0, nil), 0)
+	chk.Scalar(tst, "tshift(rmp)(1.5)", 1e-15, fcns[2].F(1.5, nil), 0.5)
+
+	// errors
+	if _, err = NewFromJSON([]byte(`{"type": "unknown", "prms": []}`)); err == nil {
+		tst.Errorf("unknown function should have failed\n")
+	}
+	raw := new(Cte)
+	raw.Init(Params{&P{N: "c", V: 1}})
+	if _, err = json.Marshal(Params{&P{N: "fa", Fcn: raw}}); err == nil {
+		tst.Errorf("function not allocated by New or NewFunc should have failed\n")
+	}
+}
+
+func Test_funcdef02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("funcdef02. combinators")
+
+	newf := func(name string, prms Params) T {
+		f, err := New(name, prms)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			tst.FailNow()
+		}
+		return f
+	}
+	sin := newf("expr", Params{&P{N: "expr", Extra: "sin(2*t)*x[0] + x[1]^2"}})
+	quad := newf("expr", Params{&P{N: "expr", Extra: "t^2 + t*x[1]"}})
+	x := []float64{0.7, 0.4}
+	t := 0.3
+
+	// comp
+	comp := newf("comp", Params{&P{N: "fa", Fcn: sin}, &P{N: "fb", Fcn: quad}})
+	chk.Scalar(tst, "comp", 1e-15, comp.F(t, x), sin.F(quad.F(t, x), x))
+	CheckDerivT(tst, comp, 0, 2, x, 7, nil, 1e-10, 1e-7, 1e-7, chk.Verbose)
+	CheckDerivX(tst, comp, 1.1, []float64{-1, -1}, []float64{1, 1}, 5, nil, 1e-10, 1e-7, chk.Verbose)
+
+	// gradients of add and mul
+	add := newf("add", Params{&P{N: "a", V: 2}, &P{N: "b", V: -3}, &P{N: "fa", Fcn: sin}, &P{N: "fb", Fcn: quad}})
+	mul := newf("mul", Params{&P{N: "fa", Fcn: sin}, &P{N: "fb", Fcn: quad}})
+	CheckDerivX(tst, add, 1.1, []float64{-1, -1}, []float64{1, 1}, 5, nil, 1e-10, 1e-7, chk.Verbose)
+	CheckDerivX(tst, mul, 1.1, []float64{-1, -1}, []float64{1, 1}, 5, nil, 1e-10, 1e-7, chk.Verbose)
+
+	// min and max
+	min := newf("min", Params{&P{N: "fa", Fcn: sin}, &P{N: "fb", Fcn: quad}})
+	max := newf("max", Params{&P{N: "fa", Fcn: sin}, &P{N: "fb", Fcn: quad}})
+	for _, τ := range []float64{0, 0.3, 0.9, 1.5, 2.0} {
+		a, b := sin.F(τ, x), quad.F(τ, x)
+		lo, hi := a, b
+		if b < a {
+			lo, hi = b, a
+		}
+		chk.Scalar(tst, "min", 1e-15, min.F(τ, x), lo)
+		chk.Scalar(tst, "max", 1e-15, max.F(τ, x), hi)
+	}
+
+	// clamp
+	clamp := newf("clamp", Params{&P{N: "ymin", V: -0.5}, &P{N: "ymax", V: 0.5}, &P{N: "fa", Fcn: sin}})
+	chk.Scalar(tst, "clamp (inside)", 1e-15, clamp.F(0.1, []float64{1, 0}), sin.F(0.1, []float64{1, 0}))
+	chk.Scalar(tst, "clamp (above)", 1e-15, clamp.F(0.7, []float64{1, 0}), 0.5)
+	chk.Scalar(tst, "clamp (below)", 1e-15, clamp.F(2.0, []float64{1, 0}), -0.5)
+	chk.Scalar(tst, "clamp: G (above)", 1e-15, clamp.G(0.7, []float64{1, 0}), 0)
+	chk.Scalar(tst, "clamp: G (inside)", 1e-15, clamp.G(0.1, []float64{1, 0}), sin.G(0.1, []float64{1, 0}))
+
+	// piecewise
+	pw := newf("piecewise", Params{&P{N: "f", Fcn: sin}, &P{N: "t", V: 1}, &P{N: "f", Fcn: quad}, &P{N: "t", V: 2}, &P{N: "f", Fcn: &One}})
+	chk.Scalar(tst, "piecewise (t<1)", 1e-15, pw.F(0.5, x), sin.F(0.5, x))
+	chk.Scalar(tst, "piecewise (t=1)", 1e-15, pw.F(1, x), quad.F(1, x))
+	chk.Scalar(tst, "piecewise (1<t<2)", 1e-15, pw.G(1.5, x), quad.G(1.5, x))
+	chk.Scalar(tst, "piecewise (t>2)", 1e-15, pw.F(3, x), 1)
+
+	// periodic
+	per := newf("periodic", Params{&P{N: "period", V: 2}, &P{N: "t0", V: 1}, &P{N: "fa", Fcn: quad}})
+	for _, τ := range []float64{1, 1.5, 2.9} {
+		for _, k := range []float64{-2, -1, 1, 3} {
+			chk.Scalar(tst, io.Sf("periodic(%g)", τ+2*k), 1e-13, per.F(τ+2*k, x), quad.F(τ, x))
+			chk.Scalar(tst, io.Sf("periodic: G(%g)", τ+2*k), 1e-13, per.G(τ+2*k, x), quad.G(τ, x))
+		}
+	}
+
+	// time shift and scale
+	shift := newf("tshift", Params{&P{N: "ts", V: 0.5}, &P{N: "fa", Fcn: sin}})
+	scale := newf("tscale", Params{&P{N: "s", V: 3}, &P{N: "fa", Fcn: sin}})
+	chk.Scalar(tst, "tshift", 1e-15, shift.F(t, x), sin.F(t-0.5, x))
+	chk.Scalar(tst, "tscale", 1e-15, scale.F(t, x), sin.F(3*t, x))
+	CheckDerivT(tst, shift, 0, 2, x, 7, nil, 1e-10, 1e-7, 1e-7, chk.Verbose)
+	CheckDerivT(tst, scale, 0, 2, x, 7, nil, 1e-10, 1e-7, 1e-7, chk.Verbose)
+	CheckDerivX(tst, scale, 1.1, []float64{-1, -1}, []float64{1, 1}, 5, nil, 1e-10, 1e-7, chk.Verbose)
+
+	// errors
+	for _, c := range []struct {
+		name string
+		prms Params
+	}{
+		{"comp", Params{&P{N: "fa", Fcn: sin}}},
+		{"clamp", Params{&P{N: "ymin", V: 1}, &P{N: "ymax", V: 0}, &P{N: "fa", Fcn: sin}}},
+		{"piecewise", Params{&P{N: "f", Fcn: sin}, &P{N: "t", V: 1}}},
+		{"piecewise", Params{&P{N: "f", Fcn: sin}, &P{N: "t", V: 1}, &P{N: "f", Fcn: sin}, &P{N: "t", V: 0.5}, &P{N: "f", Fcn: sin}}},
+		{"periodic", Params{&P{N: "period", V: 0}, &P{N: "fa", Fcn: sin}}},
+		{"tshift", Params{&P{N: "ts", V: 0}}},
+	} {
+		if _, err := New(c.name, c.prms); err == nil {
+			tst.Errorf("%s with %v should have failed\n", c.name, c.prms)
+		}
+	}
+}
+
+func Test_funcdef03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("funcdef03. JSON of functions allocated by New")
+
+	cos, err := New("cos", Params{&P{N: "a", V: 1}, &P{N: "b/pi", V: 2}, &P{N: "c", V: 1}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	if _, ok := cos.(*Cos); !ok {
+		tst.Errorf("New should return the concrete function\n")
+		return
+	}
+	add, err := New("add", Params{&P{N: "a", V: 2}, &P{N: "b", V: -1}, &P{N: "fa", Fcn: cos}, &P{N: "fb", Fcn: &Zero}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	comp, err := New("comp", Params{&P{N: "fa", Fcn: add}, &P{N: "fb", Fcn: cos}})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// save and load
+	def, ok := Definition(comp)
+	if !ok {
+		tst.Errorf("New should record the definition\n")
+		return
+	}
+	chk.String(tst, def.Type, "comp")
+	b, err := json.Marshal(def)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%s\n", b)
+	res, err := NewFromJSON(b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	checkSameT(tst, "comp", res, comp, []float64{0, 0.2, 0.45, 0.5, 0.8, 2}, nil)
+
+	// same JSON as with NewFunc
+	cosF, _ := NewFunc("cos", Params{&P{N: "a", V: 1}, &P{N: "b/pi", V: 2}, &P{N: "c", V: 1}})
+	zeroF, _ := NewFunc("zero", nil)
+	addF, _ := NewFunc("add", Params{&P{N: "a", V: 2}, &P{N: "b", V: -1}, &P{N: "fa", Fcn: cosF}, &P{N: "fb", Fcn: zeroF}})
+	compF, _ := NewFunc("comp", Params{&P{N: "fa", Fcn: addF}, &P{N: "fb", Fcn: cosF}})
+	b2, err := json.Marshal(compF)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, string(b), string(b2))
+
+	// functions not allocated by New
+	if _, ok = Definition(new(Cte)); ok {
+		tst.Errorf("function not allocated by New should have no definition\n")
+	}
+	if _, ok = Definition(nil); ok {
+		tst.Errorf("nil function should have no definition\n")
+	}
+}
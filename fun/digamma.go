@@ -0,0 +1,60 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// Digamma computes the digamma function ψ(x) = d ln Γ(x) / dx
+//   NOTE: returns NaN at the poles x = 0, -1, -2, ...
+//   The recurrence ψ(x) = ψ(x+1) - 1/x is employed to shift x to [10, ∞) where the asymptotic
+//   expansion is used. The reflection formula ψ(1-x) - ψ(x) = π cot(π x) is used for x < 0.
+func Digamma(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, -1) || isNonPosInt(x) {
+		return math.NaN()
+	}
+	if math.IsInf(x, 1) {
+		return x
+	}
+	if x < 0 {
+		r := x - math.Floor(x+0.5) // cot(π x) has period 1
+		return Digamma(1.0-x) - math.Pi/math.Tan(math.Pi*r)
+	}
+	res := 0.0
+	for x < 10 {
+		res -= 1.0 / x
+		x++
+	}
+	x2 := 1.0 / (x * x)
+	res += math.Log(x) - 0.5/x - x2*(1.0/12.0-x2*(1.0/120.0-x2*(1.0/252.0-x2*(1.0/240.0-x2*(1.0/132.0-x2*(691.0/32760.0-x2/12.0))))))
+	return res
+}
+
+// Polygamma computes the polygamma function of order n
+//
+//                  dⁿ⁺¹ ln Γ(x)                      n+1
+//      ψ⁽ⁿ⁾(x) = ――――――――――――――  =  (-1)     n! ζ(n+1, x)
+//                    dxⁿ⁺¹
+//
+//   where ζ(s, q) is the Hurwitz zeta function. Polygamma(0, x) = Digamma(x)
+//   NOTE: returns NaN if n < 0 or at the poles x = 0, -1, -2, ...
+func Polygamma(n int, x float64) float64 {
+	if n < 0 || math.IsNaN(x) || isNonPosInt(x) {
+		return math.NaN()
+	}
+	if n == 0 {
+		return Digamma(x)
+	}
+	fact := math.Gamma(float64(n + 1))
+	sgn := 1.0
+	if n%2 == 0 {
+		sgn = -1.0
+	}
+	res := 0.0
+	for x < 0 { // ψ⁽ⁿ⁾(x) = ψ⁽ⁿ⁾(x+1) + (-1)ⁿ⁺¹ n! / xⁿ⁺¹
+		res += sgn * fact / math.Pow(x, float64(n+1))
+		x++
+	}
+	return res + sgn*fact*HurwitzZeta(float64(n+1), x)
+}
@@ -0,0 +1,213 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// Dual implements dual numbers a + b⋅ε with ε² = 0 for forward-mode automatic differentiation.
+// If the dual part of the independent variable is set to 1 (see DualVar), the dual part of the
+// result of any sequence of operations is the (exact) derivative with respect to that variable.
+//  Example:
+//   x := fun.DualVar(2)
+//   y := x.Mul(x).Add(x.Sin()) // y.V = x² + sin(x) and y.D = 2x + cos(x)
+type Dual struct {
+	V float64 // value (real part)
+	D float64 // derivative (dual part)
+}
+
+// DualVar returns the dual number representing an independent variable; i.e. {v, 1}
+func DualVar(v float64) Dual {
+	return Dual{v, 1}
+}
+
+// DualCte returns the dual number representing a constant; i.e. {v, 0}
+func DualCte(v float64) Dual {
+	return Dual{v, 0}
+}
+
+// arithmetic ///////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a Dual) Add(b Dual) Dual {
+	return Dual{a.V + b.V, a.D + b.D}
+}
+
+// Sub returns a - b
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{a.V - b.V, a.D - b.D}
+}
+
+// Mul returns a * b
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{a.V * b.V, a.D*b.V + a.V*b.D}
+}
+
+// Div returns a / b
+func (a Dual) Div(b Dual) Dual {
+	return Dual{a.V / b.V, (a.D*b.V - a.V*b.D) / (b.V * b.V)}
+}
+
+// Neg returns -a
+func (a Dual) Neg() Dual {
+	return Dual{-a.V, -a.D}
+}
+
+// Inv returns 1 / a
+func (a Dual) Inv() Dual {
+	return a.chain(1.0/a.V, -1.0/(a.V*a.V))
+}
+
+// AddS returns a + s where s is a scalar
+func (a Dual) AddS(s float64) Dual {
+	return Dual{a.V + s, a.D}
+}
+
+// MulS returns s * a where s is a scalar
+func (a Dual) MulS(s float64) Dual {
+	return Dual{s * a.V, s * a.D}
+}
+
+// elementary functions /////////////////////////////////////////////////////////////////////////////
+
+// Sqrt returns √a
+func (a Dual) Sqrt() Dual {
+	s := math.Sqrt(a.V)
+	return a.chain(s, 0.5/s)
+}
+
+// Exp returns exp(a)
+func (a Dual) Exp() Dual {
+	e := math.Exp(a.V)
+	return a.chain(e, e)
+}
+
+// Log returns the natural logarithm of a
+func (a Dual) Log() Dual {
+	return a.chain(math.Log(a.V), 1.0/a.V)
+}
+
+// Pow returns aᵖ where p is a scalar
+func (a Dual) Pow(p float64) Dual {
+	return a.chain(math.Pow(a.V, p), p*math.Pow(a.V, p-1))
+}
+
+// PowD returns aᵇ = exp(b log(a)) where b is a dual number
+func (a Dual) PowD(b Dual) Dual {
+	return b.Mul(a.Log()).Exp()
+}
+
+// Sin returns sin(a)
+func (a Dual) Sin() Dual {
+	return a.chain(math.Sin(a.V), math.Cos(a.V))
+}
+
+// Cos returns cos(a)
+func (a Dual) Cos() Dual {
+	return a.chain(math.Cos(a.V), -math.Sin(a.V))
+}
+
+// Tan returns tan(a)
+func (a Dual) Tan() Dual {
+	t := math.Tan(a.V)
+	return a.chain(t, 1.0+t*t)
+}
+
+// Asin returns asin(a)
+func (a Dual) Asin() Dual {
+	return a.chain(math.Asin(a.V), 1.0/math.Sqrt(1.0-a.V*a.V))
+}
+
+// Acos returns acos(a)
+func (a Dual) Acos() Dual {
+	return a.chain(math.Acos(a.V), -1.0/math.Sqrt(1.0-a.V*a.V))
+}
+
+// Atan returns atan(a)
+func (a Dual) Atan() Dual {
+	return a.chain(math.Atan(a.V), 1.0/(1.0+a.V*a.V))
+}
+
+// Sinh returns sinh(a)
+func (a Dual) Sinh() Dual {
+	return a.chain(math.Sinh(a.V), math.Cosh(a.V))
+}
+
+// Cosh returns cosh(a)
+func (a Dual) Cosh() Dual {
+	return a.chain(math.Cosh(a.V), math.Sinh(a.V))
+}
+
+// Tanh returns tanh(a)
+func (a Dual) Tanh() Dual {
+	t := math.Tanh(a.V)
+	return a.chain(t, 1.0-t*t)
+}
+
+// Abs returns |a|. The derivative at a = 0 is taken as zero
+func (a Dual) Abs() Dual {
+	return a.chain(math.Abs(a.V), Sign(a.V))
+}
+
+// Sramp returns the smooth ramp function Sramp(a, β)
+func (a Dual) Sramp(β float64) Dual {
+	return a.chain(Sramp(a.V, β), SrampD1(a.V, β))
+}
+
+// Sabs returns the smooth abs function Sabs(a, eps)
+func (a Dual) Sabs(eps float64) Dual {
+	return a.chain(Sabs(a.V, eps), SabsD1(a.V, eps))
+}
+
+// DualAtan2 returns atan2(y, x)
+func DualAtan2(y, x Dual) Dual {
+	r2 := x.V*x.V + y.V*y.V
+	return Dual{math.Atan2(y.V, x.V), (x.V*y.D - y.V*x.D) / r2}
+}
+
+// Carlson's elliptic integrals //////////////////////////////////////////////////////////////////////
+//
+// The values are computed with the real functions (CarlsonRf, etc.) and the dual parts with the
+// duplication algorithm run on hyper-dual numbers (see the Carlson functions in hyperdual.go); i.e.
+// the derivatives are exact even if the arguments coincide or nearly coincide.
+//  NOTE: the derivatives with respect to an argument equal to zero are infinite
+
+// DualCarlsonRf computes Carlson's elliptic integral of the first kind Rf(x,y,z) with dual numbers.
+// See CarlsonRf
+func DualCarlsonRf(x, y, z Dual) Dual {
+	return HyperDualCarlsonRf(x.hyper(), y.hyper(), z.hyper()).dual1()
+}
+
+// DualCarlsonRd computes Carlson's elliptic integral of the second kind Rd(x,y,z) with dual
+// numbers. See CarlsonRd
+func DualCarlsonRd(x, y, z Dual) Dual {
+	return HyperDualCarlsonRd(x.hyper(), y.hyper(), z.hyper()).dual1()
+}
+
+// DualCarlsonRj computes Carlson's elliptic integral of the third kind Rj(x,y,z,p) with dual
+// numbers. See CarlsonRj
+func DualCarlsonRj(x, y, z, p Dual) Dual {
+	return HyperDualCarlsonRj(x.hyper(), y.hyper(), z.hyper(), p.hyper()).dual1()
+}
+
+// DualCarlsonRc computes Carlson's degenerate elliptic integral Rc(x,y) with dual numbers.
+// See CarlsonRc
+func DualCarlsonRc(x, y Dual) Dual {
+	return HyperDualCarlsonRc(x.hyper(), y.hyper()).dual1()
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// chain applies the chain rule: f(a) = f(a.V) + f'(a.V)⋅a.D⋅ε
+func (a Dual) chain(f, df float64) Dual {
+	if a.D == 0 { // avoid 0 * Inf
+		return Dual{f, 0}
+	}
+	return Dual{f, df * a.D}
+}
+
+// hyper returns the hyper-dual number with the ε₁ part equal to the dual part; i.e. {V, D, 0, 0}
+func (a Dual) hyper() HyperDual {
+	return HyperDual{a.V, a.D, 0, 0}
+}
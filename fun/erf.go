@@ -0,0 +1,132 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// ErfInv computes the inverse of the error function; i.e. x such that erf(x) = y
+//   NOTE: returns ±Inf if y = ±1 and NaN if |y| > 1
+func ErfInv(y float64) float64 {
+	if math.IsNaN(y) || y < -1 || y > 1 {
+		return math.NaN()
+	}
+	if y > 0.5 {
+		return ErfcInv(1.0 - y)
+	}
+	if y < -0.5 {
+		return -ErfcInv(1.0 + y)
+	}
+	x := math.Erfinv(y)
+	return erfHalley(x, math.Erf(x)-y, 1)
+}
+
+// ErfcInv computes the inverse of the complementary error function; i.e. x such that erfc(x) = y.
+// Unlike math.Erfcinv, the result is accurate for very small y; e.g. ErfcInv(1e-300) ≈ 26.2
+//   NOTE: returns +Inf if y = 0, -Inf if y = 2 and NaN if y ∉ [0, 2]
+func ErfcInv(y float64) float64 {
+	if math.IsNaN(y) || y < 0 || y > 2 {
+		return math.NaN()
+	}
+	if y == 0 {
+		return math.Inf(1)
+	}
+	if y > 1 {
+		return -ErfcInv(2.0 - y)
+	}
+	if y >= 0.5 {
+		x := math.Erfinv(1.0 - y)
+		return erfHalley(x, math.Erfc(x)-y, -1)
+	}
+
+	// initial guess from the asymptotic expansion erfc(x) ≈ exp(-x²) / (x √π)
+	x := math.Sqrt(-math.Log(y))
+	for i := 0; i < 2; i++ {
+		x = math.Sqrt(-math.Log(y * math.Sqrt(math.Pi) * x))
+	}
+
+	// Halley's method
+	for i := 0; i < 20; i++ {
+		xnew := erfHalley(x, math.Erfc(x)-y, -1)
+		if math.Abs(xnew-x) < 1e-15*x {
+			return xnew
+		}
+		x = xnew
+	}
+	return x
+}
+
+// Faddeeva computes the Faddeeva (scaled complementary error) function of complex argument
+//
+//      w(z) = exp(-z²) erfc(-i z)
+//
+//   NOTE: (1) Weideman's rational approximation (with N=40 terms) is used for |z| < 8 and a
+//             continued fraction is used otherwise; the symmetry w(z) = 2 exp(-z²) - w(-z) is
+//             used in the lower half-plane
+//         (2) the real part of w(x) for real x is exp(-x²) and the imaginary part is 2/√π
+//             times Dawson's integral
+//   Reference:
+//   [1] Weideman JAC (1994) Computation of the complex error function. SIAM Journal on Numerical
+//       Analysis, 31(5):1497-1518
+//   [2] Gautschi W (1970) Efficient computation of the complex error function. SIAM Journal on
+//       Numerical Analysis, 7(1):187-198
+func Faddeeva(z complex128) complex128 {
+	if imag(z) < 0 {
+		return 2*cmplx.Exp(-z*z) - Faddeeva(-z)
+	}
+	if cmplx.Abs(z) >= 8 {
+		r := complex(0, 0) // w(z) = (i/√π) / (z - (1/2)/(z - 1/(z - (3/2)/(z - ...))))
+		for k := 60; k >= 1; k-- {
+			r = complex(float64(k)/2.0, 0) / (z - r)
+		}
+		return complex(0, 1.0/math.Sqrt(math.Pi)) / (z - r)
+	}
+	L := complex(faddeevaL, 0)
+	iz := complex(-imag(z), real(z))
+	den := L - iz
+	Z := (L + iz) / den
+	p := complex(0, 0)
+	for n := len(faddeevaA) - 1; n >= 0; n-- {
+		p = p*Z + complex(faddeevaA[n], 0)
+	}
+	return 2*p/(den*den) + complex(1.0/math.Sqrt(math.Pi), 0)/den
+}
+
+// Weideman's coefficients for the Faddeeva function
+var faddeevaL, faddeevaA = faddeevaCoefs(40)
+
+// faddeevaCoefs computes the N coefficients of Weideman's approximation by a cosine transform
+func faddeevaCoefs(N int) (L float64, a []float64) {
+	M := 2 * N
+	L = math.Sqrt(float64(N) / math.Sqrt2)
+	φ := make([]float64, M)
+	φ[0] = L * L
+	for k := 1; k < M; k++ {
+		t := L * math.Tan(float64(k)*math.Pi/float64(2*M))
+		φ[k] = math.Exp(-t*t) * (L*L + t*t)
+	}
+	a = make([]float64, N)
+	for n := 1; n <= N; n++ {
+		sum := φ[0]
+		for k := 1; k < M; k++ {
+			sum += 2.0 * φ[k] * math.Cos(math.Pi*float64(k*n)/float64(M))
+		}
+		a[n-1] = sum / float64(2*M)
+	}
+	return
+}
+
+// erfHalley performs one Halley step to solve erf(x) = y (sgn = 1) or erfc(x) = y (sgn = -1)
+// given the residual f = erf(x) - y or f = erfc(x) - y
+func erfHalley(x, f, sgn float64) float64 {
+	d := sgn * 2.0 / math.Sqrt(math.Pi) * math.Exp(-x*x)
+	if d == 0 {
+		return x
+	}
+	u := f / d
+	return x - u/(1.0+x*u)
+}
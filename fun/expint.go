@@ -0,0 +1,145 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// EulerGamma is the Euler-Mascheroni constant γ
+const EulerGamma = 0.577215664901532860606512090082
+
+// ExpIntE1 computes the exponential integral E₁(x) = ∫₁^∞ exp(-x t) / t dt for x > 0
+//   NOTE: returns +Inf if x = 0 and NaN if x < 0
+func ExpIntE1(x float64) float64 {
+	return ExpIntEn(1, x)
+}
+
+// ExpIntEn computes the generalised exponential integral
+//
+//                ∞  exp(-x t)
+//      Eₙ(x) =  ∫   ――――――――― dt      n ≥ 0,  x ≥ 0  (x > 0 if n ≤ 1)
+//               1       tⁿ
+//
+//   NOTE: returns +Inf if x = 0 and n ≤ 1 and NaN if n < 0 or x < 0
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func ExpIntEn(n int, x float64) float64 {
+	if n < 0 || x < 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		if n <= 1 {
+			return math.Inf(1)
+		}
+		return 1.0 / float64(n-1)
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	if n == 0 {
+		return math.Exp(-x) / x
+	}
+	nm1 := float64(n - 1)
+
+	// continued fraction (modified Lentz's method)
+	if x > 1 {
+		b := x + float64(n)
+		c := 1.0 / incgFpmin
+		d := 1.0 / b
+		h := d
+		for i := 1; i < 10000; i++ {
+			a := -float64(i) * (nm1 + float64(i))
+			b += 2.0
+			d = 1.0 / (a*d + b)
+			c = b + a/c
+			del := c * d
+			h *= del
+			if math.Abs(del-1.0) <= incgEps {
+				break
+			}
+		}
+		return h * math.Exp(-x)
+	}
+
+	// series
+	var ans float64
+	if n == 1 {
+		ans = -math.Log(x) - EulerGamma
+	} else {
+		ans = 1.0 / nm1
+	}
+	fact := 1.0
+	for i := 1; i < 10000; i++ {
+		fact *= -x / float64(i)
+		var del float64
+		if i != n-1 {
+			del = -fact / (float64(i) - nm1)
+		} else {
+			ψ := -EulerGamma
+			for ii := 1; ii <= n-1; ii++ {
+				ψ += 1.0 / float64(ii)
+			}
+			del = fact * (-math.Log(x) + ψ)
+		}
+		ans += del
+		if math.Abs(del) < math.Abs(ans)*incgEps {
+			break
+		}
+	}
+	return ans
+}
+
+// ExpIntEi computes the exponential integral (Cauchy principal value)
+//
+//                   x  exp(t)
+//      Ei(x) = - P ∫   ―――――― dt      x ≠ 0
+//                 -x     t
+//
+//   NOTE: Ei(x) = -E₁(-x) for x < 0 and Ei(0) = -Inf
+func ExpIntEi(x float64) float64 {
+	if math.IsNaN(x) {
+		return x
+	}
+	if x == 0 {
+		return math.Inf(-1)
+	}
+	if x < 0 {
+		return -ExpIntE1(-x)
+	}
+	if math.IsInf(x, 1) {
+		return x
+	}
+
+	// power series
+	if x <= 50 {
+		sum, fact := 0.0, 1.0
+		for k := 1; k < 1000; k++ {
+			fact *= x / float64(k)
+			term := fact / float64(k)
+			sum += term
+			if term < incgEps*sum {
+				break
+			}
+		}
+		return sum + EulerGamma + math.Log(x)
+	}
+
+	// asymptotic series
+	sum, term := 1.0, 1.0
+	for k := 1; k < 100; k++ {
+		prev := term
+		term *= float64(k) / x
+		if term < incgEps {
+			break
+		}
+		if term < prev {
+			sum += term
+		} else {
+			sum -= prev
+			break
+		}
+	}
+	return math.Exp(x) * sum / x
+}
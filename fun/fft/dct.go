@@ -0,0 +1,164 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Dct computes the discrete cosine transform of type I, II, III or IV of real data. The
+// definitions (non-normalised) are the same as in FFTW (REDFT00, REDFT10, REDFT01 and REDFT11):
+//
+//   kind = 1:   Y[k] = x[0] + (-1)ᵏ x[N-1] + 2 Σ_{j=1}^{N-2} x[j] cos(π j k / (N-1))       N ≥ 2
+//   kind = 2:   Y[k] = 2 Σ_{j=0}^{N-1} x[j] cos(π (j+½) k / N)
+//   kind = 3:   Y[k] = x[0] + 2 Σ_{j=1}^{N-1} x[j] cos(π j (k+½) / N)
+//   kind = 4:   Y[k] = 2 Σ_{j=0}^{N-1} x[j] cos(π (j+½) (k+½) / N)
+//
+//   NOTE: the inverse of type I is type I divided by 2(N-1); the inverse of type II is type III
+//         divided by 2N (and vice-versa); the inverse of type IV is type IV divided by 2N
+//
+//   The transforms are computed with complex FFTs of even/odd extensions of x with length 2N
+//   (or 2(N-1) for type I)
+func Dct(x []float64, kind int) (Y []float64, err error) {
+	N := len(x)
+	if N < 1 || (kind == 1 && N < 2) {
+		return nil, chk.Err("length of data is too small for DCT type %d. N=%d is invalid\n", kind, N)
+	}
+	Y = make([]float64, N)
+	switch kind {
+
+	// even extension: [x0, x1, ..., xN-1, xN-2, ..., x1]
+	case 1:
+		n := 2 * (N - 1)
+		z := make([]complex128, n)
+		for j := 0; j < N; j++ {
+			z[j] = complex(x[j], 0)
+		}
+		for j := 1; j < N-1; j++ {
+			z[n-j] = z[j]
+		}
+		err = Fft1d(z, false)
+		for k := 0; k < N; k++ {
+			Y[k] = real(z[k])
+		}
+
+	// even extension: [x0, ..., xN-1, xN-1, ..., x0] => Y[k] = exp(-iπk/2N) Z[k]
+	case 2:
+		n := 2 * N
+		z := make([]complex128, n)
+		for j := 0; j < N; j++ {
+			z[j] = complex(x[j], 0)
+			z[n-1-j] = z[j]
+		}
+		err = Fft1d(z, false)
+		for k := 0; k < N; k++ {
+			Y[k] = real(expmi(math.Pi*float64(k)/float64(n)) * z[k])
+		}
+
+	// Y[k] = Re Σ w[j] x[j] exp(iπj/2N) exp(2πi j k/2N) with w[0] = 1 and w[j>0] = 2
+	case 3:
+		n := 2 * N
+		z := make([]complex128, n)
+		z[0] = complex(x[0], 0)
+		for j := 1; j < N; j++ {
+			z[j] = complex(2.0*x[j], 0) * expmi(-math.Pi*float64(j)/float64(n))
+		}
+		err = Fft1d(z, true)
+		for k := 0; k < N; k++ {
+			Y[k] = real(z[k])
+		}
+
+	// Y[k] = 2 Re exp(-iπ(k+½)/2N) Σ x[j] exp(-iπj/2N) exp(-2πi j k/2N)
+	case 4:
+		n := 2 * N
+		z := make([]complex128, n)
+		for j := 0; j < N; j++ {
+			z[j] = complex(x[j], 0) * expmi(math.Pi*float64(j)/float64(n))
+		}
+		err = Fft1d(z, false)
+		for k := 0; k < N; k++ {
+			Y[k] = 2.0 * real(expmi(math.Pi*(float64(k)+0.5)/float64(n))*z[k])
+		}
+
+	default:
+		return nil, chk.Err("kind of DCT must be 1, 2, 3 or 4. kind=%d is invalid\n", kind)
+	}
+	return
+}
+
+// Dst computes the discrete sine transform of type I, II, III or IV of real data. The
+// definitions (non-normalised) are the same as in FFTW (RODFT00, RODFT10, RODFT01 and RODFT11):
+//
+//   kind = 1:   Y[k] = 2 Σ_{j=0}^{N-1} x[j] sin(π (j+1) (k+1) / (N+1))
+//   kind = 2:   Y[k] = 2 Σ_{j=0}^{N-1} x[j] sin(π (j+½) (k+1) / N)
+//   kind = 3:   Y[k] = (-1)ᵏ x[N-1] + 2 Σ_{j=0}^{N-2} x[j] sin(π (j+1) (k+½) / N)
+//   kind = 4:   Y[k] = 2 Σ_{j=0}^{N-1} x[j] sin(π (j+½) (k+½) / N)
+//
+//   NOTE: the inverse of type I is type I divided by 2(N+1); the inverse of type II is type III
+//         divided by 2N (and vice-versa); the inverse of type IV is type IV divided by 2N
+//
+//   Types II, III and IV are computed from the corresponding DCTs:
+//     DST-II(x)[k]  = DCT-II(x̃)[N-1-k]       with x̃[j] = (-1)ʲ x[j]
+//     DST-III(x)[k] = (-1)ᵏ DCT-III(x̄)[k]    with x̄[j] = x[N-1-j]
+//     DST-IV(x)[k]  = (-1)ᵏ DCT-IV(x̄)[k]
+func Dst(x []float64, kind int) (Y []float64, err error) {
+	N := len(x)
+	if N < 1 {
+		return nil, chk.Err("length of data is too small for DST type %d. N=%d is invalid\n", kind, N)
+	}
+	switch kind {
+
+	// odd extension: [0, x0, ..., xN-1, 0, -xN-1, ..., -x0] => Y[k] = -Im Z[k+1]
+	case 1:
+		n := 2 * (N + 1)
+		z := make([]complex128, n)
+		for j := 0; j < N; j++ {
+			z[j+1] = complex(x[j], 0)
+			z[n-1-j] = complex(-x[j], 0)
+		}
+		err = Fft1d(z, false)
+		Y = make([]float64, N)
+		for k := 0; k < N; k++ {
+			Y[k] = -imag(z[k+1])
+		}
+
+	case 2:
+		xt := make([]float64, N)
+		for j := 0; j < N; j++ {
+			xt[j] = x[j]
+			if j%2 == 1 {
+				xt[j] = -x[j]
+			}
+		}
+		var Yc []float64
+		Yc, err = Dct(xt, 2)
+		if err != nil {
+			return
+		}
+		Y = make([]float64, N)
+		for k := 0; k < N; k++ {
+			Y[k] = Yc[N-1-k]
+		}
+
+	case 3, 4:
+		xr := make([]float64, N)
+		for j := 0; j < N; j++ {
+			xr[j] = x[N-1-j]
+		}
+		Y, err = Dct(xr, kind)
+		if err != nil {
+			return
+		}
+		for k := 1; k < N; k += 2 {
+			Y[k] = -Y[k]
+		}
+
+	default:
+		return nil, chk.Err("kind of DST must be 1, 2, 3 or 4. kind=%d is invalid\n", kind)
+	}
+	return
+}
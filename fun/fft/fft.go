@@ -0,0 +1,214 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fft implements Fast Fourier Transforms in pure Go (no cgo). Transforms of any length are
+// computed with the mixed-radix Cooley-Tukey algorithm or, if the length has large prime factors,
+// with Bluestein's algorithm. The Plan structures mirror the ones in fun/fftw
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Fft1d computes the discrete Fourier transform (DFT) of x in place
+//
+//   Computes:
+//                      N-1         -i 2 π k l / N
+//               X[l] =  Σ  x[k] ⋅ e
+//                      k=0
+//
+//   Input:
+//     x       -- complex array of any length N ≥ 1
+//     inverse -- computes the inverse transform (exponent with positive sign)
+//
+//   NOTE: both transforms are non-normalised; i.e. the inverse transform must be multiplied by 1/N
+func Fft1d(x []complex128, inverse bool) (err error) {
+	k, err := newKernel(len(x))
+	if err != nil {
+		return
+	}
+	k.transform(x, inverse)
+	return
+}
+
+// FftNd computes the multidimensional discrete Fourier transform of x in place
+//
+//   Computes (e.g. 2D):
+//                      N1-1 N0-1             -i 2 π k1 l1 / N1    -i 2 π k0 l0 / N0
+//           X[l0,l1] =   Σ    Σ  x[k0,k1] ⋅ e                  ⋅ e
+//                      k1=0 k0=0
+//   Input:
+//     x       -- complex array in row-major order; e.g. x[k0,k1] = x[k0⋅N1 + k1]
+//     dims    -- dimensions [N0, N1, ...]. len(x) == N0⋅N1⋅...
+//     inverse -- computes the inverse transform
+//
+//   NOTE: both transforms are non-normalised; i.e. the inverse transform must be multiplied by
+//         1/(N0⋅N1⋅...)
+func FftNd(x []complex128, dims []int, inverse bool) (err error) {
+	kernels, err := newKernelsNd(dims, len(x))
+	if err != nil {
+		return
+	}
+	transformNd(x, dims, kernels, inverse)
+	return
+}
+
+// RealFft1d computes the discrete Fourier transform of real data. Because the output is Hermitian
+// symmetric (X[N-l] = conj(X[l])), only the first N/2+1 values are returned. For even N, the
+// transform is computed with a complex FFT of length N/2
+func RealFft1d(x []float64) (X []complex128, err error) {
+	k, err := newRealKernel(len(x))
+	if err != nil {
+		return
+	}
+	X = make([]complex128, len(x)/2+1)
+	realForward(X, x, k, make([]complex128, k.n))
+	return
+}
+
+// RealIfft1d computes the inverse transform of Hermitian symmetric data. X holds the first N/2+1
+// values of the spectrum (e.g. the output of RealFft1d) and the real result has length N
+//   NOTE: the transform is non-normalised; i.e. the result must be multiplied by 1/N
+func RealIfft1d(X []complex128, N int) (x []float64, err error) {
+	if len(X) != N/2+1 {
+		return nil, chk.Err("len(X) must be equal to N/2+1. %d != %d\n", len(X), N/2+1)
+	}
+	k, err := newRealKernel(N)
+	if err != nil {
+		return
+	}
+	x = make([]float64, N)
+	realInverse(x, X, k, make([]complex128, k.n))
+	return
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// newKernelsNd allocates the kernels for each dimension of a multidimensional transform
+func newKernelsNd(dims []int, ntot int) (kernels []*kernel, err error) {
+	n := 1
+	for _, d := range dims {
+		n *= d
+	}
+	if len(dims) < 1 || n != ntot {
+		return nil, chk.Err("length of data must be equal to the product of dims. %d != %d\n", ntot, n)
+	}
+	kernels = make([]*kernel, len(dims))
+	for i, d := range dims {
+		kernels[i], err = newKernel(d)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// transformNd computes the multidimensional transform by applying 1D transforms along each
+// dimension
+func transformNd(x []complex128, dims []int, kernels []*kernel, inverse bool) {
+	ntot, nmax := len(x), 0
+	for _, n := range dims {
+		if n > nmax {
+			nmax = n
+		}
+	}
+	line := make([]complex128, nmax)
+	stride := ntot
+	for idim, n := range dims {
+		stride /= n
+		if n == 1 {
+			continue
+		}
+		block := n * stride
+		for start := 0; start < ntot; start += block { // all lines along this dimension
+			for off := 0; off < stride; off++ {
+				for i := 0; i < n; i++ {
+					line[i] = x[start+off+i*stride]
+				}
+				kernels[idim].transform(line[:n], inverse)
+				for i := 0; i < n; i++ {
+					x[start+off+i*stride] = line[i]
+				}
+			}
+		}
+	}
+}
+
+// newRealKernel allocates the kernel for real transforms with length N; i.e. with length N/2 if
+// N is even or N if N is odd
+func newRealKernel(N int) (*kernel, error) {
+	if N < 1 {
+		return nil, chk.Err("length of real data must be greater than zero. N=%d is invalid\n", N)
+	}
+	if N%2 == 1 {
+		return newKernel(N)
+	}
+	return newKernel(N / 2)
+}
+
+// realForward computes the first N/2+1 values X of the transform of real data x using the kernel
+// allocated by newRealKernel and a workspace z with length k.n
+func realForward(X []complex128, x []float64, k *kernel, z []complex128) {
+	N := len(x)
+
+	// odd N: complex transform
+	if N%2 == 1 {
+		for i := 0; i < N; i++ {
+			z[i] = complex(x[i], 0)
+		}
+		k.forward(z)
+		copy(X, z)
+		return
+	}
+
+	// even N: z[j] = x[2j] + i x[2j+1] with length M = N/2
+	M := N / 2
+	for j := 0; j < M; j++ {
+		z[j] = complex(x[2*j], x[2*j+1])
+	}
+	k.forward(z)
+	for l := 0; l <= M; l++ {
+		zl, zc := z[l%M], cmplx.Conj(z[(M-l)%M])
+		e := (zl + zc) * 0.5
+		o := (zl - zc) * complex(0, -0.5) // (zl - zc) / (2i)
+		X[l] = e + expmi(2.0*math.Pi*float64(l)/float64(N))*o
+	}
+}
+
+// realInverse computes the (non-normalised) inverse transform x with length N of the Hermitian
+// symmetric data X with length N/2+1 using the kernel allocated by newRealKernel and a workspace z
+// with length k.n
+func realInverse(x []float64, X []complex128, k *kernel, z []complex128) {
+	N := len(x)
+
+	// odd N: complex transform
+	if N%2 == 1 {
+		copy(z, X)
+		for l := len(X); l < N; l++ {
+			z[l] = cmplx.Conj(X[N-l])
+		}
+		k.transform(z, true)
+		for i := 0; i < N; i++ {
+			x[i] = real(z[i])
+		}
+		return
+	}
+
+	// even N: reverse the packing used by realForward
+	M := N / 2
+	for l := 0; l < M; l++ {
+		xl, xc := X[l], cmplx.Conj(X[M-l])
+		e := (xl + xc) * 0.5
+		o := (xl - xc) * 0.5 / expmi(2.0*math.Pi*float64(l)/float64(N))
+		z[l] = e + complex(0, 1)*o
+	}
+	k.transform(z, true)
+	for j := 0; j < M; j++ {
+		x[2*j] = 2.0 * real(z[j])
+		x[2*j+1] = 2.0 * imag(z[j])
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// maxRadix is the largest prime factor handled by the mixed-radix algorithm. Lengths with larger
+// prime factors are computed with Bluestein's algorithm
+const maxRadix = 31
+
+// kernel holds the data to compute complex FFTs of a given length n
+type kernel struct {
+	n       int          // length of transform
+	factors []int        // radices: n = factors[0] ⋅ factors[1] ⋅ ...
+	tw      []complex128 // twiddle factors: exp(-2πi k/n)
+	scratch []complex128 // workspace for the generic butterfly
+	buf     []complex128 // output buffer of the mixed-radix algorithm
+	blue    bool         // use Bluestein's algorithm
+	chirp   []complex128 // Bluestein: exp(-πi k²/n)
+	bfft    []complex128 // Bluestein: FFT of the conjugate chirp (convolution kernel)
+	work    []complex128 // Bluestein: workspace with length m
+	inner   *kernel      // Bluestein: power-of-two kernel with length m ≥ 2n-1
+}
+
+// newKernel allocates a new kernel for transforms with length n
+func newKernel(n int) (o *kernel, err error) {
+	if n < 1 {
+		return nil, chk.Err("length of transform must be greater than zero. n=%d is invalid\n", n)
+	}
+	o = new(kernel)
+	o.n = n
+
+	// factorise
+	rem := n
+	for rem%4 == 0 {
+		o.factors = append(o.factors, 4)
+		rem /= 4
+	}
+	for rem%2 == 0 {
+		o.factors = append(o.factors, 2)
+		rem /= 2
+	}
+	for p := 3; p*p <= rem; p += 2 {
+		for rem%p == 0 {
+			o.factors = append(o.factors, p)
+			rem /= p
+		}
+	}
+	if rem > 1 {
+		o.factors = append(o.factors, rem)
+	}
+
+	// Bluestein's algorithm
+	if rem > maxRadix {
+		o.blue = true
+		m := 1
+		for m < 2*n-1 {
+			m <<= 1
+		}
+		o.inner, err = newKernel(m)
+		if err != nil {
+			return
+		}
+		o.chirp = make([]complex128, n)
+		n2 := int64(2 * n)
+		for k := 0; k < n; k++ {
+			kk := (int64(k) * int64(k)) % n2 // avoids loss of precision with large k
+			o.chirp[k] = expmi(math.Pi * float64(kk) / float64(n))
+		}
+		o.bfft = make([]complex128, m)
+		o.bfft[0] = cmplx.Conj(o.chirp[0])
+		for k := 1; k < n; k++ {
+			o.bfft[k] = cmplx.Conj(o.chirp[k])
+			o.bfft[m-k] = o.bfft[k]
+		}
+		o.inner.forward(o.bfft)
+		o.work = make([]complex128, m)
+		return
+	}
+
+	// mixed-radix algorithm
+	o.tw = make([]complex128, n)
+	for k := 0; k < n; k++ {
+		o.tw[k] = expmi(2.0 * math.Pi * float64(k) / float64(n))
+	}
+	pmax := 0
+	for _, p := range o.factors {
+		if p > pmax {
+			pmax = p
+		}
+	}
+	o.scratch = make([]complex128, pmax)
+	o.buf = make([]complex128, n)
+	return
+}
+
+// transform computes the direct or inverse (non-normalised) transform of x in place
+func (o *kernel) transform(x []complex128, inverse bool) {
+	if inverse { // ifft(x) = conj(fft(conj(x)))
+		for i := 0; i < o.n; i++ {
+			x[i] = cmplx.Conj(x[i])
+		}
+		o.forward(x)
+		for i := 0; i < o.n; i++ {
+			x[i] = cmplx.Conj(x[i])
+		}
+		return
+	}
+	o.forward(x)
+}
+
+// forward computes the direct transform of x in place
+//                      n-1         -i 2 π k l / n
+//               X[l] =  Σ  x[k] ⋅ e
+//                      k=0
+func (o *kernel) forward(x []complex128) {
+	if o.n == 1 {
+		return
+	}
+	if o.blue {
+		o.bluestein(x)
+		return
+	}
+	o.mixed(o.buf, x, o.n, 1, o.factors)
+	copy(x, o.buf)
+}
+
+// mixed computes the decimation-in-time mixed-radix FFT of in[0], in[s], in[2s], ... (n values)
+// and stores the results in out[0:n]
+//   Reference:
+//   [1] Cooley JW, Tukey JW (1965) An algorithm for the machine calculation of complex Fourier
+//       series. Mathematics of Computation, 19(90):297-301
+func (o *kernel) mixed(out, in []complex128, n, s int, factors []int) {
+	p := factors[0]
+	m := n / p
+	if m == 1 {
+		for q := 0; q < p; q++ {
+			out[q] = in[q*s]
+		}
+	} else {
+		for q := 0; q < p; q++ {
+			o.mixed(out[q*m:], in[q*s:], m, s*p, factors[1:])
+		}
+	}
+	switch p {
+	case 2:
+		o.butterfly2(out, s, m)
+	case 4:
+		o.butterfly4(out, s, m)
+	default:
+		o.butterfly(out, s, p, m)
+	}
+}
+
+// butterfly2 combines two sub-transforms with length m
+func (o *kernel) butterfly2(out []complex128, s, m int) {
+	for u := 0; u < m; u++ {
+		t := out[u+m] * o.tw[u*s]
+		out[u+m] = out[u] - t
+		out[u] += t
+	}
+}
+
+// butterfly4 combines four sub-transforms with length m
+func (o *kernel) butterfly4(out []complex128, s, m int) {
+	for u := 0; u < m; u++ {
+		a0 := out[u]
+		a1 := out[u+m] * o.tw[u*s]
+		a2 := out[u+2*m] * o.tw[2*u*s]
+		a3 := out[u+3*m] * o.tw[3*u*s]
+		b0, b1 := a0+a2, a0-a2
+		b2, b3 := a1+a3, complex(imag(a1-a3), -real(a1-a3)) // b3 = -i (a1 - a3)
+		out[u] = b0 + b2
+		out[u+m] = b1 + b3
+		out[u+2*m] = b0 - b2
+		out[u+3*m] = b1 - b3
+	}
+}
+
+// butterfly combines p sub-transforms with length m (generic radix)
+func (o *kernel) butterfly(out []complex128, s, p, m int) {
+	sm := s * m
+	for u := 0; u < m; u++ {
+		for q := 0; q < p; q++ {
+			o.scratch[q] = out[u+q*m] * o.tw[u*q*s]
+		}
+		for k := 0; k < p; k++ {
+			sum := o.scratch[0]
+			idx, step := 0, k*sm
+			for q := 1; q < p; q++ {
+				idx += step
+				if idx >= o.n {
+					idx -= o.n
+				}
+				sum += o.scratch[q] * o.tw[idx]
+			}
+			out[u+k*m] = sum
+		}
+	}
+}
+
+// bluestein computes the FFT of x in place using Bluestein's algorithm; i.e. the DFT is written
+// as a convolution which is computed with power-of-two FFTs
+//   Reference:
+//   [1] Bluestein LI (1970) A linear filtering approach to the computation of discrete Fourier
+//       transform. IEEE Transactions on Audio and Electroacoustics, 18(4):451-455
+func (o *kernel) bluestein(x []complex128) {
+	m := o.inner.n
+	for k := 0; k < o.n; k++ {
+		o.work[k] = x[k] * o.chirp[k]
+	}
+	for k := o.n; k < m; k++ {
+		o.work[k] = 0
+	}
+	o.inner.forward(o.work)
+	for k := 0; k < m; k++ {
+		o.work[k] = cmplx.Conj(o.work[k] * o.bfft[k])
+	}
+	o.inner.forward(o.work) // conj(ifft(y)) = fft(conj(y))
+	scale := 1.0 / float64(m)
+	for k := 0; k < o.n; k++ {
+		x[k] = o.chirp[k] * cmplx.Conj(o.work[k]) * complex(scale, 0)
+	}
+}
+
+// expmi computes exp(-i a)
+func expmi(a float64) complex128 {
+	s, c := math.Sincos(a)
+	return complex(c, -s)
+}
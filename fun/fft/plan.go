@@ -0,0 +1,357 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"github.com/cpmech/gosl/chk"
+)
+
+// Plan1d holds the data to compute direct or inverse 1D FTs. It mirrors fftw.Plan1d; thus, code
+// using FFTW may switch to this package by changing only the import path
+type Plan1d struct {
+	Xin     []float64    // input: complex pairs len=2*N or real values len=N (real input)
+	Xout    []float64    // output: complex pairs len=2*N or len=2*(N/2+1) (real input)
+	realIn  bool         // set with real input?
+	inverse bool         // inverse transform
+	n       int          // length of transform
+	k       *kernel      // kernel
+	z       []complex128 // workspace
+}
+
+// NewPlan1d allocates a new "plan" to compute 1D Fourier Transforms with complex numbers input
+//
+//   Computes:
+//                      N-1         -i 2 π k l / N
+//               X[l] =  Σ  x[k] ⋅ e
+//                      k=0
+//   INPUT:
+//
+//     data -- is a complex array stored as a real array of length 2*n. [real,imag, real,imag, ...]
+//             data may be nil, in this case N must be provided
+//
+//     N -- half-size of the data array. Use this to allocate input (data) array.
+//          data must be nil to use N
+//
+//     inverse -- will perform inverse transform; otherwise will perform direct
+//                Note: both transforms are non-normalised;
+//                i.e. the user will have to multiply by (1/n) if computing inverse transforms
+//
+//     inplace -- use data array as output as well, thus data will be overwritten
+//
+//     measure -- ignored; available for compatibility with fftw.NewPlan1d
+//
+//   NOTE: any N ≥ 2 is accepted
+//
+func NewPlan1d(data []float64, N int, inverse, inplace, measure bool) (o *Plan1d, err error) {
+
+	// set/allocate input array
+	o = new(Plan1d)
+	if len(data) < 2 {
+		if N < 2 {
+			return nil, chk.Err("N must be greater than 1 when data==nil or len(data)<2. N=%d is invalid\n", N)
+		}
+		o.Xin = make([]float64, 2*N)
+	} else {
+		if len(data)%2 > 0 {
+			return nil, chk.Err("len(data) must be even. %d is invalid\n", len(data))
+		}
+		N = len(data) / 2
+		o.Xin = data
+	}
+
+	// set or allocate output array
+	if inplace {
+		o.Xout = o.Xin
+	} else {
+		o.Xout = make([]float64, 2*N)
+	}
+
+	// kernel
+	o.n, o.inverse = N, inverse
+	o.k, err = newKernel(N)
+	o.z = make([]complex128, N)
+	return
+}
+
+// NewPlan1dReal allocates a new "plan" to compute 1D Fourier Transforms with real numbers input
+//   INPUT:
+//     data -- is a real array of length N and may be nil, in this case N must be non-zero
+//   NOTE: (1) see NewPlan1d for further information on the input
+//         (2) if inverse==true, the input is the first N/2+1 (complex) values of a Hermitian
+//             symmetric spectrum stored as RC pairs in Xin (len=2*(N/2+1)); data must be nil and
+//             N must be given. The real output is stored in Xout (len=N)
+func NewPlan1dReal(data []float64, N int, inverse, measure bool) (o *Plan1d, err error) {
+
+	// set/allocate input array
+	o = new(Plan1d)
+	if len(data) < 2 {
+		if N < 2 {
+			return nil, chk.Err("N must be greater than 1 when data==nil or len(data)<2. N=%d is invalid\n", N)
+		}
+	} else {
+		if inverse {
+			return nil, chk.Err("data must be nil with inverse transforms of real data\n")
+		}
+		N = len(data)
+		o.Xin = data
+	}
+	o.n, o.realIn, o.inverse = N, true, inverse
+
+	// allocate arrays
+	if inverse {
+		o.Xin = make([]float64, 2*(N/2+1)) // ×2 => complex128
+		o.Xout = make([]float64, N)
+	} else {
+		if o.Xin == nil {
+			o.Xin = make([]float64, N)
+		}
+		o.Xout = make([]float64, 2*(N/2+1)) // ×2 => complex128
+	}
+
+	// kernel
+	o.k, err = newRealKernel(N)
+	if err != nil {
+		return
+	}
+	o.z = make([]complex128, N/2+1+o.k.n)
+	return
+}
+
+// Free does nothing; it is available for compatibility with fftw.Plan1d
+func (o *Plan1d) Free() {}
+
+// Input sets input value located at "i". Complex numbers input.
+//   NOTE: (1) this method does not check for out-of-range indices
+//         (2) this method must not be used when the input is initialised as "real"; except with
+//             the inverse transform of real data, where i < N/2+1
+func (o *Plan1d) Input(i int, v complex128) {
+	o.Xin[i*2] = real(v)
+	o.Xin[i*2+1] = imag(v)
+}
+
+// InputReal sets input value located at "i". Real numbers input.
+//   NOTE: (1) this method does not check for out-of-range indices
+//         (2) this method must not be used when the input is initialised as "complex"
+func (o *Plan1d) InputReal(i int, v float64) {
+	o.Xin[i] = v
+}
+
+// Output gets output value located at "i". Complex numbers input.
+//   NOTE: this method does not check for out-of-range indices
+func (o *Plan1d) Output(i int) (v complex128) {
+	if o.realIn {
+		if o.inverse {
+			return complex(o.Xout[i], 0)
+		}
+		if i < o.n/2+1 {
+			return complex(o.Xout[i*2], o.Xout[i*2+1])
+		}
+		j := o.n - i // complex conjugate (reversed)
+		return complex(o.Xout[j*2], -o.Xout[j*2+1])
+	}
+	return complex(o.Xout[i*2], o.Xout[i*2+1])
+}
+
+// GetOutput returns a new slice with the output, for real-input or not
+func (o *Plan1d) GetOutput() (res []complex128) {
+	res = make([]complex128, o.n)
+	for i := 0; i < o.n; i++ {
+		res[i] = o.Output(i)
+	}
+	return
+}
+
+// Execute performs the Fourier transform
+func (o *Plan1d) Execute() {
+	if o.realIn {
+		nh := o.n/2 + 1
+		X, z := o.z[:nh], o.z[nh:]
+		if o.inverse {
+			for i := 0; i < nh; i++ {
+				X[i] = complex(o.Xin[2*i], o.Xin[2*i+1])
+			}
+			realInverse(o.Xout, X, o.k, z)
+			return
+		}
+		realForward(X, o.Xin, o.k, z)
+		for i := 0; i < nh; i++ {
+			o.Xout[2*i], o.Xout[2*i+1] = real(X[i]), imag(X[i])
+		}
+		return
+	}
+	for i := 0; i < o.n; i++ {
+		o.z[i] = complex(o.Xin[2*i], o.Xin[2*i+1])
+	}
+	o.k.transform(o.z, o.inverse)
+	for i := 0; i < o.n; i++ {
+		o.Xout[2*i], o.Xout[2*i+1] = real(o.z[i]), imag(o.z[i])
+	}
+}
+
+// 2d and 3d /////////////////////////////////////////////////////////////////////////////////////
+
+// planNd holds the data to compute multidimensional FTs
+type planNd struct {
+	dims    []int        // dimensions
+	Xin     []float64    // input: complex pairs len=2*N0*N1*...
+	Xout    []float64    // output: complex pairs len=2*N0*N1*...
+	inverse bool         // inverse transform
+	kernels []*kernel    // one kernel for each dimension
+	z       []complex128 // workspace
+}
+
+// init initialises planNd
+func (o *planNd) init(data []float64, dims []int, inverse, inplace bool) (err error) {
+	ntot := 1
+	for _, n := range dims {
+		if n < 1 {
+			return chk.Err("dimensions must be greater than zero. %v is invalid\n", dims)
+		}
+		ntot *= n
+	}
+	if len(data) < 2 {
+		o.Xin = make([]float64, 2*ntot)
+	} else {
+		if len(data) != 2*ntot {
+			return chk.Err("len(data) must be equal to 2*%v. %d is invalid (should be %d)\n", dims, len(data), 2*ntot)
+		}
+		o.Xin = data
+	}
+	if inplace {
+		o.Xout = o.Xin
+	} else {
+		o.Xout = make([]float64, 2*ntot)
+	}
+	o.dims, o.inverse = dims, inverse
+	o.kernels, err = newKernelsNd(dims, ntot)
+	o.z = make([]complex128, ntot)
+	return
+}
+
+// execute performs the Fourier transform
+func (o *planNd) execute() {
+	for l := 0; l < len(o.z); l++ {
+		o.z[l] = complex(o.Xin[2*l], o.Xin[2*l+1])
+	}
+	transformNd(o.z, o.dims, o.kernels, o.inverse)
+	for l := 0; l < len(o.z); l++ {
+		o.Xout[2*l], o.Xout[2*l+1] = real(o.z[l]), imag(o.z[l])
+	}
+}
+
+// Plan2d holds the data to compute direct or inverse 2D FTs. It mirrors fftw.Plan2d
+type Plan2d struct {
+	planNd
+	n0 int // length along first dimension
+	n1 int // length along second dimension
+}
+
+// NewPlan2d allocates a new "plan" to compute 2D Fourier Transforms
+//
+//   Computes:
+//                      N1-1 N0-1             -i 2 π k1 l1 / N1    -i 2 π k0 l0 / N0
+//           X[l0,l1] =   Σ    Σ  x[k0,k1] ⋅ e                  ⋅ e
+//                      k1=0 k0=0
+//   INPUT:
+//     data   -- complex array in row-major order stored as RC pairs (see fftw.NewPlan2d); i.e.
+//               data[2⋅l] and data[2⋅l+1] with l = N1⋅i + j. data may be nil
+//     N0, N1 -- dimensions (any positive value)
+//     NOTE: see NewPlan1d for the other arguments
+func NewPlan2d(data []float64, N0, N1 int, inverse, inplace, measure bool) (o *Plan2d, err error) {
+	o = new(Plan2d)
+	o.n0, o.n1 = N0, N1
+	err = o.init(data, []int{N0, N1}, inverse, inplace)
+	return
+}
+
+// Free does nothing; it is available for compatibility with fftw.Plan2d
+func (o *Plan2d) Free() {}
+
+// Input sets input value located at "i,j". NOTE: this method does not check for out-of-range indices
+func (o *Plan2d) Input(i, j int, vReal, vImag float64) {
+	l := o.n1*i + j
+	o.Xin[2*l] = vReal
+	o.Xin[2*l+1] = vImag
+}
+
+// Output gets output value located at "i,j". NOTE: this method does not check for out-of-range indices
+func (o *Plan2d) Output(i, j int) (v complex128) {
+	l := o.n1*i + j
+	return complex(o.Xout[2*l], o.Xout[2*l+1])
+}
+
+// Execute performs the Fourier transform
+func (o *Plan2d) Execute() {
+	o.execute()
+}
+
+// GetOutput gets the output array as a matrix of complex numbers
+func (o *Plan2d) GetOutput() (out [][]complex128) {
+	out = make([][]complex128, o.n0)
+	for i := 0; i < o.n0; i++ {
+		out[i] = make([]complex128, o.n1)
+		for j := 0; j < o.n1; j++ {
+			out[i][j] = o.Output(i, j)
+		}
+	}
+	return
+}
+
+// Plan3d holds the data to compute direct or inverse 3D FTs
+type Plan3d struct {
+	planNd
+	n0 int // length along first dimension
+	n1 int // length along second dimension
+	n2 int // length along third dimension
+}
+
+// NewPlan3d allocates a new "plan" to compute 3D Fourier Transforms
+//   INPUT:
+//     data       -- complex array in row-major order stored as RC pairs; i.e. data[2⋅l] and
+//                   data[2⋅l+1] with l = (N1⋅i + j)⋅N2 + k. data may be nil
+//     N0, N1, N2 -- dimensions (any positive value)
+//     NOTE: see NewPlan1d for the other arguments
+func NewPlan3d(data []float64, N0, N1, N2 int, inverse, inplace, measure bool) (o *Plan3d, err error) {
+	o = new(Plan3d)
+	o.n0, o.n1, o.n2 = N0, N1, N2
+	err = o.init(data, []int{N0, N1, N2}, inverse, inplace)
+	return
+}
+
+// Free does nothing; it is available for compatibility with the other plans
+func (o *Plan3d) Free() {}
+
+// Input sets input value located at "i,j,k". NOTE: this method does not check for out-of-range indices
+func (o *Plan3d) Input(i, j, k int, vReal, vImag float64) {
+	l := (o.n1*i+j)*o.n2 + k
+	o.Xin[2*l] = vReal
+	o.Xin[2*l+1] = vImag
+}
+
+// Output gets output value located at "i,j,k". NOTE: this method does not check for out-of-range indices
+func (o *Plan3d) Output(i, j, k int) (v complex128) {
+	l := (o.n1*i+j)*o.n2 + k
+	return complex(o.Xout[2*l], o.Xout[2*l+1])
+}
+
+// Execute performs the Fourier transform
+func (o *Plan3d) Execute() {
+	o.execute()
+}
+
+// GetOutput gets the output array as a 3D array of complex numbers
+func (o *Plan3d) GetOutput() (out [][][]complex128) {
+	out = make([][][]complex128, o.n0)
+	for i := 0; i < o.n0; i++ {
+		out[i] = make([][]complex128, o.n1)
+		for j := 0; j < o.n1; j++ {
+			out[i][j] = make([]complex128, o.n2)
+			for k := 0; k < o.n2; k++ {
+				out[i][j][k] = o.Output(i, j, k)
+			}
+		}
+	}
+	return
+}
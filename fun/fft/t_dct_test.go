@@ -0,0 +1,145 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestDct01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dct01. DCT and DST types I-IV")
+
+	for _, N := range []int{1, 2, 3, 4, 7, 8, 13, 40} {
+		x := make([]float64, N)
+		for j := 0; j < N; j++ {
+			x[j] = math.Cos(float64(j)) + 0.1*float64(j)
+		}
+		for kind := 1; kind <= 4; kind++ {
+
+			// DCT
+			if N > 1 || kind > 1 {
+				Y, err := Dct(x, kind)
+				if err != nil {
+					tst.Errorf("%v\n", err)
+					return
+				}
+				chk.Vector(tst, io.Sf("DCT-%d (N=%d)", kind, N), 1e-12, Y, dctNaive(x, kind))
+				y, err := Dct(Y, dctInverseKind[kind])
+				if err != nil {
+					tst.Errorf("%v\n", err)
+					return
+				}
+				la.VecScale(y, 0, 1.0/dctInverseScale(kind, N), y)
+				chk.Vector(tst, io.Sf("iDCT-%d (N=%d)", kind, N), 1e-13, y, x)
+			}
+
+			// DST
+			Y, err := Dst(x, kind)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			chk.Vector(tst, io.Sf("DST-%d (N=%d)", kind, N), 1e-12, Y, dstNaive(x, kind))
+			y, err := Dst(Y, dctInverseKind[kind])
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			scale := dctInverseScale(kind, N)
+			if kind == 1 {
+				scale = 2.0 * float64(N+1)
+			}
+			la.VecScale(y, 0, 1.0/scale, y)
+			chk.Vector(tst, io.Sf("iDST-%d (N=%d)", kind, N), 1e-13, y, x)
+		}
+	}
+
+	// errors
+	if _, err := Dct([]float64{1}, 1); err == nil {
+		tst.Errorf("DCT-I should have failed with N = 1\n")
+	}
+	if _, err := Dst([]float64{1, 2}, 5); err == nil {
+		tst.Errorf("DST should have failed with kind = 5\n")
+	}
+}
+
+// dctInverseKind holds the kind of the inverse transforms
+var dctInverseKind = []int{0, 1, 3, 2, 4}
+
+// dctInverseScale returns the scaling factor of the inverse DCT
+func dctInverseScale(kind, N int) float64 {
+	if kind == 1 {
+		return 2.0 * float64(N-1)
+	}
+	return 2.0 * float64(N)
+}
+
+// dctNaive computes the DCT using the definition (slow: for testing only)
+func dctNaive(x []float64, kind int) (Y []float64) {
+	N := len(x)
+	n := float64(N)
+	Y = make([]float64, N)
+	for k := 0; k < N; k++ {
+		fk := float64(k)
+		switch kind {
+		case 1:
+			Y[k] = x[0] + math.Pow(-1, fk)*x[N-1]
+			for j := 1; j < N-1; j++ {
+				Y[k] += 2 * x[j] * math.Cos(math.Pi*float64(j)*fk/(n-1))
+			}
+		case 2:
+			for j := 0; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Cos(math.Pi*(float64(j)+0.5)*fk/n)
+			}
+		case 3:
+			Y[k] = x[0]
+			for j := 1; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Cos(math.Pi*float64(j)*(fk+0.5)/n)
+			}
+		case 4:
+			for j := 0; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Cos(math.Pi*(float64(j)+0.5)*(fk+0.5)/n)
+			}
+		}
+	}
+	return
+}
+
+// dstNaive computes the DST using the definition (slow: for testing only)
+func dstNaive(x []float64, kind int) (Y []float64) {
+	N := len(x)
+	n := float64(N)
+	Y = make([]float64, N)
+	for k := 0; k < N; k++ {
+		fk := float64(k)
+		switch kind {
+		case 1:
+			for j := 0; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Sin(math.Pi*float64(j+1)*(fk+1)/(n+1))
+			}
+		case 2:
+			for j := 0; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Sin(math.Pi*(float64(j)+0.5)*(fk+1)/n)
+			}
+		case 3:
+			Y[k] = math.Pow(-1, fk) * x[N-1]
+			for j := 0; j < N-1; j++ {
+				Y[k] += 2 * x[j] * math.Sin(math.Pi*float64(j+1)*(fk+0.5)/n)
+			}
+		case 4:
+			for j := 0; j < N; j++ {
+				Y[k] += 2 * x[j] * math.Sin(math.Pi*(float64(j)+0.5)*(fk+0.5)/n)
+			}
+		}
+	}
+	return
+}
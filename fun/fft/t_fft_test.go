@@ -0,0 +1,157 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestFft01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fft01. arbitrary lengths: mixed-radix and Bluestein")
+
+	for _, N := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 12, 15, 16, 25, 30, 31, 37, 49, 60, 64, 97, 100, 127, 210, 256, 1000, 1013} {
+		x := make([]complex128, N)
+		for i := 0; i < N; i++ {
+			x[i] = complex(math.Sin(float64(i)+0.5), math.Cos(0.3*float64(i*i)))
+		}
+		k, _ := newKernel(N)
+		io.Pforan("N = %4d  factors = %v  Bluestein = %v\n", N, k.factors, k.blue)
+
+		// direct
+		X := make([]complex128, N)
+		copy(X, x)
+		err := Fft1d(X, false)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		tol := 1e-12 * float64(N)
+		chk.VectorC(tst, io.Sf("X (N=%d)", N), tol, X, dft1d(x, false))
+
+		// inverse
+		err = Fft1d(X, true)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i := 0; i < N; i++ {
+			X[i] /= complex(float64(N), 0)
+		}
+		chk.VectorC(tst, io.Sf("x (N=%d)", N), 1e-13, X, x)
+	}
+
+	// error
+	if Fft1d(nil, false) == nil {
+		tst.Errorf("Fft1d should have failed with N = 0\n")
+	}
+}
+
+func TestFft02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fft02. real input")
+
+	for _, N := range []int{1, 2, 3, 4, 5, 8, 11, 12, 37, 74, 100} {
+		x := make([]float64, N)
+		xc := make([]complex128, N)
+		for i := 0; i < N; i++ {
+			x[i] = math.Exp(-0.1*float64(i)) + math.Sin(float64(i))
+			xc[i] = complex(x[i], 0)
+		}
+		X, err := RealFft1d(x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.VectorC(tst, io.Sf("X (N=%d)", N), 1e-12*float64(N), X, dft1d(xc, false)[:N/2+1])
+		y, err := RealIfft1d(X, N)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		la.VecScale(y, 0, 1.0/float64(N), y)
+		chk.Vector(tst, io.Sf("x (N=%d)", N), 1e-14, y, x)
+	}
+}
+
+func TestFft03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fft03. multidimensional")
+
+	dims := []int{3, 4, 5}
+	N := 3 * 4 * 5
+	x := make([]complex128, N)
+	for l := 0; l < N; l++ {
+		x[l] = complex(float64(l%7), float64(l%3)-1)
+	}
+	X := make([]complex128, N)
+	copy(X, x)
+	err := FftNd(X, dims, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// naive DFT
+	Xref := make([]complex128, N)
+	for l0 := 0; l0 < 3; l0++ {
+		for l1 := 0; l1 < 4; l1++ {
+			for l2 := 0; l2 < 5; l2++ {
+				var sum complex128
+				for k0 := 0; k0 < 3; k0++ {
+					for k1 := 0; k1 < 4; k1++ {
+						for k2 := 0; k2 < 5; k2++ {
+							a := 2 * math.Pi * (float64(k0*l0)/3 + float64(k1*l1)/4 + float64(k2*l2)/5)
+							sum += x[(k0*4+k1)*5+k2] * expmi(a)
+						}
+					}
+				}
+				Xref[(l0*4+l1)*5+l2] = sum
+			}
+		}
+	}
+	chk.VectorC(tst, "X", 1e-12, X, Xref)
+
+	// inverse
+	err = FftNd(X, dims, true)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for l := 0; l < N; l++ {
+		X[l] /= complex(float64(N), 0)
+	}
+	chk.VectorC(tst, "x", 1e-14, X, x)
+
+	// error
+	if FftNd(X, []int{3, 4}, false) == nil {
+		tst.Errorf("FftNd should have failed with wrong dims\n")
+	}
+}
+
+// dft1d computes the discrete Fourier transform of x (very slow: for testing only)
+func dft1d(x []complex128, inverse bool) (X []complex128) {
+	N := len(x)
+	X = make([]complex128, N)
+	sign := 1.0
+	if inverse {
+		sign = -1.0
+	}
+	for l := 0; l < N; l++ {
+		for k := 0; k < N; k++ {
+			a := 2.0 * math.Pi * float64((k*l)%N) / float64(N)
+			X[l] += x[k] * expmi(sign*a)
+		}
+	}
+	return
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestPlan01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Plan01. Plan1d (same as fftw.TestOneDver01)")
+
+	// allocate plan
+	N := 4
+	plan, err := NewPlan1d(nil, N, false, false, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	defer plan.Free()
+
+	// set input data
+	x := make([]complex128, N)
+	for i := 0; i < N; i++ {
+		ii := float64(i * 2)
+		x[i] = complex(ii+1, ii+2)
+		plan.Input(i, x[i])
+	}
+	chk.Vector(tst, "input: x", 1e-15, plan.Xin, []float64{1, 2, 3, 4, 5, 6, 7, 8})
+
+	// perform Fourier transform
+	plan.Execute()
+	Xref := dft1d(x, false)
+	chk.Vector(tst, "output: Xrc", 1e-14, plan.Xout, la.ComplexToRCpairs(Xref))
+	chk.VectorC(tst, "output: Xcc", 1e-14, plan.GetOutput(), Xref)
+
+	// inverse and inplace with length 7 (any length)
+	data := []float64{1, 0, 2, -1, 3, 0.5, 4, 0, 5, 2, 6, 0, 7, -3}
+	x = make([]complex128, 7)
+	for i := 0; i < 7; i++ {
+		x[i] = complex(data[2*i], data[2*i+1])
+	}
+	plan, err = NewPlan1d(data, 0, true, true, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	plan.Execute()
+	chk.VectorC(tst, "inverse: X", 1e-13, plan.GetOutput(), dft1d(x, true))
+	chk.Vector(tst, "inplace: data", 1e-15, data, plan.Xout)
+}
+
+func TestPlan02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Plan02. Plan1d with real input")
+
+	for _, N := range []int{8, 9} {
+
+		// direct
+		x := make([]float64, N)
+		xc := make([]complex128, N)
+		for i := 0; i < N; i++ {
+			x[i] = math.Sin(float64(i)) + 1
+			xc[i] = complex(x[i], 0)
+		}
+		plan, err := NewPlan1dReal(nil, N, false, false)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i := 0; i < N; i++ {
+			plan.InputReal(i, x[i])
+		}
+		plan.Execute()
+		Xref := dft1d(xc, false)
+		chk.VectorC(tst, "X", 1e-13, plan.GetOutput(), Xref)
+
+		// inverse
+		iplan, err := NewPlan1dReal(nil, N, true, false)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i := 0; i < N/2+1; i++ {
+			iplan.Input(i, Xref[i])
+		}
+		iplan.Execute()
+		la.VecScale(iplan.Xout, 0, 1.0/float64(N), iplan.Xout)
+		chk.Vector(tst, "x", 1e-14, iplan.Xout, x)
+	}
+}
+
+func TestPlan03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Plan03. Plan2d and Plan3d")
+
+	// 2D
+	N0, N1 := 3, 4
+	x := make([]complex128, N0*N1)
+	plan, err := NewPlan2d(nil, N0, N1, false, false, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	defer plan.Free()
+	for i := 0; i < N0; i++ {
+		for j := 0; j < N1; j++ {
+			x[i*N1+j] = complex(float64(i+j), float64(i*j))
+			plan.Input(i, j, real(x[i*N1+j]), imag(x[i*N1+j]))
+		}
+	}
+	plan.Execute()
+	X := make([]complex128, len(x))
+	copy(X, x)
+	FftNd(X, []int{N0, N1}, false)
+	out := plan.GetOutput()
+	for i := 0; i < N0; i++ {
+		chk.VectorC(tst, "X2d", 1e-13, out[i], X[i*N1:(i+1)*N1])
+	}
+	for i := 0; i < N0; i++ { // compare with 1D transforms along each dimension
+		for j := 0; j < N1; j++ {
+			var sum complex128
+			for k0 := 0; k0 < N0; k0++ {
+				for k1 := 0; k1 < N1; k1++ {
+					sum += x[k0*N1+k1] * expmi(2*math.Pi*(float64(k0*i)/float64(N0)+float64(k1*j)/float64(N1)))
+				}
+			}
+			chk.ScalarC(tst, "X2d(naive)", 1e-13, plan.Output(i, j), sum)
+		}
+	}
+
+	// 3D: inverse of direct
+	N2 := 5
+	p3, err := NewPlan3d(nil, N0, N1, N2, false, false, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i := 0; i < N0; i++ {
+		for j := 0; j < N1; j++ {
+			for k := 0; k < N2; k++ {
+				p3.Input(i, j, k, float64(i-j+k), float64(i*k))
+			}
+		}
+	}
+	p3.Execute()
+	i3, err := NewPlan3d(p3.Xout, N0, N1, N2, true, true, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	i3.Execute()
+	la.VecScale(i3.Xout, 0, 1.0/float64(N0*N1*N2), i3.Xout)
+	chk.Vector(tst, "x3d", 1e-14, i3.Xout, p3.Xin)
+	chk.ScalarC(tst, "out[1][2][3]", 1e-14, i3.GetOutput()[1][2][3], complex(float64(1-2+3), float64(3)))
+
+	// error
+	if _, err = NewPlan2d(make([]float64, 10), 2, 3, false, false, false); err == nil {
+		tst.Errorf("NewPlan2d should have failed with wrong len(data)\n")
+	}
+}
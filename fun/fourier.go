@@ -32,6 +32,7 @@ import (
 //       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
 //
 //   NOTE: if possible, use the fun/fftw package that may be up to 5 times faster than this function
+//         or the fun/fft package (pure Go) that handles any length n
 //
 func Dft1d(data []float64, inverse bool) (err error) {
 
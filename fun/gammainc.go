@@ -0,0 +1,435 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// constants for incomplete gamma and beta functions
+const (
+	incgEps    = 2.220446049250313e-16 // machine epsilon
+	incgFpmin  = 1e-300                // near the smallest representable number
+	incgAswtch = 100                   // a ≥ incgAswtch ⇒ quadrature
+	incbSwitch = 3000                  // a,b > incbSwitch ⇒ quadrature
+)
+
+// 32-point Gauss-Legendre rule on [0, 1] used for large parameters
+var incgY, incgW = gaussLegendre01(32)
+
+// GammaP computes the regularised lower incomplete gamma function
+//
+//                  1      x
+//      P(a, x) = ―――――   ∫  exp(-t) t^(a-1) dt      a > 0,  x ≥ 0
+//                 Γ(a)   0
+//
+//   NOTE: returns NaN if a ≤ 0 or x < 0
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func GammaP(a, x float64) float64 {
+	if x < 0 || a <= 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+	if a >= incgAswtch && math.Abs(x-a) < 5.0*math.Sqrt(a) {
+		return gammaIncQuad(a, x, true)
+	}
+	if x < a+1.0 {
+		return gammaIncSer(a, x)
+	}
+	return 1.0 - gammaIncCf(a, x)
+}
+
+// GammaQ computes the regularised upper incomplete gamma function Q(a, x) = 1 - P(a, x)
+//   NOTE: returns NaN if a ≤ 0 or x < 0
+func GammaQ(a, x float64) float64 {
+	if x < 0 || a <= 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	if a >= incgAswtch && math.Abs(x-a) < 5.0*math.Sqrt(a) {
+		return gammaIncQuad(a, x, false)
+	}
+	if x < a+1.0 {
+		return 1.0 - gammaIncSer(a, x)
+	}
+	return gammaIncCf(a, x)
+}
+
+// GammaPinv computes the inverse of the regularised lower incomplete gamma function;
+// i.e. x such that P(a, x) = p
+//   NOTE: returns NaN if a ≤ 0 or p ∉ [0, 1]
+func GammaPinv(a, p float64) float64 {
+	return gammaIncInv(a, p, 1.0-p)
+}
+
+// GammaQinv computes the inverse of the regularised upper incomplete gamma function;
+// i.e. x such that Q(a, x) = q
+//   NOTE: returns NaN if a ≤ 0 or q ∉ [0, 1]
+func GammaQinv(a, q float64) float64 {
+	return gammaIncInv(a, 1.0-q, q)
+}
+
+// BetaInc computes the regularised incomplete beta function
+//
+//                      1       x
+//      I_x(a, b) = ――――――――   ∫  t^(a-1) (1-t)^(b-1) dt      a, b > 0,  0 ≤ x ≤ 1
+//                   B(a,b)    0
+//
+//   NOTE: returns NaN if a ≤ 0, b ≤ 0 or x ∉ [0, 1]
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func BetaInc(a, b, x float64) float64 {
+	if a <= 0 || b <= 0 || x < 0 || x > 1 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 || x == 1 {
+		return x
+	}
+	if a > incbSwitch && b > incbSwitch {
+		return betaIncQuad(a, b, x)
+	}
+	bt := betaIncPrefactor(a, b, x)
+	if x < (a+1.0)/(a+b+2.0) {
+		return bt * betaIncCf(a, b, x) / a
+	}
+	return 1.0 - bt*betaIncCf(b, a, 1.0-x)/b
+}
+
+// BetaIncInv computes the inverse of the regularised incomplete beta function;
+// i.e. x such that I_x(a, b) = p
+//   NOTE: returns NaN if a ≤ 0, b ≤ 0 or p ∉ [0, 1]
+func BetaIncInv(a, b, p float64) float64 {
+	if a <= 0 || b <= 0 || p < 0 || p > 1 || math.IsNaN(p) {
+		return math.NaN()
+	}
+	if p == 0 || p == 1 {
+		return p
+	}
+
+	// use the symmetry I_x(a,b) = 1 - I_{1-x}(b,a) to invert the smallest tail
+	if p > 0.5 {
+		return 1.0 - BetaIncInv(b, a, 1.0-p)
+	}
+
+	// initial guess
+	a1, b1 := a-1.0, b-1.0
+	var x, t, u, w float64
+	if a >= 1 && b >= 1 {
+		t = math.Sqrt(-2.0 * math.Log(p))
+		x = -((2.30753+t*0.27061)/(1.0+t*(0.99229+t*0.04481)) - t)
+		al := (x*x - 3.0) / 6.0
+		h := 2.0 / (1.0/(2.0*a-1.0) + 1.0/(2.0*b-1.0))
+		w = (x*math.Sqrt(al+h)/h - (1.0/(2.0*b-1.0)-1.0/(2.0*a-1.0))*(al+5.0/6.0-2.0/(3.0*h)))
+		x = a / (a + b*math.Exp(2.0*w))
+	} else {
+		lna, lnb := math.Log(a/(a+b)), math.Log(b/(a+b))
+		t = math.Exp(a*lna) / a
+		u = math.Exp(b*lnb) / b
+		w = t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1.0/a)
+		} else {
+			x = 1.0 - math.Pow(b*w*(1.0-p), 1.0/b)
+		}
+	}
+
+	// Halley's method
+	lab, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	afac := lab - la - lb
+	for j := 0; j < 100; j++ {
+		if x == 0 || x == 1 {
+			return x
+		}
+		err := BetaInc(a, b, x) - p
+		t = math.Exp(a1*math.Log(x) + b1*math.Log1p(-x) + afac)
+		u = err / t
+		t = u / (1.0 - 0.5*math.Min(1.0, u*(a1/x-b1/(1.0-x))))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if x >= 1 {
+			x = 0.5 * (x + t + 1.0)
+		}
+		if math.Abs(t) < 1e-15*x && j > 0 {
+			break
+		}
+	}
+	return x
+}
+
+// gammaIncSer computes P(a, x) by its series representation
+func gammaIncSer(a, x float64) float64 {
+	ap := a
+	del := 1.0 / a
+	sum := del
+	for {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*incgEps {
+			return sum * gammaIncPrefactor(a, x)
+		}
+	}
+}
+
+// gammaIncCf computes Q(a, x) by its continued fraction representation (modified Lentz's method)
+func gammaIncCf(a, x float64) float64 {
+	b := x + 1.0 - a
+	c := 1.0 / incgFpmin
+	d := 1.0 / b
+	h := d
+	for i := 1; ; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2.0
+		d = an*d + b
+		if math.Abs(d) < incgFpmin {
+			d = incgFpmin
+		}
+		c = b + an/c
+		if math.Abs(c) < incgFpmin {
+			c = incgFpmin
+		}
+		d = 1.0 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1.0) <= incgEps {
+			break
+		}
+	}
+	return gammaIncPrefactor(a, x) * h
+}
+
+// gammaIncQuad computes P(a, x) (lower=true) or Q(a, x) by Gauss-Legendre quadrature for large a
+func gammaIncQuad(a, x float64, lower bool) float64 {
+	a1 := a - 1.0
+	sqrta1 := math.Sqrt(a1)
+	var xu float64
+	if x > a1 {
+		xu = math.Max(a1+11.5*sqrta1, x+6.0*sqrta1)
+	} else {
+		xu = math.Max(0, math.Min(a1-7.5*sqrta1, x-5.0*sqrta1))
+	}
+	sum := 0.0
+	for j := 0; j < len(incgY); j++ {
+		t := x + (xu-x)*incgY[j]
+		sum += incgW[j] * math.Exp(a1*log1pmx((t-a1)/a1))
+	}
+	ans := sum * (xu - x) * math.Exp(-0.5*math.Log(2.0*math.Pi*a1)-lgammaCorr(a1))
+	if lower {
+		if ans > 0 {
+			return 1.0 - ans
+		}
+		return -ans
+	}
+	if ans >= 0 {
+		return ans
+	}
+	return 1.0 + ans
+}
+
+// gammaIncInv inverts P(a, x) = p or, equivalently, Q(a, x) = q = 1 - p by Halley's method. The
+// function with the smallest value (p or q) is used in the residual to preserve the accuracy
+func gammaIncInv(a, p, q float64) float64 {
+	if a <= 0 || p < 0 || p > 1 || q < 0 || q > 1 || math.IsNaN(p) || math.IsNaN(q) {
+		return math.NaN()
+	}
+	if p == 0 {
+		return 0
+	}
+	if q == 0 {
+		return math.Inf(1)
+	}
+
+	// initial guess
+	a1 := a - 1.0
+	gln, _ := math.Lgamma(a)
+	var x, lna1, afac float64
+	if a > 1 {
+		lna1 = math.Log(a1)
+		afac = math.Exp(a1*(lna1-1.0) - gln)
+		pp := math.Min(p, q)
+		t := math.Sqrt(-2.0 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1.0+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		x = math.Max(1e-3, a*math.Pow(1.0-1.0/(9.0*a)-x/(3.0*math.Sqrt(a)), 3))
+	} else {
+		t := 1.0 - a*(0.253+a*0.12)
+		if p < t {
+			x = math.Pow(p/t, 1.0/a)
+		} else {
+			x = 1.0 - math.Log(q/(1.0-t))
+		}
+	}
+
+	// Halley's method
+	for j := 0; j < 100; j++ {
+		if x <= 0 {
+			return 0
+		}
+		var err float64
+		if p <= 0.5 {
+			err = GammaP(a, x) - p
+		} else {
+			err = q - GammaQ(a, x)
+		}
+		var t float64
+		if a > 1 {
+			t = afac * math.Exp(-(x-a1)+a1*(math.Log(x)-lna1))
+		} else {
+			t = math.Exp(-x + a1*math.Log(x) - gln)
+		}
+		u := err / t
+		t = u / (1.0 - 0.5*math.Min(1.0, u*((a-1.0)/x-1.0)))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if math.Abs(t) < 1e-15*x {
+			break
+		}
+	}
+	return x
+}
+
+// betaIncCf evaluates the continued fraction for the incomplete beta function
+// (modified Lentz's method)
+func betaIncCf(a, b, x float64) float64 {
+	qab := a + b
+	qap := a + 1.0
+	qam := a - 1.0
+	c := 1.0
+	d := 1.0 - qab*x/qap
+	if math.Abs(d) < incgFpmin {
+		d = incgFpmin
+	}
+	d = 1.0 / d
+	h := d
+	for m := 1; m < 10000; m++ {
+		fm := float64(m)
+		m2 := 2.0 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1.0 + aa*d
+		if math.Abs(d) < incgFpmin {
+			d = incgFpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < incgFpmin {
+			c = incgFpmin
+		}
+		d = 1.0 / d
+		h *= d * c
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1.0 + aa*d
+		if math.Abs(d) < incgFpmin {
+			d = incgFpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < incgFpmin {
+			c = incgFpmin
+		}
+		d = 1.0 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1.0) <= incgEps {
+			break
+		}
+	}
+	return h
+}
+
+// betaIncQuad computes I_x(a, b) by Gauss-Legendre quadrature for large a and b
+func betaIncQuad(a, b, x float64) float64 {
+	a1, b1 := a-1.0, b-1.0
+	mu := a / (a + b)
+	lnmu, lnmuc := math.Log(mu), math.Log1p(-mu)
+	t := math.Sqrt(a * b / ((a + b) * (a + b) * (a + b + 1.0)))
+	var xu float64
+	if x > a/(a+b) {
+		xu = math.Min(1, math.Max(mu+10.0*t, x+5.0*t))
+	} else {
+		xu = math.Max(0, math.Min(mu-10.0*t, x-5.0*t))
+	}
+	sum := 0.0
+	for j := 0; j < len(incgY); j++ {
+		t = x + (xu-x)*incgY[j]
+		lin := (t - mu) * (a + b) * (1.0/b - 1.0/a) // = a1⋅δ1 + b1⋅δ2
+		sum += incgW[j] * math.Exp(a1*log1pmx((t-mu)/mu)+b1*log1pmx((mu-t)/(1.0-mu))+lin)
+	}
+	lnfac := 0.5*(math.Log(a+b)-lnmu-lnmuc-math.Log(2.0*math.Pi)) - lgammaCorr(a) - lgammaCorr(b) + lgammaCorr(a+b)
+	ans := sum * (xu - x) * math.Exp(lnfac)
+	if ans > 0 {
+		return 1.0 - ans
+	}
+	return -ans
+}
+
+// gammaIncPrefactor computes xᵃ exp(-x) / Γ(a) avoiding the cancellation of large terms
+func gammaIncPrefactor(a, x float64) float64 {
+	return math.Exp(a*lnRatioMinus(x, a) + 0.5*math.Log(a/(2.0*math.Pi)) - lgammaCorr(a))
+}
+
+// betaIncPrefactor computes xᵃ (1-x)ᵇ / B(a, b) avoiding the cancellation of large terms
+func betaIncPrefactor(a, b, x float64) float64 {
+	mu := a / (a + b)
+	muc := b / (a + b)
+	ln := a*lnRatioMinus(x, mu) + b*lnRatioMinus(1.0-x, muc) + 0.5*math.Log(a*muc/(2.0*math.Pi))
+	return math.Exp(ln - lgammaCorr(a) - lgammaCorr(b) + lgammaCorr(a+b))
+}
+
+// lgammaCorr computes the remainder of Stirling's formula
+//
+//      c(z) = ln Γ(z) - (z - 1/2) ln z + z - ln(2π)/2
+//
+func lgammaCorr(z float64) float64 {
+	if z < 10 {
+		lg, _ := math.Lgamma(z)
+		return lg - (z-0.5)*math.Log(z) + z - 0.5*math.Log(2.0*math.Pi)
+	}
+	z2 := 1.0 / (z * z)
+	return (1.0/12.0 - z2*(1.0/360.0-z2*(1.0/1260.0-z2*(1.0/1680.0-z2*(1.0/1188.0-z2*(691.0/360360.0-z2*(1.0/156.0-z2*3617.0/122400.0))))))) / z
+}
+
+// lnRatioMinus computes ln(y/m) - (y-m)/m; i.e. log1pmx((y-m)/m) without losing the relative
+// precision of y/m when y is much smaller than m
+func lnRatioMinus(y, m float64) float64 {
+	u := (y - m) / m
+	if math.Abs(u) > 0.1 {
+		return math.Log(y/m) - u
+	}
+	return log1pmx(u)
+}
+
+// log1pmx computes ln(1+u) - u accurately for small u
+func log1pmx(u float64) float64 {
+	if math.Abs(u) > 0.1 {
+		return math.Log1p(u) - u
+	}
+	sum, t := 0.0, u
+	for k := 2; k < 40; k++ {
+		t *= -u
+		d := t / float64(k)
+		sum += d
+		if math.Abs(d) < 1e-17*math.Abs(sum) {
+			break
+		}
+	}
+	return sum
+}
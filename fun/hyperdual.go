@@ -0,0 +1,480 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// HyperDual implements hyper-dual numbers a + b⋅ε₁ + c⋅ε₂ + d⋅ε₁ε₂ with ε₁² = ε₂² = 0 for the
+// computation of exact first and second derivatives by forward-mode automatic differentiation.
+// If the ε₁ and ε₂ parts of the independent variables xᵢ and xⱼ are set to 1, respectively, then:
+//   V = f,  D1 = ∂f/∂xᵢ,  D2 = ∂f/∂xⱼ  and  D12 = ∂²f/∂xᵢ∂xⱼ
+//  Example:
+//   x := fun.HyperDualVar(2)
+//   y := x.Mul(x).Mul(x) // y.V = x³, y.D1 = y.D2 = 3x² and y.D12 = 6x
+//   Reference:
+//   [1] Fike JA, Alonso JJ (2011) The development of hyper-dual numbers for exact second-derivative
+//       calculations. 49th AIAA Aerospace Sciences Meeting, AIAA 2011-886.
+type HyperDual struct {
+	V   float64 // value (real part)
+	D1  float64 // ε₁ part (first derivative)
+	D2  float64 // ε₂ part (first derivative)
+	D12 float64 // ε₁ε₂ part (second derivative)
+}
+
+// HyperDualVar returns the hyper-dual number representing an independent variable; i.e. {v, 1, 1, 0}
+func HyperDualVar(v float64) HyperDual {
+	return HyperDual{v, 1, 1, 0}
+}
+
+// HyperDualCte returns the hyper-dual number representing a constant; i.e. {v, 0, 0, 0}
+func HyperDualCte(v float64) HyperDual {
+	return HyperDual{v, 0, 0, 0}
+}
+
+// arithmetic ///////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a HyperDual) Add(b HyperDual) HyperDual {
+	return HyperDual{a.V + b.V, a.D1 + b.D1, a.D2 + b.D2, a.D12 + b.D12}
+}
+
+// Sub returns a - b
+func (a HyperDual) Sub(b HyperDual) HyperDual {
+	return HyperDual{a.V - b.V, a.D1 - b.D1, a.D2 - b.D2, a.D12 - b.D12}
+}
+
+// Mul returns a * b
+func (a HyperDual) Mul(b HyperDual) HyperDual {
+	return HyperDual{
+		a.V * b.V,
+		a.D1*b.V + a.V*b.D1,
+		a.D2*b.V + a.V*b.D2,
+		a.D12*b.V + a.D1*b.D2 + a.D2*b.D1 + a.V*b.D12,
+	}
+}
+
+// Div returns a / b
+func (a HyperDual) Div(b HyperDual) HyperDual {
+	return a.Mul(b.Inv())
+}
+
+// Neg returns -a
+func (a HyperDual) Neg() HyperDual {
+	return HyperDual{-a.V, -a.D1, -a.D2, -a.D12}
+}
+
+// Inv returns 1 / a
+func (a HyperDual) Inv() HyperDual {
+	v := 1.0 / a.V
+	return a.chain(v, -v*v, 2.0*v*v*v)
+}
+
+// AddS returns a + s where s is a scalar
+func (a HyperDual) AddS(s float64) HyperDual {
+	return HyperDual{a.V + s, a.D1, a.D2, a.D12}
+}
+
+// MulS returns s * a where s is a scalar
+func (a HyperDual) MulS(s float64) HyperDual {
+	return HyperDual{s * a.V, s * a.D1, s * a.D2, s * a.D12}
+}
+
+// elementary functions /////////////////////////////////////////////////////////////////////////////
+
+// Sqrt returns √a
+func (a HyperDual) Sqrt() HyperDual {
+	s := math.Sqrt(a.V)
+	return a.chain(s, 0.5/s, -0.25/(s*a.V))
+}
+
+// Exp returns exp(a)
+func (a HyperDual) Exp() HyperDual {
+	e := math.Exp(a.V)
+	return a.chain(e, e, e)
+}
+
+// Log returns the natural logarithm of a
+func (a HyperDual) Log() HyperDual {
+	return a.chain(math.Log(a.V), 1.0/a.V, -1.0/(a.V*a.V))
+}
+
+// Pow returns aᵖ where p is a scalar
+func (a HyperDual) Pow(p float64) HyperDual {
+	return a.chain(math.Pow(a.V, p), p*math.Pow(a.V, p-1), p*(p-1)*math.Pow(a.V, p-2))
+}
+
+// PowD returns aᵇ = exp(b log(a)) where b is a hyper-dual number
+func (a HyperDual) PowD(b HyperDual) HyperDual {
+	return b.Mul(a.Log()).Exp()
+}
+
+// Sin returns sin(a)
+func (a HyperDual) Sin() HyperDual {
+	s, c := math.Sin(a.V), math.Cos(a.V)
+	return a.chain(s, c, -s)
+}
+
+// Cos returns cos(a)
+func (a HyperDual) Cos() HyperDual {
+	s, c := math.Sin(a.V), math.Cos(a.V)
+	return a.chain(c, -s, -c)
+}
+
+// Tan returns tan(a)
+func (a HyperDual) Tan() HyperDual {
+	t := math.Tan(a.V)
+	return a.chain(t, 1.0+t*t, 2.0*t*(1.0+t*t))
+}
+
+// Asin returns asin(a)
+func (a HyperDual) Asin() HyperDual {
+	d := 1.0 - a.V*a.V
+	return a.chain(math.Asin(a.V), 1.0/math.Sqrt(d), a.V/(d*math.Sqrt(d)))
+}
+
+// Acos returns acos(a)
+func (a HyperDual) Acos() HyperDual {
+	d := 1.0 - a.V*a.V
+	return a.chain(math.Acos(a.V), -1.0/math.Sqrt(d), -a.V/(d*math.Sqrt(d)))
+}
+
+// Atan returns atan(a)
+func (a HyperDual) Atan() HyperDual {
+	d := 1.0 + a.V*a.V
+	return a.chain(math.Atan(a.V), 1.0/d, -2.0*a.V/(d*d))
+}
+
+// Sinh returns sinh(a)
+func (a HyperDual) Sinh() HyperDual {
+	s, c := math.Sinh(a.V), math.Cosh(a.V)
+	return a.chain(s, c, s)
+}
+
+// Cosh returns cosh(a)
+func (a HyperDual) Cosh() HyperDual {
+	s, c := math.Sinh(a.V), math.Cosh(a.V)
+	return a.chain(c, s, c)
+}
+
+// Tanh returns tanh(a)
+func (a HyperDual) Tanh() HyperDual {
+	t := math.Tanh(a.V)
+	return a.chain(t, 1.0-t*t, -2.0*t*(1.0-t*t))
+}
+
+// Abs returns |a|. The derivatives at a = 0 are taken as zero
+func (a HyperDual) Abs() HyperDual {
+	return a.chain(math.Abs(a.V), Sign(a.V), 0)
+}
+
+// Sramp returns the smooth ramp function Sramp(a, β)
+func (a HyperDual) Sramp(β float64) HyperDual {
+	return a.chain(Sramp(a.V, β), SrampD1(a.V, β), SrampD2(a.V, β))
+}
+
+// Sabs returns the smooth abs function Sabs(a, eps)
+func (a HyperDual) Sabs(eps float64) HyperDual {
+	return a.chain(Sabs(a.V, eps), SabsD1(a.V, eps), SabsD2(a.V, eps))
+}
+
+// HyperDualAtan2 returns atan2(y, x)
+func HyperDualAtan2(y, x HyperDual) HyperDual {
+	r2 := x.V*x.V + y.V*y.V
+	r4 := r2 * r2
+	fx, fy := -y.V/r2, x.V/r2
+	fxx, fyy, fxy := 2.0*x.V*y.V/r4, -2.0*x.V*y.V/r4, (y.V*y.V-x.V*x.V)/r4
+	return HyperDual{
+		math.Atan2(y.V, x.V),
+		fx*x.D1 + fy*y.D1,
+		fx*x.D2 + fy*y.D2,
+		fx*x.D12 + fy*y.D12 + fxx*x.D1*x.D2 + fyy*y.D1*y.D2 + fxy*(x.D1*y.D2+y.D1*x.D2),
+	}
+}
+
+// Carlson's elliptic integrals //////////////////////////////////////////////////////////////////////
+//
+// The values are computed with the real functions (CarlsonRf, etc.) and the other parts by running
+// the duplication algorithm of the real functions on hyper-dual numbers. Thus, the first and second
+// derivatives are exact (to the accuracy of the real functions) even if the arguments coincide or
+// nearly coincide; whereas closed-form partial derivatives using partial fractions, such as
+//   ∂Rd(x,y,z)/∂x = (Rd(x,y,z) - Rd(y,z,x)) / (2 (z - x))
+//  suffer from cancellation if z ≈ x.
+//  NOTE: the derivatives with respect to an argument equal to zero are infinite
+
+// HyperDualCarlsonRf computes Carlson's elliptic integral of the first kind Rf(x,y,z) with hyper-dual numbers.
+// See CarlsonRf
+func HyperDualCarlsonRf(x, y, z HyperDual) HyperDual {
+	f := CarlsonRf(x.V, y.V, z.V)
+	if r, ok := carlsonSpecial(f, x, y, z); ok {
+		return r
+	}
+	r := hyperDualRf(x, y, z)
+	r.V = f
+	return r
+}
+
+// HyperDualCarlsonRd computes Carlson's elliptic integral of the second kind Rd(x,y,z) with hyper-dual
+// numbers. See CarlsonRd
+func HyperDualCarlsonRd(x, y, z HyperDual) HyperDual {
+	f := CarlsonRd(x.V, y.V, z.V)
+	if r, ok := carlsonSpecial(f, x, y, z); ok {
+		return r
+	}
+	r := hyperDualRd(x, y, z)
+	r.V = f
+	return r
+}
+
+// HyperDualCarlsonRj computes Carlson's elliptic integral of the third kind Rj(x,y,z,p) with hyper-dual
+// numbers. See CarlsonRj
+func HyperDualCarlsonRj(x, y, z, p HyperDual) HyperDual {
+	f := CarlsonRj(x.V, y.V, z.V, p.V)
+	if r, ok := carlsonSpecial(f, x, y, z, p); ok {
+		return r
+	}
+	r := hyperDualRj(x, y, z, p)
+	r.V = f
+	return r
+}
+
+// HyperDualCarlsonRc computes Carlson's degenerate elliptic integral Rc(x,y) with hyper-dual numbers.
+// See CarlsonRc
+func HyperDualCarlsonRc(x, y HyperDual) HyperDual {
+	f := CarlsonRc(x.V, y.V)
+	if r, ok := carlsonSpecial(f, x, y); ok {
+		return r
+	}
+	r := hyperDualRc(x, y)
+	r.V = f
+	return r
+}
+
+// carlsonSpecial handles the cases where all arguments are constant or where an argument equal to
+// zero is not constant (infinite derivatives). ok = false means that none of these cases applies
+func carlsonSpecial(f float64, a ...HyperDual) (r HyperDual, ok bool) {
+	r.V, ok = f, true
+	for _, ai := range a {
+		if ai.V != 0 || ai.isCte() {
+			continue
+		}
+		if ai.D1 != 0 {
+			r.D1 = math.Inf(-1) * ai.D1
+		}
+		if ai.D2 != 0 {
+			r.D2 = math.Inf(-1) * ai.D2
+		}
+		if ai.D1 != 0 && ai.D2 != 0 {
+			r.D12 = math.Inf(1) * ai.D1 * ai.D2
+		} else if ai.D12 != 0 {
+			r.D12 = math.Inf(-1) * ai.D12
+		}
+		return
+	}
+	for _, ai := range a {
+		if !ai.isCte() {
+			return r, false
+		}
+	}
+	return
+}
+
+// hyperDualRf runs the duplication algorithm of CarlsonRf with hyper-dual numbers
+func hyperDualRf(x, y, z HyperDual) HyperDual {
+	ERRTOL := 0.0025
+	THIRD := 1.0 / 3.0
+	C1 := 1.0 / 24.0
+	C2 := 0.1
+	C3 := 3.0 / 44.0
+	C4 := 1.0 / 14.0
+	xt, yt, zt := x, y, z
+	var ave, delx, dely, delz HyperDual
+	for it := 0; it < 11; it++ {
+		sqrtx, sqrty, sqrtz := xt.Sqrt(), yt.Sqrt(), zt.Sqrt()
+		alamb := sqrtx.Mul(sqrty.Add(sqrtz)).Add(sqrty.Mul(sqrtz))
+		xt = xt.Add(alamb).MulS(0.25)
+		yt = yt.Add(alamb).MulS(0.25)
+		zt = zt.Add(alamb).MulS(0.25)
+		ave = xt.Add(yt).Add(zt).MulS(THIRD)
+		delx = ave.Sub(xt).Div(ave)
+		dely = ave.Sub(yt).Div(ave)
+		delz = ave.Sub(zt).Div(ave)
+		if max(max(math.Abs(delx.V), math.Abs(dely.V)), math.Abs(delz.V)) < ERRTOL {
+			break
+		}
+	}
+	e2 := delx.Mul(dely).Sub(delz.Mul(delz))
+	e3 := delx.Mul(dely).Mul(delz)
+	s := e2.MulS(C1).AddS(-C2).Sub(e3.MulS(C3)).Mul(e2).Add(e3.MulS(C4)).AddS(1)
+	return s.Div(ave.Sqrt())
+}
+
+// hyperDualRd runs the duplication algorithm of CarlsonRd with hyper-dual numbers
+func hyperDualRd(x, y, z HyperDual) HyperDual {
+	ERRTOL := 0.0015
+	C1 := 3.0 / 14.0
+	C2 := 1.0 / 6.0
+	C3 := 9.0 / 22.0
+	C4 := 3.0 / 26.0
+	C5 := 0.25 * C3
+	C6 := 1.5 * C4
+	xt, yt, zt := x, y, z
+	sum := HyperDualCte(0)
+	fac := 1.0
+	var ave, delx, dely, delz HyperDual
+	for it := 0; it < 11; it++ {
+		sqrtx, sqrty, sqrtz := xt.Sqrt(), yt.Sqrt(), zt.Sqrt()
+		alamb := sqrtx.Mul(sqrty.Add(sqrtz)).Add(sqrty.Mul(sqrtz))
+		sum = sum.Add(sqrtz.Mul(zt.Add(alamb)).Inv().MulS(fac))
+		fac = 0.25 * fac
+		xt = xt.Add(alamb).MulS(0.25)
+		yt = yt.Add(alamb).MulS(0.25)
+		zt = zt.Add(alamb).MulS(0.25)
+		ave = xt.Add(yt).Add(zt.MulS(3)).MulS(0.2)
+		delx = ave.Sub(xt).Div(ave)
+		dely = ave.Sub(yt).Div(ave)
+		delz = ave.Sub(zt).Div(ave)
+		if max(max(math.Abs(delx.V), math.Abs(dely.V)), math.Abs(delz.V)) < ERRTOL {
+			break
+		}
+	}
+	ea := delx.Mul(dely)
+	eb := delz.Mul(delz)
+	ec := ea.Sub(eb)
+	ed := ea.Sub(eb.MulS(6))
+	ee := ed.Add(ec).Add(ec)
+	t1 := ed.Mul(ed.MulS(C5).AddS(-C1).Sub(delz.Mul(ee).MulS(C6)))
+	t2 := delz.Mul(ee.MulS(C2).Add(delz.Mul(ec.MulS(-C3).Add(delz.Mul(ea).MulS(C4)))))
+	return sum.MulS(3).Add(t1.Add(t2).AddS(1).MulS(fac).Div(ave.Mul(ave.Sqrt())))
+}
+
+// hyperDualRj runs the duplication algorithm of CarlsonRj with hyper-dual numbers
+func hyperDualRj(x, y, z, p HyperDual) HyperDual {
+	ERRTOL := 0.0015
+	C1 := 3.0 / 14.0
+	C2 := 1.0 / 3.0
+	C3 := 3.0 / 22.0
+	C4 := 3.0 / 26.0
+	C5 := 0.75 * C3
+	C6 := 1.5 * C4
+	C7 := 0.5 * C2
+	C8 := C3 + C3
+	var a, b, rcx, xt, yt, zt, pt HyperDual
+	if p.V > 0.0 {
+		xt, yt, zt, pt = x, y, z, p
+	} else {
+		s := []HyperDual{x, y, z} // sorted by the real parts
+		for i := 1; i < 3; i++ {
+			for j := i; j > 0 && s[j].V < s[j-1].V; j-- {
+				s[j], s[j-1] = s[j-1], s[j]
+			}
+		}
+		xt, yt, zt = s[0], s[1], s[2]
+		a = yt.Sub(p).Inv()
+		b = a.Mul(zt.Sub(yt)).Mul(yt.Sub(xt))
+		pt = yt.Add(b)
+		rho := xt.Mul(zt).Div(yt)
+		tau := p.Mul(pt).Div(yt)
+		rcx = HyperDualCarlsonRc(rho, tau)
+	}
+	sum := HyperDualCte(0)
+	fac := 1.0
+	var ave, delx, dely, delz, delp HyperDual
+	for it := 0; it < 11; it++ {
+		sqrtx, sqrty, sqrtz := xt.Sqrt(), yt.Sqrt(), zt.Sqrt()
+		alamb := sqrtx.Mul(sqrty.Add(sqrtz)).Add(sqrty.Mul(sqrtz))
+		alpha := pt.Mul(sqrtx.Add(sqrty).Add(sqrtz)).Add(sqrtx.Mul(sqrty).Mul(sqrtz))
+		alpha = alpha.Mul(alpha)
+		beta := pt.Mul(pt.Add(alamb)).Mul(pt.Add(alamb))
+		sum = sum.Add(hyperDualRc(alpha, beta).MulS(fac))
+		fac = 0.25 * fac
+		xt = xt.Add(alamb).MulS(0.25)
+		yt = yt.Add(alamb).MulS(0.25)
+		zt = zt.Add(alamb).MulS(0.25)
+		pt = pt.Add(alamb).MulS(0.25)
+		ave = xt.Add(yt).Add(zt).Add(pt.MulS(2)).MulS(0.2)
+		delx = ave.Sub(xt).Div(ave)
+		dely = ave.Sub(yt).Div(ave)
+		delz = ave.Sub(zt).Div(ave)
+		delp = ave.Sub(pt).Div(ave)
+		if max(max(math.Abs(delx.V), math.Abs(dely.V)), max(math.Abs(delz.V), math.Abs(delp.V))) < ERRTOL {
+			break
+		}
+	}
+	ea := delx.Mul(dely.Add(delz)).Add(dely.Mul(delz))
+	eb := delx.Mul(dely).Mul(delz)
+	ec := delp.Mul(delp)
+	ed := ea.Sub(ec.MulS(3))
+	ee := eb.Add(delp.Mul(ea.Sub(ec)).MulS(2))
+	t1 := ed.Mul(ed.MulS(C5).AddS(-C1).Sub(ee.MulS(C6)))
+	t2 := eb.Mul(delp.Mul(delp.MulS(C4).AddS(-C8)).AddS(C7))
+	t3 := delp.Mul(ea).Mul(delp.MulS(-C3).AddS(C2))
+	t4 := delp.Mul(ec).MulS(-C2)
+	ans := sum.MulS(3).Add(t1.Add(t2).Add(t3).Add(t4).AddS(1).MulS(fac).Div(ave.Mul(ave.Sqrt())))
+	if p.V <= 0.0 {
+		ans = a.Mul(b.Mul(ans).Add(rcx.Sub(hyperDualRf(xt, yt, zt)).MulS(3)))
+	}
+	return ans
+}
+
+// hyperDualRc runs the duplication algorithm of CarlsonRc with hyper-dual numbers
+func hyperDualRc(x, y HyperDual) HyperDual {
+	ERRTOL := 0.0012
+	THIRD := 1.0 / 3.0
+	C1 := 0.3
+	C2 := 1.0 / 7.0
+	C3 := 0.375
+	C4 := 9.0 / 22.0
+	var xt, yt, w HyperDual
+	if y.V > 0.0 {
+		xt, yt, w = x, y, HyperDualCte(1)
+	} else {
+		xt, yt = x.Sub(y), y.Neg()
+		w = x.Sqrt().Div(xt.Sqrt())
+	}
+	var ave, s HyperDual
+	for it := 0; it < 11; it++ {
+		alamb := xt.Sqrt().Mul(yt.Sqrt()).MulS(2).Add(yt)
+		xt = xt.Add(alamb).MulS(0.25)
+		yt = yt.Add(alamb).MulS(0.25)
+		ave = xt.Add(yt).Add(yt).MulS(THIRD)
+		s = yt.Sub(ave).Div(ave)
+		if math.Abs(s.V) < ERRTOL {
+			break
+		}
+	}
+	poly := s.Mul(s.MulS(C4).AddS(C3)).AddS(C2)
+	poly = s.Mul(poly).AddS(C1)
+	return w.Mul(s.Mul(s).Mul(poly).AddS(1)).Div(ave.Sqrt())
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// chain applies the chain rule with the first and second derivatives df and d2f of f at a.V
+func (a HyperDual) chain(f, df, d2f float64) HyperDual {
+	r := HyperDual{V: f}
+	if a.D1 != 0 { // avoid 0 * Inf
+		r.D1 = df * a.D1
+	}
+	if a.D2 != 0 {
+		r.D2 = df * a.D2
+	}
+	if a.D12 != 0 {
+		r.D12 = df * a.D12
+	}
+	if a.D1 != 0 && a.D2 != 0 {
+		r.D12 += d2f * a.D1 * a.D2
+	}
+	return r
+}
+
+// dual1 returns the dual number made of the real and ε₁ parts
+func (a HyperDual) dual1() Dual {
+	return Dual{a.V, a.D1}
+}
+
+// isCte returns whether a is a constant; i.e. all parts except the real one are zero
+func (a HyperDual) isCte() bool {
+	return a.D1 == 0 && a.D2 == 0 && a.D12 == 0
+}
@@ -0,0 +1,152 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// Hyp2f1 computes the Gauss hypergeometric function
+//
+//                         ∞  (a)ₖ (b)ₖ  xᵏ
+//      ₂F₁(a, b; c; x) =  Σ  ―――――――――― ――      x ≤ 1
+//                        k=0    (c)ₖ    k!
+//
+//   where (a)ₖ = a (a+1) ... (a+k-1) is the Pochhammer symbol
+//
+//   NOTE: (1) the series is summed directly if a or b is a non-positive integer (polynomial) or
+//             if |x| ≤ 0.5. Otherwise, the Pfaff transformation maps x < 0 into (0, 1) and the
+//             linear transformation to 1-x is used for x > 0.5, including the degenerate cases
+//             with c-a-b integer (A&S 15.3.10 and 15.3.11)
+//         (2) the accuracy is reduced if x > 0.5 and c-a-b is very close, but not equal, to an
+//             integer
+//         (3) returns NaN if x > 1 or c is a non-positive integer (and the series does not
+//             terminate before the pole) and +Inf if x = 1 and c-a-b ≤ 0
+//   Reference:
+//   [1] Abramowitz M, Stegun IA (1972) Handbook of Mathematical Functions with Formulas, Graphs,
+//       and Mathematical Tables. U.S. Department of Commerce, NIST. Chapter 15
+func Hyp2f1(a, b, c, x float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(c) || math.IsNaN(x) || x > 1 {
+		return math.NaN()
+	}
+
+	// polynomial
+	if isNonPosInt(a) || isNonPosInt(b) {
+		m := math.Max(a, b)
+		if !isNonPosInt(m) {
+			m = math.Min(a, b)
+		}
+		if isNonPosInt(c) && c > m {
+			return math.NaN()
+		}
+		return hyp2f1Series(a, b, c, x, int(-m)+1)
+	}
+	if isNonPosInt(c) {
+		return math.NaN()
+	}
+
+	// trivial and special values
+	if x == 0 {
+		return 1
+	}
+	if x == 1 {
+		if c-a-b <= 0 {
+			return math.Inf(1)
+		}
+		return gammaRatio([]float64{c, c - a - b}, []float64{c - a, c - b})
+	}
+
+	// direct summation
+	if math.Abs(x) <= 0.5 {
+		return hyp2f1Series(a, b, c, x, 0)
+	}
+
+	// Pfaff transformation: F(a,b;c;x) = (1-x)⁻ᵃ F(a,c-b;c;x/(x-1))
+	if x < 0 {
+		return math.Pow(1.0-x, -a) * Hyp2f1(a, c-b, c, x/(x-1.0))
+	}
+
+	// 0.5 < x < 1: linear transformation to 1-x
+	m := c - a - b
+	mi := math.Floor(m + 0.5)
+	if m != mi {
+		y := 1.0 - x
+		t1 := gammaRatio([]float64{c, m}, []float64{c - a, c - b}) * hyp2f1Series(a, b, 1.0-m, y, 0)
+		t2 := gammaRatio([]float64{c, -m}, []float64{a, b}) * math.Pow(y, m) * hyp2f1Series(c-a, c-b, m+1.0, y, 0)
+		return t1 + t2
+	}
+
+	// degenerate case with m = c-a-b integer; Euler's transformation if m < 0
+	if mi < 0 {
+		return math.Pow(1.0-x, mi) * hyp2f1Degenerate(c-a, c-b, int(-mi), x)
+	}
+	return hyp2f1Degenerate(a, b, int(mi), x)
+}
+
+// hyp2f1Series sums the hypergeometric series. If nterms > 0, only nterms are summed
+func hyp2f1Series(a, b, c, x float64, nterms int) float64 {
+	sum, t := 1.0, 1.0
+	for k := 0; k < 100000; k++ {
+		if nterms > 0 && k+1 >= nterms {
+			break
+		}
+		fk := float64(k)
+		t *= (a + fk) * (b + fk) / ((c + fk) * (fk + 1.0)) * x
+		sum += t
+		if nterms == 0 && math.Abs(t) < 1e-17*math.Abs(sum) {
+			break
+		}
+	}
+	return sum
+}
+
+// hyp2f1Degenerate computes F(a, b; a+b+m; x) for integer m ≥ 0 and 0.5 < x < 1 (A&S 15.3.11)
+//
+//      F = Γ(m) Γ(a+b+m) / (Γ(a+m) Γ(b+m)) Σ_{n=0}^{m-1} (a)ₙ (b)ₙ / (n! (1-m)ₙ) (1-x)ⁿ
+//        - (x-1)ᵐ Γ(a+b+m) / (Γ(a) Γ(b)) Σ_{n=0}^∞ (a+m)ₙ (b+m)ₙ / (n! (n+m)!) (1-x)ⁿ
+//          × [ln(1-x) - ψ(n+1) - ψ(n+m+1) + ψ(a+n+m) + ψ(b+n+m)]
+//
+func hyp2f1Degenerate(a, b float64, m int, x float64) float64 {
+	y := 1.0 - x
+	fm := float64(m)
+	c := a + b + fm
+
+	// finite sum
+	var s1 float64
+	if m > 0 {
+		t := 1.0
+		for n := 0; n < m; n++ {
+			fn := float64(n)
+			if n > 0 {
+				t *= (a + fn - 1.0) * (b + fn - 1.0) / (fn * (fn - fm)) * y
+			}
+			s1 += t
+		}
+		s1 *= gammaRatio([]float64{fm, c}, []float64{a + fm, b + fm})
+	}
+
+	// infinite sum
+	lny := math.Log(y)
+	ψ1, ψ2 := Digamma(1), Digamma(fm+1.0)  // ψ(n+1) and ψ(n+m+1)
+	ψa, ψb := Digamma(a+fm), Digamma(b+fm) // ψ(a+n+m) and ψ(b+n+m)
+	t := 1.0 / math.Gamma(fm+1.0)
+	var s2 float64
+	for n := 0; n < 10000; n++ {
+		fn := float64(n)
+		del := t * (lny - ψ1 - ψ2 + ψa + ψb)
+		s2 += del
+		if n > 0 && math.Abs(del) < 1e-17*math.Abs(s2) {
+			break
+		}
+		t *= (a + fm + fn) * (b + fm + fn) / ((fn + 1.0) * (fn + fm + 1.0)) * y
+		ψ1 += 1.0 / (fn + 1.0)
+		ψ2 += 1.0 / (fn + fm + 1.0)
+		ψa += 1.0 / (a + fm + fn)
+		ψb += 1.0 / (b + fm + fn)
+	}
+	sgn := 1.0
+	if m%2 == 1 {
+		sgn = -1.0 // (x-1)ᵐ = (-1)ᵐ yᵐ
+	}
+	return s1 - sgn*math.Pow(y, fm)*gammaRatio([]float64{c}, []float64{a, b})*s2
+}
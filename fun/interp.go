@@ -19,13 +19,39 @@ var (
 
 	// PolyInterpKind defines the polynomial interpolator kind
 	PolyInterpKind = io.NewEnum("Polynomial", "fun.interp", "L", "Polynomial Interpolator")
+
+	// CubicNaturalInterpKind defines the C² cubic spline kind with zero second derivatives at ends
+	CubicNaturalInterpKind = io.NewEnum("CubicNatural", "fun.interp", "CN", "Natural Cubic Spline Interpolator")
+
+	// CubicClampedInterpKind defines the C² cubic spline kind with given first derivatives at ends
+	CubicClampedInterpKind = io.NewEnum("CubicClamped", "fun.interp", "CC", "Clamped Cubic Spline Interpolator")
+
+	// CubicNotAKnotInterpKind defines the C² cubic spline kind with continuous third derivatives
+	// at the second and second-to-last points
+	CubicNotAKnotInterpKind = io.NewEnum("CubicNotAKnot", "fun.interp", "CK", "Not-a-knot Cubic Spline Interpolator")
+
+	// CubicPeriodicInterpKind defines the C² cubic spline kind with periodic ends
+	CubicPeriodicInterpKind = io.NewEnum("CubicPeriodic", "fun.interp", "CP", "Periodic Cubic Spline Interpolator")
+
+	// AkimaInterpKind defines the Akima (C¹) piecewise cubic interpolator kind
+	AkimaInterpKind = io.NewEnum("Akima", "fun.interp", "A", "Akima Interpolator")
+
+	// PchipInterpKind defines the monotone piecewise cubic Hermite interpolator kind (Fritsch-Carlson)
+	PchipInterpKind = io.NewEnum("Pchip", "fun.interp", "H", "Monotone Piecewise Cubic Hermite Interpolator")
+
+	// SmoothingSplineInterpKind defines the penalised (Reinsch) smoothing cubic spline kind
+	SmoothingSplineInterpKind = io.NewEnum("SmoothingSpline", "fun.interp", "S", "Smoothing Cubic Spline")
 )
 
 // Interpolator implements numeric interpolators
 type Interpolator struct {
 
 	// configuration data
-	DisableHunt bool // do not use hunt code at all
+	DisableHunt bool      // do not use hunt code at all
+	D0          float64   // CubicClamped: dy/dx at the first point. See SetClamped
+	Dn          float64   // CubicClamped: dy/dx at the last point. See SetClamped
+	Lambda      float64   // SmoothingSpline: smoothing parameter λ ≥ 0. See SetSmoothing
+	Weights     []float64 // SmoothingSpline: weights of data points [optional]. See SetSmoothing
 
 	// output data
 	Dy float64 // error estimate
@@ -43,6 +69,14 @@ type Interpolator struct {
 	useHunt bool // use hunt code instead of locate
 	ascnd   bool // ascending order of x-values
 
+	// piecewise cubic (or linear): y = a[j] + b[j]⋅t + c[j]⋅t² + d[j]⋅t³ with t = x - xx[j]
+	cubic bool      // kind is piecewise cubic
+	ca    []float64 // coefficients a
+	cb    []float64 // coefficients b
+	cc    []float64 // coefficients c
+	cd    []float64 // coefficients d
+	cumI  []float64 // integral from xx[0] to xx[j]
+
 	// implementation
 	interp func(j int, x float64) float64
 }
@@ -50,9 +84,13 @@ type Interpolator struct {
 // NewInterpolator creates new interpolator of type=Type for data point sets xx and yy (with same lengths)
 //   Input:
 //     Type -- type of interpolator
-//     p    -- order of interpolator
+//     p    -- order of interpolator (Polynomial kind only)
 //     xx   -- x-data
 //     yy   -- y-data
+//   NOTE: (1) the spline kinds (Cubic*, Akima, Pchip and SmoothingSpline) require strictly
+//             increasing x-data
+//         (2) the default end derivatives of CubicClamped are zero; use SetClamped to change them
+//         (3) the default λ of SmoothingSpline is zero (interpolation); use SetSmoothing to change it
 func NewInterpolator(Type io.Enum, p int, xx, yy []float64) (o *Interpolator, err error) {
 	o = new(Interpolator)
 	o.itype = Type
@@ -63,6 +101,11 @@ func NewInterpolator(Type io.Enum, p int, xx, yy []float64) (o *Interpolator, er
 	case PolyInterpKind:
 		o.m = p + 1
 		o.interp = o.polyInterp
+	case CubicNaturalInterpKind, CubicClampedInterpKind, CubicNotAKnotInterpKind, CubicPeriodicInterpKind,
+		AkimaInterpKind, PchipInterpKind, SmoothingSplineInterpKind:
+		o.m = 2
+		o.cubic = true
+		o.interp = o.cubicInterp
 	default:
 		return nil, chk.Err("cannot find interpolator type == %q\n", Type)
 	}
@@ -87,18 +130,18 @@ func (o *Interpolator) Reset(xx, yy []float64) (err error) {
 	o.djHunt = imin(1, int(math.Pow(float64(o.n), 0.25)))
 	o.useHunt = false
 	o.ascnd = o.xx[o.n-1] >= o.xx[0]
+	if o.cubic {
+		return o.splineCoefficients()
+	}
+	if o.itype == LinearInterpKind {
+		o.linearCoefficients()
+	}
 	return
 }
 
 // P computes P(x); i.e. performs the interpolation
 func (o *Interpolator) P(x float64) float64 {
-	var jlo int
-	if o.useHunt && !o.DisableHunt {
-		jlo = o.hunt(x)
-	} else {
-		jlo = o.locate(x)
-	}
-	return o.interp(jlo, x)
+	return o.interp(o.find(x), x)
 }
 
 // locate returns a value j such that x is (insofar as possible) centered in the subrange
@@ -0,0 +1,502 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SetClamped sets the first derivatives at the first and last points of the CubicClamped spline
+// and re-computes the coefficients
+func (o *Interpolator) SetClamped(d0, dn float64) (err error) {
+	o.D0, o.Dn = d0, dn
+	return o.Reset(o.xx, o.yy)
+}
+
+// SetSmoothing sets the smoothing parameter λ and the weights of the SmoothingSpline and
+// re-computes the coefficients. The spline minimises
+//                 n-1                          ⌠
+//          S(f) =  Σ  wᵢ (yᵢ - f(xᵢ))²  +  λ  │ f''(x)² dx
+//                 i=0                          ⌡
+//   Input:
+//     lambda  -- smoothing parameter: λ = 0 gives the natural cubic spline (interpolation) and
+//                λ → ∞ gives the least-squares straight line
+//     weights -- weights of data points; may be nil => all ones
+func (o *Interpolator) SetSmoothing(lambda float64, weights []float64) (err error) {
+	o.Lambda, o.Weights = lambda, weights
+	return o.Reset(o.xx, o.yy)
+}
+
+// D1 computes the first derivative dP/dx at x
+//   NOTE: not available for the Polynomial kind
+func (o *Interpolator) D1(x float64) float64 {
+	j, t := o.piece(x)
+	return o.cb[j] + t*(2.0*o.cc[j]+3.0*o.cd[j]*t)
+}
+
+// D2 computes the second derivative d²P/dx² at x
+//   NOTE: not available for the Polynomial kind
+func (o *Interpolator) D2(x float64) float64 {
+	j, t := o.piece(x)
+	return 2.0*o.cc[j] + 6.0*o.cd[j]*t
+}
+
+// Integral computes the integral of P(x) from a to b. Values outside the data range are
+// extrapolated using the first or last piece
+//   NOTE: not available for the Polynomial kind
+func (o *Interpolator) Integral(a, b float64) float64 {
+	return o.primitive(b) - o.primitive(a)
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// find returns the index of the first point of the subrange containing x
+func (o *Interpolator) find(x float64) int {
+	if o.useHunt && !o.DisableHunt {
+		return o.hunt(x)
+	}
+	return o.locate(x)
+}
+
+// piece returns the index j of the piece containing x and t = x - xx[j]
+func (o *Interpolator) piece(x float64) (j int, t float64) {
+	if o.ca == nil {
+		chk.Panic("derivatives and integrals are not available for %q interpolator\n", o.itype)
+	}
+	j = o.find(x)
+	return j, x - o.xx[j]
+}
+
+// primitive computes the integral of P from xx[0] to x
+func (o *Interpolator) primitive(x float64) float64 {
+	j, t := o.piece(x)
+	return o.cumI[j] + t*(o.ca[j]+t*(o.cb[j]/2.0+t*(o.cc[j]/3.0+t*o.cd[j]/4.0)))
+}
+
+// cubicInterp evaluates the piecewise cubic polynomial
+func (o *Interpolator) cubicInterp(j int, x float64) float64 {
+	t := x - o.xx[j]
+	return o.ca[j] + t*(o.cb[j]+t*(o.cc[j]+t*o.cd[j]))
+}
+
+// linearCoefficients computes the coefficients of the piecewise linear interpolator
+func (o *Interpolator) linearCoefficients() {
+	o.allocCoefficients()
+	for j := 0; j < o.n-1; j++ {
+		o.ca[j] = o.yy[j]
+		if o.xx[j+1] != o.xx[j] { // defective tables are accepted; see linInterp
+			o.cb[j] = (o.yy[j+1] - o.yy[j]) / (o.xx[j+1] - o.xx[j])
+		}
+	}
+	o.integrateCoefficients()
+}
+
+// splineCoefficients computes the coefficients of the piecewise cubic interpolators
+func (o *Interpolator) splineCoefficients() (err error) {
+
+	// check data
+	n := o.n
+	for j := 0; j < n-1; j++ {
+		if o.xx[j+1] <= o.xx[j] {
+			return chk.Err("x-data must be strictly increasing for %q interpolator. xx[%d]=%g and xx[%d]=%g are invalid\n", o.itype, j, o.xx[j], j+1, o.xx[j+1])
+		}
+	}
+
+	// spacing and slopes of segments
+	h := make([]float64, n-1)
+	δ := make([]float64, n-1)
+	for j := 0; j < n-1; j++ {
+		h[j] = o.xx[j+1] - o.xx[j]
+		δ[j] = (o.yy[j+1] - o.yy[j]) / h[j]
+	}
+
+	// smoothing spline
+	o.allocCoefficients()
+	if o.itype == SmoothingSplineInterpKind {
+		err = o.smoothingCoefficients(h)
+		if err != nil {
+			return
+		}
+		o.integrateCoefficients()
+		return
+	}
+
+	// slopes at points
+	s := make([]float64, n)
+	switch o.itype {
+	case CubicNaturalInterpKind, CubicClampedInterpKind:
+		splineSlopesC2(s, h, δ, o.itype == CubicClampedInterpKind, o.D0, o.Dn)
+	case CubicNotAKnotInterpKind:
+		splineSlopesNotAKnot(s, h, δ)
+	case CubicPeriodicInterpKind:
+		tol := 1e-12 * math.Max(math.Abs(o.yy[0]), math.Abs(o.yy[n-1]))
+		if math.Abs(o.yy[n-1]-o.yy[0]) > tol {
+			return chk.Err("first and last y-values must be equal for %q interpolator. %g != %g\n", o.itype, o.yy[0], o.yy[n-1])
+		}
+		splineSlopesPeriodic(s, h, δ)
+	case AkimaInterpKind:
+		splineSlopesAkima(s, δ)
+	case PchipInterpKind:
+		splineSlopesPchip(s, h, δ)
+	}
+
+	// Hermite coefficients
+	for j := 0; j < n-1; j++ {
+		o.ca[j] = o.yy[j]
+		o.cb[j] = s[j]
+		o.cc[j] = (3.0*δ[j] - 2.0*s[j] - s[j+1]) / h[j]
+		o.cd[j] = (s[j] + s[j+1] - 2.0*δ[j]) / (h[j] * h[j])
+	}
+	o.integrateCoefficients()
+	return
+}
+
+// allocCoefficients allocates the coefficients of the piecewise polynomial
+func (o *Interpolator) allocCoefficients() {
+	o.ca = make([]float64, o.n-1)
+	o.cb = make([]float64, o.n-1)
+	o.cc = make([]float64, o.n-1)
+	o.cd = make([]float64, o.n-1)
+	o.cumI = make([]float64, o.n-1)
+}
+
+// integrateCoefficients computes the integrals of P from xx[0] to each xx[j]
+func (o *Interpolator) integrateCoefficients() {
+	for j := 0; j < o.n-2; j++ {
+		t := o.xx[j+1] - o.xx[j]
+		o.cumI[j+1] = o.cumI[j] + t*(o.ca[j]+t*(o.cb[j]/2.0+t*(o.cc[j]/3.0+t*o.cd[j]/4.0)))
+	}
+}
+
+// splineSlopesC2 computes the slopes of the C² cubic spline with natural or clamped ends by
+// solving the tridiagonal system
+//   hⱼ sⱼ₋₁ + 2 (hⱼ₋₁ + hⱼ) sⱼ + hⱼ₋₁ sⱼ₊₁ = 3 (hⱼ δⱼ₋₁ + hⱼ₋₁ δⱼ)
+func splineSlopesC2(s, h, δ []float64, clamped bool, d0, dn float64) {
+	n := len(s)
+	a, b, c, r := splineSystem(h, δ)
+	if clamped {
+		b[0], c[0], r[0] = 1, 0, d0
+		a[n-1], b[n-1], r[n-1] = 0, 1, dn
+	} else { // natural: s''(x₀) = s''(xₙ₋₁) = 0
+		b[0], c[0], r[0] = 2, 1, 3.0*δ[0]
+		a[n-1], b[n-1], r[n-1] = 1, 2, 3.0*δ[n-2]
+	}
+	solveTridiag(s, a, b, c, r)
+}
+
+// splineSlopesNotAKnot computes the slopes of the C² cubic spline with continuous third
+// derivatives at x₁ and xₙ₋₂. With 3 points, the parabola is returned; with 2 points, the line
+func splineSlopesNotAKnot(s, h, δ []float64) {
+	n := len(s)
+	switch n {
+	case 2:
+		s[0], s[1] = δ[0], δ[0]
+		return
+	case 3:
+		q := (δ[1] - δ[0]) / (h[0] + h[1])
+		s[0], s[1], s[2] = δ[0]-q*h[0], δ[0]+q*h[0], δ[0]+q*(h[0]+2.0*h[1])
+		return
+	}
+	a, b, c, r := splineSystem(h, δ)
+	b[0], c[0] = h[1], h[0]+h[1]
+	r[0] = ((h[0]+2.0*(h[0]+h[1]))*h[1]*δ[0] + h[0]*h[0]*δ[1]) / (h[0] + h[1])
+	a[n-1], b[n-1] = h[n-2]+h[n-3], h[n-3]
+	r[n-1] = (h[n-2]*h[n-2]*δ[n-3] + (2.0*(h[n-3]+h[n-2])+h[n-2])*h[n-3]*δ[n-2]) / (h[n-3] + h[n-2])
+	solveTridiag(s, a, b, c, r)
+}
+
+// splineSlopesPeriodic computes the slopes of the C² periodic cubic spline (s₀ = sₙ₋₁) by solving
+// the cyclic tridiagonal system
+func splineSlopesPeriodic(s, h, δ []float64) {
+	N := len(s) - 1 // number of unknowns
+	a, b, c, r := make([]float64, N), make([]float64, N), make([]float64, N), make([]float64, N)
+	for j := 0; j < N; j++ {
+		jm := (j + N - 1) % N
+		a[j] = h[j]
+		b[j] = 2.0 * (h[jm] + h[j])
+		c[j] = h[jm]
+		r[j] = 3.0 * (h[j]*δ[jm] + h[jm]*δ[j])
+	}
+	if N == 1 { // constant function
+		s[0], s[1] = 0, 0
+		return
+	}
+	if N == 2 { // s[j-1] == s[j+1]
+		b01, b10 := a[0]+c[0], a[1]+c[1]
+		det := b[0]*b[1] - b01*b10
+		s[0] = (r[0]*b[1] - b01*r[1]) / det
+		s[1] = (b[0]*r[1] - b10*r[0]) / det
+		s[2] = s[0]
+		return
+	}
+	solveCyclicTridiag(s[:N], a, b, c, r, a[0], c[N-1])
+	s[N] = s[0]
+}
+
+// splineSlopesAkima computes the slopes of the Akima interpolator
+//   Reference:
+//   [1] Akima H (1970) A new method of interpolation and smooth curve fitting based on local
+//       procedures. Journal of the ACM, 17(4):589-602
+func splineSlopesAkima(s, δ []float64) {
+	n := len(s)
+	if n == 2 {
+		s[0], s[1] = δ[0], δ[0]
+		return
+	}
+
+	// extended slopes: m[k+2] = δ[k] with two extra values on each side
+	m := make([]float64, n+3)
+	copy(m[2:], δ)
+	m[1] = 2.0*m[2] - m[3]
+	m[0] = 2.0*m[1] - m[2]
+	m[n+1] = 2.0*m[n] - m[n-1]
+	m[n+2] = 2.0*m[n+1] - m[n]
+
+	// slopes
+	for i := 0; i < n; i++ {
+		w1 := math.Abs(m[i+3] - m[i+2])
+		w2 := math.Abs(m[i+1] - m[i])
+		if w1+w2 == 0 {
+			s[i] = 0.5 * (m[i+1] + m[i+2])
+		} else {
+			s[i] = (w1*m[i+1] + w2*m[i+2]) / (w1 + w2)
+		}
+	}
+}
+
+// splineSlopesPchip computes the slopes of the monotone piecewise cubic Hermite interpolator.
+// The interior slopes are weighted harmonic means of the neighbouring δ's (zero at local extrema)
+// and the end slopes are computed with a shape-preserving three-point formula
+//   Reference:
+//   [1] Fritsch FN, Carlson RE (1980) Monotone piecewise cubic interpolation. SIAM Journal on
+//       Numerical Analysis, 17(2):238-246
+//   [2] Fritsch FN, Butland J (1984) A method for constructing local monotone piecewise cubic
+//       interpolants. SIAM Journal on Scientific and Statistical Computing, 5(2):300-304
+func splineSlopesPchip(s, h, δ []float64) {
+	n := len(s)
+	if n == 2 {
+		s[0], s[1] = δ[0], δ[0]
+		return
+	}
+	for k := 1; k < n-1; k++ {
+		if δ[k-1]*δ[k] <= 0 {
+			s[k] = 0
+			continue
+		}
+		w1 := 2.0*h[k] + h[k-1]
+		w2 := h[k] + 2.0*h[k-1]
+		s[k] = (w1 + w2) / (w1/δ[k-1] + w2/δ[k])
+	}
+	s[0] = pchipEndSlope(h[0], h[1], δ[0], δ[1])
+	s[n-1] = pchipEndSlope(h[n-2], h[n-3], δ[n-2], δ[n-3])
+}
+
+// pchipEndSlope computes the end slope of PCHIP using the non-centred three-point formula
+func pchipEndSlope(h0, h1, δ0, δ1 float64) (d float64) {
+	d = ((2.0*h0+h1)*δ0 - h0*δ1) / (h0 + h1)
+	if d*δ0 <= 0 {
+		return 0
+	}
+	if δ0*δ1 <= 0 && math.Abs(d) > math.Abs(3.0*δ0) {
+		return 3.0 * δ0
+	}
+	return
+}
+
+// smoothingCoefficients computes the coefficients of the smoothing cubic spline by solving the
+// pentadiagonal system for the second derivatives γ at the interior points
+//   (R + λ Qᵀ W⁻¹ Q) γ = Qᵀ y   and   g = y - λ W⁻¹ Q γ
+// where g are the values of the spline at the data points
+//   Reference:
+//   [1] Reinsch CH (1967) Smoothing by spline functions. Numerische Mathematik, 10:177-183
+//   [2] Green PJ, Silverman BW (1994) Nonparametric Regression and Generalized Linear Models:
+//       A roughness penalty approach. Chapman and Hall. 182p
+func (o *Interpolator) smoothingCoefficients(h []float64) (err error) {
+
+	// check
+	n := o.n
+	λ := o.Lambda
+	if λ < 0 {
+		return chk.Err("smoothing parameter must be non-negative. λ=%g is invalid\n", λ)
+	}
+	w := o.Weights
+	if w == nil {
+		w = make([]float64, n)
+		for i := 0; i < n; i++ {
+			w[i] = 1
+		}
+	}
+	if len(w) != n {
+		return chk.Err("number of weights must be equal to the number of data points. %d != %d\n", len(w), n)
+	}
+	for i := 0; i < n; i++ {
+		if w[i] <= 0 {
+			return chk.Err("weights must be positive. w[%d]=%g is invalid\n", i, w[i])
+		}
+	}
+
+	// second derivatives (zero at ends)
+	M := make([]float64, n)
+	g := make([]float64, n)
+	copy(g, o.yy)
+	if n > 2 {
+
+		// qval returns Q[r][k] (the three non-zeros of column k are in rows k, k+1 and k+2)
+		qval := func(r, k int) float64 {
+			switch r - k {
+			case 0:
+				return 1.0 / h[k]
+			case 1:
+				return -1.0/h[k] - 1.0/h[k+1]
+			case 2:
+				return 1.0 / h[k+1]
+			}
+			return 0
+		}
+
+		// assemble bands of A = R + λ Qᵀ W⁻¹ Q and right-hand side Qᵀ y
+		N := n - 2
+		a0, a1, a2 := make([]float64, N), make([]float64, N), make([]float64, N)
+		rhs := make([]float64, N)
+		for k := 0; k < N; k++ {
+			a0[k] = (h[k] + h[k+1]) / 3.0
+			if k < N-1 {
+				a1[k] = h[k+1] / 6.0
+			}
+			rhs[k] = (o.yy[k+2]-o.yy[k+1])/h[k+1] - (o.yy[k+1]-o.yy[k])/h[k]
+		}
+		for r := 0; r < n; r++ {
+			for k := imax(0, r-2); k <= imin(N-1, r); k++ {
+				qk := qval(r, k) / w[r]
+				a0[k] += λ * qk * qval(r, k)
+				if k+1 <= imin(N-1, r) {
+					a1[k] += λ * qk * qval(r, k+1)
+				}
+				if k+2 <= imin(N-1, r) {
+					a2[k] += λ * qk * qval(r, k+2)
+				}
+			}
+		}
+
+		// solve and compute values
+		γ := make([]float64, N)
+		solvePentaSym(γ, a0, a1, a2, rhs)
+		copy(M[1:], γ)
+		for r := 0; r < n; r++ {
+			qγ := 0.0
+			for k := imax(0, r-2); k <= imin(N-1, r); k++ {
+				qγ += qval(r, k) * γ[k]
+			}
+			g[r] -= λ * qγ / w[r]
+		}
+	}
+
+	// coefficients
+	for j := 0; j < n-1; j++ {
+		o.ca[j] = g[j]
+		o.cb[j] = (g[j+1]-g[j])/h[j] - h[j]*(2.0*M[j]+M[j+1])/6.0
+		o.cc[j] = M[j] / 2.0
+		o.cd[j] = (M[j+1] - M[j]) / (6.0 * h[j])
+	}
+	return
+}
+
+// splineSystem allocates and fills the tridiagonal system of the C² cubic spline. The first and
+// last rows must be set by the caller
+func splineSystem(h, δ []float64) (a, b, c, r []float64) {
+	n := len(h) + 1
+	a, b, c, r = make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for j := 1; j < n-1; j++ {
+		a[j] = h[j]
+		b[j] = 2.0 * (h[j-1] + h[j])
+		c[j] = h[j-1]
+		r[j] = 3.0 * (h[j]*δ[j-1] + h[j-1]*δ[j])
+	}
+	return
+}
+
+// solveTridiag solves the tridiagonal system with sub-diagonal a, diagonal b and super-diagonal c
+// using the Thomas algorithm. a[0] and c[n-1] are not used
+func solveTridiag(x, a, b, c, r []float64) {
+	n := len(x)
+	cp := make([]float64, n)
+	β := b[0]
+	x[0] = r[0] / β
+	for j := 1; j < n; j++ {
+		cp[j] = c[j-1] / β
+		β = b[j] - a[j]*cp[j]
+		x[j] = (r[j] - a[j]*x[j-1]) / β
+	}
+	for j := n - 2; j >= 0; j-- {
+		x[j] -= cp[j+1] * x[j+1]
+	}
+}
+
+// solveCyclicTridiag solves the cyclic tridiagonal system (n ≥ 3) with the corner entries
+// A[0][n-1] = β and A[n-1][0] = α using the Sherman-Morrison formula
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func solveCyclicTridiag(x, a, b, c, r []float64, β, α float64) {
+	n := len(x)
+	γ := -b[0]
+	bb := make([]float64, n)
+	copy(bb, b)
+	bb[0] = b[0] - γ
+	bb[n-1] = b[n-1] - α*β/γ
+	solveTridiag(x, a, bb, c, r)
+	u := make([]float64, n)
+	z := make([]float64, n)
+	u[0], u[n-1] = γ, α
+	solveTridiag(z, a, bb, c, u)
+	fact := (x[0] + β*x[n-1]/γ) / (1.0 + z[0] + β*z[n-1]/γ)
+	for j := 0; j < n; j++ {
+		x[j] -= fact * z[j]
+	}
+}
+
+// solvePentaSym solves the symmetric positive-definite pentadiagonal system with diagonal d0,
+// first super-diagonal d1 and second super-diagonal d2 using the LDLᵀ decomposition
+func solvePentaSym(x, d0, d1, d2, r []float64) {
+	n := len(x)
+	D := make([]float64, n)
+	e := make([]float64, n) // L[k][k-1]
+	f := make([]float64, n) // L[k][k-2]
+	for k := 0; k < n; k++ {
+		D[k] = d0[k]
+		if k >= 2 {
+			f[k] = d2[k-2] / D[k-2]
+			D[k] -= f[k] * f[k] * D[k-2]
+		}
+		if k >= 1 {
+			e[k] = d1[k-1]
+			if k >= 2 {
+				e[k] -= f[k] * D[k-2] * e[k-1]
+			}
+			e[k] /= D[k-1]
+			D[k] -= e[k] * e[k] * D[k-1]
+		}
+	}
+	for k := 0; k < n; k++ {
+		x[k] = r[k]
+		if k >= 1 {
+			x[k] -= e[k] * x[k-1]
+		}
+		if k >= 2 {
+			x[k] -= f[k] * x[k-2]
+		}
+	}
+	for k := 0; k < n; k++ {
+		x[k] /= D[k]
+	}
+	for k := n - 2; k >= 0; k-- {
+		x[k] -= e[k+1] * x[k+1]
+		if k+2 < n {
+			x[k] -= f[k+2] * x[k+2]
+		}
+	}
+}
@@ -21,6 +21,12 @@ var (
 
 	// ChebyGaussGridKind defines the Chebyshev-Gauss 1D grid kind
 	ChebyGaussGridKind = io.NewEnum("ChebyGauss", "fun.chebygauss", "CG", "Chebyshev-Gauss 1D grid")
+
+	// ChebyGaussLobattoGridKind defines the Chebyshev-Gauss-Lobatto 1D grid kind
+	ChebyGaussLobattoGridKind = io.NewEnum("ChebyGaussLobatto", "fun.chebygausslobatto", "CGL", "Chebyshev-Gauss-Lobatto 1D grid")
+
+	// LegendreGaussLobattoGridKind defines the Legendre-Gauss-Lobatto 1D grid kind
+	LegendreGaussLobattoGridKind = io.NewEnum("LegendreGaussLobatto", "fun.legendregausslobatto", "LGL", "Legendre-Gauss-Lobatto 1D grid")
 )
 
 // LagrangeInterp implements Lagrange interpolators associated with a grid X
@@ -45,8 +51,9 @@ var (
 //               j ≠ i
 //
 type LagrangeInterp struct {
-	N int       // degree: N = len(X)-1
-	X []float64 // grid points: len(X) = P+1; generated in [-1, 1]
+	N    int       // degree: N = len(X)-1
+	X    []float64 // grid points: len(X) = P+1; generated in [-1, 1]
+	Grid io.Enum   // kind of grid
 }
 
 // NewLagrangeInterp allocates a new LagrangeInterp
@@ -59,6 +66,7 @@ func NewLagrangeInterp(N int, gridType io.Enum) (o *LagrangeInterp, err error) {
 	}
 	o = new(LagrangeInterp)
 	o.N = N
+	o.Grid = gridType
 	switch gridType {
 	case UniformGridKind:
 		o.X = utl.LinSpace(-1, 1, N+1)
@@ -68,12 +76,57 @@ func NewLagrangeInterp(N int, gridType io.Enum) (o *LagrangeInterp, err error) {
 		for i := 0; i < N+1; i++ {
 			o.X[i] = -math.Cos(h * float64(2*i+1))
 		}
+	case ChebyGaussLobattoGridKind:
+		if N < 1 {
+			return nil, chk.Err("N must be at least equal to 1 with Lobatto grids. N=%d is invalid\n", N)
+		}
+		o.X = make([]float64, N+1)
+		for i := 0; i < N+1; i++ {
+			o.X[i] = -math.Cos(math.Pi * float64(i) / float64(N))
+		}
+		o.X[0], o.X[N] = -1, 1
+		if N%2 == 0 {
+			o.X[N/2] = 0
+		}
+	case LegendreGaussLobattoGridKind:
+		if N < 1 {
+			return nil, chk.Err("N must be at least equal to 1 with Lobatto grids. N=%d is invalid\n", N)
+		}
+		o.X = legendreGaussLobatto(N)
 	default:
 		return nil, chk.Err("cannot create grid type %q\n", gridType)
 	}
 	return
 }
 
+// legendreGaussLobatto computes the Legendre-Gauss-Lobatto points; i.e. ±1 and the roots of the
+// derivative of the Legendre polynomial P_N. The points are computed with Newton's method applied
+// to (1 - x²) P'_N(x) starting from the Chebyshev-Gauss-Lobatto points
+func legendreGaussLobatto(N int) (X []float64) {
+	X = make([]float64, N+1)
+	for i := 0; i < N+1; i++ {
+		x := -math.Cos(math.Pi * float64(i) / float64(N))
+		if i > 0 && i < N {
+			for it := 0; it < 100; it++ {
+				p0, p1 := 1.0, x // P_{k-1}, P_k
+				for k := 2; k <= N; k++ {
+					p0, p1 = p1, (float64(2*k-1)*x*p1-float64(k-1)*p0)/float64(k)
+				}
+				δ := (x*p1 - p0) / (float64(N+1) * p1)
+				x -= δ
+				if math.Abs(δ) < 1e-15 {
+					break
+				}
+			}
+		}
+		X[i] = x
+	}
+	if N%2 == 0 {
+		X[N/2] = 0
+	}
+	return
+}
+
 // W computes the generating (nodal) polynomial associated with grid X. The nodal polynomial is the
 // unique polynomial of degree N+1 and leading coefficient whose zeros are the N+1 nodes of X.
 //
@@ -0,0 +1,125 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// 1/e in double-double precision
+const (
+	lambertInvEhi = 0.36787944117144233
+	lambertInvElo = -1.2428753672788363e-17
+)
+
+// LambertW computes the principal branch W₀(x) of the Lambert W function; i.e. the solution
+// w ≥ -1 of w exp(w) = x for x ≥ -1/e
+//   NOTE: returns NaN if x < -1/e
+//   Reference:
+//   [1] Corless RM, Gonnet GH, Hare DEG, Jeffrey DJ, Knuth DE (1996) On the Lambert W function.
+//       Advances in Computational Mathematics, 5:329-359
+func LambertW(x float64) float64 {
+	if math.IsNaN(x) {
+		return x
+	}
+	if x == 0 || math.IsInf(x, 1) {
+		return x
+	}
+	if x < -0.25 {
+		return lambertNearBranch(x, 1)
+	}
+
+	// initial guess
+	var w float64
+	if x < 3 {
+		w = math.Log1p(x)
+	} else {
+		l1 := math.Log(x)
+		l2 := math.Log(l1)
+		w = l1 - l2 + l2/l1
+	}
+
+	// Halley's method for x ≤ e and Newton's method for w + ln w = ln x otherwise
+	for it := 0; it < 100; it++ {
+		var δ float64
+		if x <= math.E {
+			ew := math.Exp(w)
+			f := w*ew - x
+			δ = f / (ew*(w+1.0) - (w+2.0)*f/(2.0*w+2.0))
+		} else {
+			δ = (w + math.Log(w) - math.Log(x)) * w / (w + 1.0)
+		}
+		w -= δ
+		if math.Abs(δ) <= 1e-15*math.Abs(w) {
+			break
+		}
+	}
+	return w
+}
+
+// LambertWm1 computes the secondary real branch W₋₁(x) of the Lambert W function; i.e. the
+// solution w ≤ -1 of w exp(w) = x for -1/e ≤ x < 0
+//   NOTE: returns NaN if x ∉ [-1/e, 0) and -Inf if x = 0⁻
+func LambertWm1(x float64) float64 {
+	if math.IsNaN(x) || x > 0 {
+		return math.NaN()
+	}
+	if x == 0 {
+		return math.Inf(-1)
+	}
+	if x < -0.25 {
+		return lambertNearBranch(x, -1)
+	}
+
+	// Newton's method for w + ln(-w) = ln(-x)
+	l1 := math.Log(-x)
+	l2 := math.Log(-l1)
+	w := l1 - l2 + l2/l1
+	for it := 0; it < 100; it++ {
+		δ := (w + math.Log(-w) - l1) * w / (w + 1.0)
+		w -= δ
+		if math.Abs(δ) <= 1e-15*math.Abs(w) {
+			break
+		}
+	}
+	return w
+}
+
+// lambertNearBranch computes W₀(x) (sgn=1) or W₋₁(x) (sgn=-1) near the branch point x = -1/e.
+// With w = -1 + d, the equation w exp(w) = x is rewritten without cancellation as
+//
+//      h(d) = d exp(d) - expm1(d) = e (x + 1/e)
+//
+// which is solved by Newton's method starting from the series of Corless et al. (1996)
+func lambertNearBranch(x, sgn float64) float64 {
+	xs := (x + lambertInvEhi) + lambertInvElo // x + 1/e
+	if xs < 0 {
+		if xs > -4e-16 { // roundoff
+			return -1
+		}
+		return math.NaN()
+	}
+	if xs == 0 {
+		return -1
+	}
+	p := sgn * math.Sqrt(2.0*math.E*xs)
+	d := p - p*p/3.0 + 11.0*p*p*p/72.0 - 43.0*p*p*p*p/540.0
+	rhs := math.E * xs
+	for it := 0; it < 100; it++ {
+		h, t := 0.0, d
+		for k := 2; k < 60; k++ {
+			t *= d / float64(k)
+			δh := float64(k-1) * t
+			h += δh
+			if math.Abs(δh) < 1e-17*math.Abs(h) {
+				break
+			}
+		}
+		δ := (h - rhs) / (d * math.Exp(d))
+		d -= δ
+		if math.Abs(δ) <= 1e-16*math.Abs(d) {
+			break
+		}
+	}
+	return -1.0 + d
+}
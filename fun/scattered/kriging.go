@@ -0,0 +1,398 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/gm"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+var (
+
+	// SphericalVariogramKind defines the spherical variogram model
+	SphericalVariogramKind = io.NewEnum("Spherical", "scattered.variogram", "S", "Spherical variogram")
+
+	// ExponentialVariogramKind defines the exponential variogram model
+	ExponentialVariogramKind = io.NewEnum("Exponential", "scattered.variogram", "E", "Exponential variogram")
+
+	// GaussianVariogramKind defines the Gaussian variogram model
+	GaussianVariogramKind = io.NewEnum("Gaussian", "scattered.variogram", "G", "Gaussian variogram")
+)
+
+// Variogram implements the isotropic (semi-)variogram models
+//
+//   Spherical:    γ(h) = c₀ + c ⋅ (3/2 h/a - 1/2 (h/a)³)  if h < a  or  c₀ + c  otherwise
+//   Exponential:  γ(h) = c₀ + c ⋅ (1 - exp(-3 h / a))
+//   Gaussian:     γ(h) = c₀ + c ⋅ (1 - exp(-3 h² / a²))
+//
+//   where c₀ is the nugget, c is the partial sill and a is the (practical) range. γ(0) = 0
+type Variogram struct {
+	Kind   io.Enum // model
+	Nugget float64 // c₀ ≥ 0
+	Sill   float64 // partial sill c > 0
+	Range  float64 // range a > 0
+}
+
+// G computes the variogram γ(h)
+func (o *Variogram) G(h float64) float64 {
+	if h <= 0 {
+		return 0
+	}
+	return o.Nugget + o.Sill*o.shape(h, o.Range)
+}
+
+// Fit fits the variogram model to experimental data using weighted least squares with weights
+// N(h)/h² (number of pairs over squared distance). The nugget and partial sill are computed
+// exactly for each range a, which is found by Brent's method
+func (o *Variogram) Fit(h, γ []float64, npairs []int) (err error) {
+
+	// check
+	if len(h) != len(γ) || len(h) != len(npairs) {
+		return chk.Err("sizes of experimental data must be equal. %d, %d, %d are invalid\n", len(h), len(γ), len(npairs))
+	}
+	hmax := 0.0
+	w := make([]float64, len(h))
+	nvalid := 0
+	for k := 0; k < len(h); k++ {
+		if npairs[k] > 0 && h[k] > 0 {
+			w[k] = float64(npairs[k]) / (h[k] * h[k])
+			hmax = math.Max(hmax, h[k])
+			nvalid++
+		}
+	}
+	if nvalid < 2 {
+		return chk.Err("at least 2 lags with pairs are required to fit variogram. %d is invalid\n", nvalid)
+	}
+
+	// weighted sum of squared errors as a function of the range
+	sse := func(a float64) (res float64, err error) {
+		c0, c := o.fitLinear(h, γ, w, a)
+		for k := 0; k < len(h); k++ {
+			e := γ[k] - c0 - c*o.shape(h[k], a)
+			res += w[k] * e * e
+		}
+		return
+	}
+
+	// minimise
+	var brent num.Brent
+	brent.Init(sse)
+	brent.Tol = 1e-10
+	brent.MaxIt = 100
+	a, err := brent.Min(1e-3*hmax, 2.0*hmax, true)
+	if err != nil {
+		return
+	}
+	o.Range = a
+	o.Nugget, o.Sill = o.fitLinear(h, γ, w, a)
+	return
+}
+
+// shape computes the normalised shape function f(h) ∈ [0, 1] of the model
+func (o *Variogram) shape(h, a float64) float64 {
+	switch o.Kind {
+	case SphericalVariogramKind:
+		if h >= a {
+			return 1
+		}
+		r := h / a
+		return 1.5*r - 0.5*r*r*r
+	case ExponentialVariogramKind:
+		return 1.0 - math.Exp(-3.0*h/a)
+	case GaussianVariogramKind:
+		return 1.0 - math.Exp(-3.0*h*h/(a*a))
+	}
+	chk.Panic("cannot find variogram kind == %q\n", o.Kind)
+	return 0
+}
+
+// fitLinear computes the nugget c₀ ≥ 0 and partial sill c ≥ 0 for a given range a by weighted
+// least squares
+func (o *Variogram) fitLinear(h, γ, w []float64, a float64) (c0, c float64) {
+	var sw, sf, sff, sg, sfg float64
+	for k := 0; k < len(h); k++ {
+		f := o.shape(h[k], a)
+		sw += w[k]
+		sf += w[k] * f
+		sff += w[k] * f * f
+		sg += w[k] * γ[k]
+		sfg += w[k] * f * γ[k]
+	}
+	det := sw*sff - sf*sf
+	if det != 0 {
+		c0 = (sff*sg - sf*sfg) / det
+		c = (sw*sfg - sf*sg) / det
+	}
+	if det == 0 || c0 < 0 { // no nugget
+		c0, c = 0, sfg/sff
+	}
+	if c < 0 {
+		c0, c = sg/sw, 0
+	}
+	return
+}
+
+// ExperimentalVariogram computes the experimental (semi-)variogram of scattered data
+//
+//                    1      N(h)
+//          γ*(h) = ――――――   Σ   (y(xᵢ) - y(xⱼ))²
+//                  2 N(h)
+//
+//   where the sum is over the N(h) pairs of points with distance within the lag h ± Δh/2
+//
+//   Input:
+//     X       -- [n][ndim] data points
+//     y       -- [n] values at data points
+//     nlags   -- number of lags. default (if ≤ 0) = 15
+//     maxDist -- maximum distance. default (if ≤ 0) = half of the diagonal of the bounding box
+//   Output:
+//     h      -- [nlags] average distance of pairs in each lag
+//     γ      -- [nlags] experimental variogram
+//     npairs -- [nlags] number of pairs in each lag
+func ExperimentalVariogram(X [][]float64, y []float64, nlags int, maxDist float64) (h, γ []float64, npairs []int, err error) {
+	_, err = checkData(X, y)
+	if err != nil {
+		return
+	}
+	if nlags <= 0 {
+		nlags = 15
+	}
+	if maxDist <= 0 {
+		xmin, xmax := boundingBox(X)
+		maxDist = dist(xmin, xmax) / 2.0
+	}
+	if maxDist <= 0 {
+		return nil, nil, nil, chk.Err("maximum distance must be positive. %g is invalid (repeated points?)\n", maxDist)
+	}
+	dh := maxDist / float64(nlags)
+	h = make([]float64, nlags)
+	γ = make([]float64, nlags)
+	npairs = make([]int, nlags)
+	for i := 0; i < len(X); i++ {
+		for j := i + 1; j < len(X); j++ {
+			d := dist(X[i], X[j])
+			k := int(d / dh)
+			if k >= nlags {
+				continue
+			}
+			h[k] += d
+			γ[k] += 0.5 * (y[i] - y[j]) * (y[i] - y[j])
+			npairs[k]++
+		}
+	}
+	for k := 0; k < nlags; k++ {
+		if npairs[k] > 0 {
+			h[k] /= float64(npairs[k])
+			γ[k] /= float64(npairs[k])
+		} else {
+			h[k] = (float64(k) + 0.5) * dh
+		}
+	}
+	return
+}
+
+// FitVariogram computes the experimental variogram and fits a model to it
+//   Input:
+//     kind    -- variogram model; e.g. SphericalVariogramKind
+//     X       -- [n][ndim] data points
+//     y       -- [n] values at data points
+//     nlags   -- number of lags. default (if ≤ 0) = 15
+//     maxDist -- maximum distance. default (if ≤ 0) = half of the diagonal of the bounding box
+func FitVariogram(kind io.Enum, X [][]float64, y []float64, nlags int, maxDist float64) (o *Variogram, err error) {
+	h, γ, npairs, err := ExperimentalVariogram(X, y, nlags, maxDist)
+	if err != nil {
+		return
+	}
+	o = &Variogram{Kind: kind}
+	err = o.Fit(h, γ, npairs)
+	return
+}
+
+// Kriging implements the ordinary kriging interpolator
+//
+//                 n-1                      n-1
+//          f(x) =  Σ  λᵢ(x) yᵢ     with     Σ  λᵢ = 1
+//                 i=0                      i=0
+//
+//   where the weights λ minimise the estimation variance and solve
+//
+//          ┌        ┐ ┌   ┐   ┌    ┐
+//          │ Γ    1 │ │ λ │   │ γ₀ │
+//          │        │ │   │ = │    │
+//          │ 1ᵀ   0 │ │ μ │   │ 1  │
+//          └        ┘ └   ┘   └    ┘
+//
+//   with Γᵢⱼ = γ(‖xᵢ - xⱼ‖) and γ₀ᵢ = γ(‖x - xᵢ‖). The kriging variance is σ² = λᵀγ₀ + μ
+//
+//   By default, all points are used (global kriging) and the matrix is factorised only once with
+//   the sparse solver given by la.DefaultSolver; then, f(x) is computed in O(n) operations using
+//   the dual form. If Radius > 0, only the points within Radius are used (local kriging with a
+//   moving neighbourhood) and these points are found with gm.Bins
+type Kriging struct {
+
+	// input
+	V      *Variogram // variogram model
+	Radius float64    // search radius for local kriging. 0 => global kriging
+
+	// derived
+	xx     [][]float64 // [n][ndim] data points (read only)
+	yy     []float64   // [n] values at data points (read only)
+	solver la.LinSol   // global: factorised kriging matrix
+	dual   []float64   // global: [n+1] solution of the dual system
+	bins   *gm.Bins    // local: bins with data points
+}
+
+// NewKriging allocates a new ordinary kriging interpolator
+//   Input:
+//     X      -- [n][ndim] data points
+//     y      -- [n] values at data points
+//     v      -- variogram model; e.g. from FitVariogram
+//     radius -- search radius for local kriging. 0 => global kriging
+func NewKriging(X [][]float64, y []float64, v *Variogram, radius float64) (o *Kriging, err error) {
+
+	// check
+	_, err = checkData(X, y)
+	if err != nil {
+		return
+	}
+	if v == nil || v.Range <= 0 || v.Sill <= 0 || v.Nugget < 0 {
+		return nil, chk.Err("variogram must have positive range and sill and non-negative nugget\n")
+	}
+	o = new(Kriging)
+	o.V = v
+	o.Radius = radius
+	o.xx, o.yy = X, y
+
+	// local kriging
+	if radius > 0 {
+		o.bins, err = newBins(X, len(X))
+		if err != nil {
+			return
+		}
+		for i, x := range X {
+			err = o.bins.Append(x, i, nil)
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	// global kriging
+	n := len(X)
+	var A la.Triplet
+	A.Init(n+1, n+1, n*n+2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				A.Put(i, j, v.G(dist(X[i], X[j])))
+			}
+		}
+		A.Put(i, n, 1)
+		A.Put(n, i, 1)
+	}
+	o.solver = la.GetSolver(la.DefaultSolver)
+	err = o.solver.InitR(&A, false, false, false)
+	if err != nil {
+		return
+	}
+	err = o.solver.Fact()
+	if err != nil {
+		return nil, chk.Err("cannot factorise kriging matrix (are there repeated points?):\n%v", err)
+	}
+	rhs := make([]float64, n+1)
+	copy(rhs, y)
+	o.dual = make([]float64, n+1)
+	err = o.solver.SolveR(o.dual, rhs, false)
+	return
+}
+
+// Free frees memory allocated by the linear solver
+func (o *Kriging) Free() {
+	if o.solver != nil {
+		o.solver.Free()
+	}
+}
+
+// F computes the interpolated value at x
+func (o *Kriging) F(x []float64) (res float64) {
+	if o.bins == nil {
+		n := len(o.xx)
+		for i := 0; i < n; i++ {
+			res += o.dual[i] * o.V.G(dist(x, o.xx[i]))
+		}
+		return res + o.dual[n]
+	}
+	res, _ = o.Estimate(x)
+	return
+}
+
+// Estimate computes the interpolated value and the kriging variance at x
+//   NOTE: with local kriging, NaN values are returned if there are no points within Radius
+func (o *Kriging) Estimate(x []float64) (val, variance float64) {
+
+	// points
+	var ids []int
+	if o.bins != nil {
+		ids = o.bins.FindWithinRadius(x, o.Radius)
+		if len(ids) == 0 {
+			return math.NaN(), math.NaN()
+		}
+	} else {
+		ids = make([]int, len(o.xx))
+		for i := 0; i < len(o.xx); i++ {
+			ids[i] = i
+		}
+	}
+
+	// right-hand side
+	m := len(ids)
+	γ0 := make([]float64, m+1)
+	for k, i := range ids {
+		γ0[k] = o.V.G(dist(x, o.xx[i]))
+	}
+	γ0[m] = 1
+
+	// weights
+	λ := make([]float64, m+1)
+	if o.bins == nil {
+		err := o.solver.SolveR(λ, γ0, false)
+		if err != nil {
+			chk.Panic("cannot solve kriging system:\n%v", err)
+		}
+	} else {
+		A := make([][]float64, m+1)
+		for k := 0; k <= m; k++ {
+			A[k] = make([]float64, m+1)
+		}
+		for k, i := range ids {
+			for l, j := range ids {
+				if k != l {
+					A[k][l] = o.V.G(dist(o.xx[i], o.xx[j]))
+				}
+			}
+			A[k][m], A[m][k] = 1, 1
+		}
+		b := make([]float64, m+1)
+		copy(b, γ0)
+		err := la.DenseSolve(λ, A, b)
+		if err != nil {
+			chk.Panic("cannot solve local kriging system (are there repeated points?):\n%v", err)
+		}
+	}
+
+	// results
+	for k, i := range ids {
+		val += λ[k] * o.yy[i]
+		variance += λ[k] * γ0[k]
+	}
+	variance += λ[m]
+	return
+}
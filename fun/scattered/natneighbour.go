@@ -0,0 +1,337 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/gm"
+	"github.com/cpmech/gosl/gm/tri"
+)
+
+// NatNeighbour implements the natural neighbour (Sibson) interpolator in 2D
+//
+//                     n-1
+//              f(x) =  Σ  wᵢ(x) yᵢ
+//                     i=0
+//
+//   where the weights wᵢ (natural neighbour coordinates) are the fractions of the area of the
+//   Voronoi cell of x (inserted into the Delaunay triangulation) stolen from the cells of the data
+//   points. The interpolator is C¹ except at the data points and reproduces linear functions.
+//   The triangles are stored in gm.Bins to quickly locate x
+//
+//   NOTE: (1) the interpolator is defined within the convex hull of the data points only; the
+//             value Outside is returned otherwise
+//         (2) on the boundary of the convex hull, the interpolation is linear along the edge
+//
+//   Reference:
+//   [1] Sibson R (1981) A brief description of natural neighbour interpolation. In: Barnett V
+//       (ed) Interpreting Multivariate Data. Wiley. pp 21-36
+//   [2] Watson DF (1992) Contouring: A Guide to the Analysis and Display of Spatial Data.
+//       Pergamon Press. 321p
+type NatNeighbour struct {
+
+	// input
+	Outside float64 // value returned for points outside the convex hull. default = NaN
+
+	// derived
+	verts [][]float64 // [nverts][2] vertices (data points)
+	cells [][]int     // [ncells][3] triangles (counter-clockwise)
+	yy    []float64   // [nverts] values at vertices
+	cc    [][]float64 // [ncells][2] circumcentres
+	r2    []float64   // [ncells] squared radii of circumcircles
+	adj   [][3]int    // [ncells] triangle across the edge opposite to each vertex (-1 => boundary)
+	bins  *gm.Bins    // triangles overlapping each bin
+}
+
+// NewNatNeighbour allocates a new natural neighbour interpolator by computing the Delaunay
+// triangulation of the data points with gm/tri
+//   Input:
+//     X -- [n][2] data points
+//     y -- [n] values at data points
+func NewNatNeighbour(X [][]float64, y []float64) (o *NatNeighbour, err error) {
+	ndim, err := checkData(X, y)
+	if err != nil {
+		return
+	}
+	if ndim != 2 {
+		return nil, chk.Err("natural neighbour interpolation is only available in 2D. ndim=%d is invalid\n", ndim)
+	}
+	xs, ys := make([]float64, len(X)), make([]float64, len(X))
+	for i, x := range X {
+		xs[i], ys[i] = x[0], x[1]
+	}
+	V, C, err := tri.Delaunay(xs, ys, false)
+	if err != nil {
+		return
+	}
+	if len(V) != len(X) {
+		return nil, chk.Err("Delaunay triangulation has %d vertices but there are %d data points (repeated points?)\n", len(V), len(X))
+	}
+	return NewNatNeighbourTri(V, C, y)
+}
+
+// NewNatNeighbourTri allocates a new natural neighbour interpolator with a given Delaunay
+// triangulation
+//   Input:
+//     V -- [nverts][2] vertices (data points)
+//     C -- [ncells][3] triangles; e.g. from tri.Delaunay
+//     y -- [nverts] values at vertices
+func NewNatNeighbourTri(V [][]float64, C [][]int, y []float64) (o *NatNeighbour, err error) {
+
+	// check
+	ndim, err := checkData(V, y)
+	if err != nil {
+		return
+	}
+	if ndim != 2 {
+		return nil, chk.Err("natural neighbour interpolation is only available in 2D. ndim=%d is invalid\n", ndim)
+	}
+	if len(C) < 1 {
+		return nil, chk.Err("at least one triangle is required\n")
+	}
+
+	// triangles and circumcircles
+	o = new(NatNeighbour)
+	o.Outside = math.NaN()
+	o.verts = V
+	o.yy = y
+	o.cells = make([][]int, len(C))
+	o.cc = make([][]float64, len(C))
+	o.r2 = make([]float64, len(C))
+	for t, c := range C {
+		if len(c) != 3 {
+			return nil, chk.Err("triangles must have 3 vertices. len(C[%d])=%d is invalid\n", t, len(c))
+		}
+		for _, v := range c {
+			if v < 0 || v >= len(V) {
+				return nil, chk.Err("vertex %d of triangle %d is out of range\n", v, t)
+			}
+		}
+		a, b, d := V[c[0]], V[c[1]], V[c[2]]
+		area2 := (b[0]-a[0])*(d[1]-a[1]) - (b[1]-a[1])*(d[0]-a[0])
+		if area2 == 0 {
+			return nil, chk.Err("triangle %d is degenerated (zero area)\n", t)
+		}
+		o.cells[t] = []int{c[0], c[1], c[2]}
+		if area2 < 0 {
+			o.cells[t][1], o.cells[t][2] = c[2], c[1]
+		}
+		o.cc[t] = circumcentre(a, b, d)
+		o.r2[t] = math.Pow(dist(o.cc[t], a), 2)
+	}
+
+	// adjacency
+	type edgeKey struct{ a, b int }
+	edges := make(map[edgeKey][2]int) // maps edge to {triangle, local vertex}
+	o.adj = make([][3]int, len(C))
+	for t, c := range o.cells {
+		for k := 0; k < 3; k++ {
+			o.adj[t][k] = -1
+			a, b := c[(k+1)%3], c[(k+2)%3]
+			if a > b {
+				a, b = b, a
+			}
+			key := edgeKey{a, b}
+			if other, ok := edges[key]; ok {
+				o.adj[t][k] = other[0]
+				o.adj[other[0]][other[1]] = t
+				continue
+			}
+			edges[key] = [2]int{t, k}
+		}
+	}
+
+	// bins with triangles
+	o.bins, err = newBins(V, len(C))
+	if err != nil {
+		return
+	}
+	for t, c := range o.cells {
+		xmin, xmax := boundingBox([][]float64{V[c[0]], V[c[1]], V[c[2]]})
+		var lo, hi [2]int
+		for k := 0; k < 2; k++ {
+			lo[k] = int((xmin[k] - o.bins.Xmin[k]) / o.bins.Size[k])
+			hi[k] = int((xmax[k] - o.bins.Xmin[k]) / o.bins.Size[k])
+			if hi[k] > o.bins.Npts[k]-1 {
+				hi[k] = o.bins.Npts[k] - 1
+			}
+		}
+		centroid := []float64{(xmin[0] + xmax[0]) / 2.0, (xmin[1] + xmax[1]) / 2.0}
+		for j := lo[1]; j <= hi[1]; j++ {
+			for i := lo[0]; i <= hi[0]; i++ {
+				bin := o.bins.FindBinByIndex(i + j*o.bins.Npts[0])
+				bin.Entries = append(bin.Entries, &gm.BinEntry{Id: t, X: centroid})
+			}
+		}
+	}
+	return
+}
+
+// F computes the interpolated value at x
+func (o *NatNeighbour) F(x []float64) (res float64) {
+	ids, w := o.Weights(x)
+	if ids == nil {
+		return o.Outside
+	}
+	for i, id := range ids {
+		res += w[i] * o.yy[id]
+	}
+	return
+}
+
+// Weights computes the natural neighbour (Sibson) coordinates of x
+//   Output:
+//     ids -- indices of the natural neighbours of x; nil if x is outside the convex hull
+//     w   -- weights of each neighbour (Σ w = 1)
+func (o *NatNeighbour) Weights(x []float64) (ids []int, w []float64) {
+
+	// locate x
+	t, λ := o.locate(x)
+	if t < 0 {
+		return
+	}
+	c := o.cells[t]
+
+	// x coincides with a vertex or is on the boundary of the convex hull
+	const tol = 1e-12
+	for k := 0; k < 3; k++ {
+		if λ[k] > 1.0-tol {
+			return []int{c[k]}, []float64{1}
+		}
+	}
+	for k := 0; k < 3; k++ {
+		if λ[k] < tol && o.adj[t][k] < 0 {
+			a, b := (k+1)%3, (k+2)%3
+			s := λ[a] + λ[b]
+			return []int{c[a], c[b]}, []float64{λ[a] / s, λ[b] / s}
+		}
+	}
+
+	// cavity: triangles whose circumcircles contain x (Bowyer-Watson)
+	cavity := map[int]bool{t: true}
+	list := []int{t}
+	for l := 0; l < len(list); l++ {
+		s := list[l]
+		for k := 0; k < 3; k++ {
+			nb := o.adj[s][k]
+			if nb < 0 || cavity[nb] {
+				continue
+			}
+			if math.Pow(dist(x, o.cc[nb]), 2) < o.r2[nb]*(1.0-tol) {
+				cavity[nb] = true
+				list = append(list, nb)
+			}
+		}
+	}
+
+	// boundary of cavity (counter-clockwise)
+	next := make(map[int]int)
+	start := -1
+	for _, s := range list {
+		for k := 0; k < 3; k++ {
+			nb := o.adj[s][k]
+			if nb < 0 || !cavity[nb] {
+				start = o.cells[s][(k+1)%3]
+				next[start] = o.cells[s][(k+2)%3]
+			}
+		}
+	}
+	loop := []int{start}
+	for v := next[start]; v != start; {
+		loop = append(loop, v)
+		nv, ok := next[v]
+		if !ok || len(loop) > len(next) { // degenerate cavity: use linear interpolation
+			return []int{c[0], c[1], c[2]}, []float64{λ[0], λ[1], λ[2]}
+		}
+		v = nv
+	}
+
+	// areas stolen from the Voronoi cells of the natural neighbours
+	m := len(loop)
+	ids = make([]int, m)
+	w = make([]float64, m)
+	total := 0.0
+	for i := 0; i < m; i++ {
+		p, v, q := loop[(i+m-1)%m], loop[i], loop[(i+1)%m]
+		poly := [][]float64{
+			circumcentre(x, o.verts[p], o.verts[v]),
+			circumcentre(x, o.verts[v], o.verts[q]),
+		}
+		for _, s := range list {
+			if o.cells[s][0] == v || o.cells[s][1] == v || o.cells[s][2] == v {
+				poly = append(poly, o.cc[s])
+			}
+		}
+		ids[i] = v
+		w[i] = convexArea(poly)
+		total += w[i]
+	}
+	for i := 0; i < m; i++ {
+		w[i] /= total
+	}
+	return
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// locate finds the triangle containing x and the barycentric coordinates of x. Returns t = -1 if
+// x is outside the triangulation
+func (o *NatNeighbour) locate(x []float64) (t int, λ [3]float64) {
+	t = -1
+	idx := o.bins.CalcIndex(x)
+	if idx < 0 {
+		return
+	}
+	bin := o.bins.All[idx]
+	if bin == nil {
+		return
+	}
+	for _, entry := range bin.Entries {
+		c := o.cells[entry.Id]
+		a, b, d := o.verts[c[0]], o.verts[c[1]], o.verts[c[2]]
+		det := (b[0]-a[0])*(d[1]-a[1]) - (b[1]-a[1])*(d[0]-a[0])
+		λ[1] = ((x[0]-a[0])*(d[1]-a[1]) - (x[1]-a[1])*(d[0]-a[0])) / det
+		λ[2] = ((b[0]-a[0])*(x[1]-a[1]) - (b[1]-a[1])*(x[0]-a[0])) / det
+		λ[0] = 1.0 - λ[1] - λ[2]
+		if λ[0] >= -1e-12 && λ[1] >= -1e-12 && λ[2] >= -1e-12 {
+			for k := 0; k < 3; k++ {
+				λ[k] = math.Max(0, λ[k])
+			}
+			return entry.Id, λ
+		}
+	}
+	return
+}
+
+// circumcentre computes the centre of the circle passing through a, b and c
+func circumcentre(a, b, c []float64) []float64 {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2.0 * (bx*cy - by*cx)
+	bb, cc := bx*bx+by*by, cx*cx+cy*cy
+	return []float64{a[0] + (cy*bb-by*cc)/d, a[1] + (bx*cc-cx*bb)/d}
+}
+
+// convexArea computes the area of the convex polygon with (unordered) vertices P
+func convexArea(P [][]float64) (area float64) {
+	var xc, yc float64
+	for _, p := range P {
+		xc += p[0]
+		yc += p[1]
+	}
+	xc /= float64(len(P))
+	yc /= float64(len(P))
+	sort.Slice(P, func(i, j int) bool {
+		return math.Atan2(P[i][1]-yc, P[i][0]-xc) < math.Atan2(P[j][1]-yc, P[j][0]-xc)
+	})
+	for i := 0; i < len(P); i++ {
+		j := (i + 1) % len(P)
+		area += P[i][0]*P[j][1] - P[j][0]*P[i][1]
+	}
+	return math.Abs(area) / 2.0
+}
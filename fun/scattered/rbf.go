@@ -0,0 +1,266 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/gm"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+var (
+
+	// ThinPlateRbfKind defines the thin plate spline: φ(r) = r² log(r)
+	ThinPlateRbfKind = io.NewEnum("ThinPlate", "scattered.rbf", "T", "Thin plate spline RBF")
+
+	// PolyharmonicRbfKind defines the cubic polyharmonic spline: φ(r) = r³
+	PolyharmonicRbfKind = io.NewEnum("Polyharmonic", "scattered.rbf", "P", "Cubic polyharmonic spline RBF")
+
+	// MultiquadricRbfKind defines the multiquadric function: φ(r) = sqrt(1 + (ε r)²)
+	MultiquadricRbfKind = io.NewEnum("Multiquadric", "scattered.rbf", "M", "Multiquadric RBF")
+
+	// InverseMultiquadricRbfKind defines the inverse multiquadric function: φ(r) = 1 / sqrt(1 + (ε r)²)
+	InverseMultiquadricRbfKind = io.NewEnum("InverseMultiquadric", "scattered.rbf", "I", "Inverse multiquadric RBF")
+
+	// GaussianRbfKind defines the Gaussian function: φ(r) = exp(-(ε r)²)
+	GaussianRbfKind = io.NewEnum("Gaussian", "scattered.rbf", "G", "Gaussian RBF")
+
+	// WendlandRbfKind defines Wendland's C² function with compact support ρ:
+	// φ(r) = (1 - r/ρ)⁴ (4 r/ρ + 1) if r < ρ or 0 otherwise
+	WendlandRbfKind = io.NewEnum("Wendland", "scattered.rbf", "W", "Wendland C² compact support RBF")
+)
+
+// Rbf implements the radial basis function interpolator augmented by a polynomial
+//
+//                 n-1                        m-1
+//          f(x) =  Σ  wᵢ φ(‖x - xᵢ‖)    +    Σ  cₗ pₗ(x)
+//                 i=0                        l=0
+//
+//   where the weights w and coefficients c satisfy
+//
+//          ┌           ┐ ┌   ┐   ┌   ┐
+//          │ Φ + λI  P │ │ w │   │ y │
+//          │           │ │   │ = │   │
+//          │  Pᵀ     0 │ │ c │   │ 0 │
+//          └           ┘ └   ┘   └   ┘
+//
+//   with Φᵢⱼ = φ(‖xᵢ - xⱼ‖), Pᵢₗ = pₗ(xᵢ) and the polynomial basis p = {1, x, y, z} (Degree = 1)
+//   or p = {1} (Degree = 0). The system is solved with the sparse solver given by la.DefaultSolver;
+//   with the Wendland function, the matrix Φ is sparse and gm.Bins are used to find the points
+//   within the support during assembly and evaluation
+//
+//   NOTE: ThinPlate and Polyharmonic require Degree ≥ 1 and Multiquadric requires Degree ≥ 0
+type Rbf struct {
+
+	// input
+	Kind   io.Enum // kind of radial basis function
+	Eps    float64 // shape parameter ε or support radius ρ (Wendland)
+	Degree int     // degree of augmenting polynomial: -1 (none), 0 (constant) or 1 (linear)
+	Smooth float64 // smoothing parameter λ ≥ 0 added to the diagonal of Φ (0 => interpolation)
+
+	// derived
+	ndim int         // space dimension
+	m    int         // number of polynomial terms
+	xx   [][]float64 // [n][ndim] data points (read only)
+	yy   []float64   // [n] values at data points (read only)
+	w    []float64   // [n] weights of basis functions
+	c    []float64   // [m] coefficients of polynomial
+	bins *gm.Bins    // Wendland: bins with data points
+	p    []float64   // [m] workspace: polynomial basis
+}
+
+// NewRbf allocates a new RBF interpolator and computes the weights
+//   Input:
+//     kind   -- kind of radial basis function; e.g. ThinPlateRbfKind
+//     X      -- [n][ndim] data points (ndim = 2 or 3)
+//     y      -- [n] values at data points
+//     eps    -- shape parameter ε or support radius ρ (Wendland); ignored by ThinPlate and Polyharmonic
+//     degree -- degree of augmenting polynomial: -1 (none), 0 (constant) or 1 (linear)
+func NewRbf(kind io.Enum, X [][]float64, y []float64, eps float64, degree int) (o *Rbf, err error) {
+	o = new(Rbf)
+	o.Kind = kind
+	o.Eps = eps
+	o.Degree = degree
+	err = o.Reset(X, y)
+	return
+}
+
+// SetSmoothing sets the smoothing parameter λ and re-computes the weights
+func (o *Rbf) SetSmoothing(lambda float64) (err error) {
+	o.Smooth = lambda
+	return o.Reset(o.xx, o.yy)
+}
+
+// Reset re-computes the weights for new data
+func (o *Rbf) Reset(X [][]float64, y []float64) (err error) {
+
+	// check
+	o.ndim, err = checkData(X, y)
+	if err != nil {
+		return
+	}
+	degMin := -1
+	switch o.Kind {
+	case ThinPlateRbfKind, PolyharmonicRbfKind:
+		degMin = 1
+	case MultiquadricRbfKind, InverseMultiquadricRbfKind, GaussianRbfKind, WendlandRbfKind:
+		if o.Kind == MultiquadricRbfKind {
+			degMin = 0
+		}
+		if o.Eps <= 0 {
+			return chk.Err("shape parameter (or support radius) of %q RBF must be positive. %g is invalid\n", o.Kind, o.Eps)
+		}
+	default:
+		return chk.Err("cannot find RBF kind == %q\n", o.Kind)
+	}
+	if o.Degree < degMin || o.Degree > 1 {
+		return chk.Err("degree of polynomial must be in [%d, 1] for %q RBF. %d is invalid\n", degMin, o.Kind, o.Degree)
+	}
+	if o.Smooth < 0 {
+		return chk.Err("smoothing parameter must be non-negative. λ=%g is invalid\n", o.Smooth)
+	}
+
+	// polynomial
+	n := len(X)
+	o.xx, o.yy = X, y
+	o.m = 0
+	if o.Degree >= 0 {
+		o.m = 1
+	}
+	if o.Degree == 1 {
+		o.m += o.ndim
+	}
+	o.p = make([]float64, o.m)
+
+	// bins
+	o.bins = nil
+	if o.Kind == WendlandRbfKind {
+		o.bins, err = newBins(X, n)
+		if err != nil {
+			return
+		}
+		for i, x := range X {
+			err = o.bins.Append(x, i, nil)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// neighbours of each point
+	nbrs := make([][]int, n)
+	nnz := 2 * n * o.m
+	for i := 0; i < n; i++ {
+		nbrs[i] = o.neighbours(X[i])
+		nnz += len(nbrs[i])
+	}
+	if o.Smooth > 0 {
+		nnz += n
+	}
+
+	// assemble
+	N := n + o.m
+	var A la.Triplet
+	A.Init(N, N, nnz)
+	for i := 0; i < n; i++ {
+		for _, j := range nbrs[i] {
+			A.Put(i, j, o.phi(dist(X[i], X[j])))
+		}
+		if o.Smooth > 0 {
+			A.Put(i, i, o.Smooth)
+		}
+		o.poly(X[i])
+		for l := 0; l < o.m; l++ {
+			A.Put(i, n+l, o.p[l])
+			A.Put(n+l, i, o.p[l])
+		}
+	}
+	rhs := make([]float64, N)
+	copy(rhs, y)
+
+	// solve
+	sol := make([]float64, N)
+	solver := la.GetSolver(la.DefaultSolver)
+	defer solver.Free()
+	err = solver.InitR(&A, false, false, false)
+	if err != nil {
+		return
+	}
+	err = solver.Fact()
+	if err != nil {
+		return chk.Err("cannot factorise RBF matrix (are there repeated or collinear points?):\n%v", err)
+	}
+	err = solver.SolveR(sol, rhs, false)
+	if err != nil {
+		return
+	}
+	o.w = sol[:n]
+	o.c = sol[n:]
+	return
+}
+
+// F computes the interpolated value at x
+func (o *Rbf) F(x []float64) (res float64) {
+	for _, i := range o.neighbours(x) {
+		res += o.w[i] * o.phi(dist(x, o.xx[i]))
+	}
+	o.poly(x)
+	for l := 0; l < o.m; l++ {
+		res += o.c[l] * o.p[l]
+	}
+	return
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// phi computes the radial basis function φ(r)
+func (o *Rbf) phi(r float64) float64 {
+	switch o.Kind {
+	case ThinPlateRbfKind:
+		if r == 0 {
+			return 0
+		}
+		return r * r * math.Log(r)
+	case PolyharmonicRbfKind:
+		return r * r * r
+	case MultiquadricRbfKind:
+		return math.Sqrt(1.0 + o.Eps*o.Eps*r*r)
+	case InverseMultiquadricRbfKind:
+		return 1.0 / math.Sqrt(1.0+o.Eps*o.Eps*r*r)
+	case GaussianRbfKind:
+		return math.Exp(-o.Eps * o.Eps * r * r)
+	}
+	t := r / o.Eps // Wendland
+	if t >= 1 {
+		return 0
+	}
+	return math.Pow(1.0-t, 4) * (4.0*t + 1.0)
+}
+
+// poly computes the polynomial basis at x
+func (o *Rbf) poly(x []float64) {
+	if o.m > 0 {
+		o.p[0] = 1
+	}
+	for k := 1; k < o.m; k++ {
+		o.p[k] = x[k-1]
+	}
+}
+
+// neighbours returns the indices of the points contributing to the value at x; i.e. all points or
+// the points within the support radius (Wendland)
+func (o *Rbf) neighbours(x []float64) (ids []int) {
+	if o.bins != nil {
+		return o.bins.FindWithinRadius(x, o.Eps)
+	}
+	ids = make([]int, len(o.xx))
+	for i := 0; i < len(o.xx); i++ {
+		ids[i] = i
+	}
+	return
+}
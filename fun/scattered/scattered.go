@@ -0,0 +1,109 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package scattered implements interpolators of scattered data in 2D and 3D; e.g. to map
+// experimental fields measured at sensor locations onto meshes. The available methods are
+// radial basis functions (RBF), natural neighbour (Sibson) interpolation and ordinary kriging
+package scattered
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/gm"
+	"github.com/cpmech/gosl/gm/msh"
+)
+
+// Interpolator defines scattered-data interpolators y = f(x) with x in 2D or 3D
+type Interpolator interface {
+	F(x []float64) float64 // computes y = f(x)
+}
+
+// OnMesh computes the interpolated values at all vertices of a mesh
+func OnMesh(o Interpolator, mesh *msh.Mesh) (values []float64) {
+	values = make([]float64, len(mesh.Verts))
+	for i, v := range mesh.Verts {
+		values[i] = o.F(v.X)
+	}
+	return
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// checkData checks the sizes of the data and returns the space dimension
+func checkData(X [][]float64, y []float64) (ndim int, err error) {
+	if len(X) < 1 {
+		return 0, chk.Err("at least one data point is required\n")
+	}
+	if len(X) != len(y) {
+		return 0, chk.Err("number of points and number of values must be equal. %d != %d\n", len(X), len(y))
+	}
+	ndim = len(X[0])
+	if ndim < 2 || ndim > 3 {
+		return 0, chk.Err("space dimension must be 2 or 3. ndim=%d is invalid\n", ndim)
+	}
+	for i, x := range X {
+		if len(x) != ndim {
+			return 0, chk.Err("all points must have the same dimension. len(X[%d])=%d is invalid\n", i, len(x))
+		}
+	}
+	return
+}
+
+// boundingBox computes the limits of the points X
+func boundingBox(X [][]float64) (xmin, xmax []float64) {
+	ndim := len(X[0])
+	xmin, xmax = make([]float64, ndim), make([]float64, ndim)
+	for k := 0; k < ndim; k++ {
+		xmin[k], xmax[k] = X[0][k], X[0][k]
+	}
+	for _, x := range X {
+		for k := 0; k < ndim; k++ {
+			xmin[k] = math.Min(xmin[k], x[k])
+			xmax[k] = math.Max(xmax[k], x[k])
+		}
+	}
+	return
+}
+
+// newBins allocates bins enclosing the points X with slightly enlarged limits. The number of
+// divisions is such that each bin holds a few points on average
+func newBins(X [][]float64, npts int) (bins *gm.Bins, err error) {
+	xmin, xmax := boundingBox(X)
+	ndim := len(xmin)
+	diag := 0.0
+	for k := 0; k < ndim; k++ {
+		diag = math.Max(diag, xmax[k]-xmin[k])
+	}
+	if diag < gm.XDELZERO {
+		diag = 1
+	}
+	for k := 0; k < ndim; k++ {
+		pad := 1e-8 * diag
+		if xmax[k]-xmin[k] < gm.XDELZERO {
+			pad = 0.5 * diag
+		}
+		xmin[k] -= pad
+		xmax[k] += pad
+	}
+	ndiv := int(math.Pow(float64(npts)/2.0, 1.0/float64(ndim)))
+	if ndiv < 1 {
+		ndiv = 1
+	}
+	if ndiv > 100 {
+		ndiv = 100
+	}
+	bins = new(gm.Bins)
+	err = bins.Init(xmin, xmax, ndiv)
+	return
+}
+
+// dist computes the Euclidean distance between a and b
+func dist(a, b []float64) float64 {
+	var d float64
+	for k := 0; k < len(a); k++ {
+		d += (a[k] - b[k]) * (a[k] - b[k])
+	}
+	return math.Sqrt(d)
+}
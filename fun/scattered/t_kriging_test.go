@@ -0,0 +1,149 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_variogram01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("variogram01. models and fitting")
+
+	// models
+	sph := Variogram{Kind: SphericalVariogramKind, Nugget: 0.1, Sill: 2, Range: 4}
+	chk.Scalar(tst, "sph(0)", 1e-15, sph.G(0), 0)
+	chk.Scalar(tst, "sph(2)", 1e-15, sph.G(2), 0.1+2*(0.75-0.0625))
+	chk.Scalar(tst, "sph(5)", 1e-15, sph.G(5), 2.1)
+	exp := Variogram{Kind: ExponentialVariogramKind, Nugget: 0, Sill: 1, Range: 3}
+	chk.Scalar(tst, "exp(1)", 1e-15, exp.G(1), 1-math.Exp(-1))
+	gau := Variogram{Kind: GaussianVariogramKind, Nugget: 0.5, Sill: 1, Range: 3}
+	chk.Scalar(tst, "gau(1)", 1e-15, gau.G(1), 0.5+1-math.Exp(-1.0/3.0))
+
+	// fitting recovers parameters of exact data
+	for _, ref := range []Variogram{sph, exp, gau} {
+		nlags := 20
+		h := make([]float64, nlags)
+		γ := make([]float64, nlags)
+		npairs := make([]int, nlags)
+		for k := 0; k < nlags; k++ {
+			h[k] = 0.3 * float64(k+1)
+			γ[k] = ref.G(h[k])
+			npairs[k] = 10 + k
+		}
+		v := Variogram{Kind: ref.Kind}
+		err := v.Fit(h, γ, npairs)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("%-12v nugget=%g sill=%g range=%g\n", v.Kind, v.Nugget, v.Sill, v.Range)
+		chk.Scalar(tst, "nugget", 1e-6, v.Nugget, ref.Nugget)
+		chk.Scalar(tst, "sill", 1e-6, v.Sill, ref.Sill)
+		chk.Scalar(tst, "range", 1e-6, v.Range, ref.Range)
+	}
+}
+
+func Test_variogram02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("variogram02. experimental variogram")
+
+	X := [][]float64{{0, 0}, {1, 0}, {3, 0}, {3, 4}}
+	y := []float64{1, 2, 4, 0}
+	h, γ, npairs, err := ExperimentalVariogram(X, y, 3, 6)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	// distances: 1 (0-1), 3 (0-2), 5 (0-3), 2 (1-2), √20 (1-3), 4 (2-3)
+	chk.Ints(tst, "npairs", npairs, []int{1, 2, 3})
+	chk.Vector(tst, "h", 1e-15, h, []float64{1, 2.5, (5 + math.Sqrt(20) + 4) / 3})
+	chk.Vector(tst, "γ", 1e-15, γ, []float64{0.5, (4.5 + 2) / 2, (0.5 + 2 + 8) / 3})
+}
+
+func Test_kriging01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("kriging01. ordinary kriging")
+
+	// data
+	X := halton(120, 2)
+	y := make([]float64, len(X))
+	for i, x := range X {
+		y[i] = franke(x)
+	}
+
+	// variogram
+	v, err := FitVariogram(SphericalVariogramKind, X, y, 12, 0.6)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("nugget=%g sill=%g range=%g\n", v.Nugget, v.Sill, v.Range)
+	if v.Range <= 0 || v.Sill <= 0 {
+		tst.Errorf("fitted variogram is invalid\n")
+		return
+	}
+
+	// global kriging
+	o, err := NewKriging(X, y, v, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	defer o.Free()
+	for i, x := range X {
+		val, variance := o.Estimate(x)
+		chk.Scalar(tst, "f(xi)", 1e-8, o.F(x), y[i])
+		chk.Scalar(tst, "f(xi)", 1e-8, val, y[i])
+		chk.Scalar(tst, "σ²(xi)", 1e-8, variance, 0)
+	}
+	emax := 0.0
+	for _, x := range halton(170, 2)[120:] {
+		val, variance := o.Estimate(x)
+		chk.Scalar(tst, "F == Estimate", 1e-10, o.F(x), val)
+		if variance <= 0 {
+			tst.Errorf("kriging variance must be positive away from data points\n")
+			return
+		}
+		emax = math.Max(emax, math.Abs(val-franke(x)))
+	}
+	io.Pforan("max error = %.3e\n", emax)
+	if emax > 0.05 {
+		tst.Errorf("error is too large: %g\n", emax)
+	}
+
+	// variance increases away from data
+	_, σ2a := o.Estimate([]float64{0.5, 0.5})
+	_, σ2b := o.Estimate([]float64{1.5, 1.5})
+	if σ2b <= σ2a {
+		tst.Errorf("variance should increase away from data\n")
+	}
+
+	// local kriging with large radius is equal to global kriging
+	loc, err := NewKriging(X, y, v, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, x := range halton(130, 2)[120:] {
+		chk.Scalar(tst, "local == global", 1e-7, loc.F(x), o.F(x))
+	}
+
+	// local kriging with small radius
+	loc.Radius = 0.2
+	for i, x := range X {
+		chk.Scalar(tst, "local f(xi)", 1e-8, loc.F(x), y[i])
+	}
+	if !math.IsNaN(loc.F([]float64{3, 3})) {
+		tst.Errorf("local kriging without points should return NaN\n")
+	}
+}
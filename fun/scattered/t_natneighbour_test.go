@@ -0,0 +1,129 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// gridTriangulation returns the vertices and triangles of a regular grid with n×n points in the
+// unit square; each square is divided into two triangles
+func gridTriangulation(n int) (V [][]float64, C [][]int) {
+	h := 1.0 / float64(n-1)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			V = append(V, []float64{float64(i) * h, float64(j) * h})
+		}
+	}
+	for j := 0; j < n-1; j++ {
+		for i := 0; i < n-1; i++ {
+			a, b, c, d := i+j*n, i+1+j*n, i+1+(j+1)*n, i+(j+1)*n
+			C = append(C, []int{a, b, c}, []int{a, c, d})
+		}
+	}
+	return
+}
+
+func Test_natnb01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("natnb01. natural neighbour interpolation on grid")
+
+	// linear function
+	V, C := gridTriangulation(5)
+	y := make([]float64, len(V))
+	for i, v := range V {
+		y[i] = 1 + 2*v[0] - 3*v[1]
+	}
+	o, err := NewNatNeighbourTri(V, C, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// linear precision, including vertices and boundary
+	for _, x := range halton(100, 2) {
+		chk.Scalar(tst, "linear", 1e-13, o.F(x), 1+2*x[0]-3*x[1])
+	}
+	for i, v := range V {
+		chk.Scalar(tst, "f(xi)", 1e-15, o.F(v), y[i])
+	}
+	chk.Scalar(tst, "boundary", 1e-15, o.F([]float64{0.3, 0}), 1.6)
+	chk.Scalar(tst, "boundary", 1e-15, o.F([]float64{1, 0.6}), 1.2)
+
+	// outside
+	if !math.IsNaN(o.F([]float64{1.1, 0.5})) {
+		tst.Errorf("value outside convex hull should be NaN\n")
+	}
+	o.Outside = -1
+	chk.Scalar(tst, "outside", 1e-15, o.F([]float64{0.5, -0.1}), -1)
+
+	// symmetric weights at the centre of a square
+	ids, w := o.Weights([]float64{0.375, 0.625})
+	io.Pforan("ids = %v\n", ids)
+	io.Pforan("w   = %v\n", w)
+	chk.Int(tst, "number of neighbours", len(ids), 4)
+	chk.Vector(tst, "w", 1e-14, w, []float64{0.25, 0.25, 0.25, 0.25})
+
+	// weights sum up to one and are positive
+	for _, x := range halton(50, 2) {
+		_, w = o.Weights(x)
+		sum := 0.0
+		for _, v := range w {
+			if v < 0 {
+				tst.Errorf("weights must be positive\n")
+				return
+			}
+			sum += v
+		}
+		chk.Scalar(tst, "Σw", 1e-14, sum, 1)
+	}
+}
+
+func Test_natnb02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("natnb02. natural neighbour interpolation with Delaunay triangulation")
+
+	X := halton(150, 2)
+	y := make([]float64, len(X))
+	for i, x := range X {
+		y[i] = franke(x)
+	}
+	o, err := NewNatNeighbour(X, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range X {
+		chk.Scalar(tst, "f(xi)", 1e-14, o.F(x), y[i])
+	}
+
+	// error at points inside the convex hull
+	emax := 0.0
+	for _, x := range halton(200, 2)[150:] {
+		if v := o.F(x); !math.IsNaN(v) {
+			emax = math.Max(emax, math.Abs(v-franke(x)))
+		}
+	}
+	io.Pforan("max error = %.3e\n", emax)
+	if emax > 0.05 {
+		tst.Errorf("error is too large: %g\n", emax)
+	}
+
+	// linear precision
+	for i, x := range X {
+		y[i] = 2 - x[0] + 0.5*x[1]
+	}
+	for _, x := range halton(200, 2)[150:] {
+		if v := o.F(x); !math.IsNaN(v) {
+			chk.Scalar(tst, "linear", 1e-13, v, 2-x[0]+0.5*x[1])
+		}
+	}
+}
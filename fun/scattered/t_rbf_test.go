@@ -0,0 +1,176 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scattered
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/gm/msh"
+	"github.com/cpmech/gosl/io"
+)
+
+// halton returns n points of the Halton sequence (bases 2, 3 and 5) in the unit square or cube
+func halton(n, ndim int) (X [][]float64) {
+	bases := []int{2, 3, 5}
+	X = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		X[i] = make([]float64, ndim)
+		for k := 0; k < ndim; k++ {
+			f, r, j := 1.0, 0.0, i+1
+			for j > 0 {
+				f /= float64(bases[k])
+				r += f * float64(j%bases[k])
+				j /= bases[k]
+			}
+			X[i][k] = r
+		}
+	}
+	return
+}
+
+// franke computes Franke's test function
+func franke(x []float64) float64 {
+	a, b := 9*x[0], 9*x[1]
+	return 0.75*math.Exp(-((a-2)*(a-2)+(b-2)*(b-2))/4) + 0.75*math.Exp(-(a+1)*(a+1)/49-(b+1)/10) +
+		0.5*math.Exp(-((a-7)*(a-7)+(b-3)*(b-3))/4) - 0.2*math.Exp(-(a-4)*(a-4)-(b-7)*(b-7))
+}
+
+func Test_rbf01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rbf01. RBF interpolation in 2D")
+
+	// data
+	X := halton(200, 2)
+	y := make([]float64, len(X))
+	lin := make([]float64, len(X))
+	for i, x := range X {
+		y[i] = franke(x)
+		lin[i] = 1 + 2*x[0] - 3*x[1]
+	}
+	Xt := halton(250, 2)[200:] // test points
+
+	// all kinds
+	for _, kind := range []io.Enum{ThinPlateRbfKind, PolyharmonicRbfKind, MultiquadricRbfKind,
+		InverseMultiquadricRbfKind, GaussianRbfKind, WendlandRbfKind} {
+
+		eps := 3.0
+		switch kind {
+		case GaussianRbfKind: // small ε gives ill-conditioned matrices
+			eps = 8.0
+		case WendlandRbfKind:
+			eps = 0.5
+		}
+		o, err := NewRbf(kind, X, y, eps, 1)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+
+		// interpolation of data
+		for i, x := range X {
+			chk.Scalar(tst, "f(xi)", 1e-8, o.F(x), y[i])
+		}
+
+		// approximation error
+		emax := 0.0
+		for _, x := range Xt {
+			emax = math.Max(emax, math.Abs(o.F(x)-franke(x)))
+		}
+		io.Pforan("%-20v max error = %.3e\n", kind, emax)
+		if emax > 0.03 {
+			tst.Errorf("error is too large: %g\n", emax)
+		}
+
+		// linear functions are reproduced
+		err = o.Reset(X, lin)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for _, x := range Xt {
+			chk.Scalar(tst, "linear", 1e-8, o.F(x), 1+2*x[0]-3*x[1])
+		}
+	}
+}
+
+func Test_rbf02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rbf02. Wendland RBF in 3D, smoothing and errors")
+
+	// data
+	X := halton(300, 3)
+	y := make([]float64, len(X))
+	for i, x := range X {
+		y[i] = math.Sin(2*x[0]) * math.Cos(x[1]) * (1 + x[2])
+	}
+
+	// compact support
+	o, err := NewRbf(WendlandRbfKind, X, y, 0.8, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range X {
+		chk.Scalar(tst, "f(xi)", 1e-9, o.F(x), y[i])
+	}
+	chk.Scalar(tst, "f(far)", 1e-15, o.F([]float64{5, 5, 5}), o.c[0])
+	emax := 0.0
+	for _, x := range halton(350, 3)[300:] {
+		emax = math.Max(emax, math.Abs(o.F(x)-math.Sin(2*x[0])*math.Cos(x[1])*(1+x[2])))
+	}
+	io.Pforan("max error = %.3e\n", emax)
+	if emax > 0.05 {
+		tst.Errorf("error is too large: %g\n", emax)
+	}
+
+	// smoothing
+	err = o.SetSmoothing(1e-2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	res := 0.0
+	for i, x := range X {
+		res = math.Max(res, math.Abs(o.F(x)-y[i]))
+	}
+	io.Pforan("max residual with smoothing = %.3e\n", res)
+	if res < 1e-6 || res > 0.1 {
+		tst.Errorf("smoothing spline should approximate (not interpolate) data: residual = %g\n", res)
+	}
+
+	// errors
+	_, err = NewRbf(ThinPlateRbfKind, X, y, 0, 0)
+	if err == nil {
+		tst.Errorf("thin plate spline with degree 0 should have failed\n")
+	}
+	_, err = NewRbf(GaussianRbfKind, X, y, 0, -1)
+	if err == nil {
+		tst.Errorf("Gaussian RBF with ε = 0 should have failed\n")
+	}
+	_, err = NewRbf(GaussianRbfKind, [][]float64{{1}}, []float64{1}, 1, -1)
+	if err == nil {
+		tst.Errorf("1D data should have failed\n")
+	}
+}
+
+func Test_onmesh01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("onmesh01. values at vertices of mesh")
+
+	X := [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.3}}
+	y := []float64{1, 3, 0, -2, 1.1}
+	o, err := NewRbf(ThinPlateRbfKind, X, y, 0, 1)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	mesh := &msh.Mesh{Verts: msh.VertSet{{Id: 0, X: []float64{1, 0}}, {Id: 1, X: []float64{0, 1}}, {Id: 2, X: []float64{0.5, 0.3}}}}
+	chk.Vector(tst, "values", 1e-12, OnMesh(o, mesh), []float64{3, -2, 1.1})
+}
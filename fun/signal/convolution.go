@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fft"
+)
+
+// Convolve computes the (full) linear convolution of a and b via FFT
+//
+//             min(k,na-1)
+//      c[k] =     Σ       a[j] ⋅ b[k-j]     k = 0 ... na+nb-2
+//             j=max(0,k-nb+1)
+//
+func Convolve(a, b []float64) (c []float64, err error) {
+	return fftProduct(a, b, false)
+}
+
+// Correlate computes the (full) cross-correlation of a and b via FFT
+//
+//      c[k] = Σ a[j+m] ⋅ b[j]     with lag m = k - (nb-1),  k = 0 ... na+nb-2
+//             j
+//
+//   NOTE: the lag m goes from -(nb-1) to na-1; thus, c[nb-1] corresponds to the zero lag and the
+//         autocorrelation of x is computed with Correlate(x, x)
+func Correlate(a, b []float64) (c []float64, err error) {
+	return fftProduct(a, b, true)
+}
+
+// fftProduct computes the convolution (or correlation, with b reversed) of a and b by multiplying
+// their zero-padded spectra
+func fftProduct(a, b []float64, reverse bool) (c []float64, err error) {
+	na, nb := len(a), len(b)
+	if na < 1 || nb < 1 {
+		return nil, chk.Err("arrays must not be empty. len(a) = %d, len(b) = %d\n", na, nb)
+	}
+	n := na + nb - 1
+	N := nextFastLen(n)
+	A, B := make([]float64, N), make([]float64, N)
+	copy(A, a)
+	for j := 0; j < nb; j++ {
+		if reverse {
+			B[j] = b[nb-1-j]
+		} else {
+			B[j] = b[j]
+		}
+	}
+	X, err := fft.RealFft1d(A)
+	if err != nil {
+		return
+	}
+	Y, err := fft.RealFft1d(B)
+	if err != nil {
+		return
+	}
+	for l := 0; l < len(X); l++ {
+		X[l] *= Y[l]
+	}
+	res, err := fft.RealIfft1d(X, N)
+	if err != nil {
+		return
+	}
+	c = make([]float64, n)
+	for k := 0; k < n; k++ {
+		c[k] = res[k] / float64(N)
+	}
+	return
+}
+
+// nextFastLen returns the smallest even number ≥ n whose prime factors are only 2, 3 and 5
+func nextFastLen(n int) int {
+	if n <= 2 {
+		return 2
+	}
+	for m := n + n%2; ; m += 2 {
+		r := m
+		for _, p := range []int{2, 3, 5} {
+			for r%p == 0 {
+				r /= p
+			}
+		}
+		if r == 1 {
+			return m
+		}
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Filter band types
+var (
+
+	// LowpassFilter defines filters passing frequencies below the cutoff
+	LowpassFilter = io.NewEnum("Lowpass", "fun.signal.filter", "L", "Lowpass filter")
+
+	// HighpassFilter defines filters passing frequencies above the cutoff
+	HighpassFilter = io.NewEnum("Highpass", "fun.signal.filter", "H", "Highpass filter")
+
+	// BandpassFilter defines filters passing frequencies between two cutoffs
+	BandpassFilter = io.NewEnum("Bandpass", "fun.signal.filter", "P", "Bandpass filter")
+
+	// BandstopFilter defines filters rejecting frequencies between two cutoffs
+	BandstopFilter = io.NewEnum("Bandstop", "fun.signal.filter", "S", "Bandstop filter")
+)
+
+// Butter designs a digital Butterworth filter (maximally flat passband)
+//
+//   Input:
+//     order -- order of the analogue prototype (the bandpass and bandstop filters have 2⋅order poles)
+//     wn    -- cutoff frequencies (-3 dB points) normalised by the Nyquist frequency fs/2; i.e.
+//              0 < wn < 1. One value for Lowpass/Highpass and two values for Bandpass/Bandstop
+//     btype -- LowpassFilter, HighpassFilter, BandpassFilter or BandstopFilter
+//
+//   Output:
+//     b, a -- coefficients of the transfer function H(z) = Σ b[k] z⁻ᵏ / Σ a[k] z⁻ᵏ with a[0] = 1
+//
+//   NOTE: the design uses the bilinear transformation with pre-warped cutoff frequencies. Because
+//         the polynomial coefficients are ill-conditioned for high orders and narrow bands, orders
+//         up to about 8 (or 4 for band filters) are recommended
+func Butter(order int, wn []float64, btype io.Enum) (b, a []float64, err error) {
+	if order < 1 {
+		return nil, nil, chk.Err("filter order must be at least 1. %d is invalid\n", order)
+	}
+	p := make([]complex128, order)
+	for k := 0; k < order; k++ {
+		m := float64(2*k - order + 1)
+		p[k] = -cmplx.Exp(complex(0, math.Pi*m/float64(2*order)))
+	}
+	return iirDesign(nil, p, 1, wn, btype)
+}
+
+// Cheby1 designs a digital Chebyshev type I filter (equiripple passband)
+//
+//   Input:
+//     order -- order of the analogue prototype
+//     rp    -- maximum ripple in the passband [dB]; e.g. 0.5
+//     wn    -- frequencies where the gain first drops below -rp, normalised by the Nyquist
+//              frequency (see Butter)
+//     btype -- LowpassFilter, HighpassFilter, BandpassFilter or BandstopFilter
+func Cheby1(order int, rp float64, wn []float64, btype io.Enum) (b, a []float64, err error) {
+	if order < 1 {
+		return nil, nil, chk.Err("filter order must be at least 1. %d is invalid\n", order)
+	}
+	if rp <= 0 {
+		return nil, nil, chk.Err("passband ripple must be positive. %g is invalid\n", rp)
+	}
+	ε := math.Sqrt(math.Pow(10, 0.1*rp) - 1.0)
+	μ := math.Asinh(1.0/ε) / float64(order)
+	p := make([]complex128, order)
+	k := complex(1, 0)
+	for i := 0; i < order; i++ {
+		θ := math.Pi * float64(2*i-order+1) / float64(2*order)
+		p[i] = -cmplx.Sinh(complex(μ, θ))
+		k *= -p[i]
+	}
+	gain := real(k)
+	if order%2 == 0 {
+		gain /= math.Sqrt(1.0 + ε*ε)
+	}
+	return iirDesign(nil, p, gain, wn, btype)
+}
+
+// Cheby2 designs a digital Chebyshev type II filter (equiripple stopband)
+//
+//   Input:
+//     order -- order of the analogue prototype
+//     rs    -- minimum attenuation in the stopband [dB]; e.g. 40
+//     wn    -- frequencies where the gain first reaches -rs, normalised by the Nyquist frequency
+//              (see Butter)
+//     btype -- LowpassFilter, HighpassFilter, BandpassFilter or BandstopFilter
+func Cheby2(order int, rs float64, wn []float64, btype io.Enum) (b, a []float64, err error) {
+	if order < 1 {
+		return nil, nil, chk.Err("filter order must be at least 1. %d is invalid\n", order)
+	}
+	if rs <= 0 {
+		return nil, nil, chk.Err("stopband attenuation must be positive. %g is invalid\n", rs)
+	}
+	δ := 1.0 / math.Sqrt(math.Pow(10, 0.1*rs)-1.0)
+	μ := math.Asinh(1.0/δ) / float64(order)
+	var z []complex128
+	for i := 0; i < order; i++ {
+		m := 2*i - order + 1
+		if m == 0 {
+			continue // no zero at infinity for odd orders
+		}
+		z = append(z, complex(0, 1.0/math.Sin(float64(m)*math.Pi/float64(2*order))))
+	}
+	p := make([]complex128, order)
+	k := complex(1, 0)
+	for i := 0; i < order; i++ {
+		θ := math.Pi * float64(2*i-order+1) / float64(2*order)
+		s := -cmplx.Exp(complex(0, θ))
+		p[i] = 1.0 / complex(math.Sinh(μ)*real(s), math.Cosh(μ)*imag(s))
+		k *= -p[i]
+	}
+	for _, v := range z {
+		k /= -v
+	}
+	return iirDesign(z, p, real(k), wn, btype)
+}
+
+// Lfilter filters x with the transfer function H(z) = Σ b[k] z⁻ᵏ / Σ a[k] z⁻ᵏ (direct form II
+// transposed). The coefficients are normalised by a[0]
+func Lfilter(b, a, x []float64) (y []float64, err error) {
+	bb, aa, err := normalise(b, a)
+	if err != nil {
+		return
+	}
+	y = make([]float64, len(x))
+	lfilter(y, bb, aa, x, make([]float64, len(aa)-1))
+	return
+}
+
+// Filtfilt applies the filter twice, forwards and backwards, giving zero phase distortion and the
+// squared magnitude response. The ends are extended by odd reflection (3⋅max(len(a),len(b))
+// samples) and the initial states are set to the steady-state response to a step
+//
+//   NOTE: len(x) must be greater than the padding length
+func Filtfilt(b, a, x []float64) (y []float64, err error) {
+	bb, aa, err := normalise(b, a)
+	if err != nil {
+		return
+	}
+	npad := 3 * len(aa)
+	N := len(x)
+	if N <= npad {
+		return nil, chk.Err("length of signal must be greater than %d. %d is invalid\n", npad, N)
+	}
+
+	// odd extension
+	n := N + 2*npad
+	ext := make([]float64, n)
+	for i := 0; i < npad; i++ {
+		ext[i] = 2.0*x[0] - x[npad-i]
+		ext[n-1-i] = 2.0*x[N-1] - x[N-1-npad+i]
+	}
+	copy(ext[npad:], x)
+
+	// forward and backward passes
+	zi := lfilterZi(bb, aa)
+	z := make([]float64, len(zi))
+	tmp := make([]float64, n)
+	for i := range z {
+		z[i] = zi[i] * ext[0]
+	}
+	lfilter(tmp, bb, aa, ext, z)
+	for i := 0; i < n/2; i++ {
+		tmp[i], tmp[n-1-i] = tmp[n-1-i], tmp[i]
+	}
+	for i := range z {
+		z[i] = zi[i] * tmp[0]
+	}
+	lfilter(ext, bb, aa, tmp, z)
+	y = make([]float64, N)
+	for i := 0; i < N; i++ {
+		y[i] = ext[n-1-npad-i]
+	}
+	return
+}
+
+// FreqZ computes the frequency response H(e^{iπw}) of a digital filter at the frequency w
+// normalised by the Nyquist frequency (0 ≤ w ≤ 1)
+func FreqZ(b, a []float64, w float64) complex128 {
+	z := cmplx.Exp(complex(0, -math.Pi*w)) // z⁻¹
+	var num, den complex128
+	for k := len(b) - 1; k >= 0; k-- {
+		num = num*z + complex(b[k], 0)
+	}
+	for k := len(a) - 1; k >= 0; k-- {
+		den = den*z + complex(a[k], 0)
+	}
+	return num / den
+}
+
+// auxiliary /////////////////////////////////////////////////////////////////////////////////////
+
+// iirDesign converts the analogue lowpass prototype (zeros z, poles p and gain k with cutoff
+// 1 rad/s) into the digital filter with the requested band and (normalised) cutoffs
+//
+//   Reference:
+//   [1] Oppenheim AV, Schafer RW (2009) Discrete-Time Signal Processing. 3rd Edition. Prentice Hall
+func iirDesign(z, p []complex128, k float64, wn []float64, btype io.Enum) (b, a []float64, err error) {
+
+	// check cutoffs and pre-warp with fs = 2 (bilinear transformation: s = 4 (z-1)/(z+1))
+	nw := 1
+	if btype == BandpassFilter || btype == BandstopFilter {
+		nw = 2
+	}
+	if len(wn) != nw {
+		return nil, nil, chk.Err("%v filter requires %d cutoff frequencies. %d given\n", btype, nw, len(wn))
+	}
+	ω := make([]float64, nw)
+	for i, w := range wn {
+		if w <= 0 || w >= 1 || (i > 0 && w <= wn[i-1]) {
+			return nil, nil, chk.Err("cutoff frequencies must be increasing and within (0, 1). %v is invalid\n", wn)
+		}
+		ω[i] = 4.0 * math.Tan(math.Pi*w/2.0)
+	}
+
+	// transform the prototype
+	degree := len(p) - len(z)
+	switch btype {
+	case LowpassFilter:
+		z, p = scaleRoots(z, ω[0]), scaleRoots(p, ω[0])
+		k *= math.Pow(ω[0], float64(degree))
+	case HighpassFilter:
+		k *= real(prodNeg(z) / prodNeg(p))
+		z, p = invertRoots(z, ω[0]), invertRoots(p, ω[0])
+		z = append(z, make([]complex128, degree)...)
+	case BandpassFilter:
+		wo, bw := math.Sqrt(ω[0]*ω[1]), ω[1]-ω[0]
+		z = splitRoots(scaleRoots(z, bw/2.0), wo)
+		p = splitRoots(scaleRoots(p, bw/2.0), wo)
+		z = append(z, make([]complex128, degree)...)
+		k *= math.Pow(bw, float64(degree))
+	case BandstopFilter:
+		wo, bw := math.Sqrt(ω[0]*ω[1]), ω[1]-ω[0]
+		k *= real(prodNeg(z) / prodNeg(p))
+		z = splitRoots(invertRoots(z, bw/2.0), wo)
+		p = splitRoots(invertRoots(p, bw/2.0), wo)
+		for i := 0; i < degree; i++ {
+			z = append(z, complex(0, wo), complex(0, -wo))
+		}
+	default:
+		return nil, nil, chk.Err("filter type %q is not available\n", btype)
+	}
+
+	// bilinear transformation
+	degree = len(p) - len(z)
+	num, den := complex(k, 0), complex(1, 0)
+	for i, v := range z {
+		num *= 4.0 - v
+		z[i] = (4.0 + v) / (4.0 - v)
+	}
+	for i, v := range p {
+		den *= 4.0 - v
+		p[i] = (4.0 + v) / (4.0 - v)
+	}
+	for i := 0; i < degree; i++ {
+		z = append(z, -1)
+	}
+	k = real(num / den)
+
+	// polynomials
+	b, a = polyFromRoots(z), polyFromRoots(p)
+	for i := range b {
+		b[i] *= k
+	}
+	return
+}
+
+// scaleRoots returns r⋅s
+func scaleRoots(r []complex128, s float64) (res []complex128) {
+	res = make([]complex128, len(r))
+	for i, v := range r {
+		res[i] = v * complex(s, 0)
+	}
+	return
+}
+
+// invertRoots returns s/r
+func invertRoots(r []complex128, s float64) (res []complex128) {
+	res = make([]complex128, len(r))
+	for i, v := range r {
+		res[i] = complex(s, 0) / v
+	}
+	return
+}
+
+// splitRoots returns the roots r ± √(r² - wo²) of the lowpass to band transformations
+func splitRoots(r []complex128, wo float64) (res []complex128) {
+	res = make([]complex128, 2*len(r))
+	for i, v := range r {
+		d := cmplx.Sqrt(v*v - complex(wo*wo, 0))
+		res[i], res[len(r)+i] = v+d, v-d
+	}
+	return
+}
+
+// prodNeg returns Π (-r)
+func prodNeg(r []complex128) complex128 {
+	res := complex(1, 0)
+	for _, v := range r {
+		res *= -v
+	}
+	return res
+}
+
+// polyFromRoots returns the (real parts of the) coefficients c of Π (1 - r z⁻¹) = Σ c[k] z⁻ᵏ
+func polyFromRoots(r []complex128) (c []float64) {
+	cc := make([]complex128, len(r)+1)
+	cc[0] = 1
+	for i, v := range r {
+		for j := i + 1; j > 0; j-- {
+			cc[j] -= v * cc[j-1]
+		}
+	}
+	c = make([]float64, len(cc))
+	for i, v := range cc {
+		c[i] = real(v)
+	}
+	return
+}
+
+// normalise returns copies of b and a with the same length and a[0] = 1
+func normalise(b, a []float64) (bb, aa []float64, err error) {
+	if len(a) < 1 || len(b) < 1 || a[0] == 0 {
+		return nil, nil, chk.Err("coefficients must not be empty and a[0] must be non-zero\n")
+	}
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	bb, aa = make([]float64, n), make([]float64, n)
+	for i, v := range b {
+		bb[i] = v / a[0]
+	}
+	for i, v := range a {
+		aa[i] = v / a[0]
+	}
+	return
+}
+
+// lfilter implements the direct form II transposed with (normalised) coefficients of the same
+// length n and state z (with n-1 values; modified). y may be the same as x
+func lfilter(y, b, a, x, z []float64) {
+	n := len(a)
+	for i, xi := range x {
+		yi := b[0] * xi
+		if n > 1 {
+			yi += z[0]
+			for k := 1; k < n-1; k++ {
+				z[k-1] = b[k]*xi - a[k]*yi + z[k]
+			}
+			z[n-2] = b[n-1]*xi - a[n-1]*yi
+		}
+		y[i] = yi
+	}
+}
+
+// lfilterZi computes the initial state of lfilter corresponding to the steady-state response to a
+// unit step; i.e. solves zi = A zi + B, where A is the transposed companion matrix
+func lfilterZi(b, a []float64) (zi []float64) {
+	n := len(a)
+	zi = make([]float64, n-1)
+	if n < 2 {
+		return
+	}
+	asum, csum := 1.0, 0.0
+	for k := 1; k < n; k++ {
+		asum += a[k]
+		csum += b[k] - a[k]*b[0]
+	}
+	zi[0] = csum / asum
+	asum, csum = 1.0, 0.0
+	for k := 1; k < n-1; k++ {
+		asum += a[k]
+		csum += b[k] - a[k]*b[0]
+		zi[k] = asum*zi[0] - csum
+	}
+	return
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Firwin designs a linear-phase FIR filter with the window method; i.e. the ideal (sinc) impulse
+// response is truncated to ntaps samples and multiplied by a symmetric window
+//
+//   Input:
+//     ntaps  -- number of coefficients (filter order + 1). Must be odd for HighpassFilter and
+//               BandstopFilter (a zero of even-length filters at the Nyquist frequency)
+//     wn     -- cutoff frequencies normalised by the Nyquist frequency (see Butter)
+//     btype  -- LowpassFilter, HighpassFilter, BandpassFilter or BandstopFilter
+//     window -- window kind; e.g. HammingWindow
+//     β      -- shape parameter of the Kaiser window
+//
+//   Output:
+//     b -- coefficients; the filter is applied with a = [1]; e.g. Lfilter(b, []float64{1}, x)
+//
+//   NOTE: the coefficients are scaled to give unit gain at the centre of the first passband (or
+//         at w = 0 or w = 1 if the passband includes these frequencies)
+func Firwin(ntaps int, wn []float64, btype, window io.Enum, β float64) (b []float64, err error) {
+
+	// check cutoffs
+	nw := 1
+	if btype == BandpassFilter || btype == BandstopFilter {
+		nw = 2
+	}
+	if len(wn) != nw {
+		return nil, chk.Err("%v filter requires %d cutoff frequencies. %d given\n", btype, nw, len(wn))
+	}
+	for i, w := range wn {
+		if w <= 0 || w >= 1 || (i > 0 && w <= wn[i-1]) {
+			return nil, chk.Err("cutoff frequencies must be increasing and within (0, 1). %v is invalid\n", wn)
+		}
+	}
+
+	// passbands
+	var edges []float64
+	switch btype {
+	case LowpassFilter:
+		edges = []float64{0, wn[0]}
+	case HighpassFilter:
+		edges = []float64{wn[0], 1}
+	case BandpassFilter:
+		edges = []float64{wn[0], wn[1]}
+	case BandstopFilter:
+		edges = []float64{0, wn[0], wn[1], 1}
+	default:
+		return nil, chk.Err("filter type %q is not available\n", btype)
+	}
+	if edges[len(edges)-1] == 1 && ntaps%2 == 0 {
+		return nil, chk.Err("%v filter requires an odd number of taps. %d is invalid\n", btype, ntaps)
+	}
+
+	// windowed ideal response
+	w, err := Window(window, ntaps, false, β)
+	if err != nil {
+		return
+	}
+	b = make([]float64, ntaps)
+	α := float64(ntaps-1) / 2.0
+	for n := 0; n < ntaps; n++ {
+		m := float64(n) - α
+		for i := 0; i < len(edges); i += 2 {
+			left, right := edges[i], edges[i+1]
+			b[n] += right*sinc(right*m) - left*sinc(left*m)
+		}
+		b[n] *= w[n]
+	}
+
+	// scaling
+	var s float64
+	switch {
+	case edges[0] == 0:
+		s = 0
+	case edges[1] == 1:
+		s = 1
+	default:
+		s = (edges[0] + edges[1]) / 2.0
+	}
+	var gain float64
+	for n := 0; n < ntaps; n++ {
+		gain += b[n] * math.Cos(math.Pi*(float64(n)-α)*s)
+	}
+	for n := range b {
+		b[n] /= gain
+	}
+	return
+}
+
+// sinc computes sin(πx)/(πx)
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
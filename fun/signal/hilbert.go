@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fft"
+)
+
+// Hilbert computes the analytic signal z = x + i H[x] of a real signal x, where H[x] is the
+// Hilbert transform of x. The negative frequencies of the spectrum of x are removed and the
+// positive ones are doubled
+//
+//   NOTE: the signal is assumed periodic; thus, the results near the ends may be affected by the
+//         discontinuity between x[N-1] and x[0]. A taper or padding may be applied beforehand
+func Hilbert(x []float64) (z []complex128, err error) {
+	N := len(x)
+	if N < 1 {
+		return nil, chk.Err("signal must not be empty\n")
+	}
+	z = make([]complex128, N)
+	for i := 0; i < N; i++ {
+		z[i] = complex(x[i], 0)
+	}
+	err = fft.Fft1d(z, false)
+	if err != nil {
+		return
+	}
+	for l := 1; l < N; l++ {
+		switch {
+		case 2*l < N:
+			z[l] *= 2
+		case 2*l > N:
+			z[l] = 0
+		}
+	}
+	err = fft.Fft1d(z, true)
+	if err != nil {
+		return
+	}
+	for i := 0; i < N; i++ {
+		z[i] /= complex(float64(N), 0)
+	}
+	return
+}
+
+// Envelope computes the amplitude envelope |z| of x, where z is the analytic signal (see Hilbert)
+func Envelope(x []float64) (env []float64, err error) {
+	z, err := Hilbert(x)
+	if err != nil {
+		return
+	}
+	env = make([]float64, len(z))
+	for i, v := range z {
+		env[i] = cmplx.Abs(v)
+	}
+	return
+}
+
+// InstFrequency computes the instantaneous frequency [Hz] of x sampled with frequency fs from the
+// unwrapped phase of the analytic signal (central differences; one-sided at the ends)
+func InstFrequency(x []float64, fs float64) (freq []float64, err error) {
+	z, err := Hilbert(x)
+	if err != nil {
+		return
+	}
+	N := len(z)
+	freq = make([]float64, N)
+	if N < 2 {
+		return
+	}
+	φ := make([]float64, N)
+	φ[0] = cmplx.Phase(z[0])
+	for i := 1; i < N; i++ {
+		φ[i] = φ[i-1] + cmplx.Phase(z[i]*cmplx.Conj(z[i-1])) // unwrapped phase
+	}
+	c := fs / (2.0 * math.Pi)
+	freq[0] = (φ[1] - φ[0]) * c
+	freq[N-1] = (φ[N-1] - φ[N-2]) * c
+	for i := 1; i < N-1; i++ {
+		freq[i] = (φ[i+1] - φ[i-1]) * c / 2.0
+	}
+	return
+}
@@ -0,0 +1,186 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fft"
+)
+
+// Welch estimates the one-sided power spectral density (PSD) of x with Welch's method of averaged
+// modified periodograms. The signal is split into overlapping segments with length len(w); each
+// segment has its mean removed and is multiplied by the window w before the transform
+//
+//   Input:
+//     x        -- signal with N ≥ len(w) samples
+//     fs       -- sampling frequency; e.g. [Hz]
+//     w        -- window (see Window with periodic=true); e.g. Hann with 256 points
+//     noverlap -- number of overlapping samples between segments; e.g. len(w)/2
+//
+//   Output:
+//     f -- frequencies f[l] = l⋅fs/len(w), l = 0 ... len(w)/2
+//     P -- PSD; e.g. [unit²/Hz]. The sum of P times Δf = fs/len(w) approximates the variance of x
+//
+//   Reference:
+//   [1] Welch PD (1967) The use of fast Fourier transform for the estimation of power spectra: a
+//       method based on time averaging over short, modified periodograms. IEEE Transactions on
+//       Audio and Electroacoustics, 15(2):70-73
+func Welch(x []float64, fs float64, w []float64, noverlap int) (f, P []float64, err error) {
+	nseg, step, err := segments(len(x), len(w), noverlap)
+	if err != nil {
+		return
+	}
+	f = frequencies(len(w), fs)
+	P = make([]float64, len(f))
+	for s := 0; s < nseg; s++ {
+		X, e := segmentFft(x[s*step:s*step+len(w)], w, true)
+		if e != nil {
+			return nil, nil, e
+		}
+		for l, v := range X {
+			P[l] += real(v)*real(v) + imag(v)*imag(v)
+		}
+	}
+	scale := densityScale(w, fs) / float64(nseg)
+	oneSided(P, len(w), scale)
+	return
+}
+
+// Stft computes the short-time Fourier transform of x
+//
+//   Input:
+//     x        -- signal with N ≥ len(w) samples
+//     fs       -- sampling frequency; e.g. [Hz]
+//     w        -- window; e.g. Hann
+//     noverlap -- number of overlapping samples between segments; e.g. len(w)/2
+//
+//   Output:
+//     f -- frequencies f[l] = l⋅fs/len(w), l = 0 ... len(w)/2
+//     t -- times at the centre of each segment
+//     Z -- Z[i][l] spectrum of segment i at frequency f[l], divided by the sum of w; thus, a
+//          sinusoid with amplitude A and frequency f[l] (0 < l < len(w)/2) gives |Z[i][l]| = A/2
+func Stft(x []float64, fs float64, w []float64, noverlap int) (f, t []float64, Z [][]complex128, err error) {
+	nseg, step, err := segments(len(x), len(w), noverlap)
+	if err != nil {
+		return
+	}
+	f = frequencies(len(w), fs)
+	t = segmentTimes(nseg, step, len(w), fs)
+	var sw float64
+	for _, v := range w {
+		sw += v
+	}
+	Z = make([][]complex128, nseg)
+	for s := 0; s < nseg; s++ {
+		Z[s], err = segmentFft(x[s*step:s*step+len(w)], w, false)
+		if err != nil {
+			return
+		}
+		for l := range Z[s] {
+			Z[s][l] /= complex(sw, 0)
+		}
+	}
+	return
+}
+
+// Spectrogram computes the one-sided PSD of each segment of x (the squared magnitude of the STFT
+// scaled as in Welch). The segments have their mean removed before windowing
+//
+//   Output:
+//     f -- frequencies f[l] = l⋅fs/len(w), l = 0 ... len(w)/2
+//     t -- times at the centre of each segment
+//     S -- S[i][l] PSD of segment i at frequency f[l]; e.g. [unit²/Hz]
+func Spectrogram(x []float64, fs float64, w []float64, noverlap int) (f, t []float64, S [][]float64, err error) {
+	nseg, step, err := segments(len(x), len(w), noverlap)
+	if err != nil {
+		return
+	}
+	f = frequencies(len(w), fs)
+	t = segmentTimes(nseg, step, len(w), fs)
+	scale := densityScale(w, fs)
+	S = make([][]float64, nseg)
+	for s := 0; s < nseg; s++ {
+		X, e := segmentFft(x[s*step:s*step+len(w)], w, true)
+		if e != nil {
+			return nil, nil, nil, e
+		}
+		S[s] = make([]float64, len(X))
+		for l, v := range X {
+			S[s][l] = cmplx.Abs(v) * cmplx.Abs(v)
+		}
+		oneSided(S[s], len(w), scale)
+	}
+	return
+}
+
+// auxiliary /////////////////////////////////////////////////////////////////////////////////////
+
+// segments checks the segmentation parameters and returns the number of segments and the step
+func segments(N, nperseg, noverlap int) (nseg, step int, err error) {
+	if nperseg < 1 || nperseg > N {
+		return 0, 0, chk.Err("window length must be in [1, %d]. %d is invalid\n", N, nperseg)
+	}
+	if noverlap < 0 || noverlap >= nperseg {
+		return 0, 0, chk.Err("noverlap must be in [0, %d). %d is invalid\n", nperseg, noverlap)
+	}
+	step = nperseg - noverlap
+	nseg = 1 + (N-nperseg)/step
+	return
+}
+
+// frequencies returns the n/2+1 frequencies of the one-sided spectrum
+func frequencies(n int, fs float64) (f []float64) {
+	f = make([]float64, n/2+1)
+	for l := range f {
+		f[l] = float64(l) * fs / float64(n)
+	}
+	return
+}
+
+// segmentTimes returns the times at the centre of the segments
+func segmentTimes(nseg, step, n int, fs float64) (t []float64) {
+	t = make([]float64, nseg)
+	for s := 0; s < nseg; s++ {
+		t[s] = (float64(s*step) + float64(n)/2.0) / fs
+	}
+	return
+}
+
+// segmentFft computes the (one-sided) transform of the windowed segment, optionally removing the mean
+func segmentFft(seg, w []float64, detrend bool) (X []complex128, err error) {
+	var mean float64
+	if detrend {
+		for _, v := range seg {
+			mean += v
+		}
+		mean /= float64(len(seg))
+	}
+	y := make([]float64, len(seg))
+	for i, v := range seg {
+		y[i] = (v - mean) * w[i]
+	}
+	return fft.RealFft1d(y)
+}
+
+// densityScale returns the scaling factor of |X|² to obtain a (two-sided) density
+func densityScale(w []float64, fs float64) float64 {
+	var sw2 float64
+	for _, v := range w {
+		sw2 += v * v
+	}
+	return 1.0 / (fs * sw2)
+}
+
+// oneSided scales the values of P and doubles the ones with negative frequency counterparts
+func oneSided(P []float64, n int, scale float64) {
+	for l := range P {
+		P[l] *= scale
+		if l > 0 && 2*l != n {
+			P[l] *= 2
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// ResponseSpectrum computes the response spectra of a ground acceleration record; i.e. the peak
+// responses of damped single-degree-of-freedom oscillators with natural periods T
+//
+//      ü + 2 ζ ω u̇ + ω² u = -ag(t)      ω = 2π/T
+//
+//   Input:
+//     ag      -- ground acceleration record; e.g. [m/s²]
+//     dt      -- time step of the record; e.g. [s]
+//     periods -- natural periods T ≥ 0 of the oscillators; e.g. [s]
+//     ζ       -- damping ratio (0 ≤ ζ < 1); e.g. 0.05
+//
+//   Output:
+//     Sd -- spectral displacement max|u|
+//     Sv -- spectral (relative) velocity max|u̇|
+//     Sa -- spectral (absolute) acceleration max|ü + ag|
+//
+//   NOTE: (1) the pseudo-spectral velocity and acceleration are ω⋅Sd and ω²⋅Sd, respectively
+//         (2) the oscillators start at rest and the record is assumed to vary linearly within each
+//             time step; the equation is then integrated exactly (Nigam and Jennings' method).
+//             Thus, the results do not depend on the ratio dt/T. Peaks are sampled at the record
+//             times only; the record should be interpolated beforehand if dt is coarse for short T
+//         (3) for T = 0 (rigid oscillator), Sd = Sv = 0 and Sa is the peak ground acceleration
+//
+//   Reference:
+//   [1] Nigam NC, Jennings PC (1969) Calculation of response spectra from strong-motion
+//       earthquake records. Bulletin of the Seismological Society of America, 59(2):909-922
+func ResponseSpectrum(ag []float64, dt float64, periods []float64, ζ float64) (Sd, Sv, Sa []float64, err error) {
+	if len(ag) < 2 || dt <= 0 {
+		return nil, nil, nil, chk.Err("record must have at least 2 values and dt must be positive. len(ag) = %d, dt = %g\n", len(ag), dt)
+	}
+	if ζ < 0 || ζ >= 1 {
+		return nil, nil, nil, chk.Err("damping ratio must be in [0, 1). %g is invalid\n", ζ)
+	}
+	n := len(periods)
+	Sd, Sv, Sa = make([]float64, n), make([]float64, n), make([]float64, n)
+	for j, T := range periods {
+		if T < 0 {
+			return nil, nil, nil, chk.Err("periods must be non-negative. %g is invalid\n", T)
+		}
+		if T == 0 {
+			for _, a := range ag {
+				Sa[j] = math.Max(Sa[j], math.Abs(a))
+			}
+			continue
+		}
+
+		// matrix of the homogeneous solution over one time step
+		ω := 2.0 * math.Pi / T
+		ωd := ω * math.Sqrt(1.0-ζ*ζ)
+		e := math.Exp(-ζ * ω * dt)
+		s, c := math.Sincos(ωd * dt)
+		a11 := e * (c + ζ*ω/ωd*s)
+		a12 := e * s / ωd
+		a21 := -e * ω * ω / ωd * s
+		a22 := e * (c - ζ*ω/ωd*s)
+
+		// march in time: for ag = a0 + r τ, the particular solution is u = α + β τ
+		var u, v float64
+		for i := 0; i < len(ag)-1; i++ {
+			r := (ag[i+1] - ag[i]) / dt
+			β := -r / (ω * ω)
+			α := (-ag[i] - 2.0*ζ*ω*β) / (ω * ω)
+			uh, vh := u-α, v-β
+			u = a11*uh + a12*vh + α + β*dt
+			v = a21*uh + a22*vh + β
+			Sd[j] = math.Max(Sd[j], math.Abs(u))
+			Sv[j] = math.Max(Sv[j], math.Abs(v))
+			Sa[j] = math.Max(Sa[j], math.Abs(2.0*ζ*ω*v+ω*ω*u))
+		}
+	}
+	return
+}
@@ -0,0 +1,241 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestButter01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Butter01. Butterworth filters")
+
+	// second order lowpass with cutoff at half Nyquist
+	b, a, err := Butter(2, []float64{0.5}, LowpassFilter)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	b0 := 1.0 / (2.0 + math.Sqrt2)
+	chk.Vector(tst, "b", 1e-15, b, []float64{b0, 2 * b0, b0})
+	chk.Vector(tst, "a", 1e-15, a, []float64{1, 0, (2.0 - math.Sqrt2) / (2.0 + math.Sqrt2)})
+
+	// gain at the cutoffs is 1/√2 and the response is flat away from them
+	for _, order := range []int{1, 2, 3, 4, 5} {
+		for _, c := range []struct {
+			btype io.Enum
+			wn    []float64
+			pass  []float64
+			stop  []float64
+		}{
+			{LowpassFilter, []float64{0.3}, []float64{0}, []float64{1}},
+			{HighpassFilter, []float64{0.3}, []float64{1}, []float64{0}},
+			{BandpassFilter, []float64{0.2, 0.5}, []float64{math.Sqrt(0.2 * 0.5)}, []float64{0, 1}},
+			{BandstopFilter, []float64{0.2, 0.5}, []float64{0, 1}, []float64{}},
+		} {
+			b, a, err = Butter(order, c.wn, c.btype)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			msg := io.Sf("%v (order=%d)", c.btype, order)
+			for _, w := range c.wn {
+				chk.Scalar(tst, msg+": |H(wn)|", 1e-13, cmplx.Abs(FreqZ(b, a, w)), 1/math.Sqrt2)
+			}
+			for _, w := range c.pass {
+				if c.btype != BandpassFilter {
+					chk.Scalar(tst, msg+": |H(pass)|", 1e-13, cmplx.Abs(FreqZ(b, a, w)), 1)
+				}
+			}
+			for _, w := range c.stop {
+				chk.Scalar(tst, msg+": |H(stop)|", 1e-13, cmplx.Abs(FreqZ(b, a, w)), 0)
+			}
+		}
+	}
+
+	// errors
+	if _, _, err = Butter(2, []float64{1.2}, LowpassFilter); err == nil {
+		tst.Errorf("wn > 1 should have failed\n")
+	}
+	if _, _, err = Butter(2, []float64{0.2}, BandpassFilter); err == nil {
+		tst.Errorf("bandpass with one cutoff should have failed\n")
+	}
+}
+
+func TestCheby01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cheby01. Chebyshev filters")
+
+	rp, rs := 1.0, 40.0
+	gp, gs := math.Pow(10, -rp/20), math.Pow(10, -rs/20)
+	for _, order := range []int{1, 2, 3, 4, 5, 6} {
+
+		// type I: passband between gp and 1; gain gp at the cutoff
+		b, a, err := Cheby1(order, rp, []float64{0.4}, LowpassFilter)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Scalar(tst, io.Sf("Cheby1 (order=%d): |H(wn)|", order), 1e-13, cmplx.Abs(FreqZ(b, a, 0.4)), gp)
+		for i := 0; i <= 40; i++ {
+			h := cmplx.Abs(FreqZ(b, a, 0.4*float64(i)/40))
+			if h < gp-1e-13 || h > 1+1e-13 {
+				tst.Errorf("Cheby1: passband gain %g is out of [%g, 1]\n", h, gp)
+				return
+			}
+		}
+
+		// type II: stopband below gs; gain gs at the cutoff; unit gain at DC
+		b, a, err = Cheby2(order, rs, []float64{0.4}, LowpassFilter)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Scalar(tst, io.Sf("Cheby2 (order=%d): |H(wn)|", order), 1e-13, cmplx.Abs(FreqZ(b, a, 0.4)), gs)
+		chk.Scalar(tst, io.Sf("Cheby2 (order=%d): |H(0)|", order), 1e-13, cmplx.Abs(FreqZ(b, a, 0)), 1)
+		for i := 0; i <= 40; i++ {
+			h := cmplx.Abs(FreqZ(b, a, 0.4+0.6*float64(i)/40))
+			if h > gs+1e-13 {
+				tst.Errorf("Cheby2: stopband gain %g is greater than %g\n", h, gs)
+				return
+			}
+		}
+	}
+
+	// band filters
+	b, a, err := Cheby1(3, rp, []float64{0.2, 0.6}, BandpassFilter)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "Cheby1 bandpass: |H(w1)|", 1e-13, cmplx.Abs(FreqZ(b, a, 0.2)), gp)
+	chk.Scalar(tst, "Cheby1 bandpass: |H(w2)|", 1e-13, cmplx.Abs(FreqZ(b, a, 0.6)), gp)
+	b, a, err = Cheby2(4, rs, []float64{0.2, 0.6}, BandstopFilter)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "Cheby2 bandstop: |H(w1)|", 1e-12, cmplx.Abs(FreqZ(b, a, 0.2)), gs)
+	chk.Scalar(tst, "Cheby2 bandstop: |H(w2)|", 1e-12, cmplx.Abs(FreqZ(b, a, 0.6)), gs)
+	chk.Scalar(tst, "Cheby2 bandstop: |H(0)|", 1e-13, cmplx.Abs(FreqZ(b, a, 0)), 1)
+	chk.Scalar(tst, "Cheby2 bandstop: |H(1)|", 1e-13, cmplx.Abs(FreqZ(b, a, 1)), 1)
+}
+
+func TestFiltfilt01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Filtfilt01. filtering and zero-phase filtering")
+
+	// lfilter: impulse response of y[n] = x[n] + 0.5 y[n-1]
+	x := make([]float64, 6)
+	x[0] = 1
+	y, err := Lfilter([]float64{2}, []float64{2, -1}, x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Vector(tst, "impulse response", 1e-15, y, []float64{1, 0.5, 0.25, 0.125, 0.0625, 0.03125})
+
+	// moving average
+	y, _ = Lfilter([]float64{1, 1, 1}, []float64{3}, []float64{3, 6, 9, 12})
+	chk.Vector(tst, "moving average", 1e-15, y, []float64{1, 3, 6, 9})
+
+	// low-frequency signal plus high-frequency noise
+	fs, N := 100.0, 500
+	x = make([]float64, N)
+	slow := make([]float64, N)
+	for i := range x {
+		t := float64(i) / fs
+		slow[i] = 1 + math.Sin(2*math.Pi*1*t)
+		x[i] = slow[i] + 0.5*math.Sin(2*math.Pi*35*t)
+	}
+	b, a, err := Butter(4, []float64{10 / (fs / 2)}, LowpassFilter)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	y, err = Filtfilt(b, a, x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	g := cmplx.Abs(FreqZ(b, a, 1/(fs/2)))
+	for i := 50; i < N-50; i++ {
+		chk.Scalar(tst, "zero phase", 2e-3, y[i], 1+g*g*math.Sin(2*math.Pi*float64(i)/fs))
+	}
+
+	// constant signal is preserved exactly (steady-state initial conditions)
+	c := make([]float64, 50)
+	for i := range c {
+		c[i] = 3.5
+	}
+	y, _ = Filtfilt(b, a, c)
+	chk.Vector(tst, "constant", 1e-12, y, c)
+
+	// errors
+	if _, err = Filtfilt(b, a, c[:10]); err == nil {
+		tst.Errorf("short signal should have failed\n")
+	}
+}
+
+func TestFirwin01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Firwin01. FIR filters by the window method")
+
+	// boxcar lowpass: truncated sinc
+	b, err := Firwin(5, []float64{0.5}, LowpassFilter, BoxcarWindow, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	ref := []float64{0, 1 / math.Pi, 0.5, 1 / math.Pi, 0}
+	var s float64
+	for _, v := range ref {
+		s += v
+	}
+	for i := range ref {
+		ref[i] /= s
+	}
+	chk.Vector(tst, "boxcar lowpass", 1e-15, b, ref)
+
+	// linear phase and gains
+	for _, c := range []struct {
+		btype io.Enum
+		wn    []float64
+		pass  float64
+		stop  []float64
+	}{
+		{LowpassFilter, []float64{0.3}, 0, []float64{0.6, 1}},
+		{HighpassFilter, []float64{0.3}, 1, []float64{0, 0.1}},
+		{BandpassFilter, []float64{0.3, 0.6}, 0.45, []float64{0, 0.1, 0.85, 1}},
+		{BandstopFilter, []float64{0.3, 0.6}, 0, []float64{0.45}},
+	} {
+		b, err = Firwin(61, c.wn, c.btype, HammingWindow, 0)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i := 0; i < 30; i++ {
+			chk.Scalar(tst, "symmetry", 1e-16, b[i], b[60-i])
+		}
+		chk.Scalar(tst, io.Sf("%v: |H(pass)|", c.btype), 1e-14, cmplx.Abs(FreqZ(b, []float64{1}, c.pass)), 1)
+		for _, w := range c.stop {
+			chk.Scalar(tst, io.Sf("%v: |H(%g)|", c.btype, w), 3e-3, cmplx.Abs(FreqZ(b, []float64{1}, w)), 0)
+		}
+	}
+
+	// errors
+	if _, err = Firwin(60, []float64{0.3}, HighpassFilter, HammingWindow, 0); err == nil {
+		tst.Errorf("highpass with even number of taps should have failed\n")
+	}
+}
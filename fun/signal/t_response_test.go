@@ -0,0 +1,80 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestResponse01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Response01. response spectra")
+
+	// step acceleration: u(t) = -(a0/ω²) (1 - exp(-ζωt) (cos(ωd t) + ζω/ωd sin(ωd t)))
+	a0, dt, N := 2.0, 0.01, 1001
+	ag := make([]float64, N)
+	for i := range ag {
+		ag[i] = a0
+	}
+	periods := []float64{0, 0.2, 0.5, 2.0}
+	for _, ζ := range []float64{0, 0.05, 0.2} {
+		Sd, Sv, Sa, err := ResponseSpectrum(ag, dt, periods, ζ)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for j, T := range periods {
+			if T == 0 {
+				chk.Scalar(tst, "Sa(T=0) = PGA", 1e-15, Sa[j], a0)
+				chk.Scalar(tst, "Sd(T=0)", 1e-15, Sd[j], 0)
+				continue
+			}
+			ω := 2 * math.Pi / T
+			ωd := ω * math.Sqrt(1-ζ*ζ)
+			var ud, vd, ad float64
+			for i := 1; i < N; i++ {
+				t := float64(i) * dt
+				e := math.Exp(-ζ * ω * t)
+				s, c := math.Sincos(ωd * t)
+				u := -a0 / (ω * ω) * (1 - e*(c+ζ*ω/ωd*s))
+				v := -a0 / (ω * ω) * e * ω * ω / ωd * s
+				ud = math.Max(ud, math.Abs(u))
+				vd = math.Max(vd, math.Abs(v))
+				ad = math.Max(ad, math.Abs(2*ζ*ω*v+ω*ω*u))
+			}
+			msg := io.Sf("(T=%g, ζ=%g)", T, ζ)
+			chk.Scalar(tst, "Sd "+msg, 1e-13*ud, Sd[j], ud)
+			chk.Scalar(tst, "Sv "+msg, 1e-13*vd, Sv[j], vd)
+			chk.Scalar(tst, "Sa "+msg, 1e-13*ad, Sa[j], ad)
+		}
+	}
+
+	// harmonic ground motion at resonance (undamped): the amplitude grows linearly
+	T := 1.0
+	ω := 2 * math.Pi / T
+	dt = T / 1000
+	N = 10001
+	ag = make([]float64, N)
+	for i := range ag {
+		ag[i] = math.Sin(ω * float64(i) * dt)
+	}
+	Sd, _, _, err := ResponseSpectrum(ag, dt, []float64{T}, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	tf := float64(N-1) * dt
+	chk.Scalar(tst, "resonance", 1e-5, Sd[0], tf/(2*ω)) // u = (t cos ωt)/(2ω) - sin ωt/(2ω²)
+
+	// errors
+	if _, _, _, err = ResponseSpectrum(ag, dt, []float64{T}, 1.0); err == nil {
+		tst.Errorf("ζ = 1 should have failed\n")
+	}
+}
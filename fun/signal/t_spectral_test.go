@@ -0,0 +1,232 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// testSignal returns a deterministic signal with a mean value and several tones
+func testSignal(N int, fs float64) (x []float64) {
+	x = make([]float64, N)
+	for i := 0; i < N; i++ {
+		t := float64(i) / fs
+		x[i] = 0.3 + math.Sin(2*math.Pi*5*t) + 0.5*math.Cos(2*math.Pi*12.5*t+0.3) + 0.1*math.Sin(float64(i*i)*0.37)
+	}
+	return
+}
+
+func TestConvolve01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Convolve01. convolution and correlation via FFT")
+
+	for _, n := range [][]int{{1, 1}, {5, 3}, {3, 5}, {17, 17}, {100, 7}, {97, 61}} {
+		a, b := testSignal(n[0], 7), testSignal(n[1], 3)
+		c, err := Convolve(a, b)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		r, err := Correlate(a, b)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		na, nb := len(a), len(b)
+		cc := make([]float64, na+nb-1)
+		rr := make([]float64, na+nb-1)
+		for k := range cc {
+			for j := 0; j < na; j++ {
+				if k-j >= 0 && k-j < nb {
+					cc[k] += a[j] * b[k-j]
+				}
+				if i := j - (k - (nb - 1)); i >= 0 && i < nb {
+					rr[k] += a[j] * b[i]
+				}
+			}
+		}
+		chk.Vector(tst, io.Sf("conv (%d,%d)", na, nb), 1e-13, c, cc)
+		chk.Vector(tst, io.Sf("corr (%d,%d)", na, nb), 1e-13, r, rr)
+	}
+	if nextFastLen(7) != 8 || nextFastLen(31) != 32 || nextFastLen(121) != 128 || nextFastLen(1) != 2 {
+		tst.Errorf("nextFastLen failed\n")
+	}
+}
+
+func TestWelch01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Welch01. power spectral density")
+
+	// Parseval: one segment and boxcar window
+	fs := 100.0
+	for _, N := range []int{64, 75} {
+		x := testSignal(N, fs)
+		w, _ := Window(BoxcarWindow, N, true, 0)
+		f, P, err := Welch(x, fs, w, 0)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		var mean, vari, sum float64
+		for _, v := range x {
+			mean += v / float64(N)
+		}
+		for _, v := range x {
+			vari += (v - mean) * (v - mean) / float64(N)
+		}
+		for _, v := range P {
+			sum += v * (f[1] - f[0])
+		}
+		chk.Int(tst, "len(f)", len(f), N/2+1)
+		chk.Scalar(tst, io.Sf("ΣPΔf = var(x) (N=%d)", N), 1e-14, sum, vari)
+	}
+
+	// sinusoid with amplitude A: peak at the frequency and total power A²/2
+	N, nper := 4096, 256
+	A, f0 := 2.0, 12.5
+	x := make([]float64, N)
+	for i := range x {
+		x[i] = A * math.Sin(2*math.Pi*f0*float64(i)/fs)
+	}
+	w, _ := Window(HannWindow, nper, true, 0)
+	f, P, err := Welch(x, fs, w, nper/2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	imax := 0
+	var sum float64
+	for l := range P {
+		if P[l] > P[imax] {
+			imax = l
+		}
+		sum += P[l] * (f[1] - f[0])
+	}
+	chk.Scalar(tst, "peak frequency", 1e-15, f[imax], f0)
+	chk.Scalar(tst, "power", 1e-12, sum, A*A/2)
+
+	// errors
+	if _, _, err = Welch(x, fs, w, nper); err == nil {
+		tst.Errorf("noverlap = nperseg should have failed\n")
+	}
+	if _, _, err = Welch(x[:10], fs, w, 0); err == nil {
+		tst.Errorf("short signal should have failed\n")
+	}
+}
+
+func TestStft01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Stft01. short-time Fourier transform and spectrogram")
+
+	// chirp-like signal: 10 Hz in the first half and 30 Hz in the second half
+	fs, N, nper := 200.0, 2000, 100
+	x := make([]float64, N)
+	for i := range x {
+		f := 10.0
+		if i >= N/2 {
+			f = 30.0
+		}
+		x[i] = 3 * math.Cos(2*math.Pi*f*float64(i)/fs)
+	}
+	w, _ := Window(HannWindow, nper, true, 0)
+	f, t, Z, err := Stft(x, fs, w, nper/2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "number of segments", len(t), 39)
+	chk.Int(tst, "number of frequencies", len(f), 51)
+	chk.Scalar(tst, "t[0]", 1e-15, t[0], 0.25)
+	chk.Scalar(tst, "t[1]", 1e-15, t[1], 0.5)
+	chk.Scalar(tst, "|Z| at 10 Hz (first segment)", 1e-13, cmplx.Abs(Z[0][5]), 1.5)
+	chk.Scalar(tst, "|Z| at 30 Hz (last segment)", 1e-13, cmplx.Abs(Z[38][15]), 1.5)
+	chk.Scalar(tst, "|Z| at 30 Hz (first segment)", 1e-13, cmplx.Abs(Z[0][15]), 0)
+
+	// direct DFT of a segment
+	s := 7
+	for l := range f {
+		var X complex128
+		var sw float64
+		for n := 0; n < nper; n++ {
+			X += complex(x[s*nper/2+n]*w[n], 0) * cmplx.Exp(complex(0, -2*math.Pi*float64(l*n)/float64(nper)))
+			sw += w[n]
+		}
+		chk.ScalarC(tst, io.Sf("Z[%d][%d]", s, l), 1e-13, Z[s][l], X/complex(sw, 0))
+	}
+
+	// the average of the spectrogram equals Welch's PSD
+	_, _, S, err := Spectrogram(x, fs, w, nper/2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	_, P, _ := Welch(x, fs, w, nper/2)
+	avg := make([]float64, len(f))
+	for i := range S {
+		for l := range f {
+			avg[l] += S[i][l] / float64(len(S))
+		}
+	}
+	chk.Vector(tst, "mean(S) = Welch", 1e-14, avg, P)
+}
+
+func TestHilbert01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Hilbert01. analytic signal and envelope")
+
+	// H[cos] = sin for an integer number of cycles
+	for _, N := range []int{64, 101} {
+		x := make([]float64, N)
+		y := make([]float64, N)
+		for i := range x {
+			θ := 2 * math.Pi * 3 * float64(i) / float64(N)
+			x[i], y[i] = math.Cos(θ), math.Sin(θ)
+		}
+		z, err := Hilbert(x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		re, im := make([]float64, N), make([]float64, N)
+		for i, v := range z {
+			re[i], im[i] = real(v), imag(v)
+		}
+		chk.Vector(tst, io.Sf("Re(z) = x (N=%d)", N), 1e-14, re, x)
+		chk.Vector(tst, io.Sf("Im(z) = H[x] (N=%d)", N), 1e-14, im, y)
+	}
+
+	// amplitude modulated signal: envelope and instantaneous frequency
+	fs, N := 1000.0, 1000
+	x := make([]float64, N)
+	env := make([]float64, N)
+	for i := range x {
+		t := float64(i) / fs
+		env[i] = 1 + 0.5*math.Cos(2*math.Pi*4*t)
+		x[i] = env[i] * math.Sin(2*math.Pi*100*t)
+	}
+	e, err := Envelope(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Vector(tst, "envelope", 1e-13, e, env)
+	freq, err := InstFrequency(x, fs)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i := 1; i < N-1; i++ {
+		chk.Scalar(tst, "frequency", 0.05, freq[i], 100)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestWindow01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Window01. window functions")
+
+	// symmetric
+	w, err := Window(HannWindow, 5, false, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Vector(tst, "Hann", 1e-15, w, []float64{0, 0.5, 1, 0.5, 0})
+	w, _ = Window(HammingWindow, 5, false, 0)
+	chk.Vector(tst, "Hamming", 1e-15, w, []float64{0.08, 0.54, 1, 0.54, 0.08})
+	w, _ = Window(BlackmanWindow, 5, false, 0)
+	chk.Vector(tst, "Blackman", 1e-15, w, []float64{0, 0.34, 1, 0.34, 0})
+	w, _ = Window(BoxcarWindow, 3, false, 0)
+	chk.Vector(tst, "Boxcar", 1e-15, w, []float64{1, 1, 1})
+
+	// periodic
+	w, _ = Window(HannWindow, 4, true, 0)
+	chk.Vector(tst, "Hann (periodic)", 1e-15, w, []float64{0, 0.5, 1, 0.5})
+
+	// Kaiser
+	β := 8.6
+	w, _ = Window(KaiserWindow, 9, false, β)
+	io.Pforan("Kaiser = %v\n", w)
+	chk.Scalar(tst, "Kaiser: w[0]", 1e-15, w[0], 1.0/fun.ModBesselI0(β))
+	chk.Scalar(tst, "Kaiser: w[4]", 1e-15, w[4], 1)
+	chk.Scalar(tst, "Kaiser: w[2]", 1e-15, w[2], fun.ModBesselI0(β*math.Sqrt(0.75))/fun.ModBesselI0(β))
+	for n := 0; n < 4; n++ {
+		chk.Scalar(tst, "Kaiser: symmetry", 1e-15, w[n], w[8-n])
+	}
+	w, _ = Window(KaiserWindow, 5, false, 0)
+	chk.Vector(tst, "Kaiser (β=0)", 1e-15, w, []float64{1, 1, 1, 1, 1})
+
+	// errors
+	if _, err = Window(HannWindow, 0, false, 0); err == nil {
+		tst.Errorf("N=0 should have failed\n")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package signal implements tools for time-series and spectral analysis such as windows, power
+// spectral densities, convolution via FFT, spectrograms, Hilbert transforms, digital filters and
+// response spectra of seismic records. The transforms are computed with the pure Go fun/fft package
+package signal
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// Window kinds
+var (
+
+	// BoxcarWindow defines the rectangular window: w = 1
+	BoxcarWindow = io.NewEnum("Boxcar", "fun.signal.window", "R", "Rectangular window")
+
+	// HannWindow defines the Hann window: w = 0.5 - 0.5 cos(2πn/M)
+	HannWindow = io.NewEnum("Hann", "fun.signal.window", "H", "Hann window")
+
+	// HammingWindow defines the Hamming window: w = 0.54 - 0.46 cos(2πn/M)
+	HammingWindow = io.NewEnum("Hamming", "fun.signal.window", "M", "Hamming window")
+
+	// BlackmanWindow defines the Blackman window: w = 0.42 - 0.5 cos(2πn/M) + 0.08 cos(4πn/M)
+	BlackmanWindow = io.NewEnum("Blackman", "fun.signal.window", "B", "Blackman window")
+
+	// KaiserWindow defines the Kaiser window: w = I0(β √(1 - (2n/M - 1)²)) / I0(β)
+	KaiserWindow = io.NewEnum("Kaiser", "fun.signal.window", "K", "Kaiser window")
+)
+
+// Window computes N samples of a window function
+//
+//   Input:
+//     kind     -- BoxcarWindow, HannWindow, HammingWindow, BlackmanWindow or KaiserWindow
+//     N        -- number of samples
+//     periodic -- computes the "DFT-even" window for spectral analysis; i.e. the first N samples
+//                 of the symmetric window with N+1 points (M = N). Otherwise, the window is
+//                 symmetric (M = N-1), as used in filter design
+//     β        -- shape parameter of the Kaiser window (ignored otherwise); e.g. β = 8.6 gives a
+//                 window similar to Blackman's
+//
+//   Output:
+//     w -- window values w[n], n = 0 ... N-1
+func Window(kind io.Enum, N int, periodic bool, β float64) (w []float64, err error) {
+	if N < 1 {
+		return nil, chk.Err("number of samples must be at least 1. N = %d is invalid\n", N)
+	}
+	w = make([]float64, N)
+	if N == 1 {
+		w[0] = 1
+		return
+	}
+	M := float64(N - 1)
+	if periodic {
+		M = float64(N)
+	}
+	for n := 0; n < N; n++ {
+		θ := 2.0 * math.Pi * float64(n) / M
+		switch kind {
+		case BoxcarWindow:
+			w[n] = 1
+		case HannWindow:
+			w[n] = 0.5 - 0.5*math.Cos(θ)
+		case HammingWindow:
+			w[n] = 0.54 - 0.46*math.Cos(θ)
+		case BlackmanWindow:
+			w[n] = 0.42 - 0.5*math.Cos(θ) + 0.08*math.Cos(2.0*θ)
+		case KaiserWindow:
+			r := 2.0*float64(n)/M - 1.0
+			w[n] = fun.ModBesselI0(β*math.Sqrt(math.Max(0, 1.0-r*r))) / fun.ModBesselI0(β)
+		default:
+			return nil, chk.Err("window kind %q is not available\n", kind)
+		}
+	}
+	return
+}
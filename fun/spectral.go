@@ -0,0 +1,166 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// BaryWeights computes the barycentric weights associated with grid X
+//
+//                    1
+//         λ[j] = ——————————————     (scaled such that max |λ| = 1)
+//                ┃┃ (X[j] - X[k])
+//                k≠j
+//
+//   NOTE: the weights of the Chebyshev-Gauss-Lobatto grid are computed analytically
+func (o *LagrangeInterp) BaryWeights() (λ []float64) {
+	λ = make([]float64, o.N+1)
+	if o.Grid == ChebyGaussLobattoGridKind {
+		for j := 0; j < o.N+1; j++ {
+			λ[j] = 1
+			if j%2 == 1 {
+				λ[j] = -1
+			}
+		}
+		λ[0] /= 2.0
+		λ[o.N] /= 2.0
+		return
+	}
+	λmax := 0.0
+	for j := 0; j < o.N+1; j++ {
+		λ[j] = 1
+		for k := 0; k < o.N+1; k++ {
+			if k != j {
+				λ[j] /= 2.0 * (o.X[j] - o.X[k]) // the factor 2 (capacity of [-1,1]) avoids overflow
+			}
+		}
+		λmax = math.Max(λmax, math.Abs(λ[j]))
+	}
+	for j := 0; j < o.N+1; j++ {
+		λ[j] /= λmax
+	}
+	return
+}
+
+// DiffMatrix computes the differentiation matrix of order m associated with grid X. The matrix
+// maps the values f(X[j]) to the m-th derivative of the interpolant at X[i]
+//
+//                         N
+//         dᵐ              ————   (m)
+//        ———— I{f}(X[i]) = \     D[i][j] ⋅ f(X[j])
+//        dxᵐ              /
+//                         ————
+//                         j = 0
+//
+//   The matrices are computed with the recursion (i ≠ j)
+//
+//         (m)         m      ┌  λ[j]   (m-1)      (m-1) ┐
+//        D[i][j] = ——————————│ —————— D[i][i]  -  D[i][j]│       D⁽⁰⁾ = I
+//                  X[i]-X[j] └  λ[i]                     ┘
+//
+//   and the diagonal entries are computed with the negative sum trick: D[i][i] = -Σ_{j≠i} D[i][j]
+//
+//   Input:
+//     m -- order of derivative ≥ 1
+//   Output:
+//     D -- [N+1][N+1] differentiation matrix on [-1, 1]. Scale by (2/(b-a))ᵐ for an interval [a, b]
+//
+//   Reference:
+//   [1] Welfert BD (1997) Generation of pseudospectral differentiation matrices I. SIAM Journal on
+//       Numerical Analysis, 34(4):1640-1657
+//   [2] Weideman JAC, Reddy SC (2000) A MATLAB differentiation matrix suite. ACM Transactions on
+//       Mathematical Software, 26(4):465-519
+func (o *LagrangeInterp) DiffMatrix(m int) (D [][]float64, err error) {
+	if m < 1 {
+		return nil, chk.Err("order of derivative must be at least equal to 1. m=%d is invalid\n", m)
+	}
+	n := o.N + 1
+	λ := o.BaryWeights()
+	D = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		D[i] = make([]float64, n)
+		D[i][i] = 1 // D⁽⁰⁾
+	}
+	for k := 1; k <= m; k++ {
+		for i := 0; i < n; i++ {
+			dii := D[i][i]
+			sum := 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					D[i][j] = float64(k) / (o.X[i] - o.X[j]) * (λ[j]/λ[i]*dii - D[i][j])
+					sum += D[i][j]
+				}
+			}
+			D[i][i] = -sum
+		}
+	}
+	return
+}
+
+// FourierDiffMatrix computes the Fourier (trigonometric) differentiation matrix of order m for
+// periodic functions on [0, 2π) sampled at X[j] = 2π j / N, j = 0...N-1
+//
+//         D[i][j] = Sᵐ(X[i] - X[j])
+//
+//   where S is the periodic sinc function (trigonometric cardinal function)
+//
+//                  1  ————
+//         S(x) = ———   \    exp(i ν x)         with |ν| < N/2 and the Nyquist mode ν = N/2 (N even)
+//                  N  /
+//                     ————
+//
+//   NOTE: the Nyquist mode is dropped for odd derivatives such that D is real and antisymmetric
+//
+//   Input:
+//     N -- number of points ≥ 2
+//     m -- order of derivative ≥ 1
+//   Output:
+//     X -- [N] grid points
+//     D -- [N][N] differentiation matrix. Scale by (2π/L)ᵐ for a period L
+//
+//   Reference:
+//   [1] Trefethen LN (2000) Spectral Methods in MATLAB. SIAM. 165p
+func FourierDiffMatrix(N, m int) (X []float64, D [][]float64, err error) {
+	if N < 2 {
+		return nil, nil, chk.Err("number of points must be at least equal to 2. N=%d is invalid\n", N)
+	}
+	if m < 1 {
+		return nil, nil, chk.Err("order of derivative must be at least equal to 1. m=%d is invalid\n", m)
+	}
+	h := 2.0 * math.Pi / float64(N)
+	X = make([]float64, N)
+	for j := 0; j < N; j++ {
+		X[j] = h * float64(j)
+	}
+
+	// first column of circulant matrix: c[k] = Sᵐ(k h)
+	//   pairs of modes ±ν yield (iν)ᵐ exp(iνx) + (-iν)ᵐ exp(-iνx) = 2 νᵐ cos(ν x + m π/2)
+	c := make([]float64, N)
+	M := (N - 1) / 2
+	phase := float64(m) * math.Pi / 2.0
+	for k := 0; k < N; k++ {
+		x := h * float64(k)
+		for ν := 1; ν <= M; ν++ {
+			c[k] += 2.0 * math.Pow(float64(ν), float64(m)) * math.Cos(float64(ν)*x+phase)
+		}
+		if N%2 == 0 && m%2 == 0 { // Nyquist mode
+			c[k] += math.Pow(float64(N/2), float64(m)) * math.Cos(phase) * math.Cos(float64(N/2)*x)
+		}
+		c[k] /= float64(N)
+	}
+
+	// matrix
+	D = make([][]float64, N)
+	for i := 0; i < N; i++ {
+		D[i] = make([]float64, N)
+		for j := 0; j < N; j++ {
+			D[i][j] = c[(i-j+N)%N]
+		}
+	}
+	return
+}
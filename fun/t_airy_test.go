@@ -0,0 +1,40 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestAiry01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Airy01. Airy functions")
+
+	_, dat, err := io.ReadTable("data/hp-airy.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		chkRel(tst, io.Sf("Ai(%g)", x), 1e-13, 1e-16, AiryAi(x), dat["Ai"][i])
+		chkRel(tst, io.Sf("Bi(%g)", x), 1e-13, 1e-16, AiryBi(x), dat["Bi"][i])
+	}
+
+	// Wronskian: Ai(x) Bi'(x) - Ai'(x) Bi(x) = 1/π (derivatives by finite differences)
+	h := 1e-5
+	for _, x := range []float64{-3, -0.5, 0.5, 1.5} {
+		dai := (AiryAi(x+h) - AiryAi(x-h)) / (2 * h)
+		dbi := (AiryBi(x+h) - AiryBi(x-h)) / (2 * h)
+		chk.Scalar(tst, io.Sf("W(%g)", x), 1e-9, AiryAi(x)*dbi-dai*AiryBi(x), 1/math.Pi)
+	}
+	if AiryAi(math.Inf(1)) != 0 || !math.IsInf(AiryBi(math.Inf(1)), 1) {
+		tst.Errorf("Ai(∞) should be 0 and Bi(∞) should be +Inf\n")
+	}
+}
@@ -6,6 +6,7 @@ package fun
 
 import (
 	"math"
+	"math/cmplx"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -233,3 +234,77 @@ func TestBessel02(tst *testing.T) {
 		}
 	}
 }
+
+func TestBessel03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Bessel03. Bessel functions of real order and complex argument")
+
+	_, dat, err := io.ReadTable("data/hp-bessel-complex.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, ν := range dat["nu"] {
+		z := complex(dat["zr"][i], dat["zi"][i])
+		J, Y := BesselJ(ν, z), BesselY(ν, z)
+		Jc, Yc := complex(dat["Jr"][i], dat["Ji"][i]), complex(dat["Yr"][i], dat["Yi"][i])
+		ε := 1e-16 * math.Exp(math.Abs(imag(z))) // the integrands have magnitude ~ exp(|Im z|)
+		chk.ScalarC(tst, io.Sf("J_%g(%v)", ν, z), 1e-13*cmplx.Abs(Jc)+ε, J, Jc)
+		chk.ScalarC(tst, io.Sf("Y_%g(%v)", ν, z), 1e-13*cmplx.Abs(Yc)+ε, Y, Yc)
+	}
+
+	// compare with standard functions of integer order and real argument
+	for _, x := range []float64{0.3, 2.5, 9, 17, 40} {
+		for n := 0; n < 4; n++ {
+			chk.Scalar(tst, io.Sf("J%d(%g)", n, x), 1e-14, real(BesselJ(float64(n), complex(x, 0))), math.Jn(n, x))
+			chkRel(tst, io.Sf("Y%d(%g)", n, x), 1e-13, 1e-14, real(BesselY(float64(n), complex(x, 0))), math.Yn(n, x))
+		}
+	}
+
+	// Wronskian: J_{ν+1}(z) Y_ν(z) - J_ν(z) Y_{ν+1}(z) = 2 / (π z)
+	for _, ν := range []float64{0.25, 1.5, 4} {
+		for _, z := range []complex128{0.7 + 0.2i, 3 - 6i, -5 + 1i, 8 + 8i, 25 - 3i} {
+			a, b := BesselJ(ν+1, z)*BesselY(ν, z), BesselJ(ν, z)*BesselY(ν+1, z)
+			chk.ScalarC(tst, io.Sf("W(%g,%v)", ν, z), 1e-14*(cmplx.Abs(a)+cmplx.Abs(b)), a-b, 2/(math.Pi*z))
+		}
+	}
+
+	// special values
+	chk.ScalarC(tst, "J0(0)", 1e-17, BesselJ(0, 0), 1)
+	chk.ScalarC(tst, "J1(0)", 1e-17, BesselJ(1, 0), 0)
+	if !math.IsInf(real(BesselY(1, 0)), -1) {
+		tst.Errorf("Y1(0) should be -Inf\n")
+	}
+}
+
+func TestBessel04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Bessel04. Spherical Bessel functions")
+
+	_, dat, err := io.ReadTable("data/hp-bessel-spherical.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, n := range dat["n"] {
+		x, j, y := dat["x"][i], dat["j"][i], dat["y"][i]
+		chkRel(tst, io.Sf("j%d(%g)", int(n), x), 1e-13, 1e-16, SphBesselJ(int(n), x), j)
+		chkRel(tst, io.Sf("y%d(%g)", int(n), x), 1e-13, 1e-16, SphBesselY(int(n), x), y)
+	}
+
+	// closed forms and symmetry
+	for _, x := range []float64{-2.5, 0.3, 1, 7} {
+		s, c := math.Sin(x), math.Cos(x)
+		chk.Scalar(tst, io.Sf("j0(%g)", x), 1e-15, SphBesselJ(0, x), s/x)
+		chk.Scalar(tst, io.Sf("j1(%g)", x), 1e-15, SphBesselJ(1, x), s/(x*x)-c/x)
+		chkRel(tst, io.Sf("y0(%g)", x), 1e-14, 1e-15, SphBesselY(0, x), -c/x)
+		chkRel(tst, io.Sf("y1(%g)", x), 1e-14, 1e-15, SphBesselY(1, x), -c/(x*x)-s/x)
+	}
+	chk.Scalar(tst, "j0(0)", 1e-17, SphBesselJ(0, 0), 1)
+	chk.Scalar(tst, "j2(0)", 1e-17, SphBesselJ(2, 0), 0)
+	if !math.IsInf(SphBesselY(0, 0), -1) || !math.IsNaN(SphBesselJ(-1, 1)) {
+		tst.Errorf("y0(0) should be -Inf and j₋₁ should be NaN\n")
+	}
+}
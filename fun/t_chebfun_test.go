@@ -0,0 +1,232 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestChebfun01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Chebfun01. adaptive construction and evaluation")
+
+	// exact polynomial
+	o := NewChebfunCoef(-1, 1, []float64{1, 2, 3})
+	for _, x := range utl.LinSpace(-1, 1, 7) {
+		chk.Scalar(tst, "f(x)", 1e-15, o.F(x), 1+2*x+3*(2*x*x-1))
+	}
+
+	// coefficients of x³ = (3 T₁ + T₃) / 4
+	p, err := NewChebfun(func(x float64) (float64, error) { return x * x * x, nil }, -1, 1)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "degree", p.Degree(), 3)
+	chk.Vector(tst, "c", 1e-15, p.C, []float64{0, 0.75, 0, 0.25})
+
+	// smooth function on [0, 3]
+	f := func(x float64) (float64, error) { return math.Exp(x) * math.Sin(5*x), nil }
+	g, err := NewChebfun(f, 0, 3)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("degree = %d\n", g.Degree())
+	for _, x := range utl.LinSpace(0, 3, 31) {
+		fx, _ := f(x)
+		chk.Scalar(tst, "g(x)", 1e-13, g.F(x), fx)
+	}
+
+	// fixed degree with non power-of-two N
+	h, err := NewChebfunDeg(f, 0, 3, 45)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, x := range utl.LinSpace(0, 3, 31) {
+		chk.Scalar(tst, "h(x)", 1e-12, h.F(x), g.F(x))
+	}
+
+	// errors
+	_, err = NewChebfun(f, 1, 0)
+	if err == nil {
+		tst.Errorf("NewChebfun should have failed with b < a\n")
+	}
+	_, err = NewChebfun(func(x float64) (float64, error) { return 1.0 / x, nil }, 0, 1)
+	if err == nil {
+		tst.Errorf("NewChebfun should have failed with non-finite value\n")
+	}
+}
+
+func TestChebfun02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Chebfun02. derivative and integral")
+
+	f, err := NewChebfun(func(x float64) (float64, error) { return math.Sin(x) * math.Exp(-x/2), nil }, -2, 4)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// derivative
+	df := f.Deriv()
+	for _, x := range utl.LinSpace(-2, 4, 13) {
+		ana := (math.Cos(x) - math.Sin(x)/2) * math.Exp(-x/2)
+		chk.Scalar(tst, "df/dx", 1e-12, df.F(x), ana)
+	}
+
+	// second derivative of a polynomial
+	p := NewChebfunCoef(0, 2, []float64{1, 1, 1, 1})
+	d2p := p.Deriv().Deriv()
+	num := func(x float64) float64 {
+		h := 1e-4
+		return (p.F(x+h) - 2*p.F(x) + p.F(x-h)) / (h * h)
+	}
+	for _, x := range []float64{0.3, 1.0, 1.7} {
+		chk.Scalar(tst, "d²p/dx²", 1e-6, d2p.F(x), num(x))
+	}
+
+	// indefinite integral
+	F := f.Integral()
+	prim := func(x float64) float64 { // ∫ sin(x) exp(-x/2) dx
+		return -math.Exp(-x/2) * (2*math.Sin(x) + 4*math.Cos(x)) / 5
+	}
+	chk.Scalar(tst, "F(a)", 1e-15, F.F(-2), 0)
+	for _, x := range utl.LinSpace(-2, 4, 13) {
+		chk.Scalar(tst, "F(x)", 1e-13, F.F(x), prim(x)-prim(-2))
+	}
+
+	// definite integral
+	chk.Scalar(tst, "∫f", 1e-13, f.Sum(), prim(4)-prim(-2))
+	g, _ := NewChebfun(func(x float64) (float64, error) { return 1 / (1 + 25*x*x), nil }, -1, 1)
+	chk.Scalar(tst, "∫runge", 1e-14, g.Sum(), 2*math.Atan(5)/5)
+}
+
+func TestChebfun03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Chebfun03. roots, max and min")
+
+	// roots of sin(x) in [-10, 10]
+	f, err := NewChebfun(func(x float64) (float64, error) { return math.Sin(x), nil }, -10, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	roots, err := f.Roots()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.Int(tst, "number of roots", len(roots), 7)
+	for i, r := range roots {
+		chk.Scalar(tst, "root", 1e-13, r, float64(i-3)*math.Pi)
+	}
+
+	// many roots (subdivision): Bessel-like oscillation
+	g, err := NewChebfun(func(x float64) (float64, error) { return math.Cos(x * x), nil }, 0, 12)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("degree = %d\n", g.Degree())
+	roots, err = g.Roots()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	n := int(math.Floor(144/math.Pi - 0.5))
+	chk.Int(tst, "number of roots", len(roots), n+1)
+	for k, r := range roots {
+		chk.Scalar(tst, "root", 1e-11, r, math.Sqrt((float64(k)+0.5)*math.Pi))
+	}
+
+	// max and min
+	h, err := NewChebfun(func(x float64) (float64, error) { return x * math.Exp(-x*x), nil }, -3, 1)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xmax, fmax, err := h.Max()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xmin, fmin, err := h.Min()
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "xmax", 1e-10, xmax, 1/math.Sqrt2)
+	chk.Scalar(tst, "fmax", 1e-15, fmax, math.Exp(-0.5)/math.Sqrt2)
+	chk.Scalar(tst, "xmin", 1e-10, xmin, -1/math.Sqrt2)
+	chk.Scalar(tst, "fmin", 1e-15, fmin, -math.Exp(-0.5)/math.Sqrt2)
+
+	// max at end point
+	p := NewChebfunCoef(0, 1, []float64{0, 1})
+	xmax, fmax, _ = p.Max()
+	chk.Scalar(tst, "xmax", 1e-15, xmax, 1)
+	chk.Scalar(tst, "fmax", 1e-15, fmax, 1)
+}
+
+func TestChebfun04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Chebfun04. arithmetic and composition")
+
+	f, _ := NewChebfun(func(x float64) (float64, error) { return math.Sin(x), nil }, 0, 2)
+	g, _ := NewChebfun(func(x float64) (float64, error) { return math.Cos(x), nil }, 0, 2)
+	xx := utl.LinSpace(0, 2, 11)
+
+	s, err := f.Add(g)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	d, _ := f.Sub(g)
+	m, _ := f.Mul(g)
+	g3, _ := g.Scale(2).Compose(func(x float64) (float64, error) { return x + 3, nil })
+	q, _ := f.Div(g3)
+	for _, x := range xx {
+		chk.Scalar(tst, "f+g", 1e-14, s.F(x), math.Sin(x)+math.Cos(x))
+		chk.Scalar(tst, "f-g", 1e-14, d.F(x), math.Sin(x)-math.Cos(x))
+		chk.Scalar(tst, "f*g", 1e-14, m.F(x), math.Sin(2*x)/2)
+		chk.Scalar(tst, "f/(2g+3)", 1e-14, q.F(x), math.Sin(x)/(2*math.Cos(x)+3))
+	}
+
+	// sin² + cos² = 1
+	f2, _ := f.Mul(f)
+	g2, _ := g.Mul(g)
+	one, _ := f2.Add(g2)
+	chk.Int(tst, "degree of sin²+cos²", one.Degree(), 0)
+	chk.Scalar(tst, "sin²+cos²", 1e-15, one.C[0], 1)
+
+	// composition with another Chebfun
+	e, _ := NewChebfun(func(x float64) (float64, error) { return math.Exp(x), nil }, -1, 1)
+	c, err := f.Compose(e.Ss())
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, x := range xx {
+		chk.Scalar(tst, "exp(sin(x))", 1e-14, c.F(x), math.Exp(math.Sin(x)))
+	}
+
+	// incompatible intervals
+	h := NewChebfunCoef(0, 1, []float64{1})
+	_, err = f.Add(h)
+	if err == nil {
+		tst.Errorf("Add should have failed with different intervals\n")
+	}
+}
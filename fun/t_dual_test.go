@@ -0,0 +1,350 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func Test_dual01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dual01. elementary functions")
+
+	type problem struct {
+		name      string
+		x         float64
+		fd        func(x Dual) Dual
+		fh        func(x HyperDual) HyperDual
+		f, g, h   float64 // f(x), f'(x) and f''(x)
+		tol, tol2 float64
+	}
+	x := 0.3
+	problems := []problem{
+		{"x³/(1+x)", x,
+			func(a Dual) Dual { return a.Mul(a).Mul(a).Div(a.AddS(1)) },
+			func(a HyperDual) HyperDual { return a.Mul(a).Mul(a).Div(a.AddS(1)) },
+			x * x * x / (1 + x), x * x * (2*x + 3) / math.Pow(1+x, 2), 2 * x * (x*x + 3*x + 3) / math.Pow(1+x, 3), 1e-15, 1e-15},
+		{"sqrt", x, Dual.Sqrt, HyperDual.Sqrt,
+			math.Sqrt(x), 0.5 / math.Sqrt(x), -0.25 / math.Pow(x, 1.5), 1e-15, 1e-15},
+		{"exp(sin)", x,
+			func(a Dual) Dual { return a.Sin().Exp() },
+			func(a HyperDual) HyperDual { return a.Sin().Exp() },
+			math.Exp(math.Sin(x)), math.Cos(x) * math.Exp(math.Sin(x)),
+			(math.Cos(x)*math.Cos(x) - math.Sin(x)) * math.Exp(math.Sin(x)), 1e-15, 1e-15},
+		{"log(cosh)", x,
+			func(a Dual) Dual { return a.Cosh().Log() },
+			func(a HyperDual) HyperDual { return a.Cosh().Log() },
+			math.Log(math.Cosh(x)), math.Tanh(x), 1 - math.Pow(math.Tanh(x), 2), 1e-15, 1e-15},
+		{"x^2.5", x,
+			func(a Dual) Dual { return a.Pow(2.5) },
+			func(a HyperDual) HyperDual { return a.Pow(2.5) },
+			math.Pow(x, 2.5), 2.5 * math.Pow(x, 1.5), 3.75 * math.Pow(x, 0.5), 1e-15, 1e-15},
+		{"x^x", x,
+			func(a Dual) Dual { return a.PowD(a) },
+			func(a HyperDual) HyperDual { return a.PowD(a) },
+			math.Pow(x, x), math.Pow(x, x) * (math.Log(x) + 1),
+			math.Pow(x, x) * (math.Pow(math.Log(x)+1, 2) + 1/x), 1e-15, 1e-15},
+		{"asin", x, Dual.Asin, HyperDual.Asin,
+			math.Asin(x), 1 / math.Sqrt(1-x*x), x / math.Pow(1-x*x, 1.5), 1e-15, 1e-15},
+		{"acos", x, Dual.Acos, HyperDual.Acos,
+			math.Acos(x), -1 / math.Sqrt(1-x*x), -x / math.Pow(1-x*x, 1.5), 1e-15, 1e-15},
+		{"atan", x, Dual.Atan, HyperDual.Atan,
+			math.Atan(x), 1 / (1 + x*x), -2 * x / math.Pow(1+x*x, 2), 1e-15, 1e-15},
+		{"tan", x, Dual.Tan, HyperDual.Tan,
+			math.Tan(x), 1 / math.Pow(math.Cos(x), 2), 2 * math.Tan(x) / math.Pow(math.Cos(x), 2), 1e-15, 1e-15},
+		{"tanh", x, Dual.Tanh, HyperDual.Tanh,
+			math.Tanh(x), 1 - math.Pow(math.Tanh(x), 2), -2 * math.Tanh(x) * (1 - math.Pow(math.Tanh(x), 2)), 1e-15, 1e-15},
+		{"sinh·cos", x,
+			func(a Dual) Dual { return a.Sinh().Mul(a.Cos()) },
+			func(a HyperDual) HyperDual { return a.Sinh().Mul(a.Cos()) },
+			math.Sinh(x) * math.Cos(x), math.Cosh(x)*math.Cos(x) - math.Sinh(x)*math.Sin(x),
+			-2 * math.Cosh(x) * math.Sin(x), 1e-15, 1e-15},
+		{"|-x|", x,
+			func(a Dual) Dual { return a.Neg().Abs() },
+			func(a HyperDual) HyperDual { return a.Neg().Abs() },
+			x, 1, 0, 1e-15, 1e-15},
+		{"atan2(1,x)", x,
+			func(a Dual) Dual { return DualAtan2(DualCte(1), a) },
+			func(a HyperDual) HyperDual { return HyperDualAtan2(HyperDualCte(1), a) },
+			math.Atan2(1, x), -1 / (1 + x*x), 2 * x / math.Pow(1+x*x, 2), 1e-15, 1e-15},
+		{"Sramp", x,
+			func(a Dual) Dual { return a.Sramp(6) },
+			func(a HyperDual) HyperDual { return a.Sramp(6) },
+			Sramp(x, 6), SrampD1(x, 6), SrampD2(x, 6), 1e-15, 1e-15},
+		{"Sabs", -x,
+			func(a Dual) Dual { return a.Sabs(0.1) },
+			func(a HyperDual) HyperDual { return a.Sabs(0.1) },
+			Sabs(-x, 0.1), SabsD1(-x, 0.1), SabsD2(-x, 0.1), 1e-15, 1e-15},
+	}
+
+	for _, p := range problems {
+		d := p.fd(DualVar(p.x))
+		h := p.fh(HyperDualVar(p.x))
+		io.Pforan("%-10s: f = %23.15e  g = %23.15e  h = %23.15e\n", p.name, d.V, d.D, h.D12)
+		chk.Scalar(tst, p.name+": f(dual)", 1e-15, d.V, p.f)
+		chk.Scalar(tst, p.name+": g(dual)", p.tol, d.D, p.g)
+		chk.Scalar(tst, p.name+": f(hyper)", 1e-15, h.V, p.f)
+		chk.Scalar(tst, p.name+": g1(hyper)", p.tol, h.D1, p.g)
+		chk.Scalar(tst, p.name+": g2(hyper)", p.tol, h.D2, p.g)
+		chk.Scalar(tst, p.name+": h(hyper)", p.tol2, h.D12, p.h)
+	}
+
+	// derivative as scalar function
+	dfdx := DualDeriv(func(s Dual) (Dual, error) { return s.Mul(s.Exp()), nil })
+	res, _ := dfdx(1)
+	chk.Scalar(tst, "d(x⋅exp(x))/dx", 1e-15, res, 2*math.E)
+}
+
+func Test_dual02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dual02. Carlson elliptic integrals")
+
+	x, y, z, p := 0.4, 0.9, 1.3, 0.7
+	verb := chk.Verbose
+	for i, v := range []float64{x, y, z, p} {
+
+		// partial derivatives by dual numbers with respect to argument i
+		seed := func(j int) Dual {
+			a := []float64{x, y, z, p}
+			if i == j {
+				return DualVar(a[j])
+			}
+			return DualCte(a[j])
+		}
+		X, Y, Z, P := seed(0), seed(1), seed(2), seed(3)
+		rf := DualCarlsonRf(X, Y, Z)
+		rd := DualCarlsonRd(X, Y, Z)
+		rj := DualCarlsonRj(X, Y, Z, P)
+		rc := DualCarlsonRc(X, P)
+		chk.Scalar(tst, "Rf", 1e-15, rf.V, CarlsonRf(x, y, z))
+		chk.Scalar(tst, "Rd", 1e-15, rd.V, CarlsonRd(x, y, z))
+		chk.Scalar(tst, "Rj", 1e-15, rj.V, CarlsonRj(x, y, z, p))
+		chk.Scalar(tst, "Rc", 1e-15, rc.V, CarlsonRc(x, p))
+
+		// compare with numerical derivatives
+		arg := func(s float64, j int) float64 {
+			a := []float64{x, y, z, p}
+			a[i] = s
+			return a[j]
+		}
+		if i < 3 {
+			chk.DerivScaSca(tst, io.Sf("dRf/dx%d", i), 1e-9, rf.D, v, 1e-3, verb, func(s float64) (float64, error) {
+				return CarlsonRf(arg(s, 0), arg(s, 1), arg(s, 2)), nil
+			})
+			chk.DerivScaSca(tst, io.Sf("dRd/dx%d", i), 1e-9, rd.D, v, 1e-3, verb, func(s float64) (float64, error) {
+				return CarlsonRd(arg(s, 0), arg(s, 1), arg(s, 2)), nil
+			})
+		}
+		chk.DerivScaSca(tst, io.Sf("dRj/dx%d", i), 1e-9, rj.D, v, 1e-3, verb, func(s float64) (float64, error) {
+			return CarlsonRj(arg(s, 0), arg(s, 1), arg(s, 2), arg(s, 3)), nil
+		})
+		if i == 0 || i == 3 {
+			chk.DerivScaSca(tst, io.Sf("dRc/dx%d", i), 1e-9, rc.D, v, 1e-3, verb, func(s float64) (float64, error) {
+				return CarlsonRc(arg(s, 0), arg(s, 3)), nil
+			})
+		}
+	}
+
+	// known derivative: ∂Rf/∂z = -Rd(x, y, z) / 6
+	rf := DualCarlsonRf(DualCte(x), DualCte(y), DualVar(z))
+	chk.Scalar(tst, "∂Rf/∂z = -Rd/6", 1e-14, rf.D, -CarlsonRd(x, y, z)/6)
+
+	// second derivative with hyper-dual numbers: ∂²Rf/∂z² = -(∂Rd/∂z) / 6
+	rfh := HyperDualCarlsonRf(HyperDualCte(x), HyperDualCte(y), HyperDualVar(z))
+	rd := DualCarlsonRd(DualCte(x), DualCte(y), DualVar(z))
+	chk.Scalar(tst, "∂²Rf/∂z²", 1e-14, rfh.D12, -rd.D/6)
+	rjh := HyperDualCarlsonRj(HyperDualCte(x), HyperDualCte(y), HyperDualCte(z), HyperDualVar(p))
+	rcd := DualCarlsonRc(DualCte(x), DualVar(p))
+	rch := HyperDualCarlsonRc(HyperDualCte(x), HyperDualVar(p))
+	chk.Scalar(tst, "Rj(hyper)", 1e-15, rjh.V, CarlsonRj(x, y, z, p))
+	chk.Scalar(tst, "∂Rc/∂y(hyper)", 1e-15, rch.D1, rcd.D)
+	chk.DerivScaSca(tst, "∂²Rc/∂y²", 1e-8, rch.D12, p, 1e-3, verb, func(s float64) (float64, error) {
+		r := DualCarlsonRc(DualCte(x), DualVar(s))
+		return r.D, nil
+	})
+
+	// coinciding arguments and p < 0 (Cauchy principal value)
+	for _, a := range [][]float64{{0.4, 0.9, 0.4, 0.4}, {1.3, 0.9, 1.3, -0.7}} {
+		rj := func(s float64, i, j int) float64 {
+			d := []Dual{DualCte(a[0]), DualCte(a[1]), DualCte(a[2]), DualCte(a[3])}
+			d[i].V = s
+			if j >= 0 {
+				d[j].D = 1
+			}
+			r := DualCarlsonRj(d[0], d[1], d[2], d[3])
+			if j >= 0 {
+				return r.D
+			}
+			return r.V
+		}
+		for i := 0; i < 4; i++ {
+			chk.DerivScaSca(tst, io.Sf("dRj/dx%d @ %v", i, a), 1e-9, rj(a[i], i, i), a[i], 1e-3, verb, func(s float64) (float64, error) {
+				return rj(s, i, -1), nil
+			})
+			for j := 0; j < 4; j++ {
+				h := []HyperDual{HyperDualCte(a[0]), HyperDualCte(a[1]), HyperDualCte(a[2]), HyperDualCte(a[3])}
+				h[i].D1, h[j].D2 = 1, 1
+				r := HyperDualCarlsonRj(h[0], h[1], h[2], h[3])
+				chk.Scalar(tst, io.Sf("d²Rj/dx%ddx%d @ %v", i, j, a), 1e-8, r.D12, carlsonRjD2num(a, i, j))
+			}
+		}
+	}
+
+	// all arguments equal: Rj(s,s,s,s) = Rd(s,s,s) = s^(-3/2)
+	s := HyperDualVar(0.7)
+	chk.Scalar(tst, "d²Rj(s,s,s,s)/ds²", 1e-13, HyperDualCarlsonRj(s, s, s, s).D12, 3.75*math.Pow(0.7, -3.5))
+	chk.Scalar(tst, "d²Rd(s,s,s)/ds²", 1e-13, HyperDualCarlsonRd(s, s, s).D12, 3.75*math.Pow(0.7, -3.5))
+}
+
+func Test_dual04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dual04. Carlson elliptic integrals with nearly coinciding arguments")
+
+	// Rd = 3/2 ∫ (t+x)^(-1/2) (t+y)^(-1/2) (t+z)^(-3/2) dt
+	// Rj = 3/2 ∫ (t+x)^(-1/2) (t+y)^(-1/2) (t+z)^(-1/2) (t+p)^(-1) dt
+	x, y := 0.4, 0.9
+	for _, δ := range []float64{1e-3, 1e-5, 1e-7, 2e-8, 0} {
+		z := x + δ
+		for i := 0; i < 3; i++ {
+			d := []Dual{DualCte(x), DualCte(y), DualCte(z)}
+			d[i].D = 1
+			rd := DualCarlsonRd(d[0], d[1], d[2])
+			chk.Scalar(tst, io.Sf("∂Rd/∂x%d @ z-x=%g", i, δ), 1e-12, rd.D/carlsonDerivQuad([]float64{x, y, z}, []float64{0.5, 0.5, 1.5}, i), 1)
+		}
+		p := y + δ
+		for i := 0; i < 4; i++ {
+			d := []Dual{DualCte(x), DualCte(y), DualCte(z), DualCte(p)}
+			d[i].D = 1
+			rj := DualCarlsonRj(d[0], d[1], d[2], d[3])
+			chk.Scalar(tst, io.Sf("∂Rj/∂x%d @ p-y=%g", i, δ), 1e-12, rj.D/carlsonDerivQuad([]float64{x, y, z, p}, []float64{0.5, 0.5, 0.5, 1}, i), 1)
+		}
+
+		// second derivatives: ∂²Rd/∂x∂z with hyper-dual numbers
+		h := []HyperDual{HyperDualCte(x), HyperDualCte(y), HyperDualCte(z)}
+		h[0].D1, h[2].D2 = 1, 1
+		rd := HyperDualCarlsonRd(h[0], h[1], h[2])
+		chk.Scalar(tst, io.Sf("∂Rd/∂x(hyper) @ z-x=%g", δ), 1e-12, rd.D1/carlsonDerivQuad([]float64{x, y, z}, []float64{0.5, 0.5, 1.5}, 0), 1)
+		chk.DerivScaSca(tst, io.Sf("∂²Rd/∂x∂z @ z-x=%g", δ), 1e-8, rd.D12, z, 1e-3, chk.Verbose, func(s float64) (float64, error) {
+			return DualCarlsonRd(DualVar(x), DualCte(y), DualCte(s)).D, nil
+		})
+	}
+}
+
+func Test_dual03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dual03. Jacobian, gradient and Hessian")
+
+	// f(v) = {v₀² v₁ - 3, v₁ sin(v₀) + v₂, exp(v₂)}
+	ffcn := func(f, v []Dual) error {
+		f[0] = v[0].Mul(v[0]).Mul(v[1]).AddS(-3)
+		f[1] = v[1].Mul(v[0].Sin()).Add(v[2])
+		f[2] = v[2].Exp()
+		return nil
+	}
+	v := []float64{0.5, 2, -1}
+	Jana := [][]float64{
+		{2 * v[0] * v[1], v[0] * v[0], 0},
+		{v[1] * math.Cos(v[0]), math.Sin(v[0]), 1},
+		{0, 0, math.Exp(v[2])},
+	}
+
+	// residual
+	f := make([]float64, 3)
+	DualResidual(3, ffcn)(f, v)
+	chk.Vector(tst, "f", 1e-15, f, []float64{v[0]*v[0]*v[1] - 3, v[1]*math.Sin(v[0]) + v[2], math.Exp(v[2])})
+
+	// sparse Jacobian
+	var J la.Triplet
+	J.Init(3, 3, 9)
+	jfcn := DualJacobian(3, ffcn)
+	for k := 0; k < 2; k++ { // twice to check the reuse of workspaces
+		err := jfcn(&J, v)
+		if err != nil {
+			tst.Errorf("DualJacobian failed:\n%v\n", err)
+			return
+		}
+		chk.Int(tst, "nnz", J.Len(), 6)
+		chk.Matrix(tst, "J(sparse)", 1e-15, J.ToMatrix(nil).ToDense(), Jana)
+	}
+
+	// dense Jacobian
+	Jd := la.MatAlloc(3, 3)
+	DualJacobianDense(3, ffcn)(Jd, v)
+	chk.Matrix(tst, "J(dense)", 1e-15, Jd, Jana)
+
+	// Rosenbrock function
+	rosen := func(x []Dual) (Dual, error) {
+		a := DualCte(1).Sub(x[0])
+		b := x[1].Sub(x[0].Mul(x[0]))
+		return a.Mul(a).Add(b.Mul(b).MulS(100)), nil
+	}
+	rosenH := func(x []HyperDual) (HyperDual, error) {
+		a := HyperDualCte(1).Sub(x[0])
+		b := x[1].Sub(x[0].Mul(x[0]))
+		return a.Mul(a).Add(b.Mul(b).MulS(100)), nil
+	}
+	x := []float64{-1.2, 1}
+	g := make([]float64, 2)
+	DualGradient(rosen)(g, x)
+	chk.Vector(tst, "∇f", 1e-13, g, []float64{
+		-2*(1-x[0]) - 400*x[0]*(x[1]-x[0]*x[0]),
+		200 * (x[1] - x[0]*x[0]),
+	})
+	H := la.MatAlloc(2, 2)
+	HyperDualHessian(rosenH)(H, x)
+	chk.Matrix(tst, "∇²f", 1e-12, H, [][]float64{
+		{2 - 400*x[1] + 1200*x[0]*x[0], -400 * x[0]},
+		{-400 * x[0], 200},
+	})
+}
+
+// carlsonRjD2num computes ∂²Rj/∂aᵢ∂aⱼ with fourth-order finite differences of the real function
+func carlsonRjD2num(a []float64, i, j int) float64 {
+	f := func(si, sj float64) float64 {
+		b := []float64{a[0], a[1], a[2], a[3]}
+		b[i] += si
+		b[j] += sj
+		return CarlsonRj(b[0], b[1], b[2], b[3])
+	}
+	h := 1e-3
+	w := []float64{1, -8, 8, -1}
+	d := []float64{-2, -1, 1, 2}
+	res := 0.0
+	for m := 0; m < 4; m++ {
+		for n := 0; n < 4; n++ {
+			if i == j {
+				res += w[m] * w[n] * f(d[m]*h+d[n]*h, 0)
+			} else {
+				res += w[m] * w[n] * f(d[m]*h, d[n]*h)
+			}
+		}
+	}
+	return res / (144 * h * h)
+}
+
+// carlsonDerivQuad computes ∂f/∂aᵢ with f = 3/2 ∫ Π (t + aₖ)^(-bₖ) dt (i.e. Rd or Rj) by
+// integrating ∂f/∂aᵢ = -3/2 bᵢ ∫ (t + aᵢ)^(-1) Π (t + aₖ)^(-bₖ) dt with the exp-sinh rule
+func carlsonDerivQuad(a, b []float64, i int) float64 {
+	h := 1.0 / 64.0
+	res := 0.0
+	for τ := -5.0; τ <= 5.0; τ += h {
+		t := math.Exp(math.Pi / 2 * math.Sinh(τ))
+		g := t * math.Pi / 2 * math.Cosh(τ) / (t + a[i])
+		for k := range a {
+			g *= math.Pow(t+a[k], -b[k])
+		}
+		res += g
+	}
+	return -1.5 * b[i] * res * h
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestErf01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Erf01. inverses of error functions")
+
+	_, dat, err := io.ReadTable("data/hp-erf.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		e, ec := dat["erf"][i], dat["erfc"][i]
+		chkRel(tst, io.Sf("erfcinv(%g)", ec), 1e-14, 1e-16, ErfcInv(ec), x)
+		if x <= 2 { // erf(x) and 2-erfc(x) are rounded to 1 for large x
+			chkRel(tst, io.Sf("erfinv(%g)", e), 1e-14, 1e-16, ErfInv(e), x)
+			chkRel(tst, io.Sf("erfinv(%g)", -e), 1e-14, 1e-16, ErfInv(-e), -x)
+			chkRel(tst, io.Sf("erfcinv(%g)", 2-ec), 1e-14, 1e-16, ErfcInv(2-ec), -x)
+		}
+	}
+
+	// special values
+	chk.Scalar(tst, "erfinv(0)", 1e-17, ErfInv(0), 0)
+	chk.Scalar(tst, "erfcinv(1)", 1e-17, ErfcInv(1), 0)
+	if !math.IsInf(ErfInv(1), 1) || !math.IsInf(ErfInv(-1), -1) || !math.IsInf(ErfcInv(0), 1) || !math.IsInf(ErfcInv(2), -1) {
+		tst.Errorf("erfinv(±1) and erfcinv(0 or 2) should be ±Inf\n")
+	}
+	if !math.IsNaN(ErfInv(1.1)) || !math.IsNaN(ErfcInv(-0.1)) {
+		tst.Errorf("invalid arguments should give NaN\n")
+	}
+}
+
+func TestFaddeeva01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Faddeeva01. Faddeeva function")
+
+	_, dat, err := io.ReadTable("data/hp-faddeeva.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, xr := range dat["xr"] {
+		z := complex(xr, dat["xi"][i])
+		w := Faddeeva(z)
+		wr, wi := dat["wr"][i], dat["wi"][i]
+		tol := 1e-13 * math.Hypot(wr, wi)
+		chk.Scalar(tst, io.Sf("Re w(%v)", z), tol, real(w), wr)
+		chk.Scalar(tst, io.Sf("Im w(%v)", z), tol, imag(w), wi)
+	}
+
+	// relation with erfc for real arguments
+	for _, x := range []float64{-3, -0.5, 0, 0.7, 2.5} {
+		w := Faddeeva(complex(0, x))
+		chk.Scalar(tst, io.Sf("w(i%g)", x), 1e-13*math.Exp(x*x)*math.Erfc(x), real(w), math.Exp(x*x)*math.Erfc(x))
+		chk.Scalar(tst, io.Sf("Im w(i%g)", x), 1e-15, imag(w), 0)
+	}
+}
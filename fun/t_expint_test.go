@@ -0,0 +1,48 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestExpInt01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ExpInt01. exponential integrals")
+
+	_, dat, err := io.ReadTable("data/hp-expint.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, n := range dat["n"] {
+		x := dat["x"][i]
+		chkRel(tst, io.Sf("E%d(%g)", int(n), x), 1e-14, 1e-300, ExpIntEn(int(n), x), dat["En"][i])
+		if n == 1 {
+			chkRel(tst, io.Sf("E1(%g)", x), 1e-14, 1e-300, ExpIntE1(x), dat["En"][i])
+		}
+	}
+
+	_, dat, err = io.ReadTable("data/hp-expint-ei.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		chkRel(tst, io.Sf("Ei(%g)", x), 1e-14, 1e-16, ExpIntEi(x), dat["Ei"][i])
+	}
+
+	// special values
+	chk.Scalar(tst, "E2(0)", 1e-15, ExpIntEn(2, 0), 1)
+	chk.Scalar(tst, "E3(0)", 1e-15, ExpIntEn(3, 0), 0.5)
+	if !math.IsInf(ExpIntE1(0), 1) || !math.IsInf(ExpIntEi(0), -1) || !math.IsNaN(ExpIntE1(-1)) {
+		tst.Errorf("E1(0) should be +Inf, Ei(0) should be -Inf and E1(-1) should be NaN\n")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fft"
 	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/plt"
@@ -34,6 +35,18 @@ func TestDft01(tst *testing.T) {
 	io.Pf("Y = %v\n", Y)
 
 	chk.VectorC(tst, "X", 1e-14, Y, X)
+
+	// compare with fft.Fft1d
+	N := 16
+	data := make([]float64, 2*N)
+	z := make([]complex128, N)
+	for i := 0; i < N; i++ {
+		z[i] = complex(float64(i), -float64(i*i))
+		data[2*i], data[2*i+1] = real(z[i]), imag(z[i])
+	}
+	Dft1d(data, false)
+	fft.Fft1d(z, false)
+	chk.Vector(tst, "Fft1d", 1e-12, la.ComplexToRCpairs(z), data)
 }
 
 func TestDft02(tst *testing.T) {
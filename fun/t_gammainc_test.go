@@ -0,0 +1,91 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestGammaInc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GammaInc01. regularised incomplete gamma functions and inverses")
+
+	_, dat, err := io.ReadTable("data/hp-gamma-incomplete.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, a := range dat["a"] {
+		x, P, Q := dat["x"][i], dat["P"][i], dat["Q"][i]
+		chkRel(tst, io.Sf("P(%g,%g)", a, x), 1e-12, 1e-300, GammaP(a, x), P)
+		chkRel(tst, io.Sf("Q(%g,%g)", a, x), 1e-12, 1e-300, GammaQ(a, x), Q)
+		if P < 0.5 {
+			chkRel(tst, io.Sf("Pinv(%g,%g)", a, P), 1e-13, 0, GammaPinv(a, P), x)
+		} else {
+			chkRel(tst, io.Sf("Qinv(%g,%g)", a, Q), 1e-13, 0, GammaQinv(a, Q), x)
+		}
+	}
+
+	// special values
+	chk.Scalar(tst, "P(1,x)", 1e-15, GammaP(1, 0.7), 1-math.Exp(-0.7))
+	chk.Scalar(tst, "P(a,0)", 1e-15, GammaP(2, 0), 0)
+	chk.Scalar(tst, "Q(a,0)", 1e-15, GammaQ(2, 0), 1)
+	chk.Scalar(tst, "Pinv(a,0)", 1e-15, GammaPinv(2, 0), 0)
+	if !math.IsInf(GammaPinv(2, 1), 1) {
+		tst.Errorf("GammaPinv(2,1) should be +Inf\n")
+	}
+	if !math.IsNaN(GammaP(-1, 1)) || !math.IsNaN(GammaQ(1, -1)) || !math.IsNaN(GammaPinv(1, 2)) {
+		tst.Errorf("invalid arguments should give NaN\n")
+	}
+}
+
+func TestBetaInc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BetaInc01. regularised incomplete beta function and inverse")
+
+	_, dat, err := io.ReadTable("data/hp-beta-incomplete.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, a := range dat["a"] {
+		b, x, I, Ic := dat["b"][i], dat["x"][i], dat["I"][i], dat["Ic"][i]
+		chkRel(tst, io.Sf("I(%g,%g,%g)", a, b, x), 1e-12, 1e-300, BetaInc(a, b, x), I)
+		if I < 0.5 {
+			chkRel(tst, io.Sf("Iinv(%g,%g,%g)", a, b, I), 1e-13, 0, BetaIncInv(a, b, I), x)
+		} else {
+			chkRel(tst, io.Sf("Iinv(%g,%g,%g)", b, a, Ic), 1e-13, 0, BetaIncInv(b, a, Ic), 1-x)
+		}
+	}
+
+	// symmetry and special values
+	chk.Scalar(tst, "I(a,b,x)+I(b,a,1-x)", 1e-15, BetaInc(2.5, 3.5, 0.3)+BetaInc(3.5, 2.5, 0.7), 1)
+	chk.Scalar(tst, "I(1,1,x)", 1e-15, BetaInc(1, 1, 0.3), 0.3)
+	chk.Scalar(tst, "I(a,b,0)", 1e-15, BetaInc(2, 3, 0), 0)
+	chk.Scalar(tst, "I(a,b,1)", 1e-15, BetaInc(2, 3, 1), 1)
+	chk.Scalar(tst, "Iinv(a,b,0.5)", 1e-14, BetaIncInv(4, 4, 0.5), 0.5)
+
+	// small arguments (relative precision)
+	for _, x := range []float64{1e-15, 1e-10, 1e-5} {
+		I := 2 * math.Asin(math.Sqrt(x)) / math.Pi
+		chkRel(tst, io.Sf("I(½,½,%g)", x), 1e-14, 0, BetaInc(0.5, 0.5, x), I)
+		chkRel(tst, io.Sf("Iinv(½,½,%g)", I), 1e-14, 0, BetaIncInv(0.5, 0.5, I), x)
+		chkRel(tst, io.Sf("P(3,%g)", x), 5e-14, 0, GammaP(3, x), x*x*x/6*math.Exp(-x)*(1+x/4+x*x/20))
+	}
+
+	// large parameters (quadrature)
+	chk.Scalar(tst, "I(a,a,0.5)", 1e-14, BetaInc(5000, 5000, 0.5), 0.5)
+	chk.Scalar(tst, "I(a,a,x)+I(a,a,1-x)", 1e-14, BetaInc(5000, 5000, 0.49)+BetaInc(5000, 5000, 0.51), 1)
+	chk.Scalar(tst, "Iinv(a,a,0.5)", 1e-14, BetaIncInv(5000, 5000, 0.5), 0.5)
+	if !math.IsNaN(BetaInc(0, 1, 0.5)) || !math.IsNaN(BetaInc(1, 1, 1.5)) || !math.IsNaN(BetaIncInv(1, 1, -1)) {
+		tst.Errorf("invalid arguments should give NaN\n")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestHyp2f101(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Hyp2f101. Gauss hypergeometric function")
+
+	_, dat, err := io.ReadTable("data/hp-hyp2f1.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, a := range dat["a"] {
+		b, c, x := dat["b"][i], dat["c"][i], dat["x"][i]
+		chkRel(tst, io.Sf("F(%g,%g;%g;%g)", a, b, c, x), 1e-13, 1e-15, Hyp2f1(a, b, c, x), dat["F"][i])
+	}
+
+	// elementary functions
+	for _, x := range []float64{-0.9, -0.3, 0.2, 0.6, 0.95} {
+		chk.Scalar(tst, io.Sf("ln(1+x) @ %g", x), 1e-14, x*Hyp2f1(1, 1, 2, -x), math.Log1p(x))
+		chk.Scalar(tst, io.Sf("asin(x) @ %g", x), 1e-14, x*Hyp2f1(0.5, 0.5, 1.5, x*x), math.Asin(x))
+		chk.Scalar(tst, io.Sf("(1-x)^-a @ %g", x), 1e-13, Hyp2f1(2.5, 1, 1, x), math.Pow(1-x, -2.5))
+	}
+
+	// Gauss formula at x = 1
+	chk.Scalar(tst, "F(a,b;c;1)", 1e-14, Hyp2f1(0.5, 0.5, 2, 1), math.Gamma(2)*math.Gamma(1)/(math.Gamma(1.5)*math.Gamma(1.5)))
+	if !math.IsNaN(Hyp2f1(1, 1, 2, 1.5)) || !math.IsNaN(Hyp2f1(1, 1, -2, 0.5)) || !math.IsInf(Hyp2f1(1, 1, 2, 1), 1) {
+		tst.Errorf("F(x>1) and F(c=-2) should be NaN and F(1,1;2;1) should be +Inf\n")
+	}
+}
@@ -5,6 +5,9 @@
 package fun
 
 import (
+	"math"
+	"testing"
+
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
 )
@@ -18,3 +21,8 @@ func verbose() {
 	io.Verbose = true
 	chk.Verbose = true
 }
+
+// chkRel compares res with correct using the tolerance atol + rtol⋅|correct|
+func chkRel(tst *testing.T, msg string, rtol, atol, res, correct float64) {
+	chk.Scalar(tst, msg, atol+rtol*math.Abs(correct), res, correct)
+}
@@ -5,6 +5,7 @@
 package fun
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -93,3 +94,264 @@ func TestInterp02(tst *testing.T) {
 		plt.Save("/tmp/gosl/fun", "interp02")
 	}
 }
+
+func TestInterp03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interp03. cubic splines: exactness and end conditions")
+
+	// cubic function on non-uniform grid
+	f := func(x float64) float64 { return 1 - 2*x + x*x*x/3 }
+	g := func(x float64) float64 { return -2 + x*x }
+	h := func(x float64) float64 { return 2 * x }
+	F := func(x float64) float64 { return x - x*x + x*x*x*x/12 }
+	xx := []float64{-1, -0.2, 0.5, 1.0, 1.8, 2.5, 3}
+	yy := utl.GetMapped(xx, f)
+	X := utl.LinSpace(-1, 3, 23)
+
+	// clamped and not-a-knot reproduce cubics exactly
+	o, err := NewInterpolator(CubicClampedInterpKind, 0, xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	err = o.SetClamped(g(xx[0]), g(xx[6]))
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, kind := range []io.Enum{CubicClampedInterpKind, CubicNotAKnotInterpKind} {
+		io.Pforan("%v\n", kind)
+		if kind == CubicNotAKnotInterpKind {
+			o, err = NewInterpolator(kind, 0, xx, yy)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+		}
+		for _, x := range X {
+			chk.AnaNum(tst, "P ", 1e-14, o.P(x), f(x), false)
+			chk.AnaNum(tst, "D1", 1e-13, o.D1(x), g(x), false)
+			chk.AnaNum(tst, "D2", 1e-12, o.D2(x), h(x), false)
+		}
+		chk.Scalar(tst, "∫", 1e-14, o.Integral(-0.5, 2.7), F(2.7)-F(-0.5))
+		chk.Scalar(tst, "∫", 1e-14, o.Integral(3, -1), F(-1)-F(3))
+	}
+
+	// natural: zero second derivatives at ends, continuity at nodes
+	o, err = NewInterpolator(CubicNaturalInterpKind, 0, xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "D2(x0)", 1e-14, o.D2(xx[0]), 0)
+	chk.Scalar(tst, "D2(xn)", 1e-13, o.D2(xx[6]), 0)
+	for i := 1; i < len(xx)-1; i++ {
+		chk.Scalar(tst, "P(xi)", 1e-15, o.P(xx[i]), yy[i])
+		chk.Scalar(tst, "D1 jump", 1e-10, o.D1(xx[i]-1e-12), o.D1(xx[i]+1e-12))
+		chk.Scalar(tst, "D2 jump", 1e-10, o.D2(xx[i]-1e-12), o.D2(xx[i]+1e-12))
+	}
+
+	// natural spline of a straight line is the line itself
+	o, err = NewInterpolator(CubicNaturalInterpKind, 0, []float64{0, 1, 3}, []float64{1, 3, 7})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "P(2)", 1e-15, o.P(2), 5)
+	chk.Scalar(tst, "D1(2)", 1e-15, o.D1(2), 2)
+
+	// errors
+	_, err = NewInterpolator(CubicNaturalInterpKind, 0, []float64{0, 1, 1}, []float64{1, 3, 7})
+	if err == nil {
+		tst.Errorf("repeated x-values should have caused an error\n")
+	}
+	_, err = NewInterpolator(CubicPeriodicInterpKind, 0, []float64{0, 1, 2}, []float64{1, 3, 7})
+	if err == nil {
+		tst.Errorf("non-periodic data should have caused an error\n")
+	}
+}
+
+func TestInterp04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interp04. periodic cubic spline")
+
+	N := 17
+	xx := utl.LinSpace(0, 2*math.Pi, N)
+	yy := utl.GetMapped(xx, math.Sin)
+	yy[N-1] = yy[0]
+	o, err := NewInterpolator(CubicPeriodicInterpKind, 0, xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xe := 2 * math.Pi
+	chk.Scalar(tst, "D1(0)-D1(2π)", 1e-14, o.D1(0), o.D1(xe))
+	chk.Scalar(tst, "D2(0)-D2(2π)", 1e-13, o.D2(0), o.D2(xe))
+	chk.Scalar(tst, "∫ sin", 1e-14, o.Integral(0, xe), 0)
+	for _, x := range utl.LinSpace(0, xe, 41) {
+		chk.AnaNum(tst, "P ", 1e-4, o.P(x), math.Sin(x), false)
+		chk.AnaNum(tst, "D1", 1e-3, o.D1(x), math.Cos(x), false)
+	}
+
+	// with 3 points only
+	o, err = NewInterpolator(CubicPeriodicInterpKind, 0, []float64{0, 1, 3}, []float64{1, 2, 1})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "P(1)", 1e-15, o.P(1), 2)
+	chk.Scalar(tst, "D1(0)-D1(3)", 1e-14, o.D1(0), o.D1(3))
+	chk.Scalar(tst, "D2(0)-D2(3)", 1e-14, o.D2(0), o.D2(3))
+}
+
+func TestInterp05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interp05. Akima and Pchip")
+
+	// monotone data with a flat region
+	xx := []float64{0, 1, 2, 3, 4, 5, 6, 7}
+	yy := []float64{0, 0.1, 0.1, 0.1, 0.5, 2.0, 2.1, 2.15}
+
+	if chk.Verbose {
+		plt.Reset(true, &plt.A{WidthPt: 400, Dpi: 150})
+		plt.Plot(xx, yy, &plt.A{C: "k", Ls: "none", M: "o", L: "data", NoClip: true})
+	}
+
+	X := utl.LinSpace(0, 7, 281)
+	for _, kind := range []io.Enum{AkimaInterpKind, PchipInterpKind, CubicNaturalInterpKind} {
+		o, err := NewInterpolator(kind, 0, xx, yy)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i, x := range xx {
+			chk.Scalar(tst, "P(xi)", 1e-15, o.P(x), yy[i])
+		}
+		monotone := true
+		for i := 1; i < len(X); i++ {
+			if o.P(X[i]) < o.P(X[i-1])-1e-15 {
+				monotone = false
+			}
+		}
+		io.Pforan("%-14v monotone = %v\n", kind, monotone)
+		switch kind {
+		case PchipInterpKind:
+			if !monotone {
+				tst.Errorf("Pchip must preserve monotonicity\n")
+			}
+		case CubicNaturalInterpKind:
+			if monotone {
+				tst.Errorf("natural spline should overshoot with these data\n")
+			}
+		case AkimaInterpKind: // Akima is flat where three consecutive points are collinear
+			for _, x := range utl.LinSpace(1, 3, 11) {
+				chk.Scalar(tst, "P(flat)", 1e-15, o.P(x), 0.1)
+			}
+		}
+		for _, x := range []float64{0.3, 2.7, 4.4, 6.9} {
+			chk.DerivScaSca(tst, "D1", 1e-9, o.D1(x), x, 1e-3, chk.Verbose, func(t float64) (float64, error) {
+				return o.P(t), nil
+			})
+			chk.DerivScaSca(tst, "D2", 1e-8, o.D2(x), x, 1e-3, chk.Verbose, func(t float64) (float64, error) {
+				return o.D1(t), nil
+			})
+		}
+		if chk.Verbose {
+			Y := utl.GetMapped(X, func(x float64) float64 { return o.P(x) })
+			plt.Plot(X, Y, &plt.A{Ls: "-", L: kind.String(), NoClip: true})
+		}
+	}
+
+	// Pchip slope at local extremum is zero
+	o, err := NewInterpolator(PchipInterpKind, 0, []float64{0, 1, 2.5, 3}, []float64{0, 1, 0.2, 0.1})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "D1(1)", 1e-15, o.D1(1), 0)
+
+	if chk.Verbose {
+		plt.Gll("x", "y", nil)
+		plt.HideTRborders()
+		plt.Save("/tmp/gosl/fun", "interp05")
+	}
+}
+
+func TestInterp06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interp06. smoothing spline and derivatives of linear")
+
+	xx := []float64{0, 0.5, 1.2, 2, 2.4, 3.1, 4, 5}
+	yy := []float64{0.1, 0.8, 1.1, 2.2, 2.3, 3.4, 3.9, 5.2}
+
+	// λ = 0 gives the natural spline
+	o, err := NewInterpolator(SmoothingSplineInterpKind, 0, xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	nat, err := NewInterpolator(CubicNaturalInterpKind, 0, xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, x := range utl.LinSpace(0, 5, 21) {
+		chk.Scalar(tst, "P(λ=0)", 1e-14, o.P(x), nat.P(x))
+		chk.Scalar(tst, "D1(λ=0)", 1e-13, o.D1(x), nat.D1(x))
+	}
+
+	// large λ gives the least-squares line
+	err = o.SetSmoothing(1e12, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	n := float64(len(xx))
+	var sx, sy, sxx, sxy float64
+	for i, x := range xx {
+		sx += x
+		sy += yy[i]
+		sxx += x * x
+		sxy += x * yy[i]
+	}
+	slope := (n*sxy - sx*sy) / (n*sxx - sx*sx)
+	icept := (sy - slope*sx) / n
+	for _, x := range utl.LinSpace(0, 5, 21) {
+		chk.AnaNum(tst, "P(λ=∞)", 1e-8, o.P(x), icept+slope*x, false)
+	}
+
+	// intermediate λ reduces roughness; large weight pulls the curve to the data point
+	w := []float64{1, 1, 1, 1e8, 1, 1, 1, 1}
+	err = o.SetSmoothing(0.5, w)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "P(x3)", 1e-7, o.P(xx[3]), yy[3])
+	for i := 1; i < len(xx)-1; i++ {
+		chk.Scalar(tst, "D1 jump", 1e-10, o.D1(xx[i]-1e-12), o.D1(xx[i]+1e-12))
+	}
+	chk.Scalar(tst, "D2(x0)", 1e-13, o.D2(xx[0]), 0)
+	if o.SetSmoothing(-1, nil) == nil {
+		tst.Errorf("negative λ should have caused an error\n")
+	}
+	if o.SetSmoothing(1, []float64{1, 2}) == nil {
+		tst.Errorf("wrong number of weights should have caused an error\n")
+	}
+
+	// linear interpolator
+	lin, err := NewInterpolator(LinearInterpKind, 1, []float64{0, 1, 3}, []float64{0, 2, 1})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "D1(0.5)", 1e-15, lin.D1(0.5), 2)
+	chk.Scalar(tst, "D1(2)", 1e-15, lin.D1(2), -0.5)
+	chk.Scalar(tst, "D2(2)", 1e-15, lin.D2(2), 0)
+	chk.Scalar(tst, "∫", 1e-15, lin.Integral(0, 3), 1+3)
+	chk.Scalar(tst, "∫", 1e-15, lin.Integral(0.5, 2), 0.75+1.75)
+}
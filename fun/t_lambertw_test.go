@@ -0,0 +1,49 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestLambertW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LambertW01. Lambert W function")
+
+	_, dat, err := io.ReadTable("data/hp-lambertw.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		chkRel(tst, io.Sf("W0(%g)", x), 1e-15, 1e-300, LambertW(x), dat["W"][i])
+	}
+
+	_, dat, err = io.ReadTable("data/hp-lambertwm1.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		chkRel(tst, io.Sf("W-1(%g)", x), 1e-15, 0, LambertWm1(x), dat["W"][i])
+	}
+
+	// definition and special values
+	for _, x := range []float64{-0.2, 0.01, 2, 50, 1e10} {
+		w := LambertW(x)
+		chkRel(tst, io.Sf("W(%g)exp(W(%g))", x, x), 1e-14, 1e-16, w*math.Exp(w), x)
+	}
+	chk.Scalar(tst, "W0(e)", 1e-15, LambertW(math.E), 1)
+	chk.Scalar(tst, "W0(-1/e)", 1e-15, LambertW(-1/math.E), -1)
+	chk.Scalar(tst, "W-1(-1/e)", 1e-15, LambertWm1(-1/math.E), -1)
+	if !math.IsNaN(LambertW(-0.5)) || !math.IsNaN(LambertWm1(0.5)) || !math.IsInf(LambertWm1(0), -1) {
+		tst.Errorf("W0(-0.5) and W-1(0.5) should be NaN and W-1(0) should be -Inf\n")
+	}
+}
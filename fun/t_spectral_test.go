@@ -0,0 +1,162 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestSpectral01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral01. Lobatto grids")
+
+	// Chebyshev-Gauss-Lobatto
+	o, err := NewLagrangeInterp(4, ChebyGaussLobattoGridKind)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Vector(tst, "X(CGL)", 1e-15, o.X, []float64{-1, -1 / math.Sqrt2, 0, 1 / math.Sqrt2, 1})
+
+	// Legendre-Gauss-Lobatto
+	o, err = NewLagrangeInterp(4, LegendreGaussLobattoGridKind)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	a := math.Sqrt(3.0 / 7.0)
+	chk.Vector(tst, "X(LGL)", 1e-15, o.X, []float64{-1, -a, 0, a, 1})
+	o, _ = NewLagrangeInterp(5, LegendreGaussLobattoGridKind)
+	b, c := math.Sqrt(1.0/3.0-2.0*math.Sqrt(7)/21.0), math.Sqrt(1.0/3.0+2.0*math.Sqrt(7)/21.0)
+	chk.Vector(tst, "X(LGL)", 1e-15, o.X, []float64{-1, -c, -b, b, c, 1})
+
+	// barycentric weights of CGL grid are proportional to the generic ones
+	o, _ = NewLagrangeInterp(6, ChebyGaussLobattoGridKind)
+	λ := o.BaryWeights()
+	o.Grid = UniformGridKind // force generic formula
+	λg := o.BaryWeights()
+	for j := range λ {
+		chk.Scalar(tst, "λ/λg", 1e-13, λ[j]/λg[j], λ[0]/λg[0])
+	}
+
+	// errors
+	_, err = NewLagrangeInterp(0, ChebyGaussLobattoGridKind)
+	if err == nil {
+		tst.Errorf("NewLagrangeInterp should have failed with N=0\n")
+	}
+}
+
+func TestSpectral02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral02. Chebyshev and Legendre differentiation matrices")
+
+	// polynomials are differentiated exactly
+	f := func(x float64) float64 { return x*x*x*x*x - 2*x*x + 1 }
+	df := []func(x float64) float64{
+		func(x float64) float64 { return 5*x*x*x*x - 4*x },
+		func(x float64) float64 { return 20*x*x*x - 4 },
+		func(x float64) float64 { return 60 * x * x },
+	}
+	for _, kind := range []io.Enum{ChebyGaussLobattoGridKind, LegendreGaussLobattoGridKind} {
+		o, _ := NewLagrangeInterp(7, kind)
+		u := make([]float64, o.N+1)
+		for i, x := range o.X {
+			u[i] = f(x)
+		}
+		for m := 1; m <= 3; m++ {
+			D, err := o.DiffMatrix(m)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			v := make([]float64, o.N+1)
+			la.MatVecMul(v, 1, D, u)
+			for i, x := range o.X {
+				chk.Scalar(tst, io.Sf("%s: D%d⋅u", kind, m), 1e-11, v[i], df[m-1](x))
+			}
+		}
+	}
+
+	// smooth function: spectral accuracy
+	o, _ := NewLagrangeInterp(24, ChebyGaussLobattoGridKind)
+	D, _ := o.DiffMatrix(1)
+	u := make([]float64, o.N+1)
+	for i, x := range o.X {
+		u[i] = math.Exp(x) * math.Sin(5*x)
+	}
+	v := make([]float64, o.N+1)
+	la.MatVecMul(v, 1, D, u)
+	for i, x := range o.X {
+		chk.Scalar(tst, "D⋅exp(x)sin(5x)", 1e-10, v[i], math.Exp(x)*(math.Sin(5*x)+5*math.Cos(5*x)))
+	}
+
+	// errors
+	_, err := o.DiffMatrix(0)
+	if err == nil {
+		tst.Errorf("DiffMatrix should have failed with m=0\n")
+	}
+}
+
+func TestSpectral03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral03. Fourier differentiation matrices")
+
+	// second derivative: diagonal is -π²/(3h²) - 1/6
+	N := 16
+	h := 2 * math.Pi / float64(N)
+	_, D2, err := FourierDiffMatrix(N, 2)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "D2[0][0]", 1e-12, D2[0][0], -math.Pi*math.Pi/(3*h*h)-1.0/6.0)
+	chk.Scalar(tst, "D2[0][1]", 1e-12, D2[0][1], 0.5/math.Pow(math.Sin(h/2), 2))
+
+	// derivatives of exp(sin(x)) with even and odd N
+	for _, N := range []int{32, 33} {
+		for m := 1; m <= 3; m++ {
+			X, D, err := FourierDiffMatrix(N, m)
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			u := make([]float64, N)
+			for i, x := range X {
+				u[i] = math.Exp(math.Sin(x))
+			}
+			v := make([]float64, N)
+			la.MatVecMul(v, 1, D, u)
+			for i, x := range X {
+				s, c := math.Sin(x), math.Cos(x)
+				var ana float64
+				switch m {
+				case 1:
+					ana = c
+				case 2:
+					ana = c*c - s
+				case 3:
+					ana = c*c*c - 3*s*c - c
+				}
+				chk.Scalar(tst, io.Sf("N=%d: D%d⋅u", N, m), 1e-10, v[i], ana*u[i])
+			}
+		}
+	}
+
+	// first derivative is antisymmetric
+	_, D1, _ := FourierDiffMatrix(10, 1)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			chk.Scalar(tst, "D1+D1ᵀ", 1e-14, D1[i][j]+D1[j][i], 0)
+		}
+	}
+}
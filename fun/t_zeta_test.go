@@ -0,0 +1,83 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestDigamma01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Digamma01. digamma and polygamma functions")
+
+	_, dat, err := io.ReadTable("data/hp-digamma.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, x := range dat["x"] {
+		chkRel(tst, io.Sf("ψ(%g)", x), 1e-14, 1e-15, Digamma(x), dat["psi"][i])
+	}
+
+	_, dat, err = io.ReadTable("data/hp-polygamma.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, n := range dat["n"] {
+		x := dat["x"][i]
+		chkRel(tst, io.Sf("ψ⁽%d⁾(%g)", int(n), x), 1e-13, 0, Polygamma(int(n), x), dat["psin"][i])
+	}
+
+	// recurrence for negative x
+	x := -2.3
+	chk.Scalar(tst, "ψ'(x)", 1e-12, Polygamma(1, x), Polygamma(1, x+3)+1/(x*x)+1/((x+1)*(x+1))+1/((x+2)*(x+2)))
+	chk.Scalar(tst, "ψ⁽⁰⁾", 1e-15, Polygamma(0, 2.5), Digamma(2.5))
+	if !math.IsNaN(Digamma(-2)) || !math.IsNaN(Polygamma(1, 0)) || !math.IsNaN(Polygamma(-1, 1)) {
+		tst.Errorf("poles and invalid n should give NaN\n")
+	}
+}
+
+func TestZeta01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Zeta01. Riemann and Hurwitz zeta functions")
+
+	_, dat, err := io.ReadTable("data/hp-zeta.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, s := range dat["s"] {
+		chkRel(tst, io.Sf("ζ(%g)", s), 1e-13, 1e-16, Zeta(s), dat["zeta"][i])
+	}
+
+	_, dat, err = io.ReadTable("data/hp-hurwitz.cmp")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for i, s := range dat["s"] {
+		q := dat["q"][i]
+		atol := 1e-15
+		if s < 0 {
+			atol = 1e-12 // cancellation in the direct summation
+		}
+		chkRel(tst, io.Sf("ζ(%g,%g)", s, q), 1e-13, atol, HurwitzZeta(s, q), dat["zeta"][i])
+	}
+
+	// special values
+	chk.Scalar(tst, "ζ(2)", 1e-15, Zeta(2), math.Pi*math.Pi/6)
+	chk.Scalar(tst, "ζ(-2)", 1e-15, Zeta(-2), 0)
+	chk.Scalar(tst, "ζ(2,1/2)", 1e-14, HurwitzZeta(2, 0.5), 3*Zeta(2))
+	if !math.IsInf(Zeta(1), 1) || !math.IsNaN(HurwitzZeta(2, -1)) {
+		tst.Errorf("ζ(1) should be +Inf and ζ(s,q≤0) should be NaN\n")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// zetaA holds (2j)! / B_{2j} for the Euler-Maclaurin summation; B_{2j} are Bernoulli numbers
+var zetaA = []float64{
+	12.0,
+	-720.0,
+	30240.0,
+	-1209600.0,
+	47900160.0,
+	-1.8924375803183791606e9,
+	7.47242496e10,
+	-2.950130727918164224e12,
+	1.1646782814350067249e14,
+	-4.5979787224074726105e15,
+	1.8152105401943546773e17,
+	-7.1661652561756670113e18,
+}
+
+// Zeta computes the Riemann zeta function
+//
+//             ∞    1
+//      ζ(s) = Σ  ―――――      (s > 1 and analytic continuation elsewhere)
+//            k=1   kˢ
+//
+//   NOTE: the functional equation ζ(s) = 2ˢ πˢ⁻¹ sin(π s / 2) Γ(1-s) ζ(1-s) is used for s < 0.
+//         Zeta(1) = +Inf
+func Zeta(s float64) float64 {
+	if math.IsNaN(s) {
+		return s
+	}
+	if s < 0 {
+		if math.IsInf(s, -1) {
+			return math.NaN()
+		}
+		sn := sinpi(s / 2.0)
+		if sn == 0 {
+			return 0 // trivial zeros
+		}
+		lg, sg := math.Lgamma(1.0 - s)
+		return float64(sg) * sn * math.Exp(s*math.Ln2+(s-1.0)*math.Log(math.Pi)+lg) * Zeta(1.0-s)
+	}
+	return HurwitzZeta(s, 1)
+}
+
+// HurwitzZeta computes the Hurwitz zeta function
+//
+//                  ∞      1
+//      ζ(s, q) =   Σ  ――――――――      (q > 0)
+//                 k=0  (k+q)ˢ
+//
+//   NOTE: (1) the function is evaluated by the Euler-Maclaurin summation formula after shifting q
+//             to q+N ≥ 10; the accuracy is reduced for s ≪ 0
+//         (2) returns NaN if q ≤ 0 and +Inf if s = 1
+func HurwitzZeta(s, q float64) float64 {
+	if math.IsNaN(s) || math.IsNaN(q) || q <= 0 {
+		return math.NaN()
+	}
+	if s == 1 {
+		return math.Inf(1)
+	}
+	if math.IsInf(s, 1) {
+		switch {
+		case q < 1:
+			return math.Inf(1)
+		case q == 1:
+			return 1
+		}
+		return 0
+	}
+
+	// direct summation
+	sum := 0.0
+	a := q
+	for k := 0; k < 9 || a < 10; k++ {
+		b := math.Pow(a, -s)
+		sum += b
+		a++
+		if math.Abs(b) < incgEps*math.Abs(sum) {
+			return sum
+		}
+	}
+
+	// Euler-Maclaurin tail
+	b := math.Pow(a, -s)
+	sum += a*b/(s-1.0) + 0.5*b
+	f := s * b / a
+	for j := 0; j < len(zetaA); j++ {
+		t := f / zetaA[j]
+		sum += t
+		if math.Abs(t) < incgEps*math.Abs(sum) {
+			break
+		}
+		f *= (s + float64(2*j+1)) * (s + float64(2*j+2)) / (a * a)
+	}
+	return sum
+}
@@ -172,6 +172,60 @@ func (o Bins) FindClosest(x []float64) (idClosest int, sqDistMin float64) {
 	return
 }
 
+// FindWithinRadius returns the ids of all entries whose distances to x are smaller than or equal
+// to radius. All bins overlapping the box [x-radius, x+radius] are searched
+//   NOTE: x may be outside the range of the bins. An empty result is returned if the bins have
+//         been cleared or not initialised
+func (o Bins) FindWithinRadius(x []float64, radius float64) (ids []int) {
+
+	// check
+	if len(o.All) == 0 {
+		return
+	}
+
+	// range of bins
+	lo, hi := []int{0, 0, 0}, []int{0, 0, 0}
+	for k := 0; k < o.Ndim; k++ {
+		a := math.Floor((x[k] - radius - o.Xmin[k]) / o.Size[k])
+		b := math.Floor((x[k] + radius - o.Xmin[k]) / o.Size[k])
+		if b < 0 || a > float64(o.Npts[k]-1) { // out-of-range
+			return
+		}
+		lo[k] = utl.Imax(0, int(a))
+		hi[k] = utl.Imin(o.Npts[k]-1, int(b))
+	}
+
+	// search bins
+	r2 := radius * radius
+	for k := lo[2]; k <= hi[2]; k++ {
+		for j := lo[1]; j <= hi[1]; j++ {
+			for i := lo[0]; i <= hi[0]; i++ {
+				idx := i + j*o.Npts[0]
+				if o.Ndim > 2 {
+					idx += k * o.Npts[0] * o.Npts[1]
+				}
+				if idx >= len(o.All) {
+					continue
+				}
+				bin := o.All[idx]
+				if bin == nil {
+					continue
+				}
+				for _, entry := range bin.Entries {
+					var d float64
+					for m := 0; m < o.Ndim; m++ {
+						d += (x[m] - entry.X[m]) * (x[m] - entry.X[m])
+					}
+					if d <= r2 {
+						ids = append(ids, entry.Id)
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
 // FindClosestAndAppend finds closest point and, if not found, append to bins with a new Id
 //   Input:
 //     nextId -- is the Id of the next point. Will be incremented if x is a new point to be added.
@@ -6,6 +6,7 @@ package gm
 
 import (
 	"math"
+	"sort"
 	"testing"
 
 	"math/rand"
@@ -505,6 +506,66 @@ func Test_bins06(tst *testing.T) {
 	}
 }
 
+func Test_bins07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bins07. find within radius")
+
+	// random points in 2D and 3D
+	rand.Seed(1234)
+	for ndim := 2; ndim <= 3; ndim++ {
+		var bins Bins
+		xmin, xmax := make([]float64, ndim), make([]float64, ndim)
+		for k := 0; k < ndim; k++ {
+			xmax[k] = 1
+		}
+		bins.Init(xmin, xmax, 7)
+		npts := 300
+		X := make([][]float64, npts)
+		for i := 0; i < npts; i++ {
+			X[i] = make([]float64, ndim)
+			for k := 0; k < ndim; k++ {
+				X[i][k] = rand.Float64()
+			}
+			err := bins.Append(X[i], i, nil)
+			if err != nil {
+				tst.Errorf("%v", err)
+				return
+			}
+		}
+
+		// compare with brute force search (including points outside the box)
+		for _, r := range []float64{0.05, 0.2, 0.5} {
+			for _, x := range [][]float64{{0.5, 0.5, 0.5}, {0.01, 0.99, 0.3}, {-0.1, 0.5, 1.1}, {3, 3, 3}} {
+				ids := bins.FindWithinRadius(x, r)
+				var correct []int
+				for i := 0; i < npts; i++ {
+					var d float64
+					for k := 0; k < ndim; k++ {
+						d += math.Pow(x[k]-X[i][k], 2)
+					}
+					if d <= r*r {
+						correct = append(correct, i)
+					}
+				}
+				sort.Ints(ids)
+				io.Pforan("ndim=%d r=%g x=%v: %d entries\n", ndim, r, x[:ndim], len(ids))
+				chk.Ints(tst, "ids", ids, correct)
+			}
+		}
+
+		// cleared bins
+		bins.Clear()
+		ids := bins.FindWithinRadius([]float64{0.5, 0.5, 0.5}, 0.5)
+		chk.Int(tst, "number of ids after Clear", len(ids), 0)
+	}
+
+	// uninitialised bins
+	var empty Bins
+	ids := empty.FindWithinRadius([]float64{0.5, 0.5}, 0.5)
+	chk.Int(tst, "number of ids with uninitialised bins", len(ids), 0)
+}
+
 // auxiliary /////////////////////////////////////////////////////////////////////////////////////
 
 // entries is a map with the ids of each entry in each bin: maps binId => entries ids
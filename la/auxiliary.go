@@ -29,3 +29,10 @@ func max(a, b float64) float64 {
 	}
 	return b
 }
+func irange(n int) (r []int) {
+	r = make([]int, n)
+	for i := 0; i < n; i++ {
+		r[i] = i
+	}
+	return
+}
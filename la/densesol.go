@@ -103,8 +103,139 @@ func SPDsolve2(x, X []float64, a [][]float64, b, B []float64) (err error) {
 	return
 }
 
+// DenseLU holds the LU factorisation (with partial pivoting) of a small dense square matrix
+//  NOTE: this structure should be used for small systems only
+type DenseLU struct {
+	lu   [][]float64 // factors
+	piv  []int       // pivots
+	sign float64     // sign of permutation
+}
+
+// Factor computes the LU factorisation of "a". The matrix "a" is not modified
+//  NOTE: storage is reused if Factor is called again with a matrix of the same size
+func (o *DenseLU) Factor(a [][]float64) (err error) {
+	n := len(a)
+	if len(o.lu) != n {
+		o.lu = MatAlloc(n, n)
+		o.piv = make([]int, n)
+	}
+	MatCopy(o.lu, 1, a)
+	o.sign = 1
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(o.lu[i][k]) > math.Abs(o.lu[p][k]) {
+				p = i
+			}
+		}
+		o.piv[k] = p
+		if p != k {
+			o.lu[p], o.lu[k] = o.lu[k], o.lu[p]
+			o.sign = -o.sign
+		}
+		if o.lu[k][k] == 0 {
+			return chk.Err(_densesol_err3)
+		}
+		for i := k + 1; i < n; i++ {
+			o.lu[i][k] /= o.lu[k][k]
+			for j := k + 1; j < n; j++ {
+				o.lu[i][j] -= o.lu[i][k] * o.lu[k][j]
+			}
+		}
+	}
+	return
+}
+
+// Solve solves a x = b using the factorisation computed by Factor
+//  NOTE: x and b may be the same slice
+func (o *DenseLU) Solve(x, b []float64) {
+	n := len(o.lu)
+	copy(x, b)
+	for k := 0; k < n; k++ {
+		if p := o.piv[k]; p != k {
+			x[p], x[k] = x[k], x[p]
+		}
+	}
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			x[i] -= o.lu[i][k] * x[k]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			x[i] -= o.lu[i][k] * x[k]
+		}
+		x[i] /= o.lu[i][i]
+	}
+}
+
+// SolveMat solves a X = B for multiple right-hand sides given as the columns of B
+//  NOTE: X and B may be the same matrix
+func (o *DenseLU) SolveMat(X, B [][]float64) {
+	n := len(o.lu)
+	if &X[0][0] != &B[0][0] {
+		MatCopy(X, 1, B)
+	}
+	for k := 0; k < n; k++ {
+		if p := o.piv[k]; p != k {
+			for j := 0; j < len(X[k]); j++ {
+				X[p][j], X[k][j] = X[k][j], X[p][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			for j := 0; j < len(X[i]); j++ {
+				X[i][j] -= o.lu[i][k] * X[k][j]
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			for j := 0; j < len(X[i]); j++ {
+				X[i][j] -= o.lu[i][k] * X[k][j]
+			}
+		}
+		for j := 0; j < len(X[i]); j++ {
+			X[i][j] /= o.lu[i][i]
+		}
+	}
+}
+
+// Inverse computes the inverse matrix ai := inv(a) using the factorisation computed by Factor
+func (o *DenseLU) Inverse(ai [][]float64) {
+	MatFill(ai, 0)
+	for i := 0; i < len(ai); i++ {
+		ai[i][i] = 1
+	}
+	o.SolveMat(ai, ai)
+}
+
+// Det returns the determinant of the factorised matrix
+func (o *DenseLU) Det() (res float64) {
+	res = o.sign
+	for i := 0; i < len(o.lu); i++ {
+		res *= o.lu[i][i]
+	}
+	return
+}
+
+// DenseSolve solves the small dense linear system a x = b using LU factorisation with partial
+// pivoting. The matrix "a" and vector "b" are not modified
+//  NOTE: x and b may be the same slice
+func DenseSolve(x []float64, a [][]float64, b []float64) (err error) {
+	var lu DenseLU
+	err = lu.Factor(a)
+	if err != nil {
+		return
+	}
+	lu.Solve(x, b)
+	return
+}
+
 // error messages
 var (
 	_densesol_err1 = "densesol.go: Cholesky factorization failed due to non positive-definite matrix"
 	_densesol_err2 = "densesol.go: SymPDsolve failed: %s"
+	_densesol_err3 = "densesol.go: LU factorisation failed due to singular matrix"
 )
@@ -0,0 +1,225 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// HessenbergEigen computes all eigenvalues of a real upper Hessenberg matrix by means of balancing
+// and the Francis double-shift QR algorithm
+//  Input:
+//   a -- [n][n] upper Hessenberg matrix. NOTE: a is destroyed
+//  Output:
+//   λ -- [n] eigenvalues. Complex conjugate pairs are stored in consecutive positions
+//  Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func HessenbergEigen(a [][]float64) (λ []complex128, err error) {
+	hessenbergBalance(a)
+	n := len(a)
+	λ = make([]complex128, n)
+	anorm := 0.0
+	for i := 0; i < n; i++ {
+		for j := imax(i-1, 0); j < n; j++ {
+			anorm += math.Abs(a[i][j])
+		}
+	}
+	var l, m, its int
+	var z, y, x, w, v, u, t, s, r, q, p float64
+	nn := n - 1
+	for nn >= 0 {
+		its = 0
+		for {
+			for l = nn; l > 0; l-- {
+				s = math.Abs(a[l-1][l-1]) + math.Abs(a[l][l])
+				if s == 0 {
+					s = anorm
+				}
+				if math.Abs(a[l][l-1]) <= macheps*s {
+					a[l][l-1] = 0
+					break
+				}
+			}
+			x = a[nn][nn]
+			if l == nn { // one root
+				λ[nn] = complex(x+t, 0)
+				nn--
+			} else {
+				y = a[nn-1][nn-1]
+				w = a[nn][nn-1] * a[nn-1][nn]
+				if l == nn-1 { // two roots
+					p = 0.5 * (y - x)
+					q = p*p + w
+					z = math.Sqrt(math.Abs(q))
+					x += t
+					if q >= 0 {
+						z = p + math.Copysign(z, p)
+						λ[nn-1] = complex(x+z, 0)
+						λ[nn] = λ[nn-1]
+						if z != 0 {
+							λ[nn] = complex(x-w/z, 0)
+						}
+					} else {
+						λ[nn] = complex(x+p, -z)
+						λ[nn-1] = cmplx.Conj(λ[nn])
+					}
+					nn -= 2
+				} else { // no roots found yet
+					if its == 30 {
+						return nil, chk.Err(_hessenberg_err1, its)
+					}
+					if its == 10 || its == 20 { // exceptional shift
+						t += x
+						for i := 0; i < nn+1; i++ {
+							a[i][i] -= x
+						}
+						s = math.Abs(a[nn][nn-1]) + math.Abs(a[nn-1][nn-2])
+						x = 0.75 * s
+						y = x
+						w = -0.4375 * s * s
+					}
+					its++
+					for m = nn - 2; m >= l; m-- {
+						z = a[m][m]
+						r = x - z
+						s = y - z
+						p = (r*s-w)/a[m+1][m] + a[m][m+1]
+						q = a[m+1][m+1] - z - r - s
+						r = a[m+2][m+1]
+						s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+						p /= s
+						q /= s
+						r /= s
+						if m == l {
+							break
+						}
+						u = math.Abs(a[m][m-1]) * (math.Abs(q) + math.Abs(r))
+						v = math.Abs(p) * (math.Abs(a[m-1][m-1]) + math.Abs(z) + math.Abs(a[m+1][m+1]))
+						if u <= macheps*v {
+							break
+						}
+					}
+					for i := m; i < nn-1; i++ {
+						a[i+2][i] = 0
+						if i != m {
+							a[i+2][i-1] = 0
+						}
+					}
+					for k := m; k < nn; k++ {
+						if k != m {
+							p = a[k][k-1]
+							q = a[k+1][k-1]
+							r = 0
+							if k+1 != nn {
+								r = a[k+2][k-1]
+							}
+							x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+							if x != 0 {
+								p /= x
+								q /= x
+								r /= x
+							}
+						}
+						s = math.Copysign(math.Sqrt(p*p+q*q+r*r), p)
+						if s != 0 {
+							if k == m {
+								if l != m {
+									a[k][k-1] = -a[k][k-1]
+								}
+							} else {
+								a[k][k-1] = -s * x
+							}
+							p += s
+							x = p / s
+							y = q / s
+							z = r / s
+							q /= p
+							r /= p
+							for j := k; j < nn+1; j++ {
+								p = a[k][j] + q*a[k+1][j]
+								if k+1 != nn {
+									p += r * a[k+2][j]
+									a[k+2][j] -= p * z
+								}
+								a[k+1][j] -= p * y
+								a[k][j] -= p * x
+							}
+							mmin := imin(nn, k+3)
+							for i := l; i < mmin+1; i++ {
+								p = x*a[i][k] + y*a[i][k+1]
+								if k+1 != nn {
+									p += z * a[i][k+2]
+									a[i][k+2] -= p * r
+								}
+								a[i][k+1] -= p * q
+								a[i][k] -= p
+							}
+						}
+					}
+				}
+			}
+			if l+1 >= nn {
+				break
+			}
+		}
+	}
+	return
+}
+
+// hessenbergBalance balances the matrix H in place (similarity transformation with powers of 2)
+func hessenbergBalance(H [][]float64) {
+	radix := 2.0
+	sqrdx := radix * radix
+	n := len(H)
+	done := false
+	for !done {
+		done = true
+		for i := 0; i < n; i++ {
+			r, c := 0.0, 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					c += math.Abs(H[j][i])
+					r += math.Abs(H[i][j])
+				}
+			}
+			if c != 0 && r != 0 {
+				g := r / radix
+				f := 1.0
+				s := c + r
+				for c < g {
+					f *= radix
+					c *= sqrdx
+				}
+				g = r * radix
+				for c > g {
+					f /= radix
+					c /= sqrdx
+				}
+				if (c+r)/f < 0.95*s {
+					done = false
+					g = 1.0 / f
+					for j := 0; j < n; j++ {
+						H[i][j] *= g
+					}
+					for j := 0; j < n; j++ {
+						H[j][i] *= f
+					}
+				}
+			}
+		}
+	}
+}
+
+// macheps is the smallest number satisfying 1 + macheps > 1
+var macheps = math.Nextafter(1, 2) - 1.0
+
+// error messages
+var (
+	_hessenberg_err1 = "hessenberg.go: HessenbergEigen: QR algorithm did not converge after %d iterations"
+)
@@ -15,7 +15,7 @@ package la
 func SolveRealLinSys(A *Triplet, b []float64) (x []float64, err error) {
 
 	// allocate solver
-	lis := GetSolver("umfpack")
+	lis := GetSolver(DefaultSolver)
 	defer lis.Free()
 
 	// info
@@ -53,7 +53,7 @@ func SolveRealLinSys(A *Triplet, b []float64) (x []float64, err error) {
 func SolveComplexLinSys(A *TripletC, b []complex128) (x []complex128, err error) {
 
 	// allocate solver
-	lis := GetSolver("umfpack")
+	lis := GetSolver(DefaultSolver)
 	defer lis.Free()
 
 	// info
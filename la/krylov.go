@@ -0,0 +1,273 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// LinOp defines linear operators; e.g. sparse or block matrices
+type LinOp interface {
+	MatVec(v, u []float64) // computes v := A * u
+}
+
+// Precond defines preconditioners; i.e. operators approximating inv(A)
+type Precond interface {
+	Apply(z, r []float64) error // computes z := inv(M) * r
+}
+
+// MatVec computes v := a * u and implements the LinOp interface
+func (a *CCMatrix) MatVec(v, u []float64) {
+	SpMatVecMul(v, 1, a, u)
+}
+
+// MatVec computes v := a * u and implements the LinOp interface
+func (a *CSRMatrix) MatVec(v, u []float64) {
+	SpCSRMatVecMul(v, 1, a, u)
+}
+
+// Krylov holds the parameters and results of the iterative (Krylov subspace) solvers
+//  NOTE: the zero value is ready to use with the default parameters
+type Krylov struct {
+
+	// input
+	Tol     float64 // relative tolerance: ‖b - A x‖ ≤ Tol ‖b‖ [default = 1e-10]
+	MaxIt   int     // maximum number of iterations [default = 2 n + 10]
+	Restart int     // number of iterations before restarting GMRES [default = min(n, 50)]
+	Verbose bool    // show residuals
+
+	// dot product; e.g. DistDot for distributed vectors [default = VecDot]
+	//  NOTE: n in the defaults above is the global dimension computed with Dot; thus, all
+	//        processors run the same number of iterations even with uneven partitions
+	Dot func(u, v []float64) float64
+
+	// output
+	NumIt int     // number of iterations performed
+	Resid float64 // final relative residual ‖b - A x‖ / ‖b‖
+}
+
+// Cg solves the symmetric positive-definite system A * x = b with the preconditioned
+// conjugate gradient method
+//  INPUT:
+//   x -- initial guess
+//   b -- right-hand side
+//   a -- symmetric positive-definite operator
+//   m -- symmetric positive-definite preconditioner; may be nil
+//  OUTPUT:
+//   x -- updated solution
+func (o *Krylov) Cg(x, b []float64, a LinOp, m Precond) (err error) {
+
+	// auxiliary
+	n := len(b)
+	tol, maxit, _ := o.defaults(n)
+	o.NumIt, o.Resid = 0, 0
+	bnrm := o.norm(b)
+	if bnrm == 0 {
+		VecFill(x, 0)
+		return
+	}
+
+	// residual
+	r, z, p, q := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	a.MatVec(q, x)
+	VecAdd2(r, 1, b, -1, q)
+	o.Resid = o.norm(r) / bnrm
+	if o.Resid <= tol {
+		return
+	}
+	err = krylovPrecond(m, z, r)
+	if err != nil {
+		return
+	}
+	copy(p, z)
+	rz := o.dot(r, z)
+
+	// iterations
+	for o.NumIt < maxit {
+		o.NumIt++
+		a.MatVec(q, p)
+		pq := o.dot(p, q)
+		if pq == 0 {
+			return chk.Err(_krylov_err2, o.NumIt)
+		}
+		α := rz / pq
+		VecAdd(x, α, p)
+		VecAdd(r, -α, q)
+		o.Resid = o.norm(r) / bnrm
+		if o.Verbose {
+			io.Pf("Cg: it = %4d  resid = %23.15e\n", o.NumIt, o.Resid)
+		}
+		if o.Resid <= tol {
+			return
+		}
+		err = krylovPrecond(m, z, r)
+		if err != nil {
+			return
+		}
+		rznew := o.dot(r, z)
+		β := rznew / rz
+		rz = rznew
+		for i := 0; i < n; i++ {
+			p[i] = z[i] + β*p[i]
+		}
+	}
+	return chk.Err(_krylov_err1, "Cg", o.NumIt, o.Resid)
+}
+
+// Gmres solves the system A * x = b with the restarted and (right) preconditioned GMRES
+// method. The flexible variant is employed; thus, the preconditioner may change from one
+// iteration to another; e.g. when it contains an inner iterative solver
+//  INPUT:
+//   x -- initial guess
+//   b -- right-hand side
+//   a -- operator
+//   m -- preconditioner; may be nil
+//  OUTPUT:
+//   x -- updated solution
+func (o *Krylov) Gmres(x, b []float64, a LinOp, m Precond) (err error) {
+
+	// auxiliary
+	n := len(b)
+	tol, maxit, restart := o.defaults(n)
+	o.NumIt, o.Resid = 0, 0
+	bnrm := o.norm(b)
+	if bnrm == 0 {
+		VecFill(x, 0)
+		return
+	}
+
+	// workspace
+	v := MatAlloc(restart+1, n) // orthonormal basis
+	z := MatAlloc(restart, n)   // preconditioned basis
+	h := MatAlloc(restart+1, restart)
+	cs, sn, g, y := make([]float64, restart), make([]float64, restart), make([]float64, restart+1), make([]float64, restart)
+	w := make([]float64, n)
+
+	// outer iterations
+	for {
+
+		// residual
+		a.MatVec(w, x)
+		VecAdd2(v[0], 1, b, -1, w)
+		β := o.norm(v[0])
+		o.Resid = β / bnrm
+		if o.Resid <= tol {
+			return
+		}
+		if o.NumIt >= maxit {
+			break
+		}
+		VecCopy(v[0], 1/β, v[0])
+		VecFill(g, 0)
+		g[0] = β
+
+		// Arnoldi process
+		k := 0
+		for k < restart && o.NumIt < maxit {
+			o.NumIt++
+			err = krylovPrecond(m, z[k], v[k])
+			if err != nil {
+				return
+			}
+			a.MatVec(w, z[k])
+			for i := 0; i <= k; i++ { // modified Gram-Schmidt
+				h[i][k] = o.dot(w, v[i])
+				VecAdd(w, -h[i][k], v[i])
+			}
+			h[k+1][k] = o.norm(w)
+			if h[k+1][k] > 0 {
+				VecCopy(v[k+1], 1/h[k+1][k], w)
+			}
+			for i := 0; i < k; i++ { // previous Givens rotations
+				t := cs[i]*h[i][k] + sn[i]*h[i+1][k]
+				h[i+1][k] = -sn[i]*h[i][k] + cs[i]*h[i+1][k]
+				h[i][k] = t
+			}
+			d := math.Hypot(h[k][k], h[k+1][k]) // new rotation
+			if d == 0 {
+				return chk.Err(_krylov_err2, o.NumIt)
+			}
+			cs[k], sn[k] = h[k][k]/d, h[k+1][k]/d
+			h[k][k], h[k+1][k] = d, 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+			k++
+			o.Resid = math.Abs(g[k]) / bnrm
+			if o.Verbose {
+				io.Pf("Gmres: it = %4d  resid = %23.15e\n", o.NumIt, o.Resid)
+			}
+			if o.Resid <= tol {
+				break
+			}
+		}
+
+		// update solution: solve H y = g and set x += Z y
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= h[i][j] * y[j]
+			}
+			y[i] /= h[i][i]
+		}
+		for i := 0; i < k; i++ {
+			VecAdd(x, y[i], z[i])
+		}
+	}
+	return chk.Err(_krylov_err1, "Gmres", o.NumIt, o.Resid)
+}
+
+// defaults returns the tolerance, the maximum number of iterations and the GMRES restart
+// parameter. nloc is the length of the local vectors; the global dimension is computed with
+// Dot(ones, ones) if needed
+func (o *Krylov) defaults(nloc int) (tol float64, maxit, restart int) {
+	tol, maxit, restart = o.Tol, o.MaxIt, o.Restart
+	if tol <= 0 {
+		tol = 1e-10
+	}
+	if maxit > 0 && restart > 0 {
+		return
+	}
+	ones := make([]float64, nloc)
+	VecFill(ones, 1)
+	n := int(o.dot(ones, ones) + 0.5)
+	if maxit <= 0 {
+		maxit = 2*n + 10
+	}
+	if restart <= 0 {
+		restart = imin(n, 50)
+	}
+	return
+}
+
+// dot computes the dot product u・v
+func (o *Krylov) dot(u, v []float64) float64 {
+	if o.Dot == nil {
+		return VecDot(u, v)
+	}
+	return o.Dot(u, v)
+}
+
+// norm computes the Euclidean norm of u
+func (o *Krylov) norm(u []float64) float64 {
+	return math.Sqrt(o.dot(u, u))
+}
+
+// krylovPrecond computes z := inv(M) * r or copies r into z if m is nil
+func krylovPrecond(m Precond, z, r []float64) error {
+	if m == nil {
+		copy(z, r)
+		return nil
+	}
+	return m.Apply(z, r)
+}
+
+// error messages
+var (
+	_krylov_err1 = "krylov.go: %s did not converge after %d iterations. relative residual = %g"
+	_krylov_err2 = "krylov.go: breakdown at iteration %d"
+)
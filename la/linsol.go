@@ -20,10 +20,15 @@ type LinSol interface {
 	SetOrdScal(ordering, scaling string) error                 // set ordering and scaling method
 }
 
+// DefaultSolver is the name of the linear solver used by the high-level functions and by other
+// packages (e.g. num and ode) when no specific solver is requested. It is "umfpack" if Umfpack is
+// available (cgo enabled); otherwise, it is "native"
+var DefaultSolver = "native"
+
 // lsAllocators is a "factory" for making linear solvers
 var lsAllocators = map[string]func() LinSol{} // maps solver name to solver allocator
 
-// GetSolver returns a linear solver by name. e.g. "umfpack" or "mumps"
+// GetSolver returns a linear solver by name. e.g. "umfpack", "mumps" or "native"
 func GetSolver(name string) LinSol {
 	allocator, ok := lsAllocators[name]
 	if !ok {
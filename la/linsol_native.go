@@ -0,0 +1,321 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// LinSolNative implements a sparse direct solver written in Go; i.e. without any external
+// library. Unsymmetric systems are solved with the left-looking LU factorisation (SpLU) and
+// symmetric systems with the supernodal Cholesky factorisation (SpCholSuper). The approximate
+// minimum degree ordering (SpAmdOrdering) is used by default. Complex systems are solved
+// through the equivalent real system:
+//  [ Ar  -Ac ] [ xR ]   [ bR ]
+//  [ Ac   Ar ] [ xC ] = [ bC ]
+//  NOTE: (1) with symmetric == true, only the entries in the lower triangle (i ≥ j) are considered;
+//            thus, the triplet may hold either the lower triangle only or the whole matrix. This is
+//            also the case for complex (symmetric; not Hermitian) systems
+//        (2) if the symmetric matrix turns out not to be positive-definite, the LU factorisation
+//            of the whole (mirrored) matrix is computed instead
+type LinSolNative struct {
+	linSolData
+
+	// ordering
+	ordering string // "amd" or "natural"
+
+	// factorisations
+	lu   *SpLU        // LU factorisation
+	chol *SpCholSuper // Cholesky factorisation
+
+	// derived
+	is_initialised bool
+	factorised     bool
+	bw, xw         []float64 // workspace for complex systems
+}
+
+// factory of allocators
+func init() {
+	lsAllocators["native"] = func() LinSol { return new(LinSolNative) }
+}
+
+// InitR initialises a LinSolNative data structure for Real systems
+func (o *LinSolNative) InitR(tR *Triplet, symmetric, verbose, timing bool) (err error) {
+
+	// check
+	o.tR = tR
+	if tR.pos == 0 {
+		return chk.Err(_linsol_native_err01)
+	}
+	if tR.m != tR.n {
+		return chk.Err(_linsol_native_err02, tR.m, tR.n)
+	}
+
+	// flags
+	o.name = "native"
+	o.sym = symmetric
+	o.cmplx = false
+	o.verb = verbose
+	o.ton = timing
+	if o.ordering == "" {
+		o.ordering = "amd"
+	}
+
+	// success
+	o.is_initialised = true
+	return
+}
+
+// InitC initialises a LinSolNative data structure for Complex systems
+func (o *LinSolNative) InitC(tC *TripletC, symmetric, verbose, timing bool) (err error) {
+
+	// check
+	o.tC = tC
+	if tC.pos == 0 {
+		return chk.Err(_linsol_native_err01)
+	}
+	if tC.m != tC.n {
+		return chk.Err(_linsol_native_err02, tC.m, tC.n)
+	}
+
+	// flags
+	o.name = "native"
+	o.sym = symmetric
+	o.cmplx = true
+	o.verb = verbose
+	o.ton = timing
+	if o.ordering == "" {
+		o.ordering = "amd"
+	}
+
+	// workspace
+	o.bw = make([]float64, 2*tC.n)
+	o.xw = make([]float64, 2*tC.n)
+
+	// success
+	o.is_initialised = true
+	return
+}
+
+// Fact performs symbolic/numeric factorisation. This method also converts the triplet form
+// to the compressed form, including the summation of duplicated entries
+func (o *LinSolNative) Fact() (err error) {
+
+	// check
+	if !o.is_initialised {
+		return chk.Err("linear solver must be initialised first\n")
+	}
+
+	// start time
+	if o.ton {
+		o.tini = time.Now()
+	}
+
+	// message
+	if o.verb {
+		io.Pfgreen("\n . . . . . . . . . . . . . . LinSolNative.Fact . . . . . . . . . . . . . . . \n\n")
+	}
+
+	// compressed-row matrix
+	var a *CSRMatrix
+	if o.cmplx {
+		a = o.realEquivalent()
+	} else {
+		a = o.tR.ToCSR(nil)
+	}
+	if o.sym && !o.cmplx {
+		a = spCSRMirrorLower(a)
+	}
+
+	// ordering
+	var perm []int
+	if o.ordering == "amd" {
+		perm = SpAmdOrdering(a)
+	}
+
+	// factorisation
+	o.lu, o.chol = nil, nil
+	if o.sym && !o.cmplx {
+		o.chol = new(SpCholSuper)
+		err = o.chol.Factor(a, perm)
+		if err != nil {
+			if o.verb {
+				io.Pfyel("LinSolNative: Cholesky factorisation failed; using LU instead:\n%v\n", err)
+			}
+			o.chol = nil
+		}
+	}
+	if o.chol == nil {
+		o.lu = new(SpLU)
+		err = o.lu.Factor(a.ToCC(), perm)
+		if err != nil {
+			return chk.Err(_linsol_native_err03, err)
+		}
+	}
+
+	// set flag
+	o.factorised = true
+
+	// message
+	if o.verb {
+		if o.chol != nil {
+			io.Pf("LinSolNative: Cholesky: n = %d, nnz(A) = %d, nnz(L) = %d, number of supernodes = %d\n", a.m, a.nnz, o.chol.Nnz(), o.chol.NumSuper())
+		} else {
+			nnzL, nnzU := o.lu.Nnz()
+			io.Pf("LinSolNative: LU: n = %d, nnz(A) = %d, nnz(L) = %d, nnz(U) = %d\n", a.m, a.nnz, nnzL, nnzU)
+		}
+	}
+
+	// duration
+	if o.ton {
+		io.Pfcyan("%s: Time spent in LinSolNative.Fact  = %v\n", o.name, time.Now().Sub(o.tini))
+	}
+	return
+}
+
+// SolveR solves the linear Real system A.x = b
+func (o *LinSolNative) SolveR(xR, bR []float64, dummy bool) (err error) {
+
+	// check
+	if !o.factorised {
+		return chk.Err("linear solver must be factorised first\n")
+	}
+	if o.cmplx {
+		return chk.Err(_linsol_native_err04)
+	}
+
+	// start time
+	if o.ton {
+		o.tini = time.Now()
+	}
+
+	// solve
+	if o.chol != nil {
+		o.chol.Solve(xR, bR)
+	} else {
+		o.lu.Solve(xR, bR)
+	}
+
+	// duration
+	if o.ton {
+		io.Pfcyan("%s: Time spent in LinSolNative.Solve = %v\n", o.name, time.Now().Sub(o.tini))
+	}
+	return
+}
+
+// SolveC solves the linear Complex system A.x = b
+func (o *LinSolNative) SolveC(xR, xC, bR, bC []float64, dummy bool) (err error) {
+
+	// check
+	if !o.factorised {
+		return chk.Err("linear solver must be factorised first\n")
+	}
+	if !o.cmplx {
+		return chk.Err(_linsol_native_err05)
+	}
+
+	// start time
+	if o.ton {
+		o.tini = time.Now()
+	}
+
+	// solve real equivalent system
+	n := o.tC.n
+	copy(o.bw[:n], bR)
+	copy(o.bw[n:], bC)
+	o.lu.Solve(o.xw, o.bw)
+	copy(xR, o.xw[:n])
+	copy(xC, o.xw[n:])
+
+	// duration
+	if o.ton {
+		io.Pfcyan("%s: Time spent in LinSolNative.Solve = %v\n", o.name, time.Now().Sub(o.tini))
+	}
+	return
+}
+
+// Free frees memory
+func (o *LinSolNative) Free() {
+	o.lu, o.chol = nil, nil
+	o.factorised = false
+}
+
+// SetOrdScal sets the ordering and scaling methods
+//  ordering -- "" or "amd" => approximate minimum degree; "natural" => no permutation
+//  Note: scaling is not available for the native solver
+func (o *LinSolNative) SetOrdScal(ordering, scaling string) (err error) {
+	switch ordering {
+	case "", "amd":
+		o.ordering = "amd"
+	case "natural":
+		o.ordering = "natural"
+	default:
+		return chk.Err(_linsol_native_err06, ordering)
+	}
+	return
+}
+
+// realEquivalent builds the real equivalent matrix of the complex triplet
+//  NOTE: if the system is symmetric, only the lower triangle is considered and mirrored
+func (o *LinSolNative) realEquivalent() *CSRMatrix {
+	t, n := o.tC, o.tC.n
+	var r Triplet
+	r.Init(2*n, 2*n, 8*t.pos)
+	put := func(i, j int, x, z float64) {
+		r.Put(i, j, x)
+		r.Put(n+i, n+j, x)
+		r.Put(i, n+j, -z)
+		r.Put(n+i, j, z)
+	}
+	for k := 0; k < t.pos; k++ {
+		var x, z float64
+		if t.xz != nil {
+			x, z = t.xz[k*2], t.xz[k*2+1]
+		} else {
+			x, z = t.x[k], t.z[k]
+		}
+		i, j := t.i[k], t.j[k]
+		if o.sym {
+			if i < j {
+				continue
+			}
+			if i > j {
+				put(j, i, x, z)
+			}
+		}
+		put(i, j, x, z)
+	}
+	return r.ToCSR(nil)
+}
+
+// spCSRMirrorLower returns the full symmetric matrix from the lower triangle of "a"
+func spCSRMirrorLower(a *CSRMatrix) *CSRMatrix {
+	var t Triplet
+	t.Init(a.m, a.n, imax(2*a.nnz, 1))
+	for i := 0; i < a.m; i++ {
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			if j := a.j[k]; j < i {
+				t.Put(i, j, a.x[k])
+				t.Put(j, i, a.x[k])
+			} else if j == i {
+				t.Put(i, i, a.x[k])
+			}
+		}
+	}
+	return t.ToCSR(nil)
+}
+
+// error messages
+var (
+	_linsol_native_err01 = "linsol_native.go: Init: triplet must have at least one item before calling this method\n"
+	_linsol_native_err02 = "linsol_native.go: Init: matrix must be square. (%d x %d) is invalid\n"
+	_linsol_native_err03 = "linsol_native.go: Fact: LU factorisation failed:\n%v"
+	_linsol_native_err04 = "linsol_native.go: SolveR: this method must be called with Real matrices\n"
+	_linsol_native_err05 = "linsol_native.go: SolveC: this method must be called with Complex matrices\n"
+	_linsol_native_err06 = "linsol_native.go: SetOrdScal: ordering %q is not available. options are \"amd\" or \"natural\"\n"
+)
@@ -52,6 +52,7 @@ type LinSolUmfpack struct {
 // factory of allocators
 func init() {
 	lsAllocators["umfpack"] = func() LinSol { return new(LinSolUmfpack) }
+	DefaultSolver = "umfpack"
 }
 
 // InitR initialises a LinSolUmfpack data structure for Real systems. It also performs some initial analyses.
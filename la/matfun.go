@@ -0,0 +1,554 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// MatExpm computes the matrix exponential using the scaling-and-squaring method with Padé
+// approximants of degrees 3, 5, 7, 9 or 13 (Higham, 2005)
+//  e := exp(a)
+//  NOTE: "a" must be square and "e" must be pre-allocated
+func MatExpm(e, a [][]float64) (err error) {
+	return matExpmPade(e, nil, a, nil)
+}
+
+// MatExpmFrechet computes the matrix exponential and its Fréchet derivative in the direction "d"
+// (Al-Mohy and Higham, 2009):
+//  e := exp(a)
+//  l := L(a, d) = d/dε exp(a + ε d) @ ε = 0
+//  NOTE: all matrices must be square with the same dimension; "e" and "l" must be pre-allocated
+func MatExpmFrechet(e, l, a, d [][]float64) (err error) {
+	return matExpmPade(e, l, a, d)
+}
+
+// MatLogm computes the principal matrix logarithm with the inverse scaling-and-squaring method:
+// square roots of "a" are taken until it is close to the identity and then the Padé approximant
+// of log(I+X) is evaluated in partial fraction form (Gauss-Legendre quadrature)
+//  l := log(a)
+//  NOTE: (1) "a" must not have eigenvalues on the closed negative real axis
+//        (2) "l" must be pre-allocated
+func MatLogm(l, a [][]float64) (err error) {
+
+	// inverse scaling: t := a^(1/2^s) with ‖t - I‖ ≤ 0.25
+	n := len(a)
+	t := MatClone(a)
+	s := 0
+	for matNorm1(matAddI(MatClone(t), -1)) > 0.25 {
+		if s == 64 {
+			return chk.Err(_matfun_err5, s)
+		}
+		err = MatSqrtm(t, t)
+		if err != nil {
+			return chk.Err(_matfun_err3, err)
+		}
+		s++
+	}
+
+	// log(I + x) = Σ wi x inv(I + ξi x)
+	x := matAddI(t, -1)
+	y := MatAlloc(n, n)
+	m := MatAlloc(n, n)
+	MatFill(l, 0)
+	for i := 0; i < len(_matfun_glX); i++ {
+		MatCopy(m, _matfun_glX[i], x)
+		matAddI(m, 1)
+		err = matSolve(y, m, x)
+		if err != nil {
+			return
+		}
+		matAdd(l, 1, l, _matfun_glW[i], y)
+	}
+
+	// squaring: log(a) = 2^s log(t)
+	MatScale(l, math.Ldexp(1, s))
+	return
+}
+
+// MatSqrtm computes the principal square root of a matrix using the scaled Denman-Beavers iteration:
+//  Y0 = a,  Z0 = I
+//  Yk+1 = (μk Yk + inv(μk Zk)) / 2
+//  Zk+1 = (μk Zk + inv(μk Yk)) / 2
+// where μk = |det(Yk) det(Zk)|^(-1/(2n)). Yk → sqrt(a) and Zk → inv(sqrt(a))
+//  s := sqrt(a)
+//  NOTE: (1) "a" must not have eigenvalues on the closed negative real axis
+//        (2) "s" must be pre-allocated and may be the same as "a"
+func MatSqrtm(s, a [][]float64) (err error) {
+	n := len(a)
+	y, z := MatClone(a), MatAlloc(n, n)
+	yi, zi := MatAlloc(n, n), MatAlloc(n, n)
+	matAddI(z, 1)
+	var ly, lz DenseLU
+	scaling := true
+	for it := 0; it < 100; it++ {
+		err = ly.Factor(y)
+		if err != nil {
+			return chk.Err(_matfun_err1, err)
+		}
+		err = lz.Factor(z)
+		if err != nil {
+			return chk.Err(_matfun_err1, err)
+		}
+		μ := 1.0
+		if scaling {
+			μ = math.Pow(math.Abs(ly.Det()*lz.Det()), -1.0/float64(2*n))
+		}
+		ly.Inverse(yi)
+		lz.Inverse(zi)
+		diff, nrm := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ynew := (μ*y[i][j] + zi[i][j]/μ) / 2
+				z[i][j] = (μ*z[i][j] + yi[i][j]/μ) / 2
+				diff = math.Max(diff, math.Abs(ynew-y[i][j]))
+				nrm = math.Max(nrm, math.Abs(ynew))
+				y[i][j] = ynew
+			}
+		}
+		if diff < 1e-2*nrm {
+			scaling = false
+		}
+		if diff <= 1e-14*nrm {
+			MatCopy(s, 1, y)
+			return
+		}
+	}
+	return chk.Err(_matfun_err2)
+}
+
+// MatPhi computes the φ-functions of a matrix employed by exponential integrators:
+//  φ0(a) = exp(a)
+//  φk(a) = Σ_{j=0}^∞ a^j / (j+k)!   =>   φk+1(a) = inv(a) (φk(a) - I/k!)
+//  OUTPUT:
+//   phi -- [p+1][n][n] matrices with phi[k] = φk(a) for k = 0, 1, ..., p
+//  NOTE: the functions are computed with the exponential of the augmented matrix (Sidje, 1998):
+//           [ a  I  0  ... ]       [ φ0  φ1  φ2  ... ]
+//       exp([ 0  0  I  ... ])  =   [  0   I  ...     ]
+//           [ 0  0  0  ... ]       [ ...             ]
+func MatPhi(a [][]float64, p int) (phi [][][]float64, err error) {
+	n := len(a)
+	N := n * (p + 1)
+	b := MatAlloc(N, N)
+	for i := 0; i < n; i++ {
+		copy(b[i][:n], a[i])
+	}
+	for k := 0; k < p; k++ {
+		for i := 0; i < n; i++ {
+			b[k*n+i][(k+1)*n+i] = 1
+		}
+	}
+	eb := MatAlloc(N, N)
+	err = MatExpm(eb, b)
+	if err != nil {
+		return
+	}
+	phi = make([][][]float64, p+1)
+	for k := 0; k <= p; k++ {
+		phi[k] = MatAlloc(n, n)
+		for i := 0; i < n; i++ {
+			copy(phi[k][i], eb[i][k*n:(k+1)*n])
+		}
+	}
+	return
+}
+
+// SpExpmv computes the action of the exponential of a (sparse) matrix on a vector using the
+// Krylov subspace method with adaptive time stepping (Sidje, 1998; Expokit):
+//  w := exp(t a) v
+//  INPUT:
+//   t   -- time (scaling factor); may be negative
+//   a   -- linear operator (e.g. CCMatrix or CSRMatrix)
+//   v   -- vector
+//   m   -- dimension of Krylov subspace. use 0 for the default value = min(30, n)
+//   tol -- relative tolerance. use 0 for the default value = 1e-12
+//  OUTPUT:
+//   w -- result (may be the same as v)
+func SpExpmv(w []float64, t float64, a LinOp, v []float64, m int, tol float64) (err error) {
+
+	// constants
+	n := len(v)
+	if m <= 0 {
+		m = 30
+	}
+	m = imin(m, n)
+	if tol <= 0 {
+		tol = 1e-12
+	}
+	const γ, δ = 0.9, 1.2
+	tout := math.Abs(t)
+	sgn := 1.0
+	if t < 0 {
+		sgn = -1
+	}
+
+	// workspace
+	copy(w, v)
+	β0 := VecNorm(w)
+	if β0 == 0 || t == 0 {
+		return
+	}
+	V := MatAlloc(m+1, n)
+	H := MatAlloc(m+2, m+2)
+	p := make([]float64, n)
+
+	// time stepping
+	tnow, τ := 0.0, tout
+	for tnow < tout {
+		τ = math.Min(τ, tout-tnow)
+		β := VecNorm(w)
+		VecCopy(V[0], 1/β, w)
+		MatFill(H, 0)
+
+		// Arnoldi process
+		mb, breakdown, hmax := m, false, 0.0
+		for j := 0; j < m; j++ {
+			a.MatVec(p, V[j])
+			for i := 0; i <= j; i++ {
+				H[i][j] = VecDot(p, V[i])
+				VecAdd(p, -H[i][j], V[i])
+				hmax = math.Max(hmax, math.Abs(H[i][j]))
+			}
+			s := VecNorm(p)
+			if s <= 1e-12*hmax || s == 0 {
+				mb, breakdown = j+1, true
+				τ = tout - tnow
+				break
+			}
+			H[j+1][j] = s
+			VecCopy(V[j+1], 1/s, p)
+		}
+		avnorm := 0.0
+		if !breakdown {
+			H[m+1][m] = 1
+			a.MatVec(p, V[m])
+			avnorm = VecNorm(p)
+		}
+
+		// local error estimation and step size control
+		var F [][]float64
+		var errloc, xm float64
+		for nrej := 0; ; nrej++ {
+			if nrej > 50 {
+				return chk.Err(_matfun_err4, tnow)
+			}
+			mx := mb
+			if !breakdown {
+				mx = m + 2
+			}
+			h := MatAlloc(mx, mx)
+			for i := 0; i < mx; i++ {
+				for j := 0; j < mx; j++ {
+					h[i][j] = sgn * τ * H[i][j]
+				}
+			}
+			F = MatAlloc(mx, mx)
+			err = MatExpm(F, h)
+			if err != nil {
+				return
+			}
+			if breakdown {
+				errloc, xm = 0, 1
+				break
+			}
+			φ1 := math.Abs(β * F[m][0])
+			φ2 := math.Abs(β * F[m+1][0] * avnorm)
+			switch {
+			case φ1 > 10*φ2:
+				errloc, xm = φ2, 1/float64(m)
+			case φ1 > φ2:
+				errloc, xm = φ1*φ2/(φ1-φ2), 1/float64(m)
+			default:
+				errloc, xm = φ1, 1/float64(imax(m-1, 1))
+			}
+			if errloc <= δ*(τ/tout)*tol*β0 {
+				break
+			}
+			τ = γ * τ * math.Pow((τ/tout)*tol*β0/errloc, xm)
+		}
+
+		// update solution
+		mx := mb
+		if !breakdown {
+			mx = m + 1
+		}
+		VecFill(w, 0)
+		for i := 0; i < mx; i++ {
+			VecAdd(w, β*F[i][0], V[i])
+		}
+		tnow += τ
+
+		// next step size
+		if errloc > 0 {
+			τ = math.Min(2*τ, γ*τ*math.Pow((τ/tout)*tol*β0/errloc, xm))
+		} else {
+			τ = 2 * τ
+		}
+	}
+	return
+}
+
+// matExpmPade computes the matrix exponential and (if l != nil) the Fréchet derivative
+func matExpmPade(e, l, a, d [][]float64) (err error) {
+
+	// select degree
+	n := len(a)
+	nrm := matNorm1(a)
+	var u, v, lu, lv [][]float64
+	s := 0
+	for k, θ := range _matfun_θ {
+		if nrm <= θ {
+			u, v, lu, lv = matPadeUV(a, d, 2*k+3)
+			break
+		}
+	}
+
+	// scaling
+	if u == nil {
+		θ13 := 5.371920351148152
+		if d != nil {
+			θ13 = 4.25
+		}
+		if nrm > θ13 {
+			s = int(math.Ceil(math.Log2(nrm / θ13)))
+		}
+		as := MatClone(a)
+		MatScale(as, math.Ldexp(1, -s))
+		var ds [][]float64
+		if d != nil {
+			ds = MatClone(d)
+			MatScale(ds, math.Ldexp(1, -s))
+		}
+		u, v, lu, lv = matPade13(as, ds)
+	}
+
+	// r := inv(v - u) (v + u)
+	q, r := MatAlloc(n, n), MatAlloc(n, n)
+	matAdd(q, 1, v, -1, u)
+	matAdd(r, 1, v, 1, u)
+	var fq DenseLU
+	err = fq.Factor(q)
+	if err != nil {
+		return chk.Err(_matfun_err1, err)
+	}
+	fq.SolveMat(r, r)
+
+	// l := inv(v - u) (lu + lv + (lu - lv) r)
+	var t [][]float64
+	if l != nil {
+		t = MatAlloc(n, n)
+		matAdd(lu, 1, lu, -1, lv)
+		MatMul(t, 1, lu, r)
+		matAdd(lu, 1, lu, 2, lv) // lu + lv
+		matAdd(t, 1, t, 1, lu)
+		fq.SolveMat(l, t)
+	}
+
+	// squaring
+	for k := 0; k < s; k++ {
+		if l != nil {
+			MatMul(t, 1, r, l)
+			MatMul(q, 1, l, r)
+			matAdd(l, 1, t, 1, q)
+		}
+		MatMul(q, 1, r, r)
+		r, q = q, r
+	}
+	MatCopy(e, 1, r)
+	return
+}
+
+// matPadeUV computes the odd (u) and even (v) parts of the Padé approximant of degree m ∈ {3,5,7,9}
+// and their Fréchet derivatives (if d != nil)
+func matPadeUV(a, d [][]float64, m int) (u, v, lu, lv [][]float64) {
+	n := len(a)
+	b := _matfun_b[(m-3)/2]
+	np := (m-1)/2 + 1
+	pw := make([][][]float64, np) // pw[k] = a^(2k)
+	pw[0] = matAddI(MatAlloc(n, n), 1)
+	pw[1] = MatAlloc(n, n)
+	MatMul(pw[1], 1, a, a)
+	for k := 2; k < np; k++ {
+		pw[k] = MatAlloc(n, n)
+		MatMul(pw[k], 1, pw[k-1], pw[1])
+	}
+	w := MatAlloc(n, n)
+	v = MatAlloc(n, n)
+	for k := 0; k < np; k++ {
+		matAdd(w, 1, w, b[2*k+1], pw[k])
+		matAdd(v, 1, v, b[2*k], pw[k])
+	}
+	u = MatAlloc(n, n)
+	MatMul(u, 1, a, w)
+	if d == nil {
+		return
+	}
+
+	// Fréchet derivatives: mk = L(a^(2k), d)
+	mk := make([][][]float64, np)
+	mk[1] = MatAlloc(n, n)
+	matMulAdd2(mk[1], a, d, d, a)
+	for k := 2; k < np; k++ {
+		mk[k] = MatAlloc(n, n)
+		matMulAdd2(mk[k], mk[k-1], pw[1], pw[k-1], mk[1])
+	}
+	lw := MatAlloc(n, n)
+	lv = MatAlloc(n, n)
+	for k := 1; k < np; k++ {
+		matAdd(lw, 1, lw, b[2*k+1], mk[k])
+		matAdd(lv, 1, lv, b[2*k], mk[k])
+	}
+	lu = MatAlloc(n, n)
+	matMulAdd2(lu, a, lw, d, w)
+	return
+}
+
+// matPade13 computes the odd (u) and even (v) parts of the Padé approximant of degree 13 and
+// their Fréchet derivatives (if d != nil)
+func matPade13(a, d [][]float64) (u, v, lu, lv [][]float64) {
+	n := len(a)
+	b := _matfun_b13
+	a2, a4, a6 := MatAlloc(n, n), MatAlloc(n, n), MatAlloc(n, n)
+	MatMul(a2, 1, a, a)
+	MatMul(a4, 1, a2, a2)
+	MatMul(a6, 1, a4, a2)
+	comb := func(c6, c4, c2, c0 float64, x6, x4, x2 [][]float64, identity bool) (r [][]float64) {
+		r = MatAlloc(n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				r[i][j] = c6*x6[i][j] + c4*x4[i][j] + c2*x2[i][j]
+			}
+			if identity {
+				r[i][i] += c0
+			}
+		}
+		return
+	}
+	w1 := comb(b[13], b[11], b[9], 0, a6, a4, a2, false)
+	w2 := comb(b[7], b[5], b[3], b[1], a6, a4, a2, true)
+	z1 := comb(b[12], b[10], b[8], 0, a6, a4, a2, false)
+	z2 := comb(b[6], b[4], b[2], b[0], a6, a4, a2, true)
+	w := MatAlloc(n, n)
+	MatMul(w, 1, a6, w1)
+	matAdd(w, 1, w, 1, w2)
+	u = MatAlloc(n, n)
+	MatMul(u, 1, a, w)
+	v = MatAlloc(n, n)
+	MatMul(v, 1, a6, z1)
+	matAdd(v, 1, v, 1, z2)
+	if d == nil {
+		return
+	}
+
+	// Fréchet derivatives
+	m2, m4, m6 := MatAlloc(n, n), MatAlloc(n, n), MatAlloc(n, n)
+	matMulAdd2(m2, a, d, d, a)
+	matMulAdd2(m4, a2, m2, m2, a2)
+	matMulAdd2(m6, a4, m2, m4, a2)
+	lw1 := comb(b[13], b[11], b[9], 0, m6, m4, m2, false)
+	lw2 := comb(b[7], b[5], b[3], 0, m6, m4, m2, false)
+	lz1 := comb(b[12], b[10], b[8], 0, m6, m4, m2, false)
+	lz2 := comb(b[6], b[4], b[2], 0, m6, m4, m2, false)
+	lw := MatAlloc(n, n)
+	matMulAdd2(lw, a6, lw1, m6, w1)
+	matAdd(lw, 1, lw, 1, lw2)
+	lu = MatAlloc(n, n)
+	matMulAdd2(lu, a, lw, d, w)
+	lv = MatAlloc(n, n)
+	matMulAdd2(lv, a6, lz1, m6, z1)
+	matAdd(lv, 1, lv, 1, lz2)
+	return
+}
+
+// matSolve solves a x = b with (multiple) right-hand sides
+func matSolve(x, a, b [][]float64) (err error) {
+	var f DenseLU
+	err = f.Factor(a)
+	if err != nil {
+		return chk.Err(_matfun_err1, err)
+	}
+	f.SolveMat(x, b)
+	return
+}
+
+// matNorm1 returns the 1-norm of a matrix (maximum absolute column sum)
+func matNorm1(a [][]float64) (res float64) {
+	if len(a) < 1 {
+		return
+	}
+	for j := 0; j < len(a[0]); j++ {
+		sum := 0.0
+		for i := 0; i < len(a); i++ {
+			sum += math.Abs(a[i][j])
+		}
+		res = math.Max(res, sum)
+	}
+	return
+}
+
+// matAddI adds α * I to a square matrix "a" and returns "a"
+func matAddI(a [][]float64, α float64) [][]float64 {
+	for i := 0; i < len(a); i++ {
+		a[i][i] += α
+	}
+	return a
+}
+
+// matAdd computes c := α * a + β * b (c may be the same as a or b)
+func matAdd(c [][]float64, α float64, a [][]float64, β float64, b [][]float64) {
+	for i := 0; i < len(c); i++ {
+		for j := 0; j < len(c[i]); j++ {
+			c[i][j] = α*a[i][j] + β*b[i][j]
+		}
+	}
+}
+
+// matMulAdd2 computes r := a * b + c * d
+func matMulAdd2(r, a, b, c, d [][]float64) {
+	for i := 0; i < len(a); i++ {
+		for j := 0; j < len(b[0]); j++ {
+			r[i][j] = 0
+			for k := 0; k < len(b); k++ {
+				r[i][j] += a[i][k]*b[k][j] + c[i][k]*d[k][j]
+			}
+		}
+	}
+}
+
+// constants of Padé approximants (Higham, 2005)
+var (
+	_matfun_θ = []float64{1.495585217958292e-2, 2.539398330063230e-1, 9.504178996162932e-1, 2.097847961257068e0}
+	_matfun_b = [][]float64{
+		{120, 60, 12, 1},
+		{30240, 15120, 3360, 420, 30, 1},
+		{17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+		{17643225600, 8821612800, 2075673600, 302702400, 30270240, 2162160, 110880, 3960, 90, 1},
+	}
+	_matfun_b13 = []float64{64764752532480000, 32382376266240000, 7771770303897600, 1187353796428800,
+		129060195264000, 10559470521600, 670442572800, 33522128640, 1323241920, 40840800, 960960, 16380, 182, 1}
+)
+
+// 8-point Gauss-Legendre nodes and weights on [0,1] for log(I+X) = ∫_0^1 X inv(I + ξ X) dξ
+var (
+	_matfun_glX = []float64{
+		0.019855071751231856, 0.10166676129318664, 0.2372337950418355, 0.4082826787521751,
+		0.5917173212478249, 0.7627662049581645, 0.8983332387068134, 0.9801449282487681,
+	}
+	_matfun_glW = []float64{
+		0.05061426814518813, 0.11119051722668724, 0.15685332293894363, 0.18134189168918100,
+		0.18134189168918100, 0.15685332293894363, 0.11119051722668724, 0.05061426814518813,
+	}
+)
+
+// error messages
+var (
+	_matfun_err1 = "matfun.go: cannot solve dense linear system:\n%v"
+	_matfun_err2 = "matfun.go: MatSqrtm: Denman-Beavers iterations did not converge. The matrix may have eigenvalues on the negative real axis"
+	_matfun_err3 = "matfun.go: MatLogm: cannot compute square root:\n%v"
+	_matfun_err4 = "matfun.go: SpExpmv: too many step rejections at t = %g"
+	_matfun_err5 = "matfun.go: MatLogm: matrix is not close enough to the identity after %d square roots"
+)
@@ -0,0 +1,159 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !cgo
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// MatSvd returns the singular value decomposition of a matrix such that:
+//  Note:
+//  a = u * s * v
+//  u[m][m], s[n], vt[n][n] must be pre-allocated
+//  NOTE: this function requires LAPACK and is not available when cgo is disabled
+func MatSvd(u [][]float64, s []float64, vt [][]float64, a [][]float64, tol float64) (err error) {
+	return chk.Err(_matinvg_nocgo_err1)
+}
+
+// MatInvG returns the matrix inverse of 'a' in 'ai'. 'a' can be of any size,
+// even non-square; in this case, the pseudo-inverse is returned
+//  NOTE: this version is used when cgo (LAPACK) is not available. The inverse is computed by
+//        the Gauss-Jordan method with partial pivoting and the pseudo-inverse is computed via
+//        the normal equations; thus 'a' must have full rank
+func MatInvG(ai, a [][]float64, tol float64) (err error) {
+	if len(a) < 1 {
+		return chk.Err(_matinvg_err1)
+	}
+	m, n := len(a), len(a[0])
+	if m == n && m < 4 { // call simple function
+		_, err = MatInv(ai, a, tol)
+		return
+	}
+	if m == n {
+		return matInvGaussJordan(ai, a, tol)
+	}
+	if m > n { // ai := inv(aᵀ·a) · aᵀ
+		ata := MatAlloc(n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				for k := 0; k < m; k++ {
+					ata[i][j] += a[k][i] * a[k][j]
+				}
+			}
+		}
+		atai := MatAlloc(n, n)
+		err = matInvGaussJordan(atai, ata, tol)
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				ai[i][j] = 0
+				for k := 0; k < n; k++ {
+					ai[i][j] += atai[i][k] * a[j][k]
+				}
+			}
+		}
+		return
+	}
+	aat := MatAlloc(m, m) // ai := aᵀ · inv(a·aᵀ)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			for k := 0; k < n; k++ {
+				aat[i][j] += a[i][k] * a[j][k]
+			}
+		}
+	}
+	aati := MatAlloc(m, m)
+	err = matInvGaussJordan(aati, aat, tol)
+	if err != nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			ai[i][j] = 0
+			for k := 0; k < m; k++ {
+				ai[i][j] += a[k][i] * aati[k][j]
+			}
+		}
+	}
+	return
+}
+
+// MatCondG returns the condition number of a square matrix using the inverse of this matrix; thus
+// it is not as efficient as it could be, e.g. by using the SV decomposition.
+//  normtype -- Type of norm to use:
+//    "F" or "" => Frobenius
+//    "I"       => Infinite
+func MatCondG(a [][]float64, normtype string, tol float64) (res float64, err error) {
+	if len(a) < 1 {
+		return 0, chk.Err(_matinvg_err1)
+	}
+	m, n := len(a), len(a[0])
+	ai := MatAlloc(m, n)
+	err = MatInvG(ai, a, tol)
+	if err != nil {
+		return 0, chk.Err(_matinvg_err4, err.Error())
+	}
+	if normtype == "I" {
+		res = MatNormI(a) * MatNormI(ai)
+	} else {
+		res = MatNormF(a) * MatNormF(ai)
+	}
+	return
+}
+
+// matInvGaussJordan computes the inverse of a square matrix by the Gauss-Jordan method with
+// partial pivoting
+func matInvGaussJordan(ai, a [][]float64, tol float64) (err error) {
+	n := len(a)
+	w := MatAlloc(n, 2*n)
+	for i := 0; i < n; i++ {
+		copy(w[i], a[i])
+		w[i][n+i] = 1
+	}
+	for k := 0; k < n; k++ {
+		piv := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(w[i][k]) > math.Abs(w[piv][k]) {
+				piv = i
+			}
+		}
+		if math.Abs(w[piv][k]) < tol {
+			return chk.Err(_matinvg_err2, n, n, "Gauss-Jordan", k)
+		}
+		w[k], w[piv] = w[piv], w[k]
+		d := w[k][k]
+		for j := 0; j < 2*n; j++ {
+			w[k][j] /= d
+		}
+		for i := 0; i < n; i++ {
+			if i == k || w[i][k] == 0 {
+				continue
+			}
+			f := w[i][k]
+			for j := 0; j < 2*n; j++ {
+				w[i][j] -= f * w[k][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		copy(ai[i], w[i][n:])
+	}
+	return
+}
+
+// error messages
+const (
+	_matinvg_err1 = "cannot handle nil matrix. len(a) = 0"
+	_matinvg_err2 = "inverse of (%d x %d) matrix failed with %s.status = %d"
+	_matinvg_err4 = "cannot compute inverse:\n%v"
+
+	_matinvg_nocgo_err1 = "MatSvd requires LAPACK and is not available when cgo is disabled"
+)
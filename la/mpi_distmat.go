@@ -0,0 +1,279 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows,!darwin,!appengine,!heroku
+
+package la
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/mpi"
+)
+
+// DistMatrix holds a square sparse matrix distributed by rows among (MPI) processors. Each processor
+// owns a contiguous range of rows and the corresponding range of components of vectors. The
+// components of vectors owned by other processors and required by the local rows ("ghost" or
+// "halo" components) are exchanged with DblSend and DblRecv before each matrix-vector product.
+//  NOTE: (1) vectors given to the methods of DistMatrix are the local parts with size endp1 - start
+//        (2) without MPI (or with a single processor) the matrix is serial
+//        (3) DistMatrix implements LinOp; thus Krylov.Cg and Krylov.Gmres can be used by setting
+//            Krylov.Dot = DistDot
+type DistMatrix struct {
+	n            int         // global dimension
+	start, endp1 int         // range of rows owned by this processor
+	ranges       []int       // start of the range of each processor (len = size + 1)
+	a            *CSRMatrix  // local rows with local column indices: [0,nloc) owned; [nloc,nloc+nghost) ghosts
+	ghosts       []int       // global indices of ghost components (sorted)
+	recvFrom     []int       // processors sending ghost components to this processor (sorted)
+	recvPtr      []int       // ghosts[recvPtr[k]:recvPtr[k+1]] are received from recvFrom[k]
+	sendTo       []int       // processors receiving components from this processor (sorted)
+	sendIdx      [][]int     // local indices of the components sent to each sendTo[k]
+	sendBuf      [][]float64 // buffers for the components sent to each sendTo[k]
+	uext         []float64   // extended vector: [owned components, ghosts]
+}
+
+// DistRange returns the range of rows of a matrix with dimension n owned by this processor,
+// according to the standard partition: [(rank*n)/size, ((rank+1)*n)/size)
+func DistRange(n int) (start, endp1 int) {
+	id, sz := distRankSize()
+	return (id * n) / sz, ((id + 1) * n) / sz
+}
+
+// Init initialises the distributed matrix and sets up the communication pattern
+//  INPUT:
+//   t     -- (n x n) triplet with global indices holding only the rows owned by this processor
+//   start -- first row owned by this processor
+//   endp1 -- one after the last row owned by this processor
+//  NOTE: (1) this is a collective operation; i.e. it must be called by all processors
+//        (2) the ranges of all processors must be contiguous, non-overlapping and ordered by rank
+func (o *DistMatrix) Init(t *Triplet, start, endp1 int) (err error) {
+
+	// check
+	if t.m != t.n {
+		return chk.Err(_mpi_distmat_err1, t.m, t.n)
+	}
+	id, sz := distRankSize()
+	o.n, o.start, o.endp1 = t.n, start, endp1
+	nloc := endp1 - start
+
+	// ranges of all processors
+	rng := make([]int, sz+1)
+	rng[id+1] = endp1
+	distIntMax(rng)
+	o.ranges = rng
+	for p := 0; p < sz; p++ {
+		if rng[p+1] < rng[p] {
+			return chk.Err(_mpi_distmat_err2, p, rng[p], rng[p+1])
+		}
+	}
+	if rng[sz] != o.n || start != rng[id] {
+		return chk.Err(_mpi_distmat_err3, start, endp1, o.n)
+	}
+
+	// ghost components
+	gmap := make(map[int]int)
+	for k := 0; k < t.pos; k++ {
+		i, j := t.i[k], t.j[k]
+		if i < start || i >= endp1 {
+			return chk.Err(_mpi_distmat_err4, i, start, endp1)
+		}
+		if j < start || j >= endp1 {
+			gmap[j] = 0
+		}
+	}
+	o.ghosts = make([]int, 0, len(gmap))
+	for j := range gmap {
+		o.ghosts = append(o.ghosts, j)
+	}
+	sort.Ints(o.ghosts)
+	for k, j := range o.ghosts {
+		gmap[j] = nloc + k
+	}
+
+	// local matrix
+	var tl Triplet
+	tl.Init(nloc, nloc+len(o.ghosts), imax(t.pos, 1))
+	for k := 0; k < t.pos; k++ {
+		i, j := t.i[k], t.j[k]
+		if j >= start && j < endp1 {
+			tl.Put(i-start, j-start, t.x[k])
+		} else {
+			tl.Put(i-start, gmap[j], t.x[k])
+		}
+	}
+	o.a = tl.ToCSR(nil)
+	o.uext = make([]float64, nloc+len(o.ghosts))
+
+	// ghosts received from each processor (ghosts are sorted; thus grouped by owner)
+	o.recvFrom, o.recvPtr = nil, []int{0}
+	cnt := make([]int, sz*sz) // cnt[p*sz+q] = number of components that p receives from q
+	for k, j := range o.ghosts {
+		q := o.owner(j)
+		if len(o.recvFrom) == 0 || o.recvFrom[len(o.recvFrom)-1] != q {
+			if k > 0 {
+				o.recvPtr = append(o.recvPtr, k)
+			}
+			o.recvFrom = append(o.recvFrom, q)
+		}
+		cnt[id*sz+q]++
+	}
+	o.recvPtr = append(o.recvPtr, len(o.ghosts))
+	distIntMax(cnt)
+
+	// tell the owners which components are needed (requests go to recvFrom)
+	o.sendTo, o.sendIdx, o.sendBuf = nil, nil, nil
+	for q := 0; q < sz; q++ {
+		if cnt[q*sz+id] > 0 {
+			o.sendTo = append(o.sendTo, q)
+			o.sendIdx = append(o.sendIdx, make([]int, cnt[q*sz+id]))
+			o.sendBuf = append(o.sendBuf, make([]float64, cnt[q*sz+id]))
+		}
+	}
+	distExchange(o.recvFrom, o.sendTo, func(k int) {
+		mpi.IntSend(o.ghosts[o.recvPtr[k]:o.recvPtr[k+1]], o.recvFrom[k])
+	}, func(k int) {
+		mpi.IntRecv(o.sendIdx[k], o.sendTo[k])
+		for m, j := range o.sendIdx[k] {
+			o.sendIdx[k][m] = j - start
+		}
+	})
+	return
+}
+
+// Range returns the range of rows owned by this processor
+func (o *DistMatrix) Range() (start, endp1 int) {
+	return o.start, o.endp1
+}
+
+// Dim returns the global dimension
+func (o *DistMatrix) Dim() int {
+	return o.n
+}
+
+// NumGhosts returns the number of components received from other processors in each product
+func (o *DistMatrix) NumGhosts() int {
+	return len(o.ghosts)
+}
+
+// MatVec computes v := A * u and implements the LinOp interface
+//  NOTE: this is a collective operation; u and v are local parts
+func (o *DistMatrix) MatVec(v, u []float64) {
+	o.MatVecMul(v, 1, u)
+}
+
+// MatVecMul computes the distributed matrix-vector multiplication (scaled):
+//  v := α * A * u
+//  NOTE: this is a collective operation; u and v are local parts
+func (o *DistMatrix) MatVecMul(v []float64, α float64, u []float64) {
+	o.Halo(u)
+	SpCSRMatVecMul(v, α, o.a, o.uext)
+}
+
+// Halo copies the local part u into the extended vector and receives the ghost components from
+// the other processors
+//  NOTE: this is a collective operation
+func (o *DistMatrix) Halo(u []float64) {
+	nloc := o.endp1 - o.start
+	copy(o.uext, u[:nloc])
+	distExchange(o.sendTo, o.recvFrom, func(k int) {
+		for m, i := range o.sendIdx[k] {
+			o.sendBuf[k][m] = u[i]
+		}
+		mpi.DblSend(o.sendBuf[k], o.sendTo[k])
+	}, func(k int) {
+		mpi.DblRecv(o.uext[nloc+o.recvPtr[k]:nloc+o.recvPtr[k+1]], o.recvFrom[k])
+	})
+}
+
+// Gather returns the whole (global) vector given the local parts
+//  NOTE: this is a collective operation
+func (o *DistMatrix) Gather(u []float64) (x []float64) {
+	x = make([]float64, o.n)
+	copy(x[o.start:o.endp1], u)
+	if mpi.IsOn() {
+		mpi.AllReduceSum(x, make([]float64, o.n))
+	}
+	return
+}
+
+// DistDot computes the dot product of distributed vectors u・v
+//  NOTE: this is a collective operation; u and v are local parts
+func DistDot(u, v []float64) float64 {
+	res := []float64{VecDot(u, v)}
+	if mpi.IsOn() {
+		mpi.AllReduceSum(res, []float64{0})
+	}
+	return res[0]
+}
+
+// DistNorm computes the Euclidean norm of a distributed vector
+//  NOTE: this is a collective operation; u is the local part
+func DistNorm(u []float64) float64 {
+	return math.Sqrt(DistDot(u, u))
+}
+
+// owner returns the processor owning row (component) j
+func (o *DistMatrix) owner(j int) int {
+	return sort.Search(len(o.ranges)-1, func(p int) bool { return o.ranges[p+1] > j })
+}
+
+// distExchange performs point-to-point communications with the neighbours. To avoid deadlocks, the
+// communication with a processor of lower rank starts by receiving; otherwise, it starts by sending.
+// send(k) is called for sendRanks[k] and recv(k) is called for recvRanks[k] (both sorted)
+func distExchange(sendRanks, recvRanks []int, send, recv func(k int)) {
+	id, _ := distRankSize()
+	ks, kr := 0, 0
+	for ks < len(sendRanks) || kr < len(recvRanks) {
+		q := math.MaxInt32
+		if ks < len(sendRanks) {
+			q = sendRanks[ks]
+		}
+		if kr < len(recvRanks) && recvRanks[kr] < q {
+			q = recvRanks[kr]
+		}
+		doS := ks < len(sendRanks) && sendRanks[ks] == q
+		doR := kr < len(recvRanks) && recvRanks[kr] == q
+		if doR && q < id {
+			recv(kr)
+		}
+		if doS {
+			send(ks)
+		}
+		if doR && q > id {
+			recv(kr)
+		}
+		if doS {
+			ks++
+		}
+		if doR {
+			kr++
+		}
+	}
+}
+
+// distRankSize returns the rank and size or (0, 1) if MPI is off
+func distRankSize() (id, sz int) {
+	if mpi.IsOn() {
+		return mpi.Rank(), mpi.Size()
+	}
+	return 0, 1
+}
+
+// distIntMax combines integers from all processors by selecting the maximum values
+func distIntMax(x []int) {
+	if mpi.IsOn() {
+		mpi.IntAllReduceMax(x, make([]int, len(x)))
+	}
+}
+
+// error messages
+var (
+	_mpi_distmat_err1 = "mpi_distmat.go: DistMatrix.Init: matrix must be square. (%d x %d) is invalid"
+	_mpi_distmat_err2 = "mpi_distmat.go: DistMatrix.Init: range of processor %d is invalid: [%d, %d)"
+	_mpi_distmat_err3 = "mpi_distmat.go: DistMatrix.Init: ranges must be contiguous and ordered by rank. [%d, %d) is invalid (n = %d)"
+	_mpi_distmat_err4 = "mpi_distmat.go: DistMatrix.Init: row %d is not owned by this processor. range = [%d, %d)"
+)
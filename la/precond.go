@@ -0,0 +1,138 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import "github.com/cpmech/gosl/chk"
+
+// JacobiPrecond implements the diagonal (Jacobi) preconditioner
+type JacobiPrecond struct {
+	invd []float64 // inverse of diagonal
+}
+
+// NewJacobiPrecond returns a new Jacobi preconditioner
+func NewJacobiPrecond(a *CCMatrix) (o *JacobiPrecond, err error) {
+	d := make([]float64, a.n)
+	for j := 0; j < a.n; j++ {
+		for k := a.p[j]; k < a.p[j+1]; k++ {
+			if a.i[k] == j {
+				d[j] += a.x[k]
+			}
+		}
+	}
+	for j := 0; j < a.n; j++ {
+		if d[j] == 0 {
+			return nil, chk.Err(_precond_err1, j)
+		}
+		d[j] = 1 / d[j]
+	}
+	return &JacobiPrecond{d}, nil
+}
+
+// Apply computes z := inv(diag(A)) * r
+func (o *JacobiPrecond) Apply(z, r []float64) error {
+	for i := 0; i < len(r); i++ {
+		z[i] = o.invd[i] * r[i]
+	}
+	return nil
+}
+
+// DirectPrecond implements a preconditioner by means of the exact solution computed by a sparse
+// direct solver (LinSol); e.g. to invert the diagonal blocks of block preconditioners
+type DirectPrecond struct {
+	lis LinSol   // linear solver
+	t   *Triplet // matrix
+}
+
+// NewDirectPrecond returns a new preconditioner by factorising "a" with la.DefaultSolver
+//  NOTE: call Free to release the memory held by the linear solver
+func NewDirectPrecond(a *Triplet, symmetric bool) (o *DirectPrecond, err error) {
+	o = &DirectPrecond{GetSolver(DefaultSolver), a}
+	err = o.lis.InitR(a, symmetric, false, false)
+	if err != nil {
+		return
+	}
+	err = o.lis.Fact()
+	return
+}
+
+// Apply computes z := inv(A) * r
+func (o *DirectPrecond) Apply(z, r []float64) error {
+	return o.lis.SolveR(z, r, false)
+}
+
+// Free frees memory
+func (o *DirectPrecond) Free() {
+	o.lis.Free()
+}
+
+// BlockDiagPrecond implements the block-diagonal preconditioner:
+//      [ P0          ]
+//  M = [     P1      ]
+//      [         ... ]
+// where each PI approximates the diagonal block KII (or the Schur complement in saddle-point systems)
+type BlockDiagPrecond struct {
+	K *BlockMatrix // block matrix defining the partitions
+	P []Precond    // preconditioners of each diagonal block
+}
+
+// Apply computes z := inv(M) * r
+func (o *BlockDiagPrecond) Apply(z, r []float64) (err error) {
+	for I, p := range o.P {
+		err = krylovPrecond(p, o.K.RowPart(z, I), o.K.RowPart(r, I))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// BlockTriPrecond implements the block-triangular preconditioners:
+//      [ P0          ]                    [ P0  K01  ... ]
+//  M = [ K10  P1     ]  (lower)  or  M =  [     P1   ... ]  (upper)
+//      [ ...  ... ...]                    [          ... ]
+// where each PI approximates the diagonal block KII (or the Schur complement in saddle-point
+// systems) and the off-diagonal blocks are taken from K.
+//  NOTE: with exact P0 = A and P1 = S = -B inv(A) Bᵀ, the upper version applied to the saddle-point
+//        matrix [[A, Bᵀ], [B, 0]] gives GMRES convergence in two iterations
+type BlockTriPrecond struct {
+	K     *BlockMatrix // block matrix with the off-diagonal blocks
+	P     []Precond    // preconditioners of each diagonal block
+	Upper bool         // upper block-triangular instead of lower block-triangular
+	w     []float64    // workspace
+}
+
+// Apply computes z := inv(M) * r by block forward (lower) or backward (upper) substitution
+func (o *BlockTriPrecond) Apply(z, r []float64) (err error) {
+	nb := len(o.P)
+	if len(o.w) != len(r) {
+		o.w = make([]float64, len(r))
+	}
+	copy(o.w, r)
+	for k := 0; k < nb; k++ {
+		I := k
+		if o.Upper {
+			I = nb - 1 - k
+		}
+		wI, zI := o.K.RowPart(o.w, I), o.K.RowPart(z, I)
+		for J := 0; J < nb; J++ {
+			if (o.Upper && J <= I) || (!o.Upper && J >= I) {
+				continue
+			}
+			if a := o.K.Block(I, J); a != nil {
+				SpMatVecMulAdd(wI, -1, a, o.K.ColPart(z, J))
+			}
+		}
+		err = krylovPrecond(o.P[I], zI, wI)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// error messages
+var (
+	_precond_err1 = "precond.go: NewJacobiPrecond: diagonal entry %d is zero"
+)
@@ -0,0 +1,320 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// SchurSolver solves 2x2 block systems (e.g. saddle-point systems) by means of the Schur complement:
+//  [ A  B ] [ x1 ]   [ b1 ]
+//  [ C  D ] [ x2 ] = [ b2 ]
+// with
+//  S := D - C inv(A) B
+//  S x2 = b2 - C inv(A) b1
+//  A x1 = b1 - B x2
+// The A block is factorised with la.DefaultSolver. The Schur complement S is either assembled
+// explicitly and factorised too (default) or solved with (flexible) GMRES without assembling it.
+//  NOTE: the explicit assembly of S requires one solution with A for each column of B.
+//        Thus, it is efficient when the second partition is small; e.g. for Lagrange multipliers
+type SchurSolver struct {
+
+	// input
+	Implicit bool    // solve the Schur complement system with GMRES (S is not assembled)
+	Krylov   Krylov  // parameters of the iterative solver in the implicit mode
+	Prec     Precond // preconditioner for S in the implicit mode [default = inverse of SpSchurApprox]
+
+	// data
+	k    *BlockMatrix // block matrix
+	sym  bool         // A is symmetric
+	verb bool         // verbose
+	tA   *Triplet     // A in triplet form
+	lisA LinSol       // solver for A
+	tS   *Triplet     // assembled Schur complement
+	lisS LinSol       // solver for S
+	prec *DirectPrecond
+
+	// workspace
+	w1, y1, w2 []float64
+}
+
+// Init initialises the solver
+//  INPUT:
+//   K         -- 2x2 block matrix with square diagonal blocks. A must be non-nil; D may be nil (zero)
+//   symmetric -- A is symmetric (flag passed to the linear solver)
+func (o *SchurSolver) Init(K *BlockMatrix, symmetric, verbose bool) (err error) {
+	nbr, nbc := K.NumBlocks()
+	if nbr != 2 || nbc != 2 {
+		return chk.Err(_schur_err1, nbr, nbc)
+	}
+	if K.rsz[0] != K.csz[0] || K.rsz[1] != K.csz[1] {
+		return chk.Err(_schur_err2)
+	}
+	if K.Block(0, 0) == nil {
+		return chk.Err(_schur_err3)
+	}
+	o.k, o.sym, o.verb = K, symmetric, verbose
+	n1, n2 := K.rsz[0], K.rsz[1]
+	o.w1, o.y1, o.w2 = make([]float64, n1), make([]float64, n1), make([]float64, n2)
+	return
+}
+
+// Fact factorises A and, in the explicit mode, assembles and factorises S
+func (o *SchurSolver) Fact() (err error) {
+
+	// check
+	if o.k == nil {
+		return chk.Err("Schur solver must be initialised first\n")
+	}
+	o.Free()
+	tini := time.Now()
+
+	// factorise A
+	o.tA = o.k.Block(0, 0).ToCSR().ToTriplet()
+	o.lisA = GetSolver(DefaultSolver)
+	err = o.lisA.InitR(o.tA, o.sym, false, false)
+	if err != nil {
+		return
+	}
+	err = o.lisA.Fact()
+	if err != nil {
+		return
+	}
+
+	// implicit mode: preconditioner only
+	if o.Implicit {
+		if o.Prec == nil {
+			t := SpSchurApprox(o.k.Block(0, 0), o.k.Block(0, 1), o.k.Block(1, 0), o.k.Block(1, 1)).ToCSR().ToTriplet()
+			o.prec, err = NewDirectPrecond(t, false)
+			if err != nil {
+				return chk.Err(_schur_err4, err)
+			}
+		}
+		if o.verb {
+			io.Pf("SchurSolver.Fact: implicit mode. time spent = %v\n", time.Now().Sub(tini))
+		}
+		return
+	}
+
+	// assemble S column by column
+	n1, n2 := o.k.rsz[0], o.k.rsz[1]
+	b, c, d := o.k.Block(0, 1), o.k.Block(1, 0), o.k.Block(1, 1)
+	nnz := 0
+	if d != nil {
+		nnz = d.nnz
+	}
+	o.tS = new(Triplet)
+	o.tS.Init(n2, n2, imax(nnz+n2, 1))
+	s := make([]float64, n2)
+	for j := 0; j < n2; j++ {
+		VecFill(s, 0)
+		if d != nil {
+			for k := d.p[j]; k < d.p[j+1]; k++ {
+				s[d.i[k]] = d.x[k]
+			}
+		}
+		if b != nil && c != nil && b.p[j+1] > b.p[j] {
+			VecFill(o.w1, 0)
+			for k := b.p[j]; k < b.p[j+1]; k++ {
+				o.w1[b.i[k]] = b.x[k]
+			}
+			err = o.lisA.SolveR(o.y1, o.w1, false)
+			if err != nil {
+				return
+			}
+			SpMatVecMulAdd(s, -1, c, o.y1)
+		}
+		for i := 0; i < n2; i++ {
+			if s[i] != 0 || i == j {
+				if o.tS.pos == o.tS.max {
+					o.tS = spTriGrow(o.tS)
+				}
+				o.tS.Put(i, j, s[i])
+			}
+		}
+	}
+
+	// factorise S
+	o.lisS = GetSolver(DefaultSolver)
+	err = o.lisS.InitR(o.tS, false, false, false)
+	if err != nil {
+		return
+	}
+	err = o.lisS.Fact()
+	if err != nil {
+		return chk.Err(_schur_err5, err)
+	}
+	if o.verb {
+		io.Pf("SchurSolver.Fact: n1 = %d, n2 = %d, nnz(S) = %d. time spent = %v\n", n1, n2, o.tS.pos, time.Now().Sub(tini))
+	}
+	return
+}
+
+// Solve solves the block system K x = b
+//  NOTE: x and b are vectors with the size of the whole system
+func (o *SchurSolver) Solve(x, b []float64) (err error) {
+
+	// check
+	if o.lisA == nil {
+		return chk.Err("Schur solver must be factorised first\n")
+	}
+	bmat, c := o.k.Block(0, 1), o.k.Block(1, 0)
+	b1, b2 := o.k.RowPart(b, 0), o.k.RowPart(b, 1)
+	x1, x2 := o.k.RowPart(x, 0), o.k.RowPart(x, 1)
+
+	// right-hand side of Schur system: w2 = b2 - C inv(A) b1
+	copy(o.w2, b2)
+	if c != nil {
+		err = o.lisA.SolveR(o.y1, b1, false)
+		if err != nil {
+			return
+		}
+		SpMatVecMulAdd(o.w2, -1, c, o.y1)
+	}
+
+	// solve S x2 = w2
+	if o.Implicit {
+		var prec Precond = o.prec
+		if o.Prec != nil {
+			prec = o.Prec
+		}
+		err = o.Krylov.Gmres(x2, o.w2, &schurOperator{o, make([]float64, len(o.w1)), make([]float64, len(o.y1))}, prec)
+		if err != nil {
+			return
+		}
+	} else {
+		err = o.lisS.SolveR(x2, o.w2, false)
+		if err != nil {
+			return
+		}
+	}
+
+	// solve A x1 = b1 - B x2
+	copy(o.w1, b1)
+	if bmat != nil {
+		SpMatVecMulAdd(o.w1, -1, bmat, x2)
+	}
+	return o.lisA.SolveR(x1, o.w1, false)
+}
+
+// Free frees memory
+func (o *SchurSolver) Free() {
+	if o.lisA != nil {
+		o.lisA.Free()
+		o.lisA = nil
+	}
+	if o.lisS != nil {
+		o.lisS.Free()
+		o.lisS = nil
+	}
+	if o.prec != nil {
+		o.prec.Free()
+		o.prec = nil
+	}
+}
+
+// schurOperator implements v := S u = D u - C inv(A) B u
+type schurOperator struct {
+	o      *SchurSolver
+	w1, y1 []float64
+}
+
+// MatVec implements the LinOp interface
+func (s *schurOperator) MatVec(v, u []float64) {
+	b, c, d := s.o.k.Block(0, 1), s.o.k.Block(1, 0), s.o.k.Block(1, 1)
+	VecFill(v, 0)
+	if d != nil {
+		SpMatVecMulAdd(v, 1, d, u)
+	}
+	if b != nil && c != nil {
+		SpMatVecMul(s.w1, 1, b, u)
+		err := s.o.lisA.SolveR(s.y1, s.w1, false)
+		if err != nil {
+			chk.Panic("%v", err)
+		}
+		SpMatVecMulAdd(v, -1, c, s.y1)
+	}
+}
+
+// SpSchurApprox computes an approximation of the Schur complement by replacing A by its diagonal:
+//  S̃ := D - C inv(diag(A)) B
+//  NOTE: (1) b, c and d may be nil, indicating zero blocks
+//        (2) a zero diagonal entry in A is replaced by one
+//        (3) the diagonal of the result is always stored, even if zero
+func SpSchurApprox(a, b, c, d *CCMatrix) (s *CCMatrix) {
+	n2 := 0
+	switch {
+	case d != nil:
+		n2 = d.n
+	case b != nil:
+		n2 = b.n
+	case c != nil:
+		n2 = c.m
+	}
+	var t Triplet
+	nnz := n2
+	if d != nil {
+		nnz += d.nnz
+	}
+	var cdb *CSRMatrix
+	if b != nil && c != nil {
+		cd := c.ToCSR()
+		dinv := make([]float64, a.n)
+		for j := 0; j < a.n; j++ {
+			dinv[j] = 1
+			for k := a.p[j]; k < a.p[j+1]; k++ {
+				if a.i[k] == j && a.x[k] != 0 {
+					dinv[j] = 1 / a.x[k]
+				}
+			}
+		}
+		for k := 0; k < cd.nnz; k++ {
+			cd.x[k] *= dinv[cd.j[k]]
+		}
+		cdb = SpCSRMatMatMul(1, cd, b.ToCSR())
+		nnz += cdb.nnz
+	}
+	t.Init(n2, n2, imax(nnz, 1))
+	for i := 0; i < n2; i++ {
+		t.Put(i, i, 0)
+	}
+	if d != nil {
+		for j := 0; j < d.n; j++ {
+			for k := d.p[j]; k < d.p[j+1]; k++ {
+				t.Put(d.i[k], j, d.x[k])
+			}
+		}
+	}
+	if cdb != nil {
+		for i := 0; i < cdb.m; i++ {
+			for k := cdb.p[i]; k < cdb.p[i+1]; k++ {
+				t.Put(i, cdb.j[k], -cdb.x[k])
+			}
+		}
+	}
+	return t.ToCSR(nil).ToCC()
+}
+
+// spTriGrow returns a copy of the triplet with twice the capacity
+func spTriGrow(t *Triplet) (r *Triplet) {
+	r = new(Triplet)
+	r.Init(t.m, t.n, 2*t.max)
+	for k := 0; k < t.pos; k++ {
+		r.Put(t.i[k], t.j[k], t.x[k])
+	}
+	return
+}
+
+// error messages
+var (
+	_schur_err1 = "schur.go: SchurSolver.Init: block matrix must be 2x2. (%d x %d) is invalid"
+	_schur_err2 = "schur.go: SchurSolver.Init: diagonal blocks must be square"
+	_schur_err3 = "schur.go: SchurSolver.Init: block (0,0) must not be nil"
+	_schur_err4 = "schur.go: SchurSolver.Fact: cannot factorise approximation of Schur complement:\n%v"
+	_schur_err5 = "schur.go: SchurSolver.Fact: cannot factorise Schur complement:\n%v"
+)
@@ -0,0 +1,190 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"container/heap"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SpAmdOrdering computes a fill-reducing ordering of the symmetric pattern of a+aᵀ using the
+// approximate minimum degree (AMD) algorithm. The elimination is simulated on the quotient graph
+// with element absorption and the degrees are approximated by the external degree bound of
+// Amestoy, Davis and Duff.
+//  INPUT:
+//   a -- square matrix; only its structure is used
+//  OUTPUT:
+//   perm -- permutation such that perm[new] = old
+func SpAmdOrdering(a *CSRMatrix) (perm []int) {
+	if a.m != a.n {
+		chk.Panic("AMD ordering requires a square matrix. (%dx%d) is invalid", a.m, a.n)
+	}
+	n := a.m
+	perm = make([]int, n)
+	if n == 0 {
+		return
+	}
+
+	// adjacency of a+aᵀ without the diagonal
+	at := a.Transpose()
+	adj := make([][]int, n)
+	mark := make([]int, n)
+	for i := 0; i < n; i++ {
+		mark[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		mark[i] = i
+		for _, b := range []*CSRMatrix{a, at} {
+			for k := b.p[i]; k < b.p[i+1]; k++ {
+				if j := b.j[k]; mark[j] != i {
+					mark[j] = i
+					adj[i] = append(adj[i], j)
+				}
+			}
+		}
+	}
+
+	// quotient graph
+	const (
+		variable = iota
+		element
+		absorbed
+	)
+	state := make([]int, n)  // state of each node
+	elen := make([][]int, n) // elements adjacent to each variable
+	le := make([][]int, n)   // variables of each element
+	w := make([]int, n)      // |Le \ Lp| for each element
+	wtag := make([]int, n)   // stamps for w
+	deg := make([]int, n)    // approximate degrees
+	q := make(amdHeap, 0, n)
+	for i := 0; i < n; i++ {
+		deg[i] = len(adj[i])
+		q = append(q, amdItem{deg[i], i})
+		mark[i], wtag[i] = 0, 0
+	}
+	heap.Init(&q)
+
+	// elimination
+	tag := 0
+	for k := 0; k < n; k++ {
+
+		// select pivot with minimum degree (skipping outdated heap items)
+		var p int
+		for {
+			it := heap.Pop(&q).(amdItem)
+			if state[it.node] == variable && deg[it.node] == it.deg {
+				p = it.node
+				break
+			}
+		}
+		perm[k] = p
+		state[p] = element
+
+		// Lp := (Ap ∪ Le[e] for e in Ep) \ {p}
+		tag++
+		mark[p] = tag
+		var lp []int
+		for _, v := range adj[p] {
+			if state[v] == variable && mark[v] != tag {
+				mark[v] = tag
+				lp = append(lp, v)
+			}
+		}
+		for _, e := range elen[p] {
+			if state[e] != element {
+				continue
+			}
+			for _, v := range le[e] {
+				if state[v] == variable && mark[v] != tag {
+					mark[v] = tag
+					lp = append(lp, v)
+				}
+			}
+			state[e] = absorbed
+			le[e] = nil
+		}
+		le[p], adj[p], elen[p] = lp, nil, nil
+
+		// prune lists of variables in Lp and compute |Le \ Lp| for neighbour elements
+		for _, i := range lp {
+			ne := elen[i][:0]
+			for _, e := range elen[i] {
+				if state[e] != element {
+					continue
+				}
+				if wtag[e] != tag {
+					wtag[e] = tag
+					lv := le[e][:0]
+					for _, v := range le[e] {
+						if state[v] == variable {
+							lv = append(lv, v)
+						}
+					}
+					le[e] = lv
+					w[e] = len(lv)
+				}
+				w[e]--
+				ne = append(ne, e)
+			}
+			elen[i] = append(ne, p)
+			na := adj[i][:0]
+			for _, v := range adj[i] {
+				if state[v] == variable && mark[v] != tag {
+					na = append(na, v)
+				}
+			}
+			adj[i] = na
+		}
+
+		// approximate degrees with aggressive absorption
+		for _, i := range lp {
+			d := len(adj[i]) + len(lp) - 1
+			ne := elen[i][:0]
+			for _, e := range elen[i] {
+				if e != p && w[e] <= 0 {
+					state[e] = absorbed
+					le[e] = nil
+					continue
+				}
+				if e != p {
+					d += w[e]
+				}
+				ne = append(ne, e)
+			}
+			elen[i] = ne
+			if d > n-k-2 {
+				d = n - k - 2
+			}
+			deg[i] = d
+			heap.Push(&q, amdItem{d, i})
+		}
+	}
+	return
+}
+
+// amdItem holds a node and its degree at the time of insertion into the priority queue
+type amdItem struct {
+	deg, node int
+}
+
+// amdHeap implements a min-heap of amdItem (ties are broken by the node index)
+type amdHeap []amdItem
+
+func (o amdHeap) Len() int { return len(o) }
+func (o amdHeap) Less(a, b int) bool {
+	if o[a].deg == o[b].deg {
+		return o[a].node < o[b].node
+	}
+	return o[a].deg < o[b].deg
+}
+func (o amdHeap) Swap(a, b int)       { o[a], o[b] = o[b], o[a] }
+func (o *amdHeap) Push(x interface{}) { *o = append(*o, x.(amdItem)) }
+func (o *amdHeap) Pop() (x interface{}) {
+	n := len(*o)
+	x = (*o)[n-1]
+	*o = (*o)[:n-1]
+	return
+}
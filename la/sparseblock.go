@@ -0,0 +1,161 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import "github.com/cpmech/gosl/chk"
+
+// BlockMatrix holds a sparse matrix partitioned into blocks; e.g. the 2x2 block system of
+// mixed formulations or of problems with Lagrange multipliers:
+//  [ A00  A01 ] [ x0 ]   [ b0 ]
+//  [ A10  A11 ] [ x1 ] = [ b1 ]
+// Each block is stored in column-compressed form. Blocks that are not set are zero.
+type BlockMatrix struct {
+	rsz, csz   []int         // sizes of row and column partitions
+	roff, coff []int         // offsets of row and column partitions (len = nblocks + 1)
+	blocks     [][]*CCMatrix // [nbrows][nbcols] blocks; nil means zero
+}
+
+// Init initialises the block matrix
+//  INPUT:
+//   rowSizes -- number of rows of each row partition
+//   colSizes -- number of columns of each column partition
+func (o *BlockMatrix) Init(rowSizes, colSizes []int) {
+	o.rsz, o.csz = rowSizes, colSizes
+	o.roff, o.coff = make([]int, len(rowSizes)+1), make([]int, len(colSizes)+1)
+	for i, sz := range rowSizes {
+		o.roff[i+1] = o.roff[i] + sz
+	}
+	for j, sz := range colSizes {
+		o.coff[j+1] = o.coff[j] + sz
+	}
+	o.blocks = make([][]*CCMatrix, len(rowSizes))
+	for i := 0; i < len(rowSizes); i++ {
+		o.blocks[i] = make([]*CCMatrix, len(colSizes))
+	}
+}
+
+// SetBlock sets block (I,J)
+//  NOTE: the matrix is not copied
+func (o *BlockMatrix) SetBlock(I, J int, a *CCMatrix) {
+	if a.m != o.rsz[I] || a.n != o.csz[J] {
+		chk.Panic(_sparseblock_err1, I, J, a.m, a.n, o.rsz[I], o.csz[J])
+	}
+	o.blocks[I][J] = a
+}
+
+// SetBlockT sets block (I,J) by converting a triplet to column-compressed form
+func (o *BlockMatrix) SetBlockT(I, J int, t *Triplet) {
+	o.SetBlock(I, J, t.ToCSR(nil).ToCC())
+}
+
+// SetBlockTr sets block (I,J) with the transpose of "a"; e.g. to set Bᵀ in saddle-point systems
+func (o *BlockMatrix) SetBlockTr(I, J int, a *CCMatrix) {
+	b := a.ToCSR() // the compressed-row arrays of "a" correspond to the compressed-column arrays of aᵀ
+	o.SetBlock(I, J, &CCMatrix{m: a.n, n: a.m, nnz: b.nnz, p: b.p, i: b.j, x: b.x})
+}
+
+// Block returns block (I,J); it may be nil, indicating a zero block
+func (o *BlockMatrix) Block(I, J int) *CCMatrix {
+	return o.blocks[I][J]
+}
+
+// NumBlocks returns the number of row and column partitions
+func (o *BlockMatrix) NumBlocks() (nbrows, nbcols int) {
+	return len(o.rsz), len(o.csz)
+}
+
+// Dims returns the dimensions of the whole matrix
+func (o *BlockMatrix) Dims() (m, n int) {
+	return o.roff[len(o.rsz)], o.coff[len(o.csz)]
+}
+
+// RowPart returns the part of vector v (of size m) corresponding to the row partition I
+//  NOTE: the returned slice shares memory with v
+func (o *BlockMatrix) RowPart(v []float64, I int) []float64 {
+	return v[o.roff[I]:o.roff[I+1]]
+}
+
+// ColPart returns the part of vector u (of size n) corresponding to the column partition J
+//  NOTE: the returned slice shares memory with u
+func (o *BlockMatrix) ColPart(u []float64, J int) []float64 {
+	return u[o.coff[J]:o.coff[J+1]]
+}
+
+// MatVec computes v := K * u and implements the LinOp interface
+func (o *BlockMatrix) MatVec(v, u []float64) {
+	o.MatVecMul(v, 1, u)
+}
+
+// MatVecMul computes the matrix-vector multiplication (scaled):
+//  v := α * K * u  =>  vI = α * Σ_J KIJ * uJ
+func (o *BlockMatrix) MatVecMul(v []float64, α float64, u []float64) {
+	VecFill(v, 0)
+	o.MatVecMulAdd(v, α, u)
+}
+
+// MatVecMulAdd computes the matrix-vector multiplication with addition (scaled):
+//  v += α * K * u  =>  vI += α * Σ_J KIJ * uJ
+func (o *BlockMatrix) MatVecMulAdd(v []float64, α float64, u []float64) {
+	for I, row := range o.blocks {
+		vI := o.RowPart(v, I)
+		for J, a := range row {
+			if a != nil {
+				SpMatVecMulAdd(vI, α, a, o.ColPart(u, J))
+			}
+		}
+	}
+}
+
+// ToTriplet assembles all blocks into a single triplet
+func (o *BlockMatrix) ToTriplet() (t *Triplet) {
+	nnz := 0
+	for _, row := range o.blocks {
+		for _, a := range row {
+			if a != nil {
+				nnz += a.nnz
+			}
+		}
+	}
+	m, n := o.Dims()
+	t = new(Triplet)
+	t.Init(m, n, imax(nnz, 1))
+	for I, row := range o.blocks {
+		for J, a := range row {
+			if a == nil {
+				continue
+			}
+			for j := 0; j < a.n; j++ {
+				for k := a.p[j]; k < a.p[j+1]; k++ {
+					t.Put(o.roff[I]+a.i[k], o.coff[J]+j, a.x[k])
+				}
+			}
+		}
+	}
+	return
+}
+
+// ToDense converts the block matrix to dense form
+func (o *BlockMatrix) ToDense() [][]float64 {
+	m, n := o.Dims()
+	r := MatAlloc(m, n)
+	for I, row := range o.blocks {
+		for J, a := range row {
+			if a == nil {
+				continue
+			}
+			for j := 0; j < a.n; j++ {
+				for k := a.p[j]; k < a.p[j+1]; k++ {
+					r[o.roff[I]+a.i[k]][o.coff[J]+j] += a.x[k]
+				}
+			}
+		}
+	}
+	return r
+}
+
+// error messages
+var (
+	_sparseblock_err1 = "sparseblock.go: BlockMatrix.SetBlock: block (%d,%d) with dimensions (%d x %d) is incompatible with partition (%d x %d)"
+)
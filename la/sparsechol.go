@@ -0,0 +1,289 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SpCholSuper holds the supernodal Cholesky factorisation of a symmetric positive-definite
+// matrix such that:
+//  P * A * Pᵀ = L * Lᵀ
+// where P is a (fill-reducing) symmetric permutation. Columns of L with the same structure
+// are grouped into supernodes, which are stored and factorised as dense blocks using the
+// left-looking algorithm.
+type SpCholSuper struct {
+	n      int         // dimension
+	perm   []int       // permutation: perm[new] = old
+	parent []int       // elimination tree
+	first  []int       // first column of each supernode (len = nsuper+1)
+	rows   [][]int     // row indices of each supernode (the first ncols rows are the diagonal block)
+	blk    [][]float64 // dense blocks of each supernode (column-major: blk[s][c*len(rows[s])+r])
+	work   []float64   // workspace for solution
+}
+
+// Factor computes the Cholesky factorisation of "a"
+//  INPUT:
+//   a    -- square symmetric matrix; only entries in the lower triangle (i ≥ j) are used
+//   perm -- permutation (perm[new] = old); may be nil to indicate the natural ordering
+func (o *SpCholSuper) Factor(a *CSRMatrix, perm []int) (err error) {
+
+	// check
+	if a.m != a.n {
+		return chk.Err(_sparsechol_err1, a.m, a.n)
+	}
+	n := a.n
+	if perm == nil {
+		perm = irange(n)
+	}
+	o.n, o.perm = n, perm
+	o.work = make([]float64, n)
+
+	// lower triangle of C = P * A * Pᵀ in column-compressed form (cp, ci, cx) and
+	// in compressed-row form (rp, rj)
+	iperm := make([]int, n)
+	for inew, iold := range perm {
+		iperm[iold] = inew
+	}
+	cp := make([]int, n+1)
+	for I := 0; I < n; I++ {
+		for k := a.p[I]; k < a.p[I+1]; k++ {
+			if J := a.j[k]; J <= I {
+				cp[imin(iperm[I], iperm[J])+1]++
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		cp[j+1] += cp[j]
+	}
+	ci := make([]int, cp[n])
+	cx := make([]float64, cp[n])
+	next := make([]int, n)
+	copy(next, cp[:n])
+	for I := 0; I < n; I++ {
+		for k := a.p[I]; k < a.p[I+1]; k++ {
+			if J := a.j[k]; J <= I {
+				i, j := iperm[I], iperm[J]
+				if i < j {
+					i, j = j, i
+				}
+				ci[next[j]], cx[next[j]] = i, a.x[k]
+				next[j]++
+			}
+		}
+	}
+	rp, rj, _ := spCompressedTranspose(n, n, cp, ci, cx)
+
+	// elimination tree (rows of the lower triangle are visited in order)
+	o.parent = make([]int, n)
+	ancestor := make([]int, n)
+	for i := 0; i < n; i++ {
+		o.parent[i], ancestor[i] = -1, -1
+		for k := rp[i]; k < rp[i+1]; k++ {
+			for j := rj[k]; j != -1 && j < i; {
+				jnext := ancestor[j]
+				ancestor[j] = i
+				if jnext == -1 {
+					o.parent[j] = i
+				}
+				j = jnext
+			}
+		}
+	}
+
+	// structure of each column of L (below the diagonal)
+	children := make([][]int, n)
+	for j := 0; j < n; j++ {
+		if o.parent[j] >= 0 {
+			children[o.parent[j]] = append(children[o.parent[j]], j)
+		}
+	}
+	mark := make([]int, n)
+	for i := 0; i < n; i++ {
+		mark[i] = -1
+	}
+	pattern := make([][]int, n)
+	for j := 0; j < n; j++ {
+		mark[j] = j
+		var pat []int
+		for p := cp[j]; p < cp[j+1]; p++ {
+			if i := ci[p]; mark[i] != j {
+				mark[i] = j
+				pat = append(pat, i)
+			}
+		}
+		for _, ch := range children[j] {
+			for _, i := range pattern[ch] {
+				if mark[i] != j {
+					mark[i] = j
+					pat = append(pat, i)
+				}
+			}
+		}
+		sort.Ints(pat)
+		pattern[j] = pat
+	}
+
+	// fundamental supernodes
+	o.first = []int{0}
+	for j := 1; j < n; j++ {
+		if !(o.parent[j-1] == j && len(pattern[j]) == len(pattern[j-1])-1 && len(children[j]) == 1) {
+			o.first = append(o.first, j)
+		}
+	}
+	o.first = append(o.first, n)
+	nsuper := len(o.first) - 1
+	super := make([]int, n) // maps column to supernode
+	o.rows = make([][]int, nsuper)
+	o.blk = make([][]float64, nsuper)
+	for s := 0; s < nsuper; s++ {
+		f, l := o.first[s], o.first[s+1]
+		rows := make([]int, 0, l-f+len(pattern[l-1]))
+		for j := f; j < l; j++ {
+			super[j] = s
+			rows = append(rows, j)
+		}
+		o.rows[s] = append(rows, pattern[l-1]...)
+	}
+
+	// supernodes updating each supernode
+	updaters := make([][]int, nsuper)
+	for d := 0; d < nsuper; d++ {
+		last := -1
+		ncols := o.first[d+1] - o.first[d]
+		for _, r := range o.rows[d][ncols:] {
+			if s := super[r]; s != last {
+				updaters[s] = append(updaters[s], d)
+				last = s
+			}
+		}
+	}
+
+	// numeric factorisation
+	local := make([]int, n) // maps row index to local row index in the current supernode
+	for s := 0; s < nsuper; s++ {
+		f, l := o.first[s], o.first[s+1]
+		rows := o.rows[s]
+		nr, nc := len(rows), l-f
+		for r, i := range rows {
+			local[i] = r
+		}
+		b := make([]float64, nr*nc)
+
+		// scatter A
+		for j := f; j < l; j++ {
+			for p := cp[j]; p < cp[j+1]; p++ {
+				b[(j-f)*nr+local[ci[p]]] += cx[p]
+			}
+		}
+
+		// updates from descendant supernodes
+		for _, d := range updaters[s] {
+			rd, bd := o.rows[d], o.blk[d]
+			nrd, ncd := len(rd), o.first[d+1]-o.first[d]
+			i1 := ncd + sort.SearchInts(rd[ncd:], f)
+			i2 := ncd + sort.SearchInts(rd[ncd:], l)
+			for cd := 0; cd < ncd; cd++ {
+				ld := bd[cd*nrd : (cd+1)*nrd]
+				for ia := i1; ia < i2; ia++ {
+					v := ld[ia]
+					if v == 0 {
+						continue
+					}
+					col := b[(rd[ia]-f)*nr : (rd[ia]-f+1)*nr]
+					for ib := ia; ib < nrd; ib++ {
+						col[local[rd[ib]]] -= ld[ib] * v
+					}
+				}
+			}
+		}
+
+		// dense factorisation of the supernode block
+		for k := 0; k < nc; k++ {
+			colk := b[k*nr : (k+1)*nr]
+			for c := 0; c < k; c++ {
+				colc := b[c*nr : (c+1)*nr]
+				v := colc[k]
+				if v == 0 {
+					continue
+				}
+				for r := k; r < nr; r++ {
+					colk[r] -= colc[r] * v
+				}
+			}
+			d := colk[k]
+			if d <= 0 {
+				return chk.Err(_sparsechol_err2, perm[f+k], d)
+			}
+			d = math.Sqrt(d)
+			colk[k] = d
+			for r := k + 1; r < nr; r++ {
+				colk[r] /= d
+			}
+		}
+		o.blk[s] = b
+	}
+	return
+}
+
+// Solve solves the linear system A * x = b using the factors computed by Factor
+//  NOTE: x and b may be the same slice
+func (o *SpCholSuper) Solve(x, b []float64) {
+	y := o.work
+	for k := 0; k < o.n; k++ {
+		y[k] = b[o.perm[k]]
+	}
+	nsuper := len(o.rows)
+	for s := 0; s < nsuper; s++ { // L * z = P * b
+		f, rows, blk := o.first[s], o.rows[s], o.blk[s]
+		nr, nc := len(rows), o.first[s+1]-o.first[s]
+		for c := 0; c < nc; c++ {
+			col := blk[c*nr : (c+1)*nr]
+			y[f+c] /= col[c]
+			yc := y[f+c]
+			for r := c + 1; r < nr; r++ {
+				y[rows[r]] -= col[r] * yc
+			}
+		}
+	}
+	for s := nsuper - 1; s >= 0; s-- { // Lᵀ * w = z
+		f, rows, blk := o.first[s], o.rows[s], o.blk[s]
+		nr, nc := len(rows), o.first[s+1]-o.first[s]
+		for c := nc - 1; c >= 0; c-- {
+			col := blk[c*nr : (c+1)*nr]
+			sum := y[f+c]
+			for r := c + 1; r < nr; r++ {
+				sum -= col[r] * y[rows[r]]
+			}
+			y[f+c] = sum / col[c]
+		}
+	}
+	for k := 0; k < o.n; k++ {
+		x[o.perm[k]] = y[k]
+	}
+}
+
+// NumSuper returns the number of supernodes
+func (o *SpCholSuper) NumSuper() int {
+	return len(o.rows)
+}
+
+// Nnz returns the number of non-zeros in L (including the diagonal)
+func (o *SpCholSuper) Nnz() (nnz int) {
+	for s := 0; s < len(o.rows); s++ {
+		nc := o.first[s+1] - o.first[s]
+		nnz += nc*len(o.rows[s]) - nc*(nc-1)/2
+	}
+	return
+}
+
+// error messages
+var (
+	_sparsechol_err1 = "sparsechol.go: SpCholSuper.Factor: matrix must be square. (%d x %d) is invalid"
+	_sparsechol_err2 = "sparsechol.go: SpCholSuper.Factor: matrix is not positive-definite (pivot of row %d = %g)"
+)
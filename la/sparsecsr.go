@@ -0,0 +1,469 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// CSRMatrix represents a sparse matrix using the so-called "compressed sparse row format".
+//  NOTE: the column indices within each row are kept sorted in increasing order
+type CSRMatrix struct {
+	m, n int       // matrix dimension (rows, columns)
+	nnz  int       // number of non-zeros
+	p, j []int     // pointers and column indices (len(p)=m+1, len(j)=nnz)
+	x    []float64 // values (len(x)=nnz)
+}
+
+// Set sets compressed-row matrix directly
+//  NOTE: the column indices within each row must be sorted
+func (o *CSRMatrix) Set(m, n int, Ap, Aj []int, Ax []float64) {
+	if len(Ap)-1 != m {
+		chk.Panic("len(Ap)-1 must be equal to m. %d != %d", len(Ap)-1, m)
+	}
+	nnz := len(Aj)
+	if len(Ax) != nnz {
+		chk.Panic("len(Ax) must be equal to len(Aj) == nnz. %d != %d", len(Ax), nnz)
+	}
+	if Ap[m] != nnz {
+		chk.Panic("last item in Ap must be equal to nnz. %d != %d", Ap[m], nnz)
+	}
+	o.m, o.n, o.nnz = m, n, nnz
+	o.p, o.j, o.x = Ap, Aj, Ax
+}
+
+// Dims returns the number of rows and columns
+func (o *CSRMatrix) Dims() (m, n int) {
+	return o.m, o.n
+}
+
+// Nnz returns the number of non-zeros
+func (o *CSRMatrix) Nnz() int {
+	return o.nnz
+}
+
+// Get returns the value at (i,j); zero is returned if the entry is not stored
+func (o *CSRMatrix) Get(i, j int) float64 {
+	start, endp1 := o.p[i], o.p[i+1]
+	k := start + sort.SearchInts(o.j[start:endp1], j)
+	if k < endp1 && o.j[k] == j {
+		return o.x[k]
+	}
+	return 0
+}
+
+// --------------------------------------------------------------------------------------------------
+// conversions --------------------------------------------------------------------------------------
+// --------------------------------------------------------------------------------------------------
+
+// ToCSR converts a sparse matrix in triplet form to compressed-row form. Duplicated entries
+// are summed up. This routine does not depend on external libraries.
+//  INPUT:
+//   a -- a previous CSRMatrix to be filled in; otherwise, "nil" tells to allocate a new one
+//  OUTPUT:
+//   the previous "a" matrix or a pointer to a new one
+func (t *Triplet) ToCSR(a *CSRMatrix) *CSRMatrix {
+	if a == nil {
+		a = new(CSRMatrix)
+	}
+
+	// count entries per row (with repetitions)
+	cnt := make([]int, t.m+1)
+	for k := 0; k < t.pos; k++ {
+		cnt[t.i[k]+1]++
+	}
+	for i := 0; i < t.m; i++ {
+		cnt[i+1] += cnt[i]
+	}
+
+	// scatter into rows (with repetitions)
+	tj := make([]int, t.pos)
+	tx := make([]float64, t.pos)
+	next := make([]int, t.m)
+	copy(next, cnt[:t.m])
+	for k := 0; k < t.pos; k++ {
+		r := t.i[k]
+		tj[next[r]], tx[next[r]] = t.j[k], t.x[k]
+		next[r]++
+	}
+
+	// sort columns within each row and sum duplicates
+	a.m, a.n = t.m, t.n
+	a.p = make([]int, t.m+1)
+	a.j = make([]int, 0, t.pos)
+	a.x = make([]float64, 0, t.pos)
+	for i := 0; i < t.m; i++ {
+		sort.Sort(&csrRowSorter{tj[cnt[i]:cnt[i+1]], tx[cnt[i]:cnt[i+1]]})
+		for k := cnt[i]; k < cnt[i+1]; k++ {
+			last := len(a.j) - 1
+			if last >= a.p[i] && a.j[last] == tj[k] {
+				a.x[last] += tx[k]
+				continue
+			}
+			a.j = append(a.j, tj[k])
+			a.x = append(a.x, tx[k])
+		}
+		a.p[i+1] = len(a.j)
+	}
+	a.nnz = len(a.j)
+	return a
+}
+
+// ToCSR converts a column-compressed matrix to compressed-row form
+func (a *CCMatrix) ToCSR() (b *CSRMatrix) {
+	p, j, x := spCompressedTranspose(a.m, a.n, a.p, a.i, a.x)
+	b = new(CSRMatrix)
+	b.m, b.n, b.nnz = a.m, a.n, len(j)
+	b.p, b.j, b.x = p, j, x
+	return
+}
+
+// ToCC converts a compressed-row matrix to column-compressed form
+func (a *CSRMatrix) ToCC() (b *CCMatrix) {
+	p, i, x := spCompressedTranspose(a.n, a.m, a.p, a.j, a.x)
+	b = new(CCMatrix)
+	b.m, b.n, b.nnz = a.m, a.n, len(i)
+	b.p, b.i, b.x = p, i, x
+	return
+}
+
+// ToTriplet converts a compressed-row matrix to triplet form
+func (a *CSRMatrix) ToTriplet() (t *Triplet) {
+	t = new(Triplet)
+	t.Init(a.m, a.n, imax(a.nnz, 1))
+	for i := 0; i < a.m; i++ {
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			t.Put(i, a.j[k], a.x[k])
+		}
+	}
+	return
+}
+
+// ToDense converts a compressed-row matrix to dense form
+func (a *CSRMatrix) ToDense() [][]float64 {
+	r := MatAlloc(a.m, a.n)
+	for i := 0; i < a.m; i++ {
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			r[i][a.j[k]] = a.x[k]
+		}
+	}
+	return r
+}
+
+// Transpose returns the transpose of a compressed-row matrix:
+//  b := aᵀ
+func (a *CSRMatrix) Transpose() (b *CSRMatrix) {
+	p, j, x := spCompressedTranspose(a.n, a.m, a.p, a.j, a.x)
+	b = new(CSRMatrix)
+	b.m, b.n, b.nnz = a.n, a.m, len(j)
+	b.p, b.j, b.x = p, j, x
+	return
+}
+
+// --------------------------------------------------------------------------------------------------
+// matrix-vector ------------------------------------------------------------------------------------
+// --------------------------------------------------------------------------------------------------
+
+// SpCSRMatVecMul returns the (sparse/compressed-row) matrix-vector multiplication (scaled):
+//  v := α * a * u  =>  vi = α * aij * uj
+//  NOTE: rows are distributed among goroutines if Pll == true
+func SpCSRMatVecMul(v []float64, α float64, a *CSRMatrix, u []float64) {
+	if Pll {
+		ncpu := imin(a.m, NCPU)
+		ch := make(chan int, ncpu)
+		for icpu := 0; icpu < ncpu; icpu++ {
+			start, endp1 := (icpu*a.m)/ncpu, ((icpu+1)*a.m)/ncpu
+			go func() {
+				spCSRMatVecMulRows(v, α, a, u, start, endp1, false)
+				ch <- 1
+			}()
+		}
+		for icpu := 0; icpu < ncpu; icpu++ {
+			<-ch
+		}
+	} else {
+		spCSRMatVecMulRows(v, α, a, u, 0, a.m, false)
+	}
+}
+
+// SpCSRMatVecMulAdd returns the (sparse/compressed-row) matrix-vector multiplication with addition (scaled):
+//  v += α * a * u  =>  vi += α * aij * uj
+//  NOTE: rows are distributed among goroutines if Pll == true
+func SpCSRMatVecMulAdd(v []float64, α float64, a *CSRMatrix, u []float64) {
+	if Pll {
+		ncpu := imin(a.m, NCPU)
+		ch := make(chan int, ncpu)
+		for icpu := 0; icpu < ncpu; icpu++ {
+			start, endp1 := (icpu*a.m)/ncpu, ((icpu+1)*a.m)/ncpu
+			go func() {
+				spCSRMatVecMulRows(v, α, a, u, start, endp1, true)
+				ch <- 1
+			}()
+		}
+		for icpu := 0; icpu < ncpu; icpu++ {
+			<-ch
+		}
+	} else {
+		spCSRMatVecMulRows(v, α, a, u, 0, a.m, true)
+	}
+}
+
+// SpCSRMatTrVecMul returns the (sparse/compressed-row) matrix-vector multiplication with "a" transposed (scaled):
+//  v := α * transp(a) * u  =>  vj = α * aij * ui
+//  NOTE: dense vector v will be first initialised with zeros
+func SpCSRMatTrVecMul(v []float64, α float64, a *CSRMatrix, u []float64) {
+	VecFill(v, 0)
+	for i := 0; i < a.m; i++ {
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			v[a.j[k]] += α * a.x[k] * u[i]
+		}
+	}
+}
+
+// --------------------------------------------------------------------------------------------------
+// matrix-matrix ------------------------------------------------------------------------------------
+// --------------------------------------------------------------------------------------------------
+
+// SpCSRMatMatMul computes the sparse-sparse matrix multiplication (SpGEMM):
+//  c := α * a * b  =>  cij = α * aik * bkj
+//  NOTE: Gustavson's algorithm is employed with one symbolic and one numeric pass
+func SpCSRMatMatMul(α float64, a, b *CSRMatrix) (c *CSRMatrix) {
+	if a.n != b.m {
+		chk.Panic("number of columns of 'a' (%dx%d) must be equal to number of rows of 'b' (%dx%d)", a.m, a.n, b.m, b.n)
+	}
+
+	// symbolic pass: count non-zeros of each row of c
+	c = new(CSRMatrix)
+	c.m, c.n = a.m, b.n
+	c.p = make([]int, a.m+1)
+	mark := make([]int, b.n)
+	for j := 0; j < b.n; j++ {
+		mark[j] = -1
+	}
+	for i := 0; i < a.m; i++ {
+		nrow := 0
+		for ka := a.p[i]; ka < a.p[i+1]; ka++ {
+			r := a.j[ka]
+			for kb := b.p[r]; kb < b.p[r+1]; kb++ {
+				if mark[b.j[kb]] != i {
+					mark[b.j[kb]] = i
+					nrow++
+				}
+			}
+		}
+		c.p[i+1] = c.p[i] + nrow
+	}
+
+	// numeric pass
+	c.nnz = c.p[a.m]
+	c.j = make([]int, c.nnz)
+	c.x = make([]float64, c.nnz)
+	acc := make([]float64, b.n)
+	for j := 0; j < b.n; j++ {
+		mark[j] = -1
+	}
+	for i := 0; i < a.m; i++ {
+		pos := c.p[i]
+		for ka := a.p[i]; ka < a.p[i+1]; ka++ {
+			r, aik := a.j[ka], a.x[ka]
+			for kb := b.p[r]; kb < b.p[r+1]; kb++ {
+				col := b.j[kb]
+				if mark[col] != i {
+					mark[col] = i
+					acc[col] = 0
+					c.j[pos] = col
+					pos++
+				}
+				acc[col] += aik * b.x[kb]
+			}
+		}
+		sort.Ints(c.j[c.p[i]:pos])
+		for k := c.p[i]; k < pos; k++ {
+			c.x[k] = α * acc[c.j[k]]
+		}
+	}
+	return
+}
+
+// SpCSRPermute computes the symmetric permutation of a square matrix:
+//  b := P * a * Pᵀ  =>  b[i][j] = a[perm[i]][perm[j]]
+//  NOTE: perm[new] = old
+func SpCSRPermute(a *CSRMatrix, perm []int) (b *CSRMatrix) {
+	if a.m != a.n {
+		chk.Panic("matrix must be square. (%dx%d) is invalid", a.m, a.n)
+	}
+	if len(perm) != a.n {
+		chk.Panic("len(perm) must be equal to %d. %d is invalid", a.n, len(perm))
+	}
+	iperm := make([]int, a.n)
+	for inew, iold := range perm {
+		iperm[iold] = inew
+	}
+	b = new(CSRMatrix)
+	b.m, b.n, b.nnz = a.m, a.n, a.nnz
+	b.p = make([]int, a.m+1)
+	b.j = make([]int, a.nnz)
+	b.x = make([]float64, a.nnz)
+	for inew := 0; inew < a.m; inew++ {
+		iold := perm[inew]
+		start := b.p[inew]
+		pos := start
+		for k := a.p[iold]; k < a.p[iold+1]; k++ {
+			b.j[pos], b.x[pos] = iperm[a.j[k]], a.x[k]
+			pos++
+		}
+		b.p[inew+1] = pos
+		sort.Sort(&csrRowSorter{b.j[start:pos], b.x[start:pos]})
+	}
+	return
+}
+
+// SpCSRSubMat extracts the submatrix of "a" corresponding to the given rows and columns:
+//  b := a[rows][cols]  =>  b[I][J] = a[rows[I]][cols[J]]
+//  NOTE: rows == nil or cols == nil means all rows or all columns, respectively
+func SpCSRSubMat(a *CSRMatrix, rows, cols []int) (b *CSRMatrix) {
+	if rows == nil {
+		rows = irange(a.m)
+	}
+	colmap := make([]int, a.n) // maps old column to new column
+	if cols == nil {
+		for j := 0; j < a.n; j++ {
+			colmap[j] = j
+		}
+		cols = colmap
+	} else {
+		for j := 0; j < a.n; j++ {
+			colmap[j] = -1
+		}
+		for J, j := range cols {
+			colmap[j] = J
+		}
+	}
+	b = new(CSRMatrix)
+	b.m, b.n = len(rows), len(cols)
+	b.p = make([]int, b.m+1)
+	for I, i := range rows {
+		start := len(b.j)
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			if J := colmap[a.j[k]]; J >= 0 {
+				b.j = append(b.j, J)
+				b.x = append(b.x, a.x[k])
+			}
+		}
+		b.p[I+1] = len(b.j)
+		sort.Sort(&csrRowSorter{b.j[start:], b.x[start:]})
+	}
+	b.nnz = len(b.j)
+	return
+}
+
+// SpCSRRowSlice extracts a range of rows of "a":
+//  b := a[start:endp1][:]
+func SpCSRRowSlice(a *CSRMatrix, start, endp1 int) (b *CSRMatrix) {
+	if start < 0 || endp1 > a.m || start > endp1 {
+		chk.Panic("invalid range of rows [%d,%d) for matrix with %d rows", start, endp1, a.m)
+	}
+	k0, k1 := a.p[start], a.p[endp1]
+	b = new(CSRMatrix)
+	b.m, b.n, b.nnz = endp1-start, a.n, k1-k0
+	b.p = make([]int, b.m+1)
+	for i := start; i <= endp1; i++ {
+		b.p[i-start] = a.p[i] - k0
+	}
+	b.j = make([]int, b.nnz)
+	b.x = make([]float64, b.nnz)
+	copy(b.j, a.j[k0:k1])
+	copy(b.x, a.x[k0:k1])
+	return
+}
+
+// SpCSRKron computes the Kronecker product of two sparse matrices:
+//  c := a ⊗ b  =>  c[ia*mb+ib][ja*nb+jb] = a[ia][ja] * b[ib][jb]
+func SpCSRKron(a, b *CSRMatrix) (c *CSRMatrix) {
+	c = new(CSRMatrix)
+	c.m, c.n, c.nnz = a.m*b.m, a.n*b.n, a.nnz*b.nnz
+	c.p = make([]int, c.m+1)
+	c.j = make([]int, c.nnz)
+	c.x = make([]float64, c.nnz)
+	pos := 0
+	for ia := 0; ia < a.m; ia++ {
+		for ib := 0; ib < b.m; ib++ {
+			for ka := a.p[ia]; ka < a.p[ia+1]; ka++ {
+				for kb := b.p[ib]; kb < b.p[ib+1]; kb++ {
+					c.j[pos] = a.j[ka]*b.n + b.j[kb]
+					c.x[pos] = a.x[ka] * b.x[kb]
+					pos++
+				}
+			}
+			c.p[ia*b.m+ib+1] = pos
+		}
+	}
+	return
+}
+
+// --------------------------------------------------------------------------------------------------
+// auxiliary ----------------------------------------------------------------------------------------
+// --------------------------------------------------------------------------------------------------
+
+// spCSRMatVecMulRows computes v[i] (+)= α * a[i][j] * u[j] for rows i in [start,endp1)
+func spCSRMatVecMulRows(v []float64, α float64, a *CSRMatrix, u []float64, start, endp1 int, add bool) {
+	for i := start; i < endp1; i++ {
+		sum := 0.0
+		for k := a.p[i]; k < a.p[i+1]; k++ {
+			sum += a.x[k] * u[a.j[k]]
+		}
+		if add {
+			v[i] += α * sum
+		} else {
+			v[i] = α * sum
+		}
+	}
+}
+
+// spCompressedTranspose transposes compressed arrays. It works for both compressed-row and
+// column-compressed formats, since the compressed-row form of a matrix has the same arrays
+// as the column-compressed form of its transpose.
+//  INPUT:
+//   nout -- number of compressed items in the output (e.g. number of columns of CSR input)
+//   nin  -- number of compressed items in the input (e.g. number of rows of CSR input)
+//  OUTPUT:
+//   q, k, y -- pointers, indices and values of the transposed arrays (indices are sorted)
+func spCompressedTranspose(nout, nin int, p, idx []int, x []float64) (q, k []int, y []float64) {
+	nnz := p[nin]
+	q = make([]int, nout+1)
+	k = make([]int, nnz)
+	y = make([]float64, nnz)
+	for l := 0; l < nnz; l++ {
+		q[idx[l]+1]++
+	}
+	for r := 0; r < nout; r++ {
+		q[r+1] += q[r]
+	}
+	next := make([]int, nout)
+	copy(next, q[:nout])
+	for c := 0; c < nin; c++ {
+		for l := p[c]; l < p[c+1]; l++ {
+			r := idx[l]
+			k[next[r]], y[next[r]] = c, x[l]
+			next[r]++
+		}
+	}
+	return
+}
+
+// csrRowSorter sorts column indices and values of a single row
+type csrRowSorter struct {
+	j []int
+	x []float64
+}
+
+func (o *csrRowSorter) Len() int           { return len(o.j) }
+func (o *csrRowSorter) Less(a, b int) bool { return o.j[a] < o.j[b] }
+func (o *csrRowSorter) Swap(a, b int) {
+	o.j[a], o.j[b] = o.j[b], o.j[a]
+	o.x[a], o.x[b] = o.x[b], o.x[a]
+}
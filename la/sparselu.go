@@ -0,0 +1,221 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SpLU holds the sparse LU factorisation of a square matrix such that:
+//  P * A * Q = L * U
+// where P is a row permutation computed by partial pivoting, Q is a (fill-reducing) column
+// permutation, L is a unit lower triangular matrix and U is an upper triangular matrix.
+// The left-looking algorithm of Gilbert and Peierls is employed.
+type SpLU struct {
+	PivTol float64 // threshold for partial pivoting: the diagonal is preferred if |diag| ≥ PivTol * max|column|
+
+	n      int       // dimension
+	q      []int     // column permutation: q[new] = old
+	pinv   []int     // inverse row permutation: pinv[old] = new
+	lp, li []int     // L: column pointers and row indices (diagonal is the first item in each column)
+	lx     []float64 // L: values
+	up, ui []int     // U: column pointers and row indices (diagonal is the last item in each column)
+	ux     []float64 // U: values
+	work   []float64 // workspace for solution
+}
+
+// Factor computes the LU factorisation of "a"
+//  INPUT:
+//   a -- square matrix in column-compressed form
+//   q -- column permutation (q[new] = old); may be nil to indicate the natural ordering
+func (o *SpLU) Factor(a *CCMatrix, q []int) (err error) {
+
+	// check
+	if a.m != a.n {
+		return chk.Err(_sparselu_err1, a.m, a.n)
+	}
+	n := a.n
+	if q == nil {
+		q = irange(n)
+	}
+	if o.PivTol <= 0 || o.PivTol > 1 {
+		o.PivTol = 0.1
+	}
+
+	// allocate
+	o.n, o.q = n, q
+	o.pinv = make([]int, n)
+	o.lp = make([]int, n+1)
+	o.up = make([]int, n+1)
+	o.li, o.lx = make([]int, 0, 4*a.nnz+n), make([]float64, 0, 4*a.nnz+n)
+	o.ui, o.ux = make([]int, 0, 4*a.nnz+n), make([]float64, 0, 4*a.nnz+n)
+	o.work = make([]float64, n)
+	for i := 0; i < n; i++ {
+		o.pinv[i] = -1
+	}
+
+	// workspace
+	x := make([]float64, n)   // dense column
+	xi := make([]int, n)      // nonzero pattern of x (in topological order at xi[top:])
+	stack := make([]int, n)   // DFS stack
+	pstack := make([]int, n)  // DFS positions
+	visited := make([]int, n) // DFS stamps
+	for i := 0; i < n; i++ {
+		visited[i] = -1
+	}
+
+	// loop over columns
+	for k := 0; k < n; k++ {
+		o.lp[k], o.up[k] = len(o.li), len(o.ui)
+		col := q[k]
+
+		// symbolic: reach of A(:,col) in the graph of L
+		top := n
+		for pa := a.p[col]; pa < a.p[col+1]; pa++ {
+			if visited[a.i[pa]] != k {
+				top = o.dfs(a.i[pa], k, top, xi, stack, pstack, visited)
+			}
+		}
+
+		// numeric: sparse triangular solve L * x = A(:,col)
+		for px := top; px < n; px++ {
+			x[xi[px]] = 0
+		}
+		for pa := a.p[col]; pa < a.p[col+1]; pa++ {
+			x[a.i[pa]] = a.x[pa]
+		}
+		for px := top; px < n; px++ {
+			j := xi[px]
+			jn := o.pinv[j]
+			if jn < 0 {
+				continue
+			}
+			xj := x[j]
+			for p := o.lp[jn] + 1; p < o.lp[jn+1]; p++ {
+				x[o.li[p]] -= o.lx[p] * xj
+			}
+		}
+
+		// select pivot and store U(:,k)
+		ipiv, amax := -1, -1.0
+		for px := top; px < n; px++ {
+			i := xi[px]
+			if o.pinv[i] < 0 {
+				if t := math.Abs(x[i]); t > amax {
+					amax, ipiv = t, i
+				}
+			} else {
+				o.ui = append(o.ui, o.pinv[i])
+				o.ux = append(o.ux, x[i])
+			}
+		}
+		if ipiv < 0 || amax <= 0 {
+			return chk.Err(_sparselu_err2, k)
+		}
+		if o.pinv[col] < 0 && visited[col] == k && math.Abs(x[col]) >= o.PivTol*amax {
+			ipiv = col
+		}
+		pivot := x[ipiv]
+		o.ui = append(o.ui, k)
+		o.ux = append(o.ux, pivot)
+		o.pinv[ipiv] = k
+
+		// store L(:,k)
+		o.li = append(o.li, ipiv)
+		o.lx = append(o.lx, 1)
+		for px := top; px < n; px++ {
+			i := xi[px]
+			if o.pinv[i] < 0 {
+				o.li = append(o.li, i)
+				o.lx = append(o.lx, x[i]/pivot)
+			}
+			x[i] = 0
+		}
+	}
+	o.lp[n], o.up[n] = len(o.li), len(o.ui)
+
+	// finalise row indices of L
+	for p := 0; p < len(o.li); p++ {
+		o.li[p] = o.pinv[o.li[p]]
+	}
+	return
+}
+
+// Solve solves the linear system A * x = b using the factors computed by Factor
+//  NOTE: x and b may be the same slice
+func (o *SpLU) Solve(x, b []float64) {
+	y := o.work
+	for i := 0; i < o.n; i++ {
+		y[o.pinv[i]] = b[i]
+	}
+	for j := 0; j < o.n; j++ { // L * z = P * b
+		for p := o.lp[j] + 1; p < o.lp[j+1]; p++ {
+			y[o.li[p]] -= o.lx[p] * y[j]
+		}
+	}
+	for j := o.n - 1; j >= 0; j-- { // U * w = z
+		y[j] /= o.ux[o.up[j+1]-1]
+		for p := o.up[j]; p < o.up[j+1]-1; p++ {
+			y[o.ui[p]] -= o.ux[p] * y[j]
+		}
+	}
+	for k := 0; k < o.n; k++ {
+		x[o.q[k]] = y[k]
+	}
+}
+
+// Nnz returns the number of non-zeros in L and U (including the diagonals of both)
+func (o *SpLU) Nnz() (nnzL, nnzU int) {
+	return len(o.li), len(o.ui)
+}
+
+// dfs performs a non-recursive depth-first search in the graph of L starting at row "j",
+// for the current column "k". The nodes are stored in xi[top-1], xi[top-2], ... in
+// topological order
+func (o *SpLU) dfs(j, k, top int, xi, stack, pstack, visited []int) int {
+	head := 0
+	stack[0] = j
+	for head >= 0 {
+		j = stack[head]
+		jn := o.pinv[j]
+		if visited[j] != k {
+			visited[j] = k
+			if jn < 0 {
+				pstack[head] = 0
+			} else {
+				pstack[head] = o.lp[jn] + 1
+			}
+		}
+		done := true
+		if jn >= 0 {
+			end := o.lp[jn+1]
+			for p := pstack[head]; p < end; p++ {
+				i := o.li[p]
+				if visited[i] == k {
+					continue
+				}
+				pstack[head] = p + 1
+				head++
+				stack[head] = i
+				done = false
+				break
+			}
+		}
+		if done {
+			head--
+			top--
+			xi[top] = j
+		}
+	}
+	return top
+}
+
+// error messages
+var (
+	_sparselu_err1 = "sparselu.go: SpLU.Factor: matrix must be square. (%d x %d) is invalid"
+	_sparselu_err2 = "sparselu.go: SpLU.Factor: matrix is structurally or numerically singular (column %d)"
+)
@@ -94,6 +94,17 @@ func (t *Triplet) Max() int {
 	return t.max
 }
 
+// Size returns the number of rows and columns of the matrix represented by the triplet
+func (t *Triplet) Size() (m, n int) {
+	return t.m, t.n
+}
+
+// Entry returns the row index i, column index j and value x of the k-th item inserted in the
+// triplet. k must be smaller than Len()
+func (t *Triplet) Entry(k int) (i, j int, x float64) {
+	return t.i[k], t.j[k], t.x[k]
+}
+
 // Set sets column-compressed matrix directly
 func (o *CCMatrix) Set(m, n int, Ap, Ai []int, Ax []float64) {
 	if len(Ap)-1 != n {
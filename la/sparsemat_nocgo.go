@@ -0,0 +1,64 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !cgo
+
+package la
+
+import "github.com/cpmech/gosl/chk"
+
+// ToMatrix converts a sparse matrix in triplet form to column-compressed form. This version
+// is used when cgo (Umfpack) is not available.
+//  INPUT:
+//   a -- a previous CCMatrix to be filled in; otherwise, "nil" tells to allocate a new one
+//  OUTPUT:
+//   the previous "a" matrix or a pointer to a new one
+func (t *Triplet) ToMatrix(a *CCMatrix) *CCMatrix {
+	if t.pos < 1 {
+		chk.Panic(_sparsemat_nocgo_err1, t.pos)
+	}
+	b := t.ToCSR(nil).ToCC()
+	if a == nil {
+		return b
+	}
+	*a = *b
+	return a
+}
+
+// ToMatrix converts a sparse matrix in triplet form with complex numbers to column-compressed form.
+// This version is used when cgo (Umfpack) is not available.
+//  INPUT:
+//   a -- a previous CCMatrixC to be filled in; otherwise, "nil" tells to allocate a new one
+//  OUTPUT:
+//   the previous "a" matrix or a pointer to a new one
+func (t *TripletC) ToMatrix(a *CCMatrixC) *CCMatrixC {
+	if t.pos < 1 {
+		chk.Panic(_sparsemat_nocgo_err2, t.pos)
+	}
+	var tx, tz Triplet
+	tx.Init(t.m, t.n, t.pos)
+	tz.Init(t.m, t.n, t.pos)
+	for k := 0; k < t.pos; k++ {
+		if t.xz != nil {
+			tx.Put(t.i[k], t.j[k], t.xz[k*2])
+			tz.Put(t.i[k], t.j[k], t.xz[k*2+1])
+		} else {
+			tx.Put(t.i[k], t.j[k], t.x[k])
+			tz.Put(t.i[k], t.j[k], t.z[k])
+		}
+	}
+	bx, bz := tx.ToCSR(nil).ToCC(), tz.ToCSR(nil).ToCC() // same structure
+	if a == nil {
+		a = new(CCMatrixC)
+	}
+	a.m, a.n, a.nnz = bx.m, bx.n, bx.nnz
+	a.p, a.i, a.x, a.z = bx.p, bx.i, bx.x, bz.x
+	return a
+}
+
+// error messages
+var (
+	_sparsemat_nocgo_err1 = "sparsemat_nocgo.go: la.Triplet.ToMatrix: conversion can only be made for non-empty triplets. error: (pos = %d)"
+	_sparsemat_nocgo_err2 = "sparsemat_nocgo.go: la.TripletC.ToMatrix: conversion can only be made for non-empty triplets. error: (pos = %d)"
+)
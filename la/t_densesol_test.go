@@ -123,3 +123,70 @@ func TestSPDsolve02(tst *testing.T) {
 	})
 	chk.Vector(tst, "X = inv(a) * B", 1e-13, X, []float64{0, 4, 7, -1, 8})
 }
+
+func TestDenseLU01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TestDenseLU 01")
+
+	// non-symmetric matrix requiring pivoting
+	a := [][]float64{
+		{0, 2, 1},
+		{1, 1, 0},
+		{2, 0, 3},
+	}
+	acopy := MatClone(a)
+	var lu DenseLU
+	err := lu.Factor(a)
+	if err != nil {
+		tst.Errorf("Factor failed: %v\n", err)
+		return
+	}
+	chk.Matrix(tst, "a (unmodified)", 1e-17, a, acopy)
+	chk.Scalar(tst, "det(a)", 1e-15, lu.Det(), -8)
+
+	// single right-hand side; x and b are the same slice
+	b := []float64{7, 3, 11}
+	x := []float64{7, 3, 11}
+	lu.Solve(x, x)
+	CheckResidR(tst, 1e-15, a, x, b)
+	chk.Vector(tst, "x = inv(a) * b", 1e-15, x, []float64{1, 2, 3})
+
+	// multiple right-hand sides
+	B := [][]float64{
+		{7, 2},
+		{3, 2},
+		{11, 2},
+	}
+	X := MatAlloc(3, 2)
+	lu.SolveMat(X, B)
+	chk.Matrix(tst, "X = inv(a) * B", 1e-15, X, [][]float64{
+		{1, 1},
+		{2, 1},
+		{3, 0},
+	})
+
+	// inverse
+	ai := MatAlloc(3, 3)
+	lu.Inverse(ai)
+	chk.Matrix(tst, "ai = inv(a)", 1e-15, ai, [][]float64{
+		{-3.0 / 8.0, 6.0 / 8.0, 1.0 / 8.0},
+		{3.0 / 8.0, 2.0 / 8.0, -1.0 / 8.0},
+		{2.0 / 8.0, -4.0 / 8.0, 2.0 / 8.0},
+	})
+
+	// DenseSolve
+	y := make([]float64, 3)
+	err = DenseSolve(y, a, b)
+	if err != nil {
+		tst.Errorf("DenseSolve failed: %v\n", err)
+		return
+	}
+	chk.Vector(tst, "y = inv(a) * b", 1e-15, y, []float64{1, 2, 3})
+
+	// singular matrix
+	err = DenseSolve(y, [][]float64{{1, 2}, {2, 4}}, []float64{1, 2})
+	if err == nil {
+		tst.Errorf("DenseSolve should have failed with singular matrix\n")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/mpi"
+)
+
+// laplacian puts the rows [start,endp1) of the 2D Laplacian with a convection term into t
+func laplacian(t *la.Triplet, nx, ny, start, endp1 int, conv float64) {
+	n := nx * ny
+	t.Init(n, n, 6*n)
+	for r := start; r < endp1; r++ {
+		i, j := r%nx, r/nx
+		t.Put(r, r, 4)
+		if i > 0 {
+			t.Put(r, r-1, -1-conv)
+		}
+		if i < nx-1 {
+			t.Put(r, r+1, -1)
+		}
+		if j > 0 {
+			t.Put(r, r-nx, -1)
+		}
+		if j < ny-1 {
+			t.Put(r, r+nx, -1)
+		}
+	}
+}
+
+func main() {
+
+	mpi.Start(false)
+	defer mpi.Stop(false)
+
+	if mpi.Rank() == 0 {
+		chk.PrintTitle("Test DistMatrix 01. distributed matrix-vector product and dot product")
+	}
+
+	nx, ny := 13, 7
+	n := nx * ny
+	if mpi.Size() > ny {
+		chk.Panic("the number of processors must be smaller than or equal to %d", ny)
+	}
+
+	// distributed matrix
+	start, endp1 := la.DistRange(n)
+	var t la.Triplet
+	laplacian(&t, nx, ny, start, endp1, 0.5)
+	var A la.DistMatrix
+	err := A.Init(&t, start, endp1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	io.Pf("proc # %d: range = [%d,%d) number of ghosts = %d\n", mpi.Rank(), start, endp1, A.NumGhosts())
+
+	// serial matrix
+	var ts la.Triplet
+	laplacian(&ts, nx, ny, 0, n, 0.5)
+	As := ts.ToCSR(nil)
+
+	// vectors
+	u := la.VecGetMapped(n, func(i int) float64 { return float64(i*i%11) - 5 })
+	v := make([]float64, n)
+	As.MatVec(v, u)
+	uloc := u[start:endp1]
+	vloc := make([]float64, endp1-start)
+
+	// distributed product
+	A.MatVecMul(vloc, 2, uloc)
+	la.VecCopy(vloc, 0.5, vloc)
+	var tst testing.T
+	chk.Vector(&tst, io.Sf("v @ proc %d", mpi.Rank()), 1e-13, vloc, v[start:endp1])
+	chk.Vector(&tst, io.Sf("gathered v @ proc %d", mpi.Rank()), 1e-13, A.Gather(vloc), v)
+
+	// dot product
+	chk.Scalar(&tst, io.Sf("u・v @ proc %d", mpi.Rank()), 1e-11, la.DistDot(uloc, vloc), la.VecDot(u, v))
+	chk.Scalar(&tst, io.Sf("‖u‖ @ proc %d", mpi.Rank()), 1e-13, la.DistNorm(uloc), la.VecNorm(u))
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/mpi"
+)
+
+// laplacian puts the rows [start,endp1) of the 2D Laplacian with a convection term into t
+func laplacian(t *la.Triplet, nx, ny, start, endp1 int, conv float64) {
+	n := nx * ny
+	t.Init(n, n, 6*n)
+	for r := start; r < endp1; r++ {
+		i, j := r%nx, r/nx
+		t.Put(r, r, 4)
+		if i > 0 {
+			t.Put(r, r-1, -1-conv)
+		}
+		if i < nx-1 {
+			t.Put(r, r+1, -1)
+		}
+		if j > 0 {
+			t.Put(r, r-nx, -1)
+		}
+		if j < ny-1 {
+			t.Put(r, r+nx, -1)
+		}
+	}
+}
+
+func main() {
+
+	mpi.Start(false)
+	defer mpi.Stop(false)
+
+	if mpi.Rank() == 0 {
+		chk.PrintTitle("Test DistMatrix 02. distributed CG and GMRES")
+	}
+
+	nx, ny := 20, 16
+	n := nx * ny
+	if mpi.Size() > ny {
+		chk.Panic("the number of processors must be smaller than or equal to %d", ny)
+	}
+	start, endp1 := la.DistRange(n)
+	nloc := endp1 - start
+	xref := la.VecGetMapped(n, func(i int) float64 { return float64(i%9) - 4 })
+	var tst testing.T
+
+	// CG with symmetric matrix
+	var t la.Triplet
+	laplacian(&t, nx, ny, start, endp1, 0)
+	var A la.DistMatrix
+	err := A.Init(&t, start, endp1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	b := make([]float64, nloc)
+	A.MatVec(b, xref[start:endp1])
+	x := make([]float64, nloc)
+	kry := la.Krylov{Tol: 1e-12, Dot: la.DistDot}
+	err = kry.Cg(x, b, &A, nil)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	if mpi.Rank() == 0 {
+		io.Pforan("Cg: NumIt = %d  Resid = %g\n", kry.NumIt, kry.Resid)
+	}
+	chk.Vector(&tst, io.Sf("x(Cg) @ proc %d", mpi.Rank()), 1e-9, A.Gather(x), xref)
+
+	// GMRES with unsymmetric matrix
+	laplacian(&t, nx, ny, start, endp1, 0.3)
+	var B la.DistMatrix
+	err = B.Init(&t, start, endp1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	B.MatVec(b, xref[start:endp1])
+	la.VecFill(x, 0)
+	kry.Restart = 30
+	kry.MaxIt = 5000
+	err = kry.Gmres(x, b, &B, nil)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	if mpi.Rank() == 0 {
+		io.Pforan("Gmres(30): NumIt = %d  Resid = %g\n", kry.NumIt, kry.Resid)
+	}
+	chk.Vector(&tst, io.Sf("x(Gmres) @ proc %d", mpi.Rank()), 1e-8, B.Gather(x), xref)
+}
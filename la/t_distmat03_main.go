@@ -0,0 +1,122 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/mpi"
+)
+
+// laplacian puts the rows [start,endp1) of the 2D Laplacian with a convection term into t
+func laplacian(t *la.Triplet, nx, ny, start, endp1 int, conv float64) {
+	n := nx * ny
+	t.Init(n, n, 6*n)
+	for r := start; r < endp1; r++ {
+		i, j := r%nx, r/nx
+		t.Put(r, r, 4)
+		if i > 0 {
+			t.Put(r, r-1, -1-conv)
+		}
+		if i < nx-1 {
+			t.Put(r, r+1, -1)
+		}
+		if j > 0 {
+			t.Put(r, r-nx, -1)
+		}
+		if j < ny-1 {
+			t.Put(r, r+nx, -1)
+		}
+	}
+}
+
+// unevenRange returns the range of rows owned by this processor such that processor k owns a
+// number of rows proportional to k+1
+func unevenRange(n int) (start, endp1 int) {
+	id, sz := mpi.Rank(), mpi.Size()
+	total := sz * (sz + 1) / 2
+	start = n * (id * (id + 1) / 2) / total
+	endp1 = n * ((id + 1) * (id + 2) / 2) / total
+	return
+}
+
+func main() {
+
+	mpi.Start(false)
+	defer mpi.Stop(false)
+
+	if mpi.Rank() == 0 {
+		chk.PrintTitle("Test DistMatrix 03. CG and GMRES with uneven partitions and default settings")
+	}
+
+	// the local dimensions differ; e.g. 33 and 67 rows with 2 processors. Thus, the default MaxIt
+	// and Restart must be computed with the global dimension to avoid deadlocks
+	nx, ny := 10, 10
+	n := nx * ny
+	if mpi.Size() > 10 {
+		chk.Panic("the number of processors must be smaller than or equal to 10")
+	}
+	start, endp1 := unevenRange(n)
+	nloc := endp1 - start
+	io.Pf("proc %d: rows [%d, %d)\n", mpi.Rank(), start, endp1)
+	xref := la.VecGetMapped(n, func(i int) float64 { return float64(i%7) - 3 })
+	var tst testing.T
+
+	// CG with symmetric matrix
+	var t la.Triplet
+	laplacian(&t, nx, ny, start, endp1, 0)
+	var A la.DistMatrix
+	err := A.Init(&t, start, endp1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	b := make([]float64, nloc)
+	A.MatVec(b, xref[start:endp1])
+	x := make([]float64, nloc)
+	kry := la.Krylov{Tol: 1e-12, Dot: la.DistDot}
+	err = kry.Cg(x, b, &A, nil)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	if mpi.Rank() == 0 {
+		io.Pforan("Cg: NumIt = %d  Resid = %g\n", kry.NumIt, kry.Resid)
+	}
+	chk.Vector(&tst, io.Sf("x(Cg) @ proc %d", mpi.Rank()), 1e-9, A.Gather(x), xref)
+
+	// GMRES with unsymmetric matrix: default restart = min(n, 50) = 50 with n = 100
+	laplacian(&t, nx, ny, start, endp1, 0.3)
+	var B la.DistMatrix
+	err = B.Init(&t, start, endp1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	B.MatVec(b, xref[start:endp1])
+	la.VecFill(x, 0)
+	kry = la.Krylov{Tol: 1e-12, Dot: la.DistDot}
+	err = kry.Gmres(x, b, &B, nil)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	if mpi.Rank() == 0 {
+		io.Pforan("Gmres: NumIt = %d  Resid = %g\n", kry.NumIt, kry.Resid)
+	}
+	chk.Vector(&tst, io.Sf("x(Gmres) @ proc %d", mpi.Rank()), 1e-8, B.Gather(x), xref)
+
+	// GMRES with a small number of iterations: all processors must stop at the same iteration
+	la.VecFill(x, 0)
+	kry = la.Krylov{Tol: 1e-12, Dot: la.DistDot, Restart: 5}
+	err = kry.Gmres(x, b, &B, nil)
+	its := []int{kry.NumIt}
+	mpi.IntAllReduceMax(its, make([]int, 1))
+	chk.Int(&tst, io.Sf("NumIt @ proc %d", mpi.Rank()), kry.NumIt, its[0])
+	if err == nil && mpi.Rank() == 0 {
+		io.Pf("Gmres(5): NumIt = %d  Resid = %g\n", kry.NumIt, kry.Resid)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !windows,!darwin,!appengine,!heroku
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_distmat01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("distmat01. distributed matrix (serial run)")
+
+	// matrix
+	t := laplacian2d(6, 5, false)
+	n := 30
+	start, endp1 := DistRange(n)
+	chk.Ints(tst, "range", []int{start, endp1}, []int{0, n})
+	var A DistMatrix
+	err := A.Init(t, start, endp1)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Int(tst, "dim", A.Dim(), n)
+	chk.Int(tst, "number of ghosts", A.NumGhosts(), 0)
+
+	// matrix-vector product
+	u := VecGetMapped(n, func(i int) float64 { return float64(i%4) - 1.5 })
+	v, vref := make([]float64, n), make([]float64, n)
+	t.ToCSR(nil).MatVec(vref, u)
+	A.MatVecMul(v, 1, u)
+	chk.Vector(tst, "v", 1e-15, v, vref)
+	chk.Vector(tst, "gather(v)", 1e-15, A.Gather(v), vref)
+	chk.Scalar(tst, "u・v", 1e-15, DistDot(u, v), VecDot(u, v))
+	chk.Scalar(tst, "‖v‖", 1e-15, DistNorm(v), VecNorm(v))
+
+	// CG
+	x := make([]float64, n)
+	kry := Krylov{Tol: 1e-12, Dot: DistDot}
+	err = kry.Cg(x, v, &A, nil)
+	if err != nil {
+		tst.Errorf("Cg failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x", 1e-10, x, u)
+
+	// errors
+	var B DistMatrix
+	err = B.Init(t, 0, n-1)
+	if err == nil {
+		tst.Errorf("Init should have failed because the ranges do not cover the matrix\n")
+	}
+}
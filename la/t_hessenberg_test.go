@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestHessenbergEigen01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HessenbergEigen01. companion matrix")
+
+	// p(x) = (x - 1) (x - 2) (x² + 1) = x⁴ - 3 x³ + 3 x² - 3 x + 2
+	H := [][]float64{
+		{3, -3, 3, -2},
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	}
+	λ, err := HessenbergEigen(H)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	sort.Slice(λ, func(i, j int) bool {
+		if real(λ[i]) == real(λ[j]) {
+			return imag(λ[i]) < imag(λ[j])
+		}
+		return real(λ[i]) < real(λ[j])
+	})
+	io.Pforan("λ = %v\n", λ)
+	chk.VectorC(tst, "λ", 1e-14, λ, []complex128{-1i, 1i, 1, 2})
+}
+
+func TestHessenbergEigen02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HessenbergEigen02. triangular matrix")
+
+	H := [][]float64{
+		{4, 1, 2},
+		{0, -3, 5},
+		{0, 0, 0.5},
+	}
+	λ, err := HessenbergEigen(H)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	sort.Slice(λ, func(i, j int) bool { return real(λ[i]) < real(λ[j]) })
+	chk.VectorC(tst, "λ", 1e-15, λ, []complex128{-3, 0.5, 4})
+}
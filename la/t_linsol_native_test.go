@@ -0,0 +1,441 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// laplacian2d returns the (nx*ny x nx*ny) 5-point Laplacian matrix (SPD)
+func laplacian2d(nx, ny int, lowerOnly bool) (t *Triplet) {
+	n := nx * ny
+	t = new(Triplet)
+	t.Init(n, n, 5*n)
+	for j := 0; j < ny; j++ {
+		for i := 0; i < nx; i++ {
+			r := i + j*nx
+			t.Put(r, r, 4)
+			if i > 0 {
+				t.Put(r, r-1, -1)
+			}
+			if j > 0 {
+				t.Put(r, r-nx, -1)
+			}
+			if lowerOnly {
+				continue
+			}
+			if i < nx-1 {
+				t.Put(r, r+1, -1)
+			}
+			if j < ny-1 {
+				t.Put(r, r+nx, -1)
+			}
+		}
+	}
+	return
+}
+
+func Test_native01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native01. real (unsymmetric)")
+
+	// same system as in linsol01a
+	var t Triplet
+	t.Init(5, 5, 13)
+	t.Put(0, 0, 1.0)
+	t.Put(0, 0, 1.0)
+	t.Put(1, 0, 3.0)
+	t.Put(0, 1, 3.0)
+	t.Put(2, 1, -1.0)
+	t.Put(4, 1, 4.0)
+	t.Put(1, 2, 4.0)
+	t.Put(2, 2, -3.0)
+	t.Put(3, 2, 1.0)
+	t.Put(4, 2, 2.0)
+	t.Put(2, 3, 2.0)
+	t.Put(1, 4, 6.0)
+	t.Put(4, 4, 1.0)
+	b := []float64{8.0, 45.0, -3.0, 3.0, 19.0}
+	x_correct := []float64{1, 2, 3, 4, 5}
+	run_linsol_testR(tst, "native", &t, 1e-14, 1e-13, b, x_correct, false)
+
+	// same system as in linsol02 (ill-conditioned)
+	t.Init(10, 10, 64)
+	for i := 0; i < 10; i++ {
+		j := i
+		if i > 0 {
+			j = i - 1
+		}
+		for ; j < 10; j++ {
+			val := 10.0 - float64(j)
+			if i > j {
+				val -= 1.0
+			}
+			t.Put(i, j, val)
+		}
+	}
+	b = []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0}
+	x_correct = []float64{-1, 8, -65, 454, -2725, 13624, -54497, 163490, -326981, 326991}
+	run_linsol_testR(tst, "native", &t, 1e-4, 1e-9, b, x_correct, false)
+}
+
+func Test_native02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native02. complex")
+
+	// diagonal complex system as in linsol05
+	n := 10
+	b := make([]complex128, n)
+	x_correct := make([]complex128, n)
+	var t TripletC
+	t.Init(n, n, n+2, false)
+	for i := 0; i < n; i++ {
+		ar := 10.0 + float64(i)/(float64(n)/10.0)
+		ac := 10.0 - float64(i)/(float64(n)/10.0)
+		t.Put(i, i, ar, ac)
+		x_correct[i] = complex(float64(i+1), float64(i+1)/10.0)
+	}
+	t.Put(0, n-1, 1, -2) // make it unsymmetric
+	t.Put(n-1, 0, 3, 0)
+	A := t.ToMatrix(nil).ToDense()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			b[i] += A[i][j] * x_correct[j]
+		}
+	}
+	run_linsol_testC(tst, "native", &t, 1e-13, 1e-13, b, x_correct, false)
+
+	// xz monolithic
+	var tm TripletC
+	tm.Init(2, 2, 3, true)
+	tm.Put(0, 0, 1, 1)
+	tm.Put(1, 1, 2, -1)
+	tm.Put(0, 1, 0, 1)
+	lis := GetSolver("native")
+	defer lis.Free()
+	err := lis.InitC(&tm, false, false, false)
+	if err != nil {
+		tst.Errorf("InitC failed:\n%v", err)
+		return
+	}
+	err = lis.Fact()
+	if err != nil {
+		tst.Errorf("Fact failed:\n%v", err)
+		return
+	}
+	xR, xC := make([]float64, 2), make([]float64, 2)
+	err = lis.SolveC(xR, xC, []float64{0, 3}, []float64{2, 1}, false) // b = A * {1, 1+i}
+	if err != nil {
+		tst.Errorf("SolveC failed:\n%v", err)
+		return
+	}
+	chk.VectorC(tst, "x", 1e-15, RCtoComplex(xR, xC), []complex128{1, 1 + 1i})
+
+	// complex symmetric given by the lower triangle only or by the whole matrix
+	//      [ 4+i    1-2i   0   ]
+	//  A = [ 1-2i   3     2i   ]
+	//      [ 0      2i    5-i  ]
+	xs := []complex128{1 + 1i, -2, 3 - 1i}
+	As := [][]complex128{{4 + 1i, 1 - 2i, 0}, {1 - 2i, 3, 2i}, {0, 2i, 5 - 1i}}
+	bs := make([]complex128, 3)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			bs[i] += As[i][j] * xs[j]
+		}
+	}
+	for _, lowerOnly := range []bool{true, false} {
+		var ts TripletC
+		ts.Init(3, 3, 9, false)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if As[i][j] != 0 && (i >= j || !lowerOnly) {
+					ts.Put(i, j, real(As[i][j]), imag(As[i][j]))
+				}
+			}
+		}
+		lis := GetSolver("native")
+		err = lis.InitC(&ts, true, false, false)
+		if err != nil {
+			tst.Errorf("InitC failed:\n%v", err)
+			return
+		}
+		err = lis.Fact()
+		if err != nil {
+			tst.Errorf("Fact failed:\n%v", err)
+			return
+		}
+		xR, xC = make([]float64, 3), make([]float64, 3)
+		bR, bC := ComplexToRC(bs)
+		err = lis.SolveC(xR, xC, bR, bC, false)
+		if err != nil {
+			tst.Errorf("SolveC failed:\n%v", err)
+			return
+		}
+		lis.Free()
+		chk.VectorC(tst, io.Sf("x (symmetric; lowerOnly=%v)", lowerOnly), 1e-14, RCtoComplex(xR, xC), xs)
+	}
+}
+
+func Test_native03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native03. symmetric (supernodal Cholesky)")
+
+	nx, ny := 7, 6
+	n := nx * ny
+	xcor := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xcor[i] = math.Sin(float64(i))
+	}
+	full := laplacian2d(nx, ny, false)
+	b := make([]float64, n)
+	SpTriMatVecMul(b, full, xcor)
+
+	for _, lowerOnly := range []bool{false, true} {
+		for _, ordering := range []string{"amd", "natural"} {
+			io.Pforan("lowerOnly = %v, ordering = %s\n", lowerOnly, ordering)
+			lis := GetSolver("native")
+			err := lis.SetOrdScal(ordering, "")
+			if err != nil {
+				tst.Errorf("SetOrdScal failed:\n%v", err)
+				return
+			}
+			err = lis.InitR(laplacian2d(nx, ny, lowerOnly), true, false, false)
+			if err != nil {
+				tst.Errorf("InitR failed:\n%v", err)
+				return
+			}
+			err = lis.Fact()
+			if err != nil {
+				tst.Errorf("Fact failed:\n%v", err)
+				return
+			}
+			if lis.(*LinSolNative).chol == nil {
+				tst.Errorf("Cholesky factorisation should have been used\n")
+				return
+			}
+			x := make([]float64, n)
+			err = lis.SolveR(x, b, false)
+			if err != nil {
+				tst.Errorf("SolveR failed:\n%v", err)
+				return
+			}
+			chk.Vector(tst, "x", 1e-13, x, xcor)
+			lis.Free()
+		}
+	}
+
+	// supernodes of dense matrix
+	var chol SpCholSuper
+	a := [][]float64{
+		{25.0, 15.0, -5.0},
+		{15.0, 18.0, 0.0},
+		{-5.0, 0.0, 11.0},
+	}
+	var t Triplet
+	t.Init(3, 3, 9)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t.Put(i, j, a[i][j])
+		}
+	}
+	err := chol.Factor(t.ToCSR(nil), nil)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	chk.Int(tst, "number of supernodes", chol.NumSuper(), 1)
+	chk.Int(tst, "nnz(L)", chol.Nnz(), 6)
+	chk.Vector(tst, "L", 1e-15, chol.blk[0], []float64{5, 3, -1, 0, 3, 1, 0, 0, 3})
+}
+
+func Test_native04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native04. symmetric indefinite (fallback to LU)")
+
+	var t Triplet
+	t.Init(3, 3, 9)
+	t.Put(0, 0, 1)
+	t.Put(1, 0, 2)
+	t.Put(1, 1, 1)
+	t.Put(2, 1, 1)
+	t.Put(2, 2, -3)
+	lis := GetSolver("native")
+	defer lis.Free()
+	err := lis.InitR(&t, true, false, false)
+	if err != nil {
+		tst.Errorf("InitR failed:\n%v", err)
+		return
+	}
+	err = lis.Fact()
+	if err != nil {
+		tst.Errorf("Fact failed:\n%v", err)
+		return
+	}
+	if lis.(*LinSolNative).lu == nil {
+		tst.Errorf("LU factorisation should have been used\n")
+		return
+	}
+	x := make([]float64, 3)
+	err = lis.SolveR(x, []float64{5, 7, -7}, false) // b = A * {1, 2, 3}
+	if err != nil {
+		tst.Errorf("SolveR failed:\n%v", err)
+		return
+	}
+	chk.Vector(tst, "x", 1e-14, x, []float64{1, 2, 3})
+}
+
+func Test_native05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native05. AMD ordering and fill-in")
+
+	// arrow matrix: the natural ordering produces a dense factor
+	n := 20
+	var t Triplet
+	t.Init(n, n, 3*n)
+	for i := 0; i < n; i++ {
+		t.Put(i, i, float64(n))
+		if i > 0 {
+			t.Put(i, 0, 1)
+			t.Put(0, i, 1)
+		}
+	}
+	a := t.ToCSR(nil)
+	perm := SpAmdOrdering(a)
+	if perm[n-1] != 0 && perm[n-2] != 0 {
+		tst.Errorf("the hub must be eliminated last. perm = %v\n", perm) // or together with the last leaf
+	}
+	chk.Ints(tst, "sorted perm", intsSorted(perm), irange(n))
+
+	var cholNat, cholAmd SpCholSuper
+	err := cholNat.Factor(a, nil)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	err = cholAmd.Factor(a, perm)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	io.Pforan("nnz(L): natural = %d, amd = %d\n", cholNat.Nnz(), cholAmd.Nnz())
+	chk.Int(tst, "nnz(L) natural", cholNat.Nnz(), n*(n+1)/2)
+	chk.Int(tst, "nnz(L) amd", cholAmd.Nnz(), 2*n-1)
+
+	var luNat, luAmd SpLU
+	err = luNat.Factor(a.ToCC(), nil)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	err = luAmd.Factor(a.ToCC(), perm)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	nnzLn, nnzUn := luNat.Nnz()
+	nnzLa, nnzUa := luAmd.Nnz()
+	io.Pforan("nnz(L+U): natural = %d, amd = %d\n", nnzLn+nnzUn, nnzLa+nnzUa)
+	chk.Int(tst, "nnz(L+U) amd", nnzLa+nnzUa, 2*(2*n-1))
+
+	// solutions
+	xcor := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xcor[i] = float64(i + 1)
+	}
+	b := make([]float64, n)
+	SpCSRMatVecMul(b, 1, a, xcor)
+	x := make([]float64, n)
+	for _, solver := range []interface {
+		Solve(x, b []float64)
+	}{&cholNat, &cholAmd, &luNat, &luAmd} {
+		solver.Solve(x, b)
+		chk.Vector(tst, "x", 1e-13, x, xcor)
+	}
+
+	// larger Laplacian: AMD must reduce fill-in
+	lap := laplacian2d(15, 15, false).ToCSR(nil)
+	err = cholNat.Factor(lap, nil)
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	err = cholAmd.Factor(lap, SpAmdOrdering(lap))
+	if err != nil {
+		tst.Errorf("Factor failed:\n%v", err)
+		return
+	}
+	io.Pforan("Laplacian: nnz(L): natural = %d, amd = %d\n", cholNat.Nnz(), cholAmd.Nnz())
+	if cholAmd.Nnz() >= cholNat.Nnz() {
+		tst.Errorf("AMD ordering should reduce the fill-in: %d ≥ %d\n", cholAmd.Nnz(), cholNat.Nnz())
+	}
+}
+
+func Test_native06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("native06. pivoting and singular matrix")
+
+	// zero diagonal requires pivoting
+	var t Triplet
+	t.Init(3, 3, 5)
+	t.Put(0, 1, 2)
+	t.Put(1, 0, 3)
+	t.Put(1, 2, 1)
+	t.Put(2, 0, 1)
+	t.Put(2, 2, 1)
+	x, err := func() (x []float64, err error) {
+		lis := GetSolver("native")
+		defer lis.Free()
+		err = lis.InitR(&t, false, false, false)
+		if err != nil {
+			return
+		}
+		err = lis.Fact()
+		if err != nil {
+			return
+		}
+		x = make([]float64, 3)
+		err = lis.SolveR(x, []float64{4, 6, 4}, false) // b = A * {1, 2, 3}
+		return
+	}()
+	if err != nil {
+		tst.Errorf("native solver failed:\n%v", err)
+		return
+	}
+	chk.Vector(tst, "x", 1e-15, x, []float64{1, 2, 3})
+
+	// singular
+	t.Init(2, 2, 4)
+	t.Put(0, 0, 1)
+	t.Put(0, 1, 2)
+	t.Put(1, 0, 2)
+	t.Put(1, 1, 4)
+	var lu SpLU
+	err = lu.Factor(t.ToCSR(nil).ToCC(), nil)
+	if err == nil {
+		tst.Errorf("singular matrix should have been detected\n")
+	}
+}
+
+// intsSorted returns a sorted copy of v
+func intsSorted(v []int) (s []int) {
+	s = make([]int, len(v))
+	copy(s, v)
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j] < s[j-1]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+	return
+}
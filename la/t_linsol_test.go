@@ -11,14 +11,14 @@ import (
 	"github.com/cpmech/gosl/io"
 )
 
-func run_linsol_testR(tst *testing.T, t *Triplet, tol_cmp, tol_res float64, b, x_correct []float64, verbose bool) {
+func run_linsol_testR(tst *testing.T, solver string, t *Triplet, tol_cmp, tol_res float64, b, x_correct []float64, verbose bool) {
 
 	// info
 	symmetric := false
 	timing := false
 
 	// allocate solver
-	lis := GetSolver("umfpack")
+	lis := GetSolver(solver)
 	defer lis.Free()
 
 	// initialise solver
@@ -55,14 +55,14 @@ func run_linsol_testR(tst *testing.T, t *Triplet, tol_cmp, tol_res float64, b, x
 	CheckResidR(tst, tol_res, A.ToDense(), x, b)
 }
 
-func run_linsol_testC(tst *testing.T, t *TripletC, tol_cmp, tol_res float64, b, x_correct []complex128, verbose bool) {
+func run_linsol_testC(tst *testing.T, solver string, t *TripletC, tol_cmp, tol_res float64, b, x_correct []complex128, verbose bool) {
 
 	// info
 	symmetric := false
 	timing := false
 
 	// allocate solver
-	lis := GetSolver("umfpack")
+	lis := GetSolver(solver)
 	defer lis.Free()
 
 	// initialise solver
@@ -129,7 +129,7 @@ func Test_linsol01a(tst *testing.T) {
 	// run test
 	b := []float64{8.0, 45.0, -3.0, 3.0, 19.0}
 	x_correct := []float64{1, 2, 3, 4, 5}
-	run_linsol_testR(tst, &t, 1e-14, 1e-13, b, x_correct, false)
+	run_linsol_testR(tst, "umfpack", &t, 1e-14, 1e-13, b, x_correct, false)
 }
 
 func Test_linsol01b(tst *testing.T) {
@@ -161,7 +161,7 @@ func Test_linsol01b(tst *testing.T) {
 	done := make(chan int, nch)
 	for i := 0; i < nch; i++ {
 		go func() {
-			run_linsol_testR(tst, &t, 1e-14, 1e-13, b, x_correct, false)
+			run_linsol_testR(tst, "umfpack", &t, 1e-14, 1e-13, b, x_correct, false)
 			done <- 1
 		}()
 	}
@@ -196,7 +196,7 @@ func Test_linsol02(tst *testing.T) {
 	b := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0}
 	x_correct := []float64{-1, 8, -65, 454, -2725, 13624, -54497, 163490, -326981, 326991}
 	tol := 1e-9 // TODO: check why tests fails with 1e-10 @ office but not @ home
-	run_linsol_testR(tst, &t, 1e-4, tol, b, x_correct, false)
+	run_linsol_testR(tst, "umfpack", &t, 1e-4, tol, b, x_correct, false)
 }
 
 func Test_linsol03(tst *testing.T) {
@@ -224,7 +224,7 @@ func Test_linsol03(tst *testing.T) {
 	// run test
 	b := []complex128{8.0, 45.0, -3.0, 3.0, 19.0}
 	x_correct := []complex128{1, 2, 3, 4, 5}
-	run_linsol_testC(tst, &t, 1e-14, 1e-13, b, x_correct, true)
+	run_linsol_testC(tst, "umfpack", &t, 1e-14, 1e-13, b, x_correct, true)
 }
 
 func Test_linsol04(tst *testing.T) {
@@ -252,7 +252,7 @@ func Test_linsol04(tst *testing.T) {
 	// run test
 	b := []complex128{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0}
 	x_correct := []complex128{-1, 8, -65, 454, -2725, 13624, -54497, 163490, -326981, 326991}
-	run_linsol_testC(tst, &t, 1e-4, 1e-9, b, x_correct, true)
+	run_linsol_testC(tst, "umfpack", &t, 1e-4, 1e-9, b, x_correct, true)
 }
 
 func Test_linsol05(tst *testing.T) {
@@ -284,7 +284,7 @@ func Test_linsol05(tst *testing.T) {
 	}
 
 	// run test
-	run_linsol_testC(tst, &t, 1e-14, 1e-13, b, x_correct, true)
+	run_linsol_testC(tst, "umfpack", &t, 1e-14, 1e-13, b, x_correct, true)
 }
 
 func Test_linsol06(tst *testing.T) {
@@ -371,5 +371,5 @@ func Test_linsol06(tst *testing.T) {
 	}
 
 	// run test
-	run_linsol_testC(tst, &t, 1e-3, 1e-12, b, x_correct, true)
+	run_linsol_testC(tst, "umfpack", &t, 1e-3, 1e-12, b, x_correct, true)
 }
@@ -0,0 +1,287 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// matExpTaylor computes exp(a) with the Taylor series (for testing with small matrices only)
+func matExpTaylor(a [][]float64) (e [][]float64) {
+	n := len(a)
+	e, term, tmp := MatAlloc(n, n), MatAlloc(n, n), MatAlloc(n, n)
+	matAddI(e, 1)
+	matAddI(term, 1)
+	for k := 1; k < 60; k++ {
+		MatMul(tmp, 1/float64(k), term, a)
+		MatCopy(term, 1, tmp)
+		matAdd(e, 1, e, 1, term)
+	}
+	return
+}
+
+func Test_expm01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expm01. matrix exponential")
+
+	// nilpotent
+	e := MatAlloc(2, 2)
+	err := MatExpm(e, [][]float64{{0, 1}, {0, 0}})
+	if err != nil {
+		tst.Errorf("MatExpm failed:\n%v\n", err)
+		return
+	}
+	chk.Matrix(tst, "exp(nilpotent)", 1e-15, e, [][]float64{{1, 1}, {0, 1}})
+
+	// rotations: from small (low-degree Padé) to large (scaling and squaring) angles
+	for _, θ := range []float64{0.01, 0.2, 0.9, 2, 5, 30} {
+		err = MatExpm(e, [][]float64{{0, -θ}, {θ, 0}})
+		if err != nil {
+			tst.Errorf("MatExpm failed:\n%v\n", err)
+			return
+		}
+		c, s := math.Cos(θ), math.Sin(θ)
+		chk.Matrix(tst, "exp(rotation)", 1e-13*math.Max(1, θ), e, [][]float64{{c, -s}, {s, c}})
+	}
+
+	// general matrix
+	a := [][]float64{
+		{0.5, -0.3, 0.2, 0.1},
+		{0.1, -0.4, 0.6, -0.2},
+		{-0.7, 0.2, 0.3, 0.4},
+		{0.2, 0.5, -0.1, -0.6},
+	}
+	e = MatAlloc(4, 4)
+	for _, α := range []float64{0.01, 0.5, 1, 3} {
+		aa := MatClone(a)
+		MatScale(aa, α)
+		err = MatExpm(e, aa)
+		if err != nil {
+			tst.Errorf("MatExpm failed:\n%v\n", err)
+			return
+		}
+		chk.Matrix(tst, "exp(a)", 1e-13, e, matExpTaylor(aa))
+	}
+}
+
+func Test_expm02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expm02. Fréchet derivative of matrix exponential")
+
+	a := [][]float64{
+		{1.5, -0.3, 2.2},
+		{0.1, -0.4, 0.6},
+		{-0.7, 1.2, 0.3},
+	}
+	d := [][]float64{
+		{0.3, 1, -0.5},
+		{0.2, 0.1, 0.7},
+		{-1, 0.4, 0.6},
+	}
+	for _, α := range []float64{0.01, 0.3, 1, 4} {
+		aa := MatClone(a)
+		MatScale(aa, α)
+		e, l := MatAlloc(3, 3), MatAlloc(3, 3)
+		err := MatExpmFrechet(e, l, aa, d)
+		if err != nil {
+			tst.Errorf("MatExpmFrechet failed:\n%v\n", err)
+			return
+		}
+		chk.Matrix(tst, "exp(a)", 1e-12, e, matExpTaylor(aa))
+
+		// central differences
+		h := 1e-5
+		ap, am := MatAlloc(3, 3), MatAlloc(3, 3)
+		matAdd(ap, 1, aa, h, d)
+		matAdd(am, 1, aa, -h, d)
+		ep, em, lnum := MatAlloc(3, 3), MatAlloc(3, 3), MatAlloc(3, 3)
+		MatExpm(ep, ap)
+		MatExpm(em, am)
+		matAdd(lnum, 0.5/h, ep, -0.5/h, em)
+		chk.Matrix(tst, "L(a,d)", 1e-7*MatLargest(l, 1), l, lnum)
+	}
+}
+
+func Test_sqrtm01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("sqrtm01. matrix square root")
+
+	for _, a := range [][][]float64{
+		{{4, 1, 0}, {1, 3, 1}, {0, 1, 2}},
+		{{2, -1, 0.5}, {0.3, 5, 1}, {0.1, -0.2, 1}},
+		{{1e3, 2}, {0, 1e-2}},
+	} {
+		n := len(a)
+		s, s2 := MatAlloc(n, n), MatAlloc(n, n)
+		err := MatSqrtm(s, a)
+		if err != nil {
+			tst.Errorf("MatSqrtm failed:\n%v\n", err)
+			return
+		}
+		MatMul(s2, 1, s, s)
+		chk.Matrix(tst, "sqrt(a)²", 1e-12*MatLargest(a, 1), s2, a)
+	}
+
+	// eigenvalues on the negative real axis
+	s := MatAlloc(2, 2)
+	err := MatSqrtm(s, [][]float64{{-1, 0}, {0, 1}})
+	if err == nil {
+		tst.Errorf("MatSqrtm should have failed\n")
+	}
+}
+
+func Test_logm01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("logm01. matrix logarithm")
+
+	// log(exp(b)) = b
+	b := [][]float64{
+		{0.5, -0.3, 0.2},
+		{0.1, -0.4, 0.6},
+		{-0.7, 0.2, 0.3},
+	}
+	e, l := MatAlloc(3, 3), MatAlloc(3, 3)
+	err := MatExpm(e, b)
+	if err != nil {
+		tst.Errorf("MatExpm failed:\n%v\n", err)
+		return
+	}
+	err = MatLogm(l, e)
+	if err != nil {
+		tst.Errorf("MatLogm failed:\n%v\n", err)
+		return
+	}
+	chk.Matrix(tst, "log(exp(b))", 1e-13, l, b)
+
+	// exp(log(a)) = a; e.g. logarithmic strain from a stretch tensor
+	a := [][]float64{
+		{2.5, 0.3, 0.1},
+		{0.3, 1.2, -0.2},
+		{0.1, -0.2, 0.8},
+	}
+	err = MatLogm(l, a)
+	if err != nil {
+		tst.Errorf("MatLogm failed:\n%v\n", err)
+		return
+	}
+	MatExpm(e, l)
+	chk.Matrix(tst, "exp(log(a))", 1e-13, e, a)
+	chk.Scalar(tst, "tr(log(a)) = log(det(a))", 1e-13, l[0][0]+l[1][1]+l[2][2], math.Log(matDet3(a)))
+
+	// diagonal
+	err = MatLogm(l, [][]float64{{100, 0, 0}, {0, 1, 0}, {0, 0, 1e-3}})
+	if err != nil {
+		tst.Errorf("MatLogm failed:\n%v\n", err)
+		return
+	}
+	chk.Matrix(tst, "log(diag)", 1e-13, l, [][]float64{{math.Log(100), 0, 0}, {0, 0, 0}, {0, 0, math.Log(1e-3)}})
+
+	// too far from the identity: the off-diagonal term is only halved by each square root
+	l2 := MatAlloc(2, 2)
+	err = MatLogm(l2, [][]float64{{1, 1e300}, {0, 1}})
+	if err == nil {
+		tst.Errorf("MatLogm should have failed\n")
+	}
+}
+
+func matDet3(a [][]float64) float64 {
+	return a[0][0]*(a[1][1]*a[2][2]-a[1][2]*a[2][1]) - a[0][1]*(a[1][0]*a[2][2]-a[1][2]*a[2][0]) + a[0][2]*(a[1][0]*a[2][1]-a[1][1]*a[2][0])
+}
+
+func Test_phi01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("phi01. φ-functions")
+
+	a := [][]float64{
+		{-2, 1, 0},
+		{1, -3, 1},
+		{0.5, 1, -4},
+	}
+	phi, err := MatPhi(a, 3)
+	if err != nil {
+		tst.Errorf("MatPhi failed:\n%v\n", err)
+		return
+	}
+	e := MatAlloc(3, 3)
+	MatExpm(e, a)
+	chk.Matrix(tst, "φ0", 1e-14, phi[0], e)
+
+	// a φk+1 = φk - I/k!
+	r := MatAlloc(3, 3)
+	fact := 1.0
+	for k := 0; k < 3; k++ {
+		if k > 0 {
+			fact *= float64(k)
+		}
+		MatMul(r, 1, a, phi[k+1])
+		rhs := MatClone(phi[k])
+		matAddI(rhs, -1/fact)
+		chk.Matrix(tst, "a φk+1", 1e-13, r, rhs)
+	}
+
+	// scalar
+	phi, _ = MatPhi([][]float64{{2}}, 2)
+	chk.Scalar(tst, "φ1(2)", 1e-14, phi[1][0][0], (math.Exp(2)-1)/2)
+	chk.Scalar(tst, "φ2(2)", 1e-14, phi[2][0][0], (math.Exp(2)-1-2)/4)
+}
+
+func Test_expmv01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("expmv01. Krylov action of matrix exponential")
+
+	// 1D Laplacian with convection
+	n := 60
+	var t Triplet
+	t.Init(n, n, 3*n)
+	for i := 0; i < n; i++ {
+		t.Put(i, i, -2)
+		if i > 0 {
+			t.Put(i, i-1, 1.3)
+		}
+		if i < n-1 {
+			t.Put(i, i+1, 0.7)
+		}
+	}
+	a := t.ToCSR(nil)
+	v := VecGetMapped(n, func(i int) float64 { return math.Sin(float64(i) / 5) })
+
+	// reference
+	for _, τ := range []float64{0.1, 2, 10, -0.5} {
+		ad := a.ToDense()
+		MatScale(ad, τ)
+		e := MatAlloc(n, n)
+		MatExpm(e, ad)
+		wref := make([]float64, n)
+		MatVecMul(wref, 1, e, v)
+
+		w := make([]float64, n)
+		err := SpExpmv(w, τ, a, v, 20, 1e-12)
+		if err != nil {
+			tst.Errorf("SpExpmv failed:\n%v\n", err)
+			return
+		}
+		chk.Vector(tst, "exp(τ a) v", 1e-10*VecNorm(wref), w, wref)
+	}
+
+	// happy breakdown (invariant subspace)
+	b := &CSRMatrix{}
+	b.Set(3, 3, []int{0, 1, 2, 3}, []int{0, 1, 2}, []float64{1, 2, 3})
+	w := make([]float64, 3)
+	err := SpExpmv(w, 1, b, []float64{1, 0, 0}, 0, 0)
+	if err != nil {
+		tst.Errorf("SpExpmv failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "exp(diag) e0", 1e-14, w, []float64{math.E, 0, 0})
+}
@@ -0,0 +1,330 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// saddlePointSystem returns the block matrix of a 2D Laplacian with nc constraints (Lagrange multipliers)
+//  [ A  Bᵀ ]
+//  [ B  0  ]
+func saddlePointSystem(nx, ny, nc int) (K *BlockMatrix) {
+	a := laplacian2d(nx, ny, false).ToCSR(nil).ToCC()
+	n1 := nx * ny
+	var b Triplet
+	b.Init(nc, n1, 2*n1)
+	for i := 0; i < nc; i++ {
+		for j := i; j < n1; j += nc {
+			b.Put(i, j, 1+float64(i))
+		}
+		b.Put(i, (7*i+3)%n1, -0.5)
+	}
+	bcc := b.ToCSR(nil).ToCC()
+	K = new(BlockMatrix)
+	K.Init([]int{n1, nc}, []int{n1, nc})
+	K.SetBlock(0, 0, a)
+	K.SetBlockTr(0, 1, bcc)
+	K.SetBlock(1, 0, bcc)
+	return
+}
+
+func Test_block01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("block01. block matrix")
+
+	var a, b, c Triplet
+	a.Init(2, 2, 3)
+	a.Put(0, 0, 1)
+	a.Put(1, 1, 2)
+	a.Put(0, 1, 3)
+	b.Init(2, 1, 1)
+	b.Put(1, 0, 4)
+	c.Init(1, 1, 1)
+	c.Put(0, 0, 5)
+
+	var K BlockMatrix
+	K.Init([]int{2, 1}, []int{2, 1})
+	K.SetBlockT(0, 0, &a)
+	K.SetBlockT(0, 1, &b)
+	K.SetBlockTr(1, 0, b.ToCSR(nil).ToCC())
+	K.SetBlockT(1, 1, &c)
+	Kd := [][]float64{
+		{1, 3, 0},
+		{0, 2, 4},
+		{0, 4, 5},
+	}
+
+	m, n := K.Dims()
+	nbr, nbc := K.NumBlocks()
+	chk.Ints(tst, "dims", []int{m, n, nbr, nbc}, []int{3, 3, 2, 2})
+	chk.Matrix(tst, "K", 1e-17, K.ToDense(), Kd)
+	chk.Matrix(tst, "K(triplet)", 1e-17, K.ToTriplet().ToCSR(nil).ToDense(), Kd)
+
+	u := []float64{1, 2, 3}
+	v := make([]float64, 3)
+	K.MatVecMul(v, 2, u)
+	chk.Vector(tst, "v", 1e-17, v, []float64{14, 32, 46})
+	K.MatVecMulAdd(v, -1, u)
+	chk.Vector(tst, "v", 1e-17, v, []float64{7, 16, 23})
+	chk.Vector(tst, "u1", 1e-17, K.RowPart(u, 1), []float64{3})
+	chk.Vector(tst, "u0", 1e-17, K.ColPart(u, 0), []float64{1, 2})
+
+	if K.Block(1, 0).m != 1 || K.Block(1, 0).n != 2 {
+		tst.Errorf("transposed block has wrong dimensions\n")
+	}
+}
+
+func Test_krylov01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("krylov01. CG and GMRES")
+
+	// symmetric positive-definite system
+	a := laplacian2d(10, 10, false).ToCSR(nil).ToCC()
+	n := 100
+	xref := VecGetMapped(n, func(i int) float64 { return float64(i%7) - 3 })
+	b := make([]float64, n)
+	a.MatVec(b, xref)
+
+	// CG
+	var kry Krylov
+	kry.Tol = 1e-12
+	x := make([]float64, n)
+	err := kry.Cg(x, b, a, nil)
+	if err != nil {
+		tst.Errorf("Cg failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Cg: NumIt = %d, Resid = %g\n", kry.NumIt, kry.Resid)
+	chk.Vector(tst, "x(Cg)", 1e-9, x, xref)
+
+	// CG with Jacobi preconditioner
+	jac, err := NewJacobiPrecond(a)
+	if err != nil {
+		tst.Errorf("NewJacobiPrecond failed:\n%v\n", err)
+		return
+	}
+	VecFill(x, 0)
+	err = kry.Cg(x, b, a, jac)
+	if err != nil {
+		tst.Errorf("Cg failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x(Cg+Jacobi)", 1e-9, x, xref)
+
+	// unsymmetric system: convection-diffusion
+	lap := laplacian2d(10, 10, false)
+	t := new(Triplet)
+	t.Init(n, n, lap.pos+n)
+	for k := 0; k < lap.pos; k++ {
+		t.Put(lap.i[k], lap.j[k], lap.x[k])
+	}
+	for r := 1; r < n; r++ {
+		t.Put(r, r-1, -0.3)
+	}
+	c := t.ToCSR(nil)
+	c.MatVec(b, xref)
+
+	// GMRES with restart
+	kry.Restart = 20
+	kry.MaxIt = 1000
+	VecFill(x, 0)
+	err = kry.Gmres(x, b, c, nil)
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Gmres(20): NumIt = %d, Resid = %g\n", kry.NumIt, kry.Resid)
+	chk.Vector(tst, "x(Gmres)", 1e-9, x, xref)
+
+	// GMRES with exact preconditioner => one iteration
+	dir, err := NewDirectPrecond(t, false)
+	if err != nil {
+		tst.Errorf("NewDirectPrecond failed:\n%v\n", err)
+		return
+	}
+	defer dir.Free()
+	VecFill(x, 0)
+	err = kry.Gmres(x, b, c, dir)
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	chk.Int(tst, "NumIt", kry.NumIt, 1)
+	chk.Vector(tst, "x(Gmres+direct)", 1e-10, x, xref)
+}
+
+func Test_schur01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("schur01. saddle-point system with Schur complement")
+
+	K := saddlePointSystem(5, 4, 3)
+	m, _ := K.Dims()
+	xref := VecGetMapped(m, func(i int) float64 { return 1 + float64(i%5) })
+	b := make([]float64, m)
+	K.MatVec(b, xref)
+
+	// explicit
+	var sol SchurSolver
+	err := sol.Init(K, true, chk.Verbose)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	err = sol.Fact()
+	if err != nil {
+		tst.Errorf("Fact failed:\n%v\n", err)
+		return
+	}
+	defer sol.Free()
+	x := make([]float64, m)
+	err = sol.Solve(x, b)
+	if err != nil {
+		tst.Errorf("Solve failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x(explicit)", 1e-12, x, xref)
+
+	// compare with flattened system
+	xf, err := SolveRealLinSys(K.ToTriplet(), b)
+	if err != nil {
+		tst.Errorf("SolveRealLinSys failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x(flattened)", 1e-12, xf, xref)
+
+	// implicit
+	var imp SchurSolver
+	imp.Implicit = true
+	imp.Krylov.Tol = 1e-13
+	err = imp.Init(K, true, chk.Verbose)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	err = imp.Fact()
+	if err != nil {
+		tst.Errorf("Fact failed:\n%v\n", err)
+		return
+	}
+	defer imp.Free()
+	VecFill(x, 0)
+	err = imp.Solve(x, b)
+	if err != nil {
+		tst.Errorf("Solve failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("implicit: NumIt = %d\n", imp.Krylov.NumIt)
+	chk.Vector(tst, "x(implicit)", 1e-10, x, xref)
+}
+
+func Test_block02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("block02. block preconditioners")
+
+	K := saddlePointSystem(6, 5, 4)
+	m, _ := K.Dims()
+	xref := VecGetMapped(m, func(i int) float64 { return float64(i%3) - 1 })
+	b := make([]float64, m)
+	K.MatVec(b, xref)
+
+	// exact Schur complement S = -B inv(A) Bᵀ
+	var sol SchurSolver
+	err := sol.Init(K, true, false)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	err = sol.Fact()
+	if err != nil {
+		tst.Errorf("Fact failed:\n%v\n", err)
+		return
+	}
+	defer sol.Free()
+	pA, err := NewDirectPrecond(sol.tA, true)
+	if err != nil {
+		tst.Errorf("NewDirectPrecond failed:\n%v\n", err)
+		return
+	}
+	defer pA.Free()
+	pS, err := NewDirectPrecond(sol.tS, false)
+	if err != nil {
+		tst.Errorf("NewDirectPrecond failed:\n%v\n", err)
+		return
+	}
+	defer pS.Free()
+	var minusS Triplet
+	minusS.Init(sol.tS.m, sol.tS.n, 2*sol.tS.pos)
+	SpTriAdd(&minusS, -1, sol.tS, 0, sol.tS)
+	pmS, err := NewDirectPrecond(&minusS, false)
+	if err != nil {
+		tst.Errorf("NewDirectPrecond failed:\n%v\n", err)
+		return
+	}
+	defer pmS.Free()
+
+	// block-diagonal: diag(A, -S) => three iterations
+	var kry Krylov
+	kry.Tol = 1e-12
+	x := make([]float64, m)
+	err = kry.Gmres(x, b, K, &BlockDiagPrecond{K, []Precond{pA, pmS}})
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("block-diagonal: NumIt = %d\n", kry.NumIt)
+	chk.Int(tst, "NumIt(diag)", kry.NumIt, 3)
+	chk.Vector(tst, "x(diag)", 1e-10, x, xref)
+
+	// upper block-triangular: [[A, Bᵀ], [0, S]] => two iterations
+	VecFill(x, 0)
+	err = kry.Gmres(x, b, K, &BlockTriPrecond{K: K, P: []Precond{pA, pS}, Upper: true})
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("upper block-triangular: NumIt = %d\n", kry.NumIt)
+	chk.Int(tst, "NumIt(upper)", kry.NumIt, 2)
+	chk.Vector(tst, "x(upper)", 1e-10, x, xref)
+
+	// lower block-triangular: [[A, 0], [B, S]] => two iterations
+	VecFill(x, 0)
+	err = kry.Gmres(x, b, K, &BlockTriPrecond{K: K, P: []Precond{pA, pS}})
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("lower block-triangular: NumIt = %d\n", kry.NumIt)
+	chk.Int(tst, "NumIt(lower)", kry.NumIt, 2)
+	chk.Vector(tst, "x(lower)", 1e-10, x, xref)
+
+	// approximate Schur complement with block-diagonal preconditioner
+	sa := SpSchurApprox(K.Block(0, 0), K.Block(0, 1), K.Block(1, 0), K.Block(1, 1))
+	var msa Triplet
+	tsa := sa.ToCSR().ToTriplet()
+	msa.Init(tsa.m, tsa.n, 2*tsa.pos)
+	SpTriAdd(&msa, -1, tsa, 0, tsa)
+	pmSa, err := NewDirectPrecond(&msa, false)
+	if err != nil {
+		tst.Errorf("NewDirectPrecond failed:\n%v\n", err)
+		return
+	}
+	defer pmSa.Free()
+	VecFill(x, 0)
+	err = kry.Gmres(x, b, K, &BlockDiagPrecond{K, []Precond{pA, pmSa}})
+	if err != nil {
+		tst.Errorf("Gmres failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("block-diagonal with approximate Schur: NumIt = %d\n", kry.NumIt)
+	chk.Vector(tst, "x(approx)", 1e-9, x, xref)
+}
@@ -0,0 +1,209 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func csrTestMatrix() *Triplet {
+	//  [[2, 0, 1, 0],
+	//   [0, 3, 0, 0],
+	//   [4, 0, 5, 6]]
+	var t Triplet
+	t.Init(3, 4, 8)
+	t.Put(2, 3, 6)
+	t.Put(0, 2, 0.5)
+	t.Put(1, 1, 3)
+	t.Put(0, 0, 2)
+	t.Put(2, 0, 4)
+	t.Put(2, 2, 5)
+	t.Put(0, 2, 0.5) // repeated
+	return &t
+}
+
+func Test_csr01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("csr01. conversions")
+
+	t := csrTestMatrix()
+	a := t.ToCSR(nil)
+	ad := [][]float64{
+		{2, 0, 1, 0},
+		{0, 3, 0, 0},
+		{4, 0, 5, 6},
+	}
+	chk.Int(tst, "nnz", a.Nnz(), 6)
+	chk.Ints(tst, "p", a.p, []int{0, 2, 3, 6})
+	chk.Ints(tst, "j", a.j, []int{0, 2, 1, 0, 2, 3})
+	chk.Matrix(tst, "a", 1e-17, a.ToDense(), ad)
+	chk.Scalar(tst, "a[0][2]", 1e-17, a.Get(0, 2), 1)
+	chk.Scalar(tst, "a[1][2]", 1e-17, a.Get(1, 2), 0)
+
+	c := a.ToCC()
+	chk.Matrix(tst, "cc", 1e-17, c.ToDense(), ad)
+	chk.Ints(tst, "cc.p", c.p, []int{0, 2, 3, 5, 6})
+	chk.Ints(tst, "cc.i", c.i, []int{0, 2, 1, 0, 2, 2})
+
+	b := c.ToCSR()
+	chk.Matrix(tst, "csr(cc)", 1e-17, b.ToDense(), ad)
+	chk.Ints(tst, "b.j", b.j, a.j)
+
+	tt := a.ToTriplet()
+	chk.Matrix(tst, "csr(triplet)", 1e-17, tt.ToCSR(nil).ToDense(), ad)
+
+	at := a.Transpose()
+	m, n := at.Dims()
+	chk.Int(tst, "m(aᵀ)", m, 4)
+	chk.Int(tst, "n(aᵀ)", n, 3)
+	chk.Matrix(tst, "aᵀ", 1e-17, at.ToDense(), [][]float64{
+		{2, 0, 4},
+		{0, 3, 0},
+		{1, 0, 5},
+		{0, 0, 6},
+	})
+}
+
+func Test_csr02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("csr02. matrix-vector")
+
+	a := csrTestMatrix().ToCSR(nil)
+	u := []float64{1, 2, 3, 4}
+	v := make([]float64, 3)
+	SpCSRMatVecMul(v, 2, a, u)
+	chk.Vector(tst, "v = 2*a*u", 1e-15, v, []float64{10, 12, 86})
+
+	SpCSRMatVecMulAdd(v, -1, a, u)
+	chk.Vector(tst, "v -= a*u", 1e-15, v, []float64{5, 6, 43})
+
+	w := make([]float64, 4)
+	SpCSRMatTrVecMul(w, 1, a, []float64{1, 1, 1})
+	chk.Vector(tst, "w = aᵀ*1", 1e-15, w, []float64{6, 3, 6, 6})
+
+	// parallel
+	defer func(pll bool, ncpu int) { Pll, NCPU = pll, ncpu }(Pll, NCPU)
+	Pll, NCPU = true, 2
+	n := 101
+	var t Triplet
+	t.Init(n, n, 3*n)
+	for i := 0; i < n; i++ {
+		t.Put(i, i, 2)
+		if i > 0 {
+			t.Put(i, i-1, -1)
+		}
+		if i < n-1 {
+			t.Put(i, i+1, -1)
+		}
+	}
+	b := t.ToCSR(nil)
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = float64(i * i)
+	}
+	y := make([]float64, n)
+	SpCSRMatVecMul(y, 1, b, x)
+	Pll = false
+	z := make([]float64, n)
+	SpCSRMatVecMul(z, 1, b, x)
+	chk.Vector(tst, "parallel SpMV", 1e-17, y, z)
+	chk.Scalar(tst, "y[50]", 1e-17, y[50], -2)
+}
+
+func Test_csr03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("csr03. SpGEMM and Kronecker")
+
+	a := csrTestMatrix().ToCSR(nil)
+	at := a.Transpose()
+	c := SpCSRMatMatMul(1, a, at)
+	ad := a.ToDense()
+	cd := MatAlloc(3, 3)
+	MatMul(cd, 1, ad, at.ToDense())
+	chk.Matrix(tst, "a*aᵀ", 1e-15, c.ToDense(), cd)
+	chk.Matrix(tst, "a*aᵀ", 1e-15, c.ToDense(), [][]float64{
+		{5, 0, 13},
+		{0, 9, 0},
+		{13, 0, 77},
+	})
+
+	d := SpCSRMatMatMul(0.5, at, a)
+	dd := MatAlloc(4, 4)
+	MatMul(dd, 0.5, at.ToDense(), ad)
+	chk.Matrix(tst, "0.5*aᵀ*a", 1e-15, d.ToDense(), dd)
+	for i := 0; i < d.m; i++ {
+		for k := d.p[i] + 1; k < d.p[i+1]; k++ {
+			if d.j[k] <= d.j[k-1] {
+				tst.Errorf("columns of row %d are not sorted: %v\n", i, d.j[d.p[i]:d.p[i+1]])
+			}
+		}
+	}
+
+	var t Triplet
+	t.Init(2, 2, 3)
+	t.Put(0, 0, 1)
+	t.Put(0, 1, 2)
+	t.Put(1, 1, 3)
+	k := SpCSRKron(t.ToCSR(nil), at)
+	kd := k.ToDense()
+	chk.Int(tst, "len(kron)", len(kd), 8)
+	chk.Int(tst, "len(kron[0])", len(kd[0]), 6)
+	chk.Matrix(tst, "kron", 1e-17, kd, [][]float64{
+		{2, 0, 4, 4, 0, 8},
+		{0, 3, 0, 0, 6, 0},
+		{1, 0, 5, 2, 0, 10},
+		{0, 0, 6, 0, 0, 12},
+		{0, 0, 0, 6, 0, 12},
+		{0, 0, 0, 0, 9, 0},
+		{0, 0, 0, 3, 0, 15},
+		{0, 0, 0, 0, 0, 18},
+	})
+}
+
+func Test_csr04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("csr04. permutation and submatrices")
+
+	var t Triplet
+	t.Init(3, 3, 9)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if i == 0 && j == 1 {
+				continue
+			}
+			t.Put(i, j, float64(10*i+j))
+		}
+	}
+	a := t.ToCSR(nil)
+
+	perm := []int{2, 0, 1}
+	b := SpCSRPermute(a, perm)
+	chk.Matrix(tst, "P*a*Pᵀ", 1e-17, b.ToDense(), [][]float64{
+		{22, 20, 21},
+		{2, 0, 0},
+		{12, 10, 11},
+	})
+
+	s := SpCSRSubMat(a, []int{2, 0}, []int{2, 1})
+	chk.Matrix(tst, "a[{2,0}][{2,1}]", 1e-17, s.ToDense(), [][]float64{
+		{22, 21},
+		{2, 0},
+	})
+
+	s = SpCSRSubMat(a, nil, []int{0})
+	chk.Matrix(tst, "a[:][{0}]", 1e-17, s.ToDense(), [][]float64{{0}, {10}, {20}})
+
+	r := SpCSRRowSlice(a, 1, 3)
+	chk.Matrix(tst, "a[1:3][:]", 1e-17, r.ToDense(), [][]float64{
+		{10, 11, 12},
+		{20, 21, 22},
+	})
+}
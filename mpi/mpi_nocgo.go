@@ -0,0 +1,112 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !cgo
+
+package mpi
+
+// This file implements the serial (single processor) version of this package, which is
+// used when cgo is disabled; e.g. to build static binaries without the MPI library.
+// Here, IsOn always returns false, Rank is 0 and Size is 1.
+
+// Abort aborts MPI
+func Abort() {
+}
+
+// InOn tells whether MPI is on or not
+//  NOTE: this is always false in the serial version
+func IsOn() bool {
+	return false
+}
+
+// Start initialises MPI
+func Start(debug bool) {
+}
+
+// Stop finalises MPI
+func Stop(debug bool) {
+}
+
+// Rank returns the processor rank/ID
+func Rank() int {
+	return 0
+}
+
+// Size returns the number of processors
+func Size() int {
+	return 1
+}
+
+// Barrier forces synchronisation
+func Barrier() {
+}
+
+// SumToRoot sums all values in 'orig' to 'dest' in root (Rank == 0) processor
+func SumToRoot(dest, orig []float64) {
+	copy(dest, orig)
+}
+
+// BcastFromRoot broadcasts 'x' slice from root (Rank == 0) to all other processors
+func BcastFromRoot(x []float64) {
+}
+
+// AllReduceSum combines all values in 'x' from all processors
+func AllReduceSum(x, w []float64) {
+}
+
+// AllReduceSumAdd combines all values in 'x' from all processors and adds the result to another
+// slice 'y'
+func AllReduceSumAdd(y, x, w []float64) {
+	for i := 0; i < len(x); i++ {
+		y[i] += x[i]
+	}
+}
+
+// AllReduceMin combines all values in 'x' from all processors selecting the minimum
+func AllReduceMin(x, w []float64) {
+}
+
+// AllReduceMax combines all values in 'x' from all processors selecting the maximum
+func AllReduceMax(x, w []float64) {
+}
+
+// IntAllReduceMax combines all (int) values in 'x' from all processors selecting the maximum
+func IntAllReduceMax(x, w []int) {
+}
+
+// SingleIntSend sends a single integer 'val' to processor 'to_proc'
+func SingleIntSend(val, to_proc int) {
+	panicSerial()
+}
+
+// SingleIntRecv receives a single integer 'val' from processor 'to_proc'
+func SingleIntRecv(from_proc int) (val int) {
+	panicSerial()
+	return
+}
+
+// IntSend sends a slice of integers to processor 'to_proc'
+func IntSend(vals []int, to_proc int) {
+	panicSerial()
+}
+
+// IntRecv receives a slice of integers from processor 'from_proc'
+func IntRecv(vals []int, from_proc int) {
+	panicSerial()
+}
+
+// DblSend sends a slice of floats to processor 'to_proc'
+func DblSend(vals []float64, to_proc int) {
+	panicSerial()
+}
+
+// DblRecv receives a slice of floats from processor 'from_proc'
+func DblRecv(vals []float64, from_proc int) {
+	panicSerial()
+}
+
+// panicSerial stops the program since point-to-point communication is not possible
+func panicSerial() {
+	panic("mpi: point-to-point communication is not available in the serial version (cgo is disabled)")
+}
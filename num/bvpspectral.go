@@ -0,0 +1,266 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// BvpBc defines a boundary condition of the kind
+//
+//        α ⋅ u + β ⋅ du/dx = γ
+//
+//   Dirichlet: β = 0;  Neumann: α = 0;  Robin: α ≠ 0 and β ≠ 0
+type BvpBc struct {
+	Alpha float64 // α
+	Beta  float64 // β
+	Gamma float64 // γ
+}
+
+// BvpFcn defines the right-hand side of u'' = f(x, u, u') and its derivatives
+type BvpFcn func(x, u, du float64) (f, dfdu, dfddu float64, err error)
+
+// SpectralBvp solves two-point boundary value problems on [A, B] by spectral collocation at
+// Chebyshev-Gauss-Lobatto or Legendre-Gauss-Lobatto points. The differential equation is collocated
+// at the interior points and the rows of the first and last points are replaced by the boundary
+// conditions. The linear problem
+//
+//        p(x) u'' + q(x) u' + r(x) u = s(x)
+//
+//   is solved directly whereas the nonlinear problem
+//
+//        u'' = f(x, u, u')
+//
+//   is solved with NlSolver (Newton's method with dense Jacobian)
+type SpectralBvp struct {
+
+	// input
+	A, B        float64            // interval
+	Left, Right BvpBc              // boundary conditions at A and B
+	NlsPrms     map[string]float64 // parameters for NlSolver; e.g. atol, rtol, maxIt
+
+	// derived
+	X  []float64   // [N+1] collocation points in [A, B]
+	D1 [][]float64 // [N+1][N+1] first derivative matrix on [A, B]
+	D2 [][]float64 // [N+1][N+1] second derivative matrix on [A, B]
+
+	// auxiliary
+	lag *fun.LagrangeInterp // interpolator on [-1, 1]
+	λ   []float64           // barycentric weights
+	du  []float64           // D1 ⋅ u
+	ddu []float64           // D2 ⋅ u
+}
+
+// NewSpectralBvp allocates a new spectral BVP solver
+//   Input:
+//     N           -- degree of the interpolant (number of points = N+1)
+//     grid        -- fun.ChebyGaussLobattoGridKind or fun.LegendreGaussLobattoGridKind
+//     a, b        -- interval
+//     left, right -- boundary conditions at a and b
+func NewSpectralBvp(N int, grid io.Enum, a, b float64, left, right BvpBc) (o *SpectralBvp, err error) {
+	if grid != fun.ChebyGaussLobattoGridKind && grid != fun.LegendreGaussLobattoGridKind {
+		return nil, chk.Err(_bvpspectral_err1, grid)
+	}
+	if b <= a {
+		return nil, chk.Err(_bvpspectral_err2, a, b)
+	}
+	for i, bc := range []BvpBc{left, right} {
+		if bc.Alpha == 0 && bc.Beta == 0 {
+			return nil, chk.Err(_bvpspectral_err3, []string{"left", "right"}[i])
+		}
+	}
+	o = new(SpectralBvp)
+	o.A, o.B = a, b
+	o.Left, o.Right = left, right
+	o.lag, err = fun.NewLagrangeInterp(N, grid)
+	if err != nil {
+		return nil, err
+	}
+	o.λ = o.lag.BaryWeights()
+	o.X = make([]float64, N+1)
+	for i, t := range o.lag.X {
+		o.X[i] = (a+b)/2.0 + (b-a)/2.0*t
+	}
+	o.X[0], o.X[N] = a, b
+	o.D1, err = o.lag.DiffMatrix(1)
+	if err != nil {
+		return
+	}
+	o.D2, err = o.lag.DiffMatrix(2)
+	if err != nil {
+		return
+	}
+	s := 2.0 / (b - a)
+	for i := 0; i < N+1; i++ {
+		for j := 0; j < N+1; j++ {
+			o.D1[i][j] *= s
+			o.D2[i][j] *= s * s
+		}
+	}
+	o.du = make([]float64, N+1)
+	o.ddu = make([]float64, N+1)
+	return
+}
+
+// SolveLinear solves p(x) u'' + q(x) u' + r(x) u = s(x)
+//   Input:
+//     p, q, r, s -- coefficients and right-hand side; q and r may be nil (zero)
+//   Output:
+//     u -- [N+1] solution at the collocation points X
+func (o *SpectralBvp) SolveLinear(p, q, r, s fun.Ss) (u []float64, err error) {
+	n := len(o.X)
+	var K la.Triplet
+	K.Init(n, n, n*n)
+	rhs := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		x := o.X[i]
+		var pi, qi, ri float64
+		pi, err = p(x)
+		if err != nil {
+			return
+		}
+		if q != nil {
+			qi, err = q(x)
+			if err != nil {
+				return
+			}
+		}
+		if r != nil {
+			ri, err = r(x)
+			if err != nil {
+				return
+			}
+		}
+		rhs[i], err = s(x)
+		if err != nil {
+			return
+		}
+		for j := 0; j < n; j++ {
+			kij := pi*o.D2[i][j] + qi*o.D1[i][j]
+			if i == j {
+				kij += ri
+			}
+			K.Put(i, j, kij)
+		}
+	}
+	for _, i := range []int{0, n - 1} {
+		bc := o.bc(i)
+		for j := 0; j < n; j++ {
+			kij := bc.Beta * o.D1[i][j]
+			if i == j {
+				kij += bc.Alpha
+			}
+			K.Put(i, j, kij)
+		}
+		rhs[i] = bc.Gamma
+	}
+	u, err = la.SolveRealLinSys(&K, rhs)
+	if err != nil {
+		return nil, chk.Err(_bvpspectral_err4, err)
+	}
+	return
+}
+
+// Solve solves the nonlinear problem u'' = f(x, u, u')
+//   Input:
+//     u      -- [N+1] initial guess at the collocation points X
+//     f      -- right-hand side and derivatives
+//     silent -- do not show messages from NlSolver
+//   Output:
+//     u -- [N+1] solution at the collocation points X
+func (o *SpectralBvp) Solve(u []float64, f BvpFcn, silent bool) (err error) {
+
+	// check
+	n := len(o.X)
+	if len(u) != n {
+		return chk.Err(_bvpspectral_err5, len(u), n)
+	}
+
+	// residual
+	ffcn := func(fx, v []float64) (e error) {
+		la.MatVecMul(o.du, 1, o.D1, v)
+		la.MatVecMul(o.ddu, 1, o.D2, v)
+		for i := 1; i < n-1; i++ {
+			var fi float64
+			fi, _, _, e = f(o.X[i], v[i], o.du[i])
+			if e != nil {
+				return
+			}
+			fx[i] = o.ddu[i] - fi
+		}
+		for _, i := range []int{0, n - 1} {
+			bc := o.bc(i)
+			fx[i] = bc.Alpha*v[i] + bc.Beta*o.du[i] - bc.Gamma
+		}
+		return
+	}
+
+	// Jacobian
+	jfcn := func(J [][]float64, v []float64) (e error) {
+		la.MatVecMul(o.du, 1, o.D1, v)
+		for i := 1; i < n-1; i++ {
+			var dfdu, dfddu float64
+			_, dfdu, dfddu, e = f(o.X[i], v[i], o.du[i])
+			if e != nil {
+				return
+			}
+			for j := 0; j < n; j++ {
+				J[i][j] = o.D2[i][j] - dfddu*o.D1[i][j]
+			}
+			J[i][i] -= dfdu
+		}
+		for _, i := range []int{0, n - 1} {
+			bc := o.bc(i)
+			for j := 0; j < n; j++ {
+				J[i][j] = bc.Beta * o.D1[i][j]
+			}
+			J[i][i] += bc.Alpha
+		}
+		return
+	}
+
+	// solve
+	var nls NlSolver
+	nls.Init(n, ffcn, nil, jfcn, true, false, o.NlsPrms)
+	defer nls.Free()
+	return nls.Solve(u, silent)
+}
+
+// U computes the (barycentric) interpolation of the solution u at any x in [A, B]
+func (o *SpectralBvp) U(u []float64, x float64) float64 {
+	t := (2.0*x - o.A - o.B) / (o.B - o.A)
+	var num, den float64
+	for j, tj := range o.lag.X {
+		if math.Abs(t-tj) < 1e-15 {
+			return u[j]
+		}
+		c := o.λ[j] / (t - tj)
+		num += c * u[j]
+		den += c
+	}
+	return num / den
+}
+
+// bc returns the boundary condition at point i (0 or N)
+func (o *SpectralBvp) bc(i int) BvpBc {
+	if i == 0 {
+		return o.Left
+	}
+	return o.Right
+}
+
+// error messages
+var (
+	_bvpspectral_err1 = "bvpspectral.go: NewSpectralBvp: grid must be ChebyGaussLobatto or LegendreGaussLobatto. %q is invalid\n"
+	_bvpspectral_err2 = "bvpspectral.go: NewSpectralBvp: interval must have b > a. [%g, %g] is invalid\n"
+	_bvpspectral_err3 = "bvpspectral.go: NewSpectralBvp: %s boundary condition must have α ≠ 0 or β ≠ 0\n"
+	_bvpspectral_err4 = "bvpspectral.go: SolveLinear: cannot solve linear system:\n%v"
+	_bvpspectral_err5 = "bvpspectral.go: Solve: length of u must be equal to the number of points. %d != %d\n"
+)
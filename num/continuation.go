@@ -0,0 +1,548 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// kinds of points on continuation paths
+const (
+	ContRegular = iota // regular point
+	ContFold           // fold (limit or turning point)
+	ContBranch         // branch (bifurcation) point
+)
+
+// ContPoint holds a point on the solution path computed by Continuation
+type ContPoint struct {
+	U    []float64 // solution u = {x, λ}
+	Tan  []float64 // unit tangent du/ds
+	Lam  float64   // parameter λ
+	Norm float64   // Euclidean norm of x
+	S    float64   // arclength
+	DetJ float64   // determinant of dF/dx
+	DetA float64   // determinant of the augmented matrix [dF/du; Tanᵀ]
+	Kind int       // ContRegular, ContFold or ContBranch
+}
+
+// ContPath holds the points on a solution path
+type ContPath []*ContPoint
+
+// LamNorm returns the (λ, ‖x‖) path
+func (o ContPath) LamNorm() (lam, nrm []float64) {
+	lam, nrm = make([]float64, len(o)), make([]float64, len(o))
+	for i, p := range o {
+		lam[i], nrm[i] = p.Lam, p.Norm
+	}
+	return
+}
+
+// Find returns the indices of points of a given kind (ContFold or ContBranch)
+func (o ContPath) Find(kind int) (idx []int) {
+	for i, p := range o {
+		if p.Kind == kind {
+			idx = append(idx, i)
+		}
+	}
+	return
+}
+
+// Save saves the path to a text file (that can be read with io.ReadTable) with columns:
+//  s, lam, norm, detJ and kind
+func (o ContPath) Save(dirout, fnkey string) {
+	var buf bytes.Buffer
+	io.Ff(&buf, "%23s %23s %23s %23s %5s\n", "s", "lam", "norm", "detJ", "kind")
+	for _, p := range o {
+		io.Ff(&buf, "%23.15e %23.15e %23.15e %23.15e %5d\n", p.S, p.Lam, p.Norm, p.DetJ, p.Kind)
+	}
+	io.WriteFileD(dirout, fnkey+".dat", &buf)
+}
+
+// Continuation implements the pseudo-arclength continuation method to trace the solution paths of
+//   F(x, λ) = 0    with    F: ℝⁿ × ℝ → ℝⁿ
+//  through folds (limit points), where Newton's method with fixed λ fails. The step is adapted
+//  according to the number of corrector iterations. Folds and branch points are detected by sign
+//  changes of det(dF/dx): at folds, the λ component of the tangent changes sign; whereas at
+//  branch points, the determinant of the augmented matrix [dF/du; tangentᵀ] changes sign. These
+//  points are then located by solving for the zero of the corresponding test function.
+//  NOTE: (1) the callbacks operate on u = {x, λ}; i.e. Ffcn(f, u) with len(f) = n and len(u) = n+1
+//            and JfcnSp(J, u) with J being an n × (n+1) triplet that includes the column ∂F/∂λ
+//        (2) the linear systems are solved with dense matrices: even if JfcnSp is given, the
+//            Jacobian is converted to an n × (n+1) dense matrix and the augmented matrix and dF/dx
+//            are factorised with dense LU at every corrector iteration. Thus, memory is O(n²) and
+//            each iteration costs O(n³). This is fine for small and medium systems (n up to a few
+//            hundred) but not for large sparse systems such as those from finite elements
+//   Reference:
+//   [1] Allgower EL, Georg K (2003) Introduction to Numerical Continuation Methods. SIAM. 388p.
+//   [2] Seydel R (2010) Practical Bifurcation and Stability Analysis. Third Edition. Springer. 477p.
+type Continuation struct {
+
+	// parameters
+	Ds       float64 // initial arclength step
+	DsMin    float64 // minimum arclength step
+	DsMax    float64 // maximum arclength step
+	MaxSteps int     // maximum number of continuation steps
+	MaxIt    int     // maximum number of corrector (Newton) iterations
+	NOpt     int     // optimal number of corrector iterations (for the step adaptation)
+	Tol      float64 // tolerance for the corrector
+	LamMin   float64 // stop if λ < LamMin (if LamMin < LamMax)
+	LamMax   float64 // stop if λ > LamMax (if LamMin < LamMax)
+	Dir      float64 // initial direction of λ: +1 or -1
+
+	// callbacks
+	Ffcn   fun.Vv                   // F(u) with u = {x, λ}
+	JfcnSp fun.Tv                   // dF/du (n × (n+1)). numerical Jacobian is used if nil
+	Out    func(p *ContPoint) error // output callback function (called for each new point)
+
+	// stat data
+	NFeval int // number of calls to Ffcn (function evaluations)
+	NJeval int // number of calls to JfcnSp (Jacobian evaluations)
+
+	// auxiliary
+	neq  int         // number of equations n
+	Jtri la.Triplet  // triplet for JfcnSp
+	Ju   [][]float64 // dense dF/du [n][n+1]
+	A    [][]float64 // augmented matrix [n+1][n+1]
+	Jx   [][]float64 // dF/dx [n][n]
+	fu   []float64   // F(u)
+	w    []float64   // workspace (right-hand side of corrector)
+	wf   []float64   // workspace (numerical Jacobian)
+	lu   la.DenseLU  // factorisation of A
+	lux  la.DenseLU  // factorisation of Jx
+}
+
+// Init initialises continuation solver
+//  Input:
+//   neq    -- number of equations n (size of x)
+//   Ffcn   -- F(u) with u = {x, λ}
+//   JfcnSp -- dF/du (may be nil => numerical Jacobian)
+//   prms   -- ds, dsMin, dsMax, maxSteps, maxIt, nOpt, tol, lamMin, lamMax, dir
+func (o *Continuation) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, prms map[string]float64) {
+
+	// set default values
+	o.Ds, o.DsMin, o.DsMax = 0.1, 1e-8, 1.0
+	o.MaxSteps, o.MaxIt, o.NOpt = 100, 10, 4
+	o.Tol = 1e-10
+	o.LamMin, o.LamMax = 0, 0
+	o.Dir = 1
+
+	// read parameters
+	for k, v := range prms {
+		switch k {
+		case "ds":
+			o.Ds = v
+		case "dsMin":
+			o.DsMin = v
+		case "dsMax":
+			o.DsMax = v
+		case "maxSteps":
+			o.MaxSteps = int(v)
+		case "maxIt":
+			o.MaxIt = int(v)
+		case "nOpt":
+			o.NOpt = int(v)
+		case "tol":
+			o.Tol = v
+		case "lamMin":
+			o.LamMin = v
+		case "lamMax":
+			o.LamMax = v
+		case "dir":
+			o.Dir = sign(v)
+		}
+	}
+
+	// callbacks
+	o.neq = neq
+	o.Ffcn, o.JfcnSp = Ffcn, JfcnSp
+
+	// auxiliary data
+	n := neq
+	if JfcnSp != nil {
+		o.Jtri.Init(n, n+1, n*(n+1))
+	}
+	o.Ju = la.MatAlloc(n, n+1)
+	o.A = la.MatAlloc(n+1, n+1)
+	o.Jx = la.MatAlloc(n, n)
+	o.fu = make([]float64, n)
+	o.w = make([]float64, n+1)
+	o.wf = make([]float64, n)
+}
+
+// Trace traces the solution path starting at (x0, λ0). The initial point is first corrected with
+// fixed λ = λ0. The first step goes in the direction of increasing λ if Dir > 0.
+func (o *Continuation) Trace(x0 []float64, λ0 float64) (path ContPath, err error) {
+
+	// initial point
+	o.NFeval, o.NJeval = 0, 0
+	n := o.neq
+	u0 := make([]float64, n+1)
+	copy(u0, x0)
+	u0[n] = λ0
+	eλ := make([]float64, n+1)
+	eλ[n] = 1
+	u, _, err := o.correct(u0, eλ, 0)
+	if err != nil {
+		return nil, chk.Err(_cont_err1, λ0, err)
+	}
+
+	// initial tangent
+	eλ[n] = o.Dir
+	if o.Dir == 0 {
+		eλ[n] = 1
+	}
+	p, err := o.point(u, eλ)
+	if err != nil {
+		return nil, chk.Err(_cont_err1, λ0, err)
+	}
+	return o.trace(p, false)
+}
+
+// SwitchBranch traces the branch crossing the current path at the branch point bp (a point with
+// Kind = ContBranch returned by Trace). The initial direction of the new branch is the null vector
+// of the augmented matrix at bp, orthogonal to the tangent of the current path
+//  Input:
+//   bp  -- branch point
+//   sgn -- +1 or -1 to select the side of the new branch. With sgn = +1, the largest component of
+//          the initial direction is positive
+func (o *Continuation) SwitchBranch(bp *ContPoint, sgn float64) (path ContPath, err error) {
+
+	// check
+	if bp.Kind != ContBranch {
+		return nil, chk.Err(_cont_err2)
+	}
+	o.NFeval, o.NJeval = 0, 0
+	n := o.neq
+
+	// augmented matrix at branch point (nearly singular)
+	err = o.jacobian(bp.U)
+	if err != nil {
+		return
+	}
+	o.augmented(bp.Tan)
+	if err = o.lu.Factor(o.A); err != nil { // exactly singular
+		o.A[n][n] += math.Sqrt(MACHEPS)
+		if err = o.lu.Factor(o.A); err != nil {
+			return nil, chk.Err(_cont_err3, err)
+		}
+	}
+
+	// inverse iteration for the null vector φ ⟂ Tan
+	φ := make([]float64, n+1)
+	for i := 0; i < n+1; i++ {
+		φ[i] = 1
+	}
+	for k := 0; k < 4; k++ {
+		o.lu.Solve(φ, φ)
+		la.VecAdd(φ, -la.VecDot(φ, bp.Tan), bp.Tan)
+		nrm := la.VecNorm(φ)
+		if nrm == 0 || math.IsNaN(nrm) {
+			return nil, chk.Err(_cont_err3, "cannot compute null vector")
+		}
+		la.VecScale(φ, 0, 1.0/nrm, φ)
+	}
+	imx := 0
+	for i := 1; i < n+1; i++ {
+		if math.Abs(φ[i]) > math.Abs(φ[imx]) {
+			imx = i
+		}
+	}
+	la.VecScale(φ, 0, sign(sgn)*sign(φ[imx]), φ)
+
+	// start new path
+	p := &ContPoint{U: la.VecClone(bp.U), Tan: φ, Lam: bp.Lam, Norm: bp.Norm, DetJ: bp.DetJ, DetA: bp.DetA, Kind: ContBranch}
+	return o.trace(p, true)
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// trace runs the predictor-corrector steps. fromBranch skips the detection of special points
+// during the first step
+func (o *Continuation) trace(p *ContPoint, fromBranch bool) (path ContPath, err error) {
+
+	// first point
+	n := o.neq
+	path = ContPath{p}
+	if o.Out != nil {
+		if err = o.Out(p); err != nil {
+			return
+		}
+	}
+
+	// steps
+	ds := o.Ds
+	for step := 0; step < o.MaxSteps; step++ {
+
+		// predictor-corrector
+		var q *ContPoint
+		u, nit, e := o.correct(p.U, p.Tan, ds)
+		if e == nil {
+			q, e = o.point(u, p.Tan)
+			if e == nil && la.VecDot(q.Tan, p.Tan) < 0.8 { // angle between tangents is too large
+				e = chk.Err("angle between tangents is too large")
+			}
+		}
+
+		// reject step
+		if e != nil {
+			ds /= 2.0
+			if ds < o.DsMin {
+				return path, chk.Err(_cont_err4, p.Lam, ds, e)
+			}
+			step--
+			continue
+		}
+		q.S = p.S + ds
+
+		// special points
+		if fromBranch && step == 0 {
+			fromBranch = false
+		} else {
+			kind := ContRegular
+			if q.DetA*p.DetA < 0 {
+				kind = ContBranch
+			} else if q.Tan[n]*p.Tan[n] < 0 {
+				kind = ContFold
+			}
+			if kind != ContRegular {
+				var r *ContPoint
+				r, err = o.locate(p, q, ds, kind)
+				if err != nil {
+					return
+				}
+				path = append(path, r)
+				if o.Out != nil {
+					if err = o.Out(r); err != nil {
+						return
+					}
+				}
+			}
+		}
+
+		// new point
+		path = append(path, q)
+		if o.Out != nil {
+			if err = o.Out(q); err != nil {
+				return
+			}
+		}
+		p = q
+
+		// check range of λ
+		if o.LamMin < o.LamMax && (p.Lam < o.LamMin || p.Lam > o.LamMax) {
+			return
+		}
+
+		// step adaptation
+		ξ := float64(o.NOpt) / float64(imax(nit, 1))
+		ds = math.Min(ds*math.Min(math.Max(ξ, 0.5), 2.0), o.DsMax)
+	}
+	return
+}
+
+// locate finds the special point between p and q (at arclength ds from p) by solving for the zero
+// of the test function: DetA for branch points or Tan[n] for folds
+func (o *Continuation) locate(p, q *ContPoint, ds float64, kind int) (r *ContPoint, err error) {
+	n := o.neq
+	test := func(pt *ContPoint) float64 {
+		if kind == ContBranch {
+			return pt.DetA
+		}
+		return pt.Tan[n]
+	}
+	at := func(s float64) (r *ContPoint, err error) {
+		switch s {
+		case 0:
+			r = &ContPoint{U: p.U, Tan: p.Tan, Lam: p.Lam, Norm: p.Norm, DetJ: p.DetJ, DetA: p.DetA}
+			return
+		case ds:
+			r = &ContPoint{U: q.U, Tan: q.Tan, Lam: q.Lam, Norm: q.Norm, DetJ: q.DetJ, DetA: q.DetA}
+			return
+		}
+		for k := 0; k < 2; k++ {
+			var u []float64
+			u, _, err = o.correct(p.U, p.Tan, s)
+			if err == nil {
+				r, err = o.point(u, p.Tan)
+			}
+			if err == nil {
+				return
+			}
+			s += 1e-9 * ds // the matrix may be singular exactly at the special point
+		}
+		return
+	}
+	g := func(s float64) (float64, error) {
+		r, err := at(s)
+		if err != nil {
+			return 0, err
+		}
+		return test(r), nil
+	}
+	rf := RootFinder{Tol: 1e-12 * ds}
+	s, err := rf.Toms748(g, 0, ds)
+	if err != nil {
+		return nil, chk.Err(_cont_err5, err)
+	}
+	r, err = at(s)
+	if err != nil {
+		return nil, chk.Err(_cont_err5, err)
+	}
+	r.S = p.S + s
+	r.Kind = kind
+	return
+}
+
+// correct runs Newton's method on the augmented system
+//   F(u) = 0  and  tᵀ (u - u0) - ds = 0
+//  starting from u0 + ds t
+func (o *Continuation) correct(u0, t []float64, ds float64) (u []float64, nit int, err error) {
+	n := o.neq
+	u = make([]float64, n+1)
+	la.VecAdd2(u, 1, u0, ds, t)
+	δprev := math.Inf(1)
+	for nit = 0; nit < o.MaxIt; nit++ {
+
+		// residual
+		err = o.Ffcn(o.fu, u)
+		o.NFeval++
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			o.w[i] = -o.fu[i]
+		}
+		o.w[n] = ds
+		for i := 0; i < n+1; i++ {
+			o.w[n] -= t[i] * (u[i] - u0[i])
+		}
+		if nit > 0 && la.VecLargest(o.w, 1) < o.Tol {
+			return
+		}
+
+		// Newton update
+		err = o.jacobian(u)
+		if err != nil {
+			return
+		}
+		o.augmented(t)
+		err = o.lu.Factor(o.A)
+		if err != nil {
+			return
+		}
+		o.lu.Solve(o.w, o.w)
+		la.VecAdd(u, 1, o.w)
+		δ := la.VecLargest(o.w, 1)
+		if math.IsNaN(δ) || δ > 2*δprev {
+			return u, nit, chk.Err("corrector is diverging")
+		}
+		if δ <= o.Tol*(1.0+la.VecLargest(u, 1)) {
+			return u, nit + 1, nil
+		}
+		δprev = δ
+	}
+	return u, nit, chk.Err(_cont_err6, nit)
+}
+
+// point computes the tangent and the determinants at u. tref is the reference tangent that
+// defines the orientation
+func (o *Continuation) point(u, tref []float64) (p *ContPoint, err error) {
+
+	// tangent: solve [dF/du; trefᵀ] t = {0, 1}
+	n := o.neq
+	err = o.jacobian(u)
+	if err != nil {
+		return
+	}
+	o.augmented(tref)
+	err = o.lu.Factor(o.A)
+	if err != nil {
+		return
+	}
+	t := make([]float64, n+1)
+	t[n] = 1
+	o.lu.Solve(t, t)
+	nrm := la.VecNorm(t)
+	la.VecScale(t, 0, 1.0/nrm, t)
+
+	// results
+	p = &ContPoint{U: u, Tan: t, Lam: u[n]}
+	p.Norm = la.VecNorm(u[:n])
+	p.DetA = o.lu.Det() * nrm // = det([dF/du; tᵀ]); i.e. independent of tref
+	for i := 0; i < n; i++ {
+		copy(o.Jx[i], o.Ju[i][:n])
+	}
+	if o.lux.Factor(o.Jx) == nil {
+		p.DetJ = o.lux.Det()
+	}
+	return
+}
+
+// jacobian computes the dense Jacobian dF/du
+func (o *Continuation) jacobian(u []float64) (err error) {
+	o.NJeval++
+	n := o.neq
+
+	// analytical
+	if o.JfcnSp != nil {
+		o.Jtri.Start()
+		err = o.JfcnSp(&o.Jtri, u)
+		if err != nil {
+			return
+		}
+		J := o.Jtri.ToMatrix(nil).ToDense()
+		for i := 0; i < n; i++ {
+			copy(o.Ju[i], J[i])
+		}
+		return
+	}
+
+	// numerical
+	err = o.Ffcn(o.fu, u)
+	if err != nil {
+		return
+	}
+	f1 := o.wf
+	for j := 0; j < n+1; j++ {
+		usafe := u[j]
+		δ := math.Sqrt(MACHEPS * max(1e-5, math.Abs(usafe)))
+		u[j] = usafe + δ
+		err = o.Ffcn(f1, u)
+		u[j] = usafe
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			o.Ju[i][j] = (f1[i] - o.fu[i]) / δ
+		}
+	}
+	o.NFeval += n + 2
+	return
+}
+
+// augmented sets the augmented matrix A = [dF/du; tᵀ]
+func (o *Continuation) augmented(t []float64) {
+	n := o.neq
+	for i := 0; i < n; i++ {
+		copy(o.A[i], o.Ju[i])
+	}
+	copy(o.A[n], t)
+}
+
+// error messages
+var (
+	_cont_err1 = "continuation.go: Continuation.Trace: cannot compute initial point at λ = %g:\n%v"
+	_cont_err2 = "continuation.go: Continuation.SwitchBranch: point must be a branch point (Kind = ContBranch)"
+	_cont_err3 = "continuation.go: Continuation.SwitchBranch: cannot compute direction of new branch:\n%v"
+	_cont_err4 = "continuation.go: Continuation: step size is too small after λ = %g (ds = %g):\n%v"
+	_cont_err5 = "continuation.go: Continuation: cannot locate special point:\n%v"
+	_cont_err6 = "continuation.go: Continuation: corrector did not converge after %d iterations"
+)
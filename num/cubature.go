@@ -0,0 +1,467 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// Cubature implements algorithms for the integration of f(x) over hyperrectangles
+//  [a0,b0] × [a1,b1] × ... × [a_{d-1},b_{d-1}]
+// namely: adaptive subdivision with the Genz-Malik rule [1]; Smolyak sparse grids [2] built from
+// Gauss-Legendre rules; and quasi-Monte Carlo with Halton or Sobol points and randomised shifts [3].
+//  NOTE: zero values of the parameters are replaced by the default values
+//   Reference:
+//   [1] Genz AC, Malik AA (1980) An adaptive algorithm for numerical integration over an
+//       n-dimensional rectangular region. Journal of Computational and Applied Mathematics,
+//       6(4):295-302.
+//   [2] Gerstner T, Griebel M (1998) Numerical integration using sparse grids. Numerical
+//       Algorithms, 18:209-232.
+//   [3] Owen AB (1998) Monte Carlo extension of quasi-Monte Carlo. Proceedings of the 1998
+//       Winter Simulation Conference, 571-577.
+type Cubature struct {
+	AbsTol  float64 // absolute tolerance [default = 1e-8]
+	RelTol  float64 // relative tolerance [default = 1e-8]
+	MaxEval int     // maximum number of function evaluations in GenzMalik [default = 1000000]
+}
+
+// GenzMalik computes the integral of f(x) over the hyperrectangle [a, b] using the adaptive
+// algorithm of Genz and Malik; i.e. the (sub)region with the largest error is bisected along the
+// direction with the largest fourth divided difference until
+//  abserr ≤ max(AbsTol, RelTol * |res|)
+// or the number of function evaluations exceeds MaxEval.
+//  NOTE: (1) the dimension must be at least 2; use QuadAdaptive for one-dimensional integrals
+//        (2) each region requires 2ᵈ + 2d² + 2d + 1 evaluations; thus the method is
+//            recommended for dimensions up to about 10
+//  OUTPUT:
+//   res    -- the result
+//   abserr -- estimate of the absolute error
+//   neval  -- number of function evaluations
+func (o *Cubature) GenzMalik(f fun.Sv, a, b []float64) (res, abserr float64, neval int, err error) {
+
+	// check
+	o.defaults()
+	d := len(a)
+	if d < 2 || len(b) != d {
+		return 0, 0, 0, chk.Err(_cubature_err1, len(a), len(b))
+	}
+
+	// first region
+	var rule genzMalikRule
+	rule.init(d)
+	reg := &cubRegion{c: make([]float64, d), h: make([]float64, d)}
+	for i := 0; i < d; i++ {
+		reg.c[i], reg.h[i] = (a[i]+b[i])/2.0, (b[i]-a[i])/2.0
+	}
+	err = rule.apply(f, reg)
+	if err != nil {
+		return
+	}
+	regs := cubHeap{reg}
+	res, abserr, neval = reg.res, reg.err, rule.npts
+
+	// subdivision
+	for {
+		if abserr <= math.Max(o.AbsTol, o.RelTol*math.Abs(res)) {
+			return
+		}
+		if neval+2*rule.npts > o.MaxEval {
+			return res, abserr, neval, chk.Err(_cubature_err2, o.MaxEval, abserr)
+		}
+		r := heap.Pop(&regs).(*cubRegion)
+		k := r.split
+		r1 := &cubRegion{c: make([]float64, d), h: make([]float64, d)}
+		r2 := &cubRegion{c: make([]float64, d), h: make([]float64, d)}
+		copy(r1.c, r.c)
+		copy(r2.c, r.c)
+		copy(r1.h, r.h)
+		copy(r2.h, r.h)
+		r1.h[k], r2.h[k] = r.h[k]/2.0, r.h[k]/2.0
+		r1.c[k], r2.c[k] = r.c[k]-r1.h[k], r.c[k]+r2.h[k]
+		for _, rr := range []*cubRegion{r1, r2} {
+			err = rule.apply(f, rr)
+			if err != nil {
+				return
+			}
+			heap.Push(&regs, rr)
+		}
+		neval += 2 * rule.npts
+		res, abserr = 0, 0
+		for _, rr := range regs {
+			res += rr.res
+			abserr += rr.err
+		}
+	}
+}
+
+// Smolyak computes the integral of f(x) over the hyperrectangle [a, b] using a Smolyak sparse
+// grid of the given level; see SmolyakGrid. The error is estimated by the difference with
+// respect to the result with level - 1 (which is zero when level = 1)
+func (o *Cubature) Smolyak(f fun.Sv, a, b []float64, level int) (res, abserr float64, neval int, err error) {
+	var prev float64
+	for l := imax(level-1, 1); l <= level; l++ {
+		x, w := SmolyakGrid(a, b, l)
+		var fx float64
+		res = 0
+		for k := 0; k < len(w); k++ {
+			fx, err = f(x[k])
+			if err != nil {
+				return
+			}
+			res += w[k] * fx
+		}
+		neval += len(w)
+		if l < level {
+			prev = res
+		}
+	}
+	if level > 1 {
+		abserr = math.Abs(res - prev)
+	}
+	return
+}
+
+// SmolyakGrid computes the points and weights of the Smolyak sparse grid over the hyperrectangle
+// [a, b] using the combination technique:
+//  Q = Σ_{q-d+1 ≤ |l| ≤ q} (-1)^{q-|l|} C(d-1, q-|l|) U(l_0) ⊗ ... ⊗ U(l_{d-1})
+// where q = d + level - 1 and U(l) is the Gauss-Legendre rule with 2l-1 points (see
+// GaussLegendreXW). The grid integrates polynomials of total degree 4 level - 3 exactly.
+//  OUTPUT:
+//   x -- [npts][dim] points. Duplicated points are merged
+//   w -- [npts] weights (some may be negative)
+func SmolyakGrid(a, b []float64, level int) (x [][]float64, w []float64) {
+
+	// 1D rules in [-1, 1] with exact centre
+	d := len(a)
+	if level < 1 {
+		level = 1
+	}
+	xs, ws := make([][]float64, level+1), make([][]float64, level+1)
+	for l := 1; l <= level; l++ {
+		m := 2*l - 1
+		xs[l], ws[l] = GaussLegendreXW(-1, 1, m)
+		xs[l][m/2] = 0
+	}
+
+	// combination technique
+	q := d + level - 1
+	index := make(map[string]int)
+	lv := make([]int, d)
+	ids := make([]int, d)
+	key := make([]byte, 0, 16*d)
+	var rec func(dim, sum int)
+	rec = func(dim, sum int) {
+		if dim < d {
+			for l := 1; sum+l+(d-dim-1) <= q; l++ {
+				lv[dim] = l
+				rec(dim+1, sum+l)
+			}
+			return
+		}
+		if sum < q-d+1 {
+			return
+		}
+		coef := float64(binomial(d-1, q-sum))
+		if (q-sum)%2 == 1 {
+			coef = -coef
+		}
+
+		// tensor product
+		for i := range ids {
+			ids[i] = 0
+		}
+		for {
+			wt := coef
+			key = key[:0]
+			for i := 0; i < d; i++ {
+				wt *= ws[lv[i]][ids[i]]
+				bits := math.Float64bits(xs[lv[i]][ids[i]])
+				for s := uint(0); s < 64; s += 8 {
+					key = append(key, byte(bits>>s))
+				}
+			}
+			if k, ok := index[string(key)]; ok {
+				w[k] += wt
+			} else {
+				p := make([]float64, d)
+				for i := 0; i < d; i++ {
+					p[i] = xs[lv[i]][ids[i]]
+				}
+				index[string(key)] = len(w)
+				x = append(x, p)
+				w = append(w, wt)
+			}
+			i := 0
+			for ; i < d; i++ {
+				ids[i]++
+				if ids[i] < len(xs[lv[i]]) {
+					break
+				}
+				ids[i] = 0
+			}
+			if i == d {
+				break
+			}
+		}
+	}
+	rec(0, 0)
+
+	// map to [a, b]
+	vol := 1.0
+	for i := 0; i < d; i++ {
+		vol *= (b[i] - a[i]) / 2.0
+	}
+	for k := 0; k < len(w); k++ {
+		w[k] *= vol
+		for i := 0; i < d; i++ {
+			x[k][i] = (a[i]+b[i])/2.0 + (b[i]-a[i])/2.0*x[k][i]
+		}
+	}
+	return
+}
+
+// QuasiMonteCarlo computes the integral of f(x) over the hyperrectangle [a, b] using the points u
+// of a low-discrepancy sequence in the unit hypercube; e.g. given by rnd.HaltonPoints or
+// rnd.SobolPoints. The sequence is randomised by nshift random shifts modulo 1 (Cranley-Patterson
+// rotations) and the result is the mean of the nshift estimates. The error is estimated by the
+// standard error of the mean.
+//  INPUT:
+//   u      -- [dim][n] points in [0,1); e.g. n a power of 2 for Sobol points
+//   nshift -- number of random shifts [default = 10]. Must be at least 2 for an error estimate
+//  NOTE: the random shifts are generated with math/rand; use rand.Seed (or rnd.Init) to set the seed
+func (o *Cubature) QuasiMonteCarlo(f fun.Sv, a, b []float64, u [][]float64, nshift int) (res, abserr float64, neval int, err error) {
+
+	// check
+	d := len(a)
+	if d < 1 || len(b) != d || len(u) != d || len(u[0]) < 1 {
+		return 0, 0, 0, chk.Err(_cubature_err3, len(a), len(b), len(u))
+	}
+	n := len(u[0])
+	for i := 1; i < d; i++ {
+		if len(u[i]) != n {
+			return 0, 0, 0, chk.Err(_cubature_err4, i, len(u[i]), n)
+		}
+	}
+	if nshift < 1 {
+		nshift = 10
+	}
+
+	// estimates
+	vol := 1.0
+	for i := 0; i < d; i++ {
+		vol *= b[i] - a[i]
+	}
+	shift := make([]float64, d)
+	x := make([]float64, d)
+	est := make([]float64, nshift)
+	var fx float64
+	for s := 0; s < nshift; s++ {
+		for i := 0; i < d; i++ {
+			shift[i] = rand.Float64()
+		}
+		sum := 0.0
+		for k := 0; k < n; k++ {
+			for i := 0; i < d; i++ {
+				t := u[i][k] + shift[i]
+				if t >= 1 {
+					t -= 1
+				}
+				x[i] = a[i] + (b[i]-a[i])*t
+			}
+			fx, err = f(x)
+			if err != nil {
+				return
+			}
+			sum += fx
+		}
+		est[s] = vol * sum / float64(n)
+		res += est[s]
+	}
+	res /= float64(nshift)
+	neval = n * nshift
+
+	// standard error
+	if nshift > 1 {
+		for s := 0; s < nshift; s++ {
+			abserr += (est[s] - res) * (est[s] - res)
+		}
+		abserr = math.Sqrt(abserr / float64(nshift*(nshift-1)))
+	}
+	return
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// defaults sets the default values of parameters
+func (o *Cubature) defaults() {
+	if o.AbsTol <= 0 && o.RelTol <= 0 {
+		o.AbsTol, o.RelTol = 1e-8, 1e-8
+	}
+	if o.MaxEval < 1 {
+		o.MaxEval = 1000000
+	}
+}
+
+// cubRegion holds a subregion of the Genz-Malik algorithm
+type cubRegion struct {
+	c, h  []float64 // centre and half-widths
+	res   float64   // integral
+	err   float64   // error estimate
+	split int       // direction for subdivision
+}
+
+// cubHeap implements heap.Interface with the largest error on top
+type cubHeap []*cubRegion
+
+func (h cubHeap) Len() int            { return len(h) }
+func (h cubHeap) Less(i, j int) bool  { return h[i].err > h[j].err }
+func (h cubHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *cubHeap) Push(x interface{}) { *h = append(*h, x.(*cubRegion)) }
+func (h *cubHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	r := old[n-1]
+	*h = old[:n-1]
+	return r
+}
+
+// genzMalikRule holds the degree 7 rule with embedded degree 5 rule of Genz and Malik
+type genzMalikRule struct {
+	d          int         // dimension
+	npts       int         // number of points
+	l2, l4, l5 float64     // λ2, λ4 and λ5 (λ3 = λ4)
+	w7, w5     []float64   // weights of degree 7 and 5 rules (for each group of points)
+	x          []float64   // workspace: point
+	f2, f3     [][]float64 // workspace: f at ±λ2 and ±λ3 along each direction
+}
+
+// init initialises the rule
+func (o *genzMalikRule) init(d int) {
+	n := float64(d)
+	o.d = d
+	o.npts = (1 << uint(d)) + 2*d*d + 2*d + 1
+	o.l2, o.l4, o.l5 = math.Sqrt(9.0/70.0), math.Sqrt(9.0/10.0), math.Sqrt(9.0/19.0)
+	o.w7 = []float64{
+		(12824.0 - 9120.0*n + 400.0*n*n) / 19683.0,
+		980.0 / 6561.0,
+		(1820.0 - 400.0*n) / 19683.0,
+		200.0 / 19683.0,
+		6859.0 / 19683.0 / math.Pow(2, n),
+	}
+	o.w5 = []float64{
+		(729.0 - 950.0*n + 50.0*n*n) / 729.0,
+		245.0 / 486.0,
+		(265.0 - 100.0*n) / 1458.0,
+		25.0 / 729.0,
+	}
+	o.x = make([]float64, d)
+	o.f2 = [][]float64{make([]float64, d), make([]float64, d)}
+	o.f3 = [][]float64{make([]float64, d), make([]float64, d)}
+}
+
+// apply computes the integral and error estimate in region r and selects the direction for subdivision
+func (o *genzMalikRule) apply(f fun.Sv, r *cubRegion) (err error) {
+
+	// centre
+	d := o.d
+	copy(o.x, r.c)
+	f1, err := f(o.x)
+	if err != nil {
+		return
+	}
+
+	// points along each direction
+	var s2, s3 float64
+	for i := 0; i < d; i++ {
+		for k, sgn := range []float64{-1, 1} {
+			o.x[i] = r.c[i] + sgn*o.l2*r.h[i]
+			o.f2[k][i], err = f(o.x)
+			if err != nil {
+				return
+			}
+			o.x[i] = r.c[i] + sgn*o.l4*r.h[i]
+			o.f3[k][i], err = f(o.x)
+			if err != nil {
+				return
+			}
+		}
+		o.x[i] = r.c[i]
+		s2 += o.f2[0][i] + o.f2[1][i]
+		s3 += o.f3[0][i] + o.f3[1][i]
+	}
+
+	// points on the planes of each pair of directions
+	var s4 float64
+	for i := 0; i < d-1; i++ {
+		for j := i + 1; j < d; j++ {
+			for _, si := range []float64{-1, 1} {
+				for _, sj := range []float64{-1, 1} {
+					o.x[i] = r.c[i] + si*o.l4*r.h[i]
+					o.x[j] = r.c[j] + sj*o.l4*r.h[j]
+					var fx float64
+					fx, err = f(o.x)
+					if err != nil {
+						return
+					}
+					s4 += fx
+				}
+			}
+			o.x[j] = r.c[j]
+		}
+		o.x[i] = r.c[i]
+	}
+
+	// vertices of inner hypercube
+	var s5 float64
+	for m := 0; m < (1 << uint(d)); m++ {
+		for i := 0; i < d; i++ {
+			if (m>>uint(i))&1 == 1 {
+				o.x[i] = r.c[i] + o.l5*r.h[i]
+			} else {
+				o.x[i] = r.c[i] - o.l5*r.h[i]
+			}
+		}
+		var fx float64
+		fx, err = f(o.x)
+		if err != nil {
+			return
+		}
+		s5 += fx
+	}
+
+	// results
+	vol := 1.0
+	for i := 0; i < d; i++ {
+		vol *= 2.0 * r.h[i]
+	}
+	r7 := o.w7[0]*f1 + o.w7[1]*s2 + o.w7[2]*s3 + o.w7[3]*s4 + o.w7[4]*s5
+	r5 := o.w5[0]*f1 + o.w5[1]*s2 + o.w5[2]*s3 + o.w5[3]*s4
+	r.res = vol * r7
+	r.err = vol * math.Abs(r7-r5)
+
+	// direction with largest fourth difference (ties are broken by the largest width)
+	ratio := (o.l2 * o.l2) / (o.l4 * o.l4)
+	dmax := -1.0
+	for i := 0; i < d; i++ {
+		diff := math.Abs(o.f2[0][i] + o.f2[1][i] - 2.0*f1 - ratio*(o.f3[0][i]+o.f3[1][i]-2.0*f1))
+		if diff > dmax*(1+1e-10) || (diff >= dmax*(1-1e-10) && r.h[i] > r.h[r.split]) {
+			dmax, r.split = diff, i
+		}
+	}
+	return
+}
+
+// error messages
+var (
+	_cubature_err1 = "cubature.go: Cubature.GenzMalik: dimension must be at least 2 and len(a) must be equal to len(b). len(a)=%d, len(b)=%d is invalid"
+	_cubature_err2 = "cubature.go: Cubature.GenzMalik: maximum number of function evaluations (%d) reached. abserr = %g"
+	_cubature_err3 = "cubature.go: Cubature.QuasiMonteCarlo: len(a)=%d, len(b)=%d and len(u)=%d must be equal and positive"
+	_cubature_err4 = "cubature.go: Cubature.QuasiMonteCarlo: len(u[%d])=%d must be equal to len(u[0])=%d"
+)
@@ -0,0 +1,417 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// DerivRidders approximates the derivative of f w.r.t x using Ridders' method; i.e. central
+// differences with decreasing stepsizes are extrapolated to h → 0 with a Richardson (Neville)
+// tableau. An estimate of the error is also returned
+//  INPUT:
+//      x -- station where df/dx has to be calculated
+//      h -- initial stepsize. It does not need to be small; instead, it should be an increment in x
+//           over which f changes substantially. Use h ≤ 0 to select the stepsize automatically
+//      f -- function f(x)
+//  OUTPUT:
+//      res    -- df/dx @ x
+//      errEst -- estimate of the error in res
+//   Reference:
+//   [1] Ridders CJF (1982) Accurate computation of F'(x) and F'(x)F''(x). Advances in Engineering
+//       Software, 4(2):75-76
+//   [2] Press WH, Teukolsky SA, Vetterling WT, Fannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p
+func DerivRidders(x, h float64, f fun.Ss) (res, errEst float64, err error) {
+	r, e := []float64{0}, []float64{0}
+	err = ridders(r, e, h, math.Abs(x), func(d []float64, δ float64) (ferr error) {
+		fp, ferr := f(x + δ)
+		if ferr != nil {
+			return
+		}
+		fm, ferr := f(x - δ)
+		d[0] = (fp - fm) / (2.0 * δ)
+		return
+	})
+	return r[0], e[0], err
+}
+
+// Deriv2Ridders approximates the second derivative of f w.r.t x using Ridders' method with the
+// central second difference [f(x+h) - 2 f(x) + f(x-h)] / h²
+//  NOTE: see DerivRidders for the input and output
+func Deriv2Ridders(x, h float64, f fun.Ss) (res, errEst float64, err error) {
+	f0, err := f(x)
+	if err != nil {
+		return
+	}
+	r, e := []float64{0}, []float64{0}
+	err = ridders(r, e, h, math.Abs(x), func(d []float64, δ float64) (ferr error) {
+		fp, ferr := f(x + δ)
+		if ferr != nil {
+			return
+		}
+		fm, ferr := f(x - δ)
+		d[0] = (fp - 2.0*f0 + fm) / (δ * δ)
+		return
+	})
+	return r[0], e[0], err
+}
+
+// GradRidders approximates the gradient of the scalar field f(x) using Ridders' method
+//  INPUT:
+//      x -- station where df/dx has to be calculated. x is restored on return
+//      h -- initial stepsize; use h ≤ 0 to select the stepsize automatically
+//      f -- scalar function f(x)
+//  OUTPUT:
+//      g      -- gradient: g[i] = df/dx[i] @ x [must be pre-allocated]
+//      errEst -- largest estimate of the error among the components of g
+func GradRidders(g, x []float64, h float64, f fun.Sv) (errEst float64, err error) {
+	if len(g) != len(x) {
+		return 0, chk.Err(_derivridders_err1, "GradRidders", len(g), len(x))
+	}
+	r, e := []float64{0}, []float64{0}
+	for i := 0; i < len(x); i++ {
+		xi := x[i]
+		err = ridders(r, e, h, math.Abs(xi), func(d []float64, δ float64) (ferr error) {
+			defer func() { x[i] = xi }()
+			x[i] = xi + δ
+			fp, ferr := f(x)
+			if ferr != nil {
+				return
+			}
+			x[i] = xi - δ
+			fm, ferr := f(x)
+			d[0] = (fp - fm) / (2.0 * δ)
+			return
+		})
+		if err != nil {
+			return
+		}
+		g[i] = r[0]
+		errEst = max(errEst, e[0])
+	}
+	return
+}
+
+// HessRidders approximates the Hessian of the scalar field f(x) using Ridders' method. The
+// diagonal terms are computed with central second differences and the off-diagonal terms with
+//   [f(x+hᵢ+hⱼ) - f(x+hᵢ-hⱼ) - f(x-hᵢ+hⱼ) + f(x-hᵢ-hⱼ)] / (4 h²)
+//  INPUT:
+//      x -- station where d²f/dx² has to be calculated. x is restored on return
+//      h -- initial stepsize; use h ≤ 0 to select the stepsize automatically
+//      f -- scalar function f(x)
+//  OUTPUT:
+//      H      -- Hessian: H[i][j] = d²f/(dx[i] dx[j]) @ x [must be pre-allocated]
+//      errEst -- largest estimate of the error among the components of H
+func HessRidders(H [][]float64, x []float64, h float64, f fun.Sv) (errEst float64, err error) {
+	n := len(x)
+	if len(H) != n {
+		return 0, chk.Err(_derivridders_err1, "HessRidders", len(H), n)
+	}
+	f0, err := f(x)
+	if err != nil {
+		return
+	}
+	r, e := []float64{0}, []float64{0}
+	for i := 0; i < n; i++ {
+		xi := x[i]
+		for j := i; j < n; j++ {
+			xj := x[j]
+			if i == j {
+				err = ridders(r, e, h, math.Abs(xi), func(d []float64, δ float64) (ferr error) {
+					defer func() { x[i] = xi }()
+					x[i] = xi + δ
+					fp, ferr := f(x)
+					if ferr != nil {
+						return
+					}
+					x[i] = xi - δ
+					fm, ferr := f(x)
+					d[0] = (fp - 2.0*f0 + fm) / (δ * δ)
+					return
+				})
+			} else {
+				err = ridders(r, e, h, max(math.Abs(xi), math.Abs(xj)), func(d []float64, δ float64) (ferr error) {
+					defer func() { x[i], x[j] = xi, xj }()
+					var fpp, fpm, fmp, fmm float64
+					x[i], x[j] = xi+δ, xj+δ
+					if fpp, ferr = f(x); ferr != nil {
+						return
+					}
+					x[i], x[j] = xi+δ, xj-δ
+					if fpm, ferr = f(x); ferr != nil {
+						return
+					}
+					x[i], x[j] = xi-δ, xj+δ
+					if fmp, ferr = f(x); ferr != nil {
+						return
+					}
+					x[i], x[j] = xi-δ, xj-δ
+					fmm, ferr = f(x)
+					d[0] = (fpp - fpm - fmp + fmm) / (4.0 * δ * δ)
+					return
+				})
+			}
+			if err != nil {
+				return
+			}
+			H[i][j], H[j][i] = r[0], r[0]
+			errEst = max(errEst, e[0])
+		}
+	}
+	return
+}
+
+// JacRidders approximates the (dense) Jacobian matrix of f(x) using Ridders' method. Each column
+// is computed with one extrapolation tableau holding all components of f
+//  INPUT:
+//      x    -- station where df/dx has to be calculated. x is restored on return
+//      h    -- initial stepsize; use h ≤ 0 to select the stepsize automatically
+//      ffcn -- f(x) function. len(f) == len(J)
+//  OUTPUT:
+//      J      -- Jacobian: J[i][j] = df[i]/dx[j] @ x [must be pre-allocated]
+//      errEst -- largest estimate of the error among the components of J
+func JacRidders(J [][]float64, x []float64, h float64, ffcn fun.Vv) (errEst float64, err error) {
+	m, n := len(J), len(x)
+	for i := 0; i < m; i++ {
+		if len(J[i]) != n {
+			return 0, chk.Err(_derivridders_err1, "JacRidders", len(J[i]), n)
+		}
+	}
+	r, e := make([]float64, m), make([]float64, m)
+	fp, fm := make([]float64, m), make([]float64, m)
+	for j := 0; j < n; j++ {
+		xj := x[j]
+		err = ridders(r, e, h, math.Abs(xj), func(d []float64, δ float64) (ferr error) {
+			defer func() { x[j] = xj }()
+			x[j] = xj + δ
+			if ferr = ffcn(fp, x); ferr != nil {
+				return
+			}
+			x[j] = xj - δ
+			if ferr = ffcn(fm, x); ferr != nil {
+				return
+			}
+			for i := 0; i < m; i++ {
+				d[i] = (fp[i] - fm[i]) / (2.0 * δ)
+			}
+			return
+		})
+		if err != nil {
+			return
+		}
+		for i := 0; i < m; i++ {
+			J[i][j] = r[i]
+			errEst = max(errEst, e[i])
+		}
+	}
+	return
+}
+
+// DerivScaScaRidders checks the derivative of scalar w.r.t scalar by comparing with the numerical
+// solution obtained with Ridders' method (see DerivRidders)
+//  NOTE: this function is similar to chk.DerivScaSca; but h is the initial stepsize of Ridders'
+//        method and h ≤ 0 selects the stepsize automatically
+func DerivScaScaRidders(tst *testing.T, msg string, tol, gAna, xAt, h float64, verb bool, fcn fun.Ss) {
+	res, _, err := DerivRidders(xAt, h, fcn)
+	if err != nil {
+		tst.Errorf("function call failed:\n%v\n", err)
+		return
+	}
+	chk.AnaNum(tst, msg, tol, gAna, res, verb)
+}
+
+// DerivVecScaRidders checks the derivative of vector w.r.t scalar by comparing with the numerical
+// solution obtained with Ridders' method (see DerivScaScaRidders and chk.DerivVecSca)
+func DerivVecScaRidders(tst *testing.T, msg string, tol float64, gAna []float64, xAt, h float64, verb bool, fcn fun.Vs) {
+	r, e := make([]float64, len(gAna)), make([]float64, len(gAna))
+	fp, fm := make([]float64, len(gAna)), make([]float64, len(gAna))
+	err := ridders(r, e, h, math.Abs(xAt), func(d []float64, δ float64) (ferr error) {
+		if ferr = fcn(fp, xAt+δ); ferr != nil {
+			return
+		}
+		if ferr = fcn(fm, xAt-δ); ferr != nil {
+			return
+		}
+		for i := 0; i < len(d); i++ {
+			d[i] = (fp[i] - fm[i]) / (2.0 * δ)
+		}
+		return
+	})
+	if err != nil {
+		tst.Errorf("function call failed:\n%v\n", err)
+		return
+	}
+	for i, ana := range gAna {
+		chk.AnaNum(tst, io.Sf("%s%d", msg, i), tol, ana, r[i], verb)
+	}
+}
+
+// DerivScaVecRidders checks the derivative of scalar w.r.t vector by comparing with the numerical
+// solution obtained with Ridders' method (see GradRidders and chk.DerivScaVec)
+func DerivScaVecRidders(tst *testing.T, msg string, tol float64, gAna, xAt []float64, h float64, verb bool, fcn fun.Sv) {
+	if len(gAna) != len(xAt) {
+		tst.Errorf("length of gAna vector must be equal to the length of xAt vector. %d != %d", len(gAna), len(xAt))
+		return
+	}
+	g := make([]float64, len(xAt))
+	_, err := GradRidders(g, utl.GetCopy(xAt), h, fcn)
+	if err != nil {
+		tst.Errorf("function call failed:\n%v\n", err)
+		return
+	}
+	for i, ana := range gAna {
+		chk.AnaNum(tst, io.Sf("%s%d", msg, i), tol, ana, g[i], verb)
+	}
+}
+
+// DerivVecVecRidders checks the derivative of vector w.r.t vector by comparing with the numerical
+// solution obtained with Ridders' method (see JacRidders and chk.DerivVecVec)
+func DerivVecVecRidders(tst *testing.T, msg string, tol float64, gAna [][]float64, xAt []float64, h float64, verb bool, fcn fun.Vv) {
+	nrow := len(gAna)
+	if nrow < 1 {
+		tst.Errorf("number of rows of gAna matrix must be greater than or equal to 1\n")
+		return
+	}
+	for i := 0; i < nrow; i++ {
+		if len(gAna[i]) != len(xAt) {
+			tst.Errorf("number of columns in gAna matrix must be equal to len(xAt). %d != %d\n", len(gAna[i]), len(xAt))
+			return
+		}
+	}
+	J := la.MatAlloc(nrow, len(xAt))
+	_, err := JacRidders(J, utl.GetCopy(xAt), h, fcn)
+	if err != nil {
+		tst.Errorf("function call failed:\n%v\n", err)
+		return
+	}
+	for i := 0; i < nrow; i++ {
+		for j := 0; j < len(xAt); j++ {
+			chk.AnaNum(tst, io.Sf("%s%d%d", msg, i, j), tol, gAna[i][j], J[i][j], verb)
+		}
+	}
+}
+
+// constants for Ridders' method
+const (
+	riddersCon  = 1.4   // stepsize is decreased by riddersCon at each iteration
+	riddersNtab = 10    // maximum size of the extrapolation tableau
+	riddersSafe = 2.0   // return when the error is riddersSafe worse than the best so far
+	riddersNtry = 4     // maximum number of initial stepsizes tried by the automatic selection
+	riddersDiv  = 16.0  // initial stepsize is divided by riddersDiv for each new try
+	riddersTol  = 1e-10 // relative error accepted by the automatic selection
+)
+
+// ridders runs Ridders' method: the approximations d(h) computed by fd with the stepsizes h, h/c,
+// h/c², ... (c = 1.4), where d(h) = d(0) + a₁ h² + a₂ h⁴ + ..., are extrapolated to h → 0 with a
+// Richardson (Neville) tableau. The components of res are computed independently; i.e. each
+// component stops being updated when its error increases. If h ≤ 0, the initial stepsize is
+// selected automatically: h = 0.1⋅max(1,xscale) is tried first and then divided by 16 (up to 4
+// times) until the relative error estimates are smaller than 1e-10; the estimates with the
+// smallest errors are kept
+//   Input:
+//     h      -- initial stepsize. It does not need to be small; instead, it should be an increment
+//               over which the function changes substantially. Use h ≤ 0 for automatic selection
+//     xscale -- scale of the independent variable; e.g. |x|
+//     fd     -- computes the approximations d(h); e.g. central differences. len(d) == len(res)
+//   Output:
+//     res    -- extrapolated results [must be pre-allocated]
+//     errEst -- estimates of the errors [must be pre-allocated]
+//   Reference:
+//   [1] Ridders CJF (1982) Accurate computation of F'(x) and F'(x)F''(x). Advances in Engineering
+//       Software, 4(2):75-76
+func ridders(res, errEst []float64, h, xscale float64, fd func(d []float64, h float64) error) (err error) {
+	if h > 0 {
+		return riddersTableau(res, errEst, h, fd)
+	}
+	h = 0.1 * max(1.0, xscale)
+	r, e := make([]float64, len(res)), make([]float64, len(res))
+	for try := 0; try < riddersNtry; try++ {
+		err = riddersTableau(r, e, h, fd)
+		if err != nil {
+			return
+		}
+		done := true
+		for k := 0; k < len(res); k++ {
+			if try == 0 || e[k] < errEst[k] {
+				res[k], errEst[k] = r[k], e[k]
+			}
+			if !(errEst[k] <= riddersTol*max(1.0, math.Abs(res[k]))) {
+				done = false
+			}
+		}
+		if done {
+			return
+		}
+		h /= riddersDiv
+	}
+	return
+}
+
+// riddersTableau extrapolates the approximations d(h) computed by fd with h, h/c, h/c², ... where
+// d(h) = d(0) + a₁ h² + a₂ h⁴ + ... The components of res are computed independently; i.e. each
+// component stops being updated when its error increases
+func riddersTableau(res, errEst []float64, h float64, fd func(d []float64, h float64) error) (err error) {
+	n := len(res)
+	con2 := riddersCon * riddersCon
+	a := make([][][]float64, riddersNtab) // a[j][i][k]: tableau for each component k
+	for j := 0; j < riddersNtab; j++ {
+		a[j] = make([][]float64, riddersNtab)
+		for i := j; i < riddersNtab; i++ {
+			a[j][i] = make([]float64, n)
+		}
+	}
+	done := make([]bool, n)
+	err = fd(a[0][0], h)
+	if err != nil {
+		return
+	}
+	for k := 0; k < n; k++ {
+		res[k], errEst[k] = a[0][0][k], math.Inf(1)
+	}
+	for i := 1; i < riddersNtab; i++ {
+		h /= riddersCon
+		err = fd(a[0][i], h)
+		if err != nil {
+			return
+		}
+		ndone := 0
+		for k := 0; k < n; k++ {
+			if done[k] {
+				ndone++
+				continue
+			}
+			fac := con2
+			for j := 1; j <= i; j++ {
+				a[j][i][k] = (a[j-1][i][k]*fac - a[j-1][i-1][k]) / (fac - 1.0)
+				fac *= con2
+				errt := max(math.Abs(a[j][i][k]-a[j-1][i][k]), math.Abs(a[j][i][k]-a[j-1][i-1][k]))
+				if errt <= errEst[k] {
+					errEst[k], res[k] = errt, a[j][i][k]
+				}
+			}
+			if math.Abs(a[i][i][k]-a[i-1][i-1][k]) >= riddersSafe*errEst[k] {
+				done[k] = true
+				ndone++
+			}
+		}
+		if ndone == n {
+			return
+		}
+	}
+	return
+}
+
+// error messages
+var (
+	_derivridders_err1 = "derivRidders.go: %s: size of output (%d) does not match size of input (%d)"
+)
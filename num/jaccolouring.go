@@ -0,0 +1,189 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// JacColouring holds a colouring of the columns of the sparsity pattern of a Jacobian matrix.
+// Columns with the same colour do not have non-zero entries in a common row (they are
+// structurally independent); thus, they can be perturbed at the same time when computing the
+// Jacobian by finite differences. The number of evaluations of f(x) is then reduced from the
+// number of columns to the number of colours; e.g. 3 for tridiagonal matrices
+//   Reference:
+//   [1] Curtis AR, Powell MJD, Reid JK (1974) On the estimation of sparse Jacobian matrices.
+//       IMA Journal of Applied Mathematics, 13:117-119
+//   [2] Coleman TF, Moré JJ (1983) Estimation of sparse Jacobian matrices and graph coloring
+//       problems. SIAM Journal on Numerical Analysis, 20(1):187-209
+type JacColouring struct {
+	Ncolours int     // number of colours (number of evaluations of f(x))
+	Colour   []int   // colour of each column
+	Groups   [][]int // columns with each colour
+	Nnz      int     // number of (unique) non-zeros in pattern
+	colRows  [][]int // rows of non-zeros in each column
+}
+
+// Init computes the colouring of the columns of the sparsity pattern given as a triplet. The
+// values in the triplet are ignored and repeated entries are allowed. The columns are coloured by
+// the greedy algorithm with the largest-first ordering; i.e. columns with more neighbours (other
+// columns sharing rows) are coloured first
+func (o *JacColouring) Init(pattern *la.Triplet) {
+
+	// rows of each column and columns of each row
+	m, n := pattern.Size()
+	o.colRows = make([][]int, n)
+	rowCols := make([][]int, m)
+	for k := 0; k < pattern.Len(); k++ {
+		i, j, _ := pattern.Entry(k)
+		if i < 0 || i >= m || j < 0 || j >= n {
+			chk.Panic(_jaccolouring_err1, i, j, m, n)
+		}
+		o.colRows[j] = append(o.colRows[j], i)
+	}
+	o.Nnz = 0
+	for j := 0; j < n; j++ {
+		o.colRows[j] = jacUnique(o.colRows[j])
+		o.Nnz += len(o.colRows[j])
+		for _, i := range o.colRows[j] {
+			rowCols[i] = append(rowCols[i], j)
+		}
+	}
+
+	// degree of each column in the column intersection graph
+	mark := make([]int, n)
+	for j := 0; j < n; j++ {
+		mark[j] = -1
+	}
+	degree := make([]int, n)
+	for j := 0; j < n; j++ {
+		for _, i := range o.colRows[j] {
+			for _, c := range rowCols[i] {
+				if c != j && mark[c] != j {
+					mark[c] = j
+					degree[j]++
+				}
+			}
+		}
+	}
+
+	// largest-first ordering
+	order := make([]int, n)
+	for j := 0; j < n; j++ {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return degree[order[a]] > degree[order[b]] })
+
+	// greedy colouring
+	o.Colour = make([]int, n)
+	for j := 0; j < n; j++ {
+		o.Colour[j] = -1
+	}
+	forbidden := make([]int, n+1) // forbidden[colour] == j => colour cannot be used by column j
+	for k := 0; k < n+1; k++ {
+		forbidden[k] = -1
+	}
+	o.Ncolours = 0
+	for _, j := range order {
+		for _, i := range o.colRows[j] {
+			for _, c := range rowCols[i] {
+				if o.Colour[c] >= 0 {
+					forbidden[o.Colour[c]] = j
+				}
+			}
+		}
+		colour := 0
+		for forbidden[colour] == j {
+			colour++
+		}
+		o.Colour[j] = colour
+		if colour+1 > o.Ncolours {
+			o.Ncolours = colour + 1
+		}
+	}
+
+	// groups
+	o.Groups = make([][]int, o.Ncolours)
+	for j := 0; j < n; j++ {
+		o.Groups[o.Colour[j]] = append(o.Groups[o.Colour[j]], j)
+	}
+}
+
+// JacobianColoured computes the Jacobian (sparse) matrix using a column colouring of its
+// sparsity pattern. Only Ncolours evaluations of f(x) are needed
+//  INPUT:
+//      ffcn : f(x) function
+//      x    : station where dfdx has to be calculated
+//      fx   : f @ x
+//      w    : workspace with size == len(f)
+//      col  : colouring of the sparsity pattern; see JacColouring
+//  RETURNS:
+//      J : dfdx @ x [must be pre-allocated or have Max() == 0]
+//  NOTE: entries of the Jacobian outside the sparsity pattern are assumed to be zero
+func JacobianColoured(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64, col *JacColouring) (err error) {
+	return jacobianColoured(J, ffcn, x, fx, w, col, 0, len(fx))
+}
+
+// jacobianColoured computes the entries of the Jacobian in rows [start, endp1)
+func jacobianColoured(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64, col *JacColouring, start, endp1 int) (err error) {
+	ndim := len(x)
+	if len(col.Colour) != ndim {
+		return chk.Err(_jaccolouring_err2, len(col.Colour), ndim)
+	}
+	if J.Max() == 0 {
+		J.Init(len(fx), ndim, col.Nnz)
+	}
+	J.Start()
+	delta, xsafe := make([]float64, ndim), make([]float64, ndim)
+	for _, group := range col.Groups {
+		for _, c := range group {
+			xsafe[c] = x[c]
+			delta[c] = math.Sqrt(MACHEPS * max(1e-5, math.Abs(x[c])))
+			x[c] = xsafe[c] + delta[c]
+		}
+		err = ffcn(w, x) // w := f(x+δx[group])
+		for _, c := range group {
+			x[c] = xsafe[c]
+		}
+		if err != nil {
+			return
+		}
+		for _, c := range group {
+			for _, row := range col.colRows[c] {
+				if row >= start && row < endp1 {
+					J.Put(row, c, (w[row]-fx[row])/delta[c])
+				}
+			}
+		}
+	}
+	return
+}
+
+// jacUnique sorts and removes repeated values from a
+func jacUnique(a []int) []int {
+	if len(a) < 2 {
+		return a
+	}
+	sort.Ints(a)
+	k := 1
+	for i := 1; i < len(a); i++ {
+		if a[i] != a[k-1] {
+			a[k] = a[i]
+			k++
+		}
+	}
+	return a[:k]
+}
+
+// error messages
+var (
+	_jaccolouring_err1 = "jaccolouring.go: JacColouring.Init: entry (%d,%d) is outside the %d×%d pattern"
+	_jaccolouring_err2 = "jaccolouring.go: JacobianColoured: colouring has %d columns but len(x) = %d"
+)
@@ -67,6 +67,29 @@ func JacobianMpi(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64, distr bool) (er
 	return
 }
 
+// JacobianColouredMpi computes the Jacobian (sparse) matrix using a column colouring of its
+// sparsity pattern. Only Ncolours evaluations of f(x) are needed; see JacColouring
+//  INPUT:
+//      ffcn  : f(x) function
+//      x     : station where dfdx has to be calculated
+//      fx    : f @ x
+//      w     : workspace with size == n == len(x)
+//      col   : colouring of the sparsity pattern
+//      distr : each processor computes the rows in its range only (as in JacobianMpi)
+//  RETURNS:
+//      J : dfdx @ x [must be pre-allocated or have Max() == 0]
+//  NOTE: all processors evaluate f(x) with the same perturbations; thus, the AllReduce in f
+//        calculation (if any) is consistent
+func JacobianColouredMpi(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64, col *JacColouring, distr bool) (err error) {
+	ndim := len(fx)
+	start, endp1 := 0, ndim
+	if distr {
+		id, sz := mpi.Rank(), mpi.Size()
+		start, endp1 = (id*ndim)/sz, ((id+1)*ndim)/sz
+	}
+	return jacobianColoured(J, ffcn, x, fx, w, col, start, endp1)
+}
+
 // CompareJacMpi compares Jacobian matrix (e.g. for testing)
 func CompareJacMpi(tst *testing.T, ffcn fun.Vv, Jfcn fun.Tv, x []float64, tol float64, distr bool) {
 
@@ -13,8 +13,19 @@ import (
 	"github.com/cpmech/gosl/la"
 )
 
+// methods for NlSolver
+const (
+	NlsNewton   = iota // Newton's method with analytical or numerical Jacobian (default)
+	NlsJFNK            // Jacobian-free Newton-Krylov method (GMRES with directional differences)
+	NlsAnderson        // Anderson-accelerated fixed-point iterations
+	NlsBroyden         // Broyden's quasi-Newton method
+)
+
+// NlSolver solves non-linear systems f(x) = 0 with Newton's method or one of the methods listed
+// above. The method is selected with prms["method"]
 type NlSolver struct {
 	// constants
+	Method  int     // method; e.g. NlsNewton, NlsJFNK, NlsAnderson or NlsBroyden
 	CteJac  bool    // constant Jacobian (Modified Newton's method)
 	Lsearch bool    // use linear search
 	LsMaxIt int     // linear solver maximum iterations
@@ -55,10 +66,29 @@ type NlSolver struct {
 	dφdx []float64
 	x0   []float64
 
+	// data for Jacobian-free Newton-Krylov method
+	Prec   la.Precond // preconditioner for GMRES; may be nil
+	Kry    la.Krylov  // Krylov solver: Restart and MaxIt may be set via prms
+	EtaMax float64    // maximum forcing term η (relative tolerance of GMRES)
+	jfnk   *nlsJfnkOp // linear operator with directional differences
+
+	// data for Anderson acceleration
+	AndM     int         // maximum number of previous iterates (depth)
+	AndBeta  float64     // mixing (damping) parameter β
+	AndOmega float64     // relaxation of the fixed-point map g(x) = x - ω f(x)
+	andDf    [][]float64 // differences of residuals
+	andDx    [][]float64 // differences of iterates
+
+	// data for Broyden's method
+	BroMax int         // maximum number of stored steps before restarting with a new Jacobian
+	broS   [][]float64 // stored steps
+
 	// stat data
-	It     int // number of iterations from the last call to Solve
-	NFeval int // number of calls to Ffcn (function evaluations)
-	NJeval int // number of calls to Jfcn (Jacobian evaluations)
+	It       int // number of iterations from the last call to Solve
+	NFeval   int // number of calls to Ffcn (function evaluations)
+	NJeval   int // number of calls to Jfcn (Jacobian evaluations)
+	NLinIt   int // number of linear (GMRES) iterations [JFNK only]
+	NRestart int // number of restarts [Anderson and Broyden only]
 }
 
 // Init initialises solver
@@ -66,7 +96,12 @@ type NlSolver struct {
 //   useSp -- Use sparse solver with JfcnSp
 //   useDn -- Use dense solver (matrix inversion) with JfcnDn
 //   numJ  -- Use numeric Jacobian (sparse version only)
-//   prms  -- atol, rtol, ftol, lSearch, lsMaxIt, maxIt
+//   prms  -- atol, rtol, ftol, lSearch, lsMaxIt, maxIt, method
+//            JFNK:     kryRestart, kryMaxIt, etaMax
+//            Anderson: andM, andBeta, andOmega
+//            Broyden:  broMax
+//  NOTE: the Jacobian is not used by the JFNK and Anderson methods. Broyden's method uses the
+//        Jacobian at the first iteration and after each restart
 func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, JfcnDn fun.Mv, useDn, numJ bool, prms map[string]float64) {
 
 	// set default values
@@ -74,6 +109,11 @@ func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, JfcnDn fun.Mv, useD
 	o.LsMaxIt = 20
 	o.MaxIt = 20
 	o.ChkConv = true
+	o.Method = NlsNewton
+	o.EtaMax = 0.9
+	o.AndM, o.AndBeta, o.AndOmega = 5, 1, 1
+	o.BroMax = 20
+	maxItGiven := false
 
 	// read parameters
 	for k, v := range prms {
@@ -90,8 +130,31 @@ func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, JfcnDn fun.Mv, useD
 			o.LsMaxIt = int(v)
 		case "maxIt":
 			o.MaxIt = int(v)
+			maxItGiven = true
+		case "method":
+			o.Method = int(v)
+		case "kryRestart":
+			o.Kry.Restart = int(v)
+		case "kryMaxIt":
+			o.Kry.MaxIt = int(v)
+		case "etaMax":
+			o.EtaMax = v
+		case "andM":
+			o.AndM = int(v)
+		case "andBeta":
+			o.AndBeta = v
+		case "andOmega":
+			o.AndOmega = v
+		case "broMax":
+			o.BroMax = int(v)
 		}
 	}
+	if o.Method < NlsNewton || o.Method > NlsBroyden {
+		chk.Panic(_nls_err10, o.Method)
+	}
+	if o.Method == NlsAnderson && !maxItGiven {
+		o.MaxIt = 200 // fixed-point iterations converge linearly
+	}
 
 	// set tolerances
 	o.SetTols(atol, rtol, ftol, MACHEPS)
@@ -108,6 +171,23 @@ func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, JfcnDn fun.Mv, useD
 	// type of linear solver and Jacobian matrix (numerical or analytical: sparse only)
 	o.useDn, o.numJ = useDn, numJ
 
+	// allocate slices for line search
+	o.dφdx = make([]float64, o.neq)
+	o.x0 = make([]float64, o.neq)
+
+	// Jacobian-free methods
+	switch o.Method {
+	case NlsJFNK:
+		o.jfnk = &nlsJfnkOp{o: o, xp: make([]float64, o.neq), fp: make([]float64, o.neq)}
+		return
+	case NlsAnderson:
+		o.andDf = make([][]float64, 0, o.AndM)
+		o.andDx = make([][]float64, 0, o.AndM)
+		return
+	case NlsBroyden:
+		o.broS = make([][]float64, 0, o.BroMax+1)
+	}
+
 	// use dense linear solver
 	if o.useDn {
 		o.J = la.MatAlloc(o.neq, o.neq)
@@ -122,17 +202,13 @@ func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnSp fun.Tv, JfcnDn fun.Mv, useD
 		if o.numJ {
 			o.w = make([]float64, o.neq)
 		}
-		o.lis = la.GetSolver("umfpack")
+		o.lis = la.GetSolver(la.DefaultSolver)
 	}
-
-	// allocate slices for line search
-	o.dφdx = make([]float64, o.neq)
-	o.x0 = make([]float64, o.neq)
 }
 
 // Free frees memory
 func (o *NlSolver) Free() {
-	if !o.useDn {
+	if o.lis != nil {
 		o.lis.Free()
 	}
 }
@@ -146,6 +222,16 @@ func (o *NlSolver) SetTols(Atol, Rtol, Ftol, ϵ float64) {
 // Solve solves non-linear problem f(x) == 0
 func (o *NlSolver) Solve(x []float64, silent bool) (err error) {
 
+	// other methods
+	switch o.Method {
+	case NlsJFNK:
+		return o.solveJfnk(x, silent)
+	case NlsAnderson:
+		return o.solveAnderson(x, silent)
+	case NlsBroyden:
+		return o.solveBroyden(x, silent)
+	}
+
 	// compute scaling vector
 	la.VecScaleAbs(o.scal, o.atol, o.rtol, x) // scal := Atol + Rtol*abs(x)
 
@@ -401,15 +487,29 @@ func (o *NlSolver) msg(typ string, it int, Ldx, fx_max float64, first, last bool
 	}
 }
 
+// msgMethod prints information on residuals and on a method-specific quantity
+func (o *NlSolver) msgMethod(typ, key string, it int, Ldx, fx_max, val float64, first, last bool) {
+	if first {
+		io.Pf("\n%4s%23s%23s%14s\n", "it", "Ldx", "fx_max", key)
+		io.Pf("%4s%23s%23s\n", "", io.Sf("(%7.1e)", o.fnewt), io.Sf("(%7.1e)", o.ftol))
+		return
+	}
+	io.Pf("%4d%23.15e%23.15e%14.6g\n", it, Ldx, fx_max, val)
+	if last {
+		io.Pf(". . . converged with %s. nit=%d, nFeval=%d, nJeval=%d, nLinIt=%d, nRestart=%d\n", typ, it, o.NFeval, o.NJeval, o.NLinIt, o.NRestart)
+	}
+}
+
 // error messages
 var (
-	_nls_err1 = "nlsolver.go: NlSolver.Solve failed: cannot compute inverse of Jacobian (dense) matrix:\n%v"
-	_nls_err2 = "nlsolver.go: NlSolver.Solve: LineSearch failed:\n%v"
-	_nls_err3 = "nlsolver.go: NlSolver.Solve is diverging with Θ = %g (Ldx=%g, Ldx_prev=%g)"
-	_nls_err4 = "nlsolver.go: NlSolver.Solve did not converge after %d iterations"
-	_nls_err5 = "nlsolver.go: NlSolver.CheckJ: %s: failed:\n%v"
-	_nls_err6 = "nlsolver.go: NlSolver.CheckJ failed: cannot compute condition number\n%v"
-	_nls_err7 = "nlsolver.go: NlSolver.CheckJ failed: condition number is Inf or NaN: %v"
-	_nls_err8 = "nlsolver.go: NlSolver.CheckJ failed: maxdiff = %g"
-	_nls_err9 = "nlsolver.go: NlSolver.Init: cannot initialise LinSol('umfpack'):\n%v\n"
+	_nls_err1  = "nlsolver.go: NlSolver.Solve failed: cannot compute inverse of Jacobian (dense) matrix:\n%v"
+	_nls_err2  = "nlsolver.go: NlSolver.Solve: LineSearch failed:\n%v"
+	_nls_err3  = "nlsolver.go: NlSolver.Solve is diverging with Θ = %g (Ldx=%g, Ldx_prev=%g)"
+	_nls_err4  = "nlsolver.go: NlSolver.Solve did not converge after %d iterations"
+	_nls_err5  = "nlsolver.go: NlSolver.CheckJ: %s: failed:\n%v"
+	_nls_err6  = "nlsolver.go: NlSolver.CheckJ failed: cannot compute condition number\n%v"
+	_nls_err7  = "nlsolver.go: NlSolver.CheckJ failed: condition number is Inf or NaN: %v"
+	_nls_err8  = "nlsolver.go: NlSolver.CheckJ failed: maxdiff = %g"
+	_nls_err9  = "nlsolver.go: NlSolver.Init: cannot initialise LinSol('umfpack'):\n%v\n"
+	_nls_err10 = "nlsolver.go: NlSolver.Init: method = %d is invalid"
 )
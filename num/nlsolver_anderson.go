@@ -0,0 +1,175 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// solveAnderson solves f(x) = 0 with Anderson-accelerated iterations of the fixed-point map
+//   g(x) = x - ω f(x)
+// The new iterate combines the last m iterates such that the linearised residual is minimised:
+//   x ← x + β r - Σ γⱼ (Δxⱼ + β Δrⱼ)   with   γ = argmin ‖r - Σ γⱼ Δrⱼ‖   and   r = g(x) - x
+// The least-squares problem is solved by QR decomposition (modified Gram-Schmidt); columns that
+// are nearly linearly dependent are discarded
+//  NOTE: (1) the convergence is checked on f(x) only because small steps do not indicate
+//            convergence of fixed-point iterations
+//        (2) if ChkConv is true, the history is cleared whenever ‖f‖ increases (safeguard)
+//   Reference:
+//   [1] Walker HF, Ni P (2011) Anderson acceleration for fixed-point iterations. SIAM Journal on
+//       Numerical Analysis, 49(4):1715-1735
+func (o *NlSolver) solveAnderson(x []float64, silent bool) (err error) {
+
+	// evaluate function @ x
+	la.VecScaleAbs(o.scal, o.atol, o.rtol, x) // scal := Atol + Rtol*abs(x)
+	err = o.Ffcn(o.fx, x)                     // fx := f(x)
+	o.NFeval, o.NJeval, o.NLinIt, o.NRestart = 1, 0, 0, 0
+	if err != nil {
+		return
+	}
+
+	// show message
+	if !silent {
+		o.msgMethod("", "m", 0, 0, 0, 0, true, false)
+	}
+
+	// workspace
+	n := o.neq
+	r := make([]float64, n)    // r = -ω f(x)
+	rold := make([]float64, n) // previous r
+	q := la.MatAlloc(o.AndM, n)
+	R := la.MatAlloc(o.AndM, o.AndM)
+	γ := make([]float64, o.AndM)
+	o.andDf, o.andDx = o.andDf[:0], o.andDx[:0]
+	la.VecCopy(r, -o.AndOmega, o.fx)
+
+	// iterations
+	var Ldx, fx_max float64
+	fnrm := la.VecNorm(o.fx)
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		// check convergence on f(x)
+		fx_max = la.VecLargest(o.fx, 1.0) // den = 1.0
+		if fx_max < o.ftol {
+			if !silent {
+				o.msgMethod("fx_max", "", o.It, Ldx, fx_max, float64(len(o.andDf)), false, true)
+			}
+			break
+		}
+
+		// show message
+		if !silent {
+			o.msgMethod("", "", o.It, Ldx, fx_max, float64(len(o.andDf)), false, false)
+		}
+
+		// output
+		if o.Out != nil {
+			o.Out(x)
+		}
+
+		// update history
+		if o.It > 0 && o.AndM > 0 {
+			var df, dx []float64
+			if len(o.andDf) == o.AndM { // reuse oldest
+				df, dx = o.andDf[0], o.andDx[0]
+				o.andDf, o.andDx = append(o.andDf[:0], o.andDf[1:]...), append(o.andDx[:0], o.andDx[1:]...)
+			} else {
+				df, dx = make([]float64, n), make([]float64, n)
+			}
+			la.VecAdd2(df, 1, r, -1, rold)
+			la.VecAdd2(dx, 1, x, -1, o.x0)
+			o.andDf, o.andDx = append(o.andDf, df), append(o.andDx, dx)
+		}
+
+		// solve least-squares problem
+		m := o.andQR(q, R)
+		for i := 0; i < m; i++ {
+			γ[i] = la.VecDot(q[i], r)
+		}
+		for i := m - 1; i >= 0; i-- {
+			for j := i + 1; j < m; j++ {
+				γ[i] -= R[i][j] * γ[j]
+			}
+			γ[i] /= R[i][i]
+		}
+
+		// update x
+		copy(o.x0, x)
+		copy(rold, r)
+		for k := 0; k < n; k++ {
+			x[k] += o.AndBeta * r[k]
+			for j := 0; j < m; j++ {
+				x[k] -= γ[j] * (o.andDx[j][k] + o.AndBeta*o.andDf[j][k])
+			}
+			o.mdx[k] = o.x0[k] - x[k]
+		}
+		Ldx = nlsRms(o.mdx, o.scal)
+
+		// calculate fx := f(x) @ update x
+		err = o.Ffcn(o.fx, x)
+		o.NFeval++
+		if err != nil {
+			return
+		}
+		la.VecCopy(r, -o.AndOmega, o.fx)
+
+		// safeguard
+		fnrm_prev := fnrm
+		fnrm = la.VecNorm(o.fx)
+		if math.IsNaN(fnrm) || math.IsInf(fnrm, 0) {
+			return chk.Err(_nlsand_err1, o.It)
+		}
+		if o.ChkConv && fnrm > fnrm_prev && len(o.andDf) > 0 {
+			o.andDf, o.andDx = o.andDf[:0], o.andDx[:0]
+			o.NRestart++
+		}
+	}
+
+	// output
+	if o.Out != nil {
+		o.Out(x)
+	}
+
+	// check convergence
+	if o.It == o.MaxIt {
+		err = chk.Err(_nls_err4, o.It)
+	}
+	return
+}
+
+// andQR computes the QR decomposition of the matrix with columns andDf using the modified
+// Gram-Schmidt method. Oldest columns are removed from the history while the matrix is nearly
+// rank-deficient. Returns the number of columns m
+func (o *NlSolver) andQR(q, R [][]float64) (m int) {
+	for {
+		m = len(o.andDf)
+		ok := true
+		for j := 0; j < m; j++ {
+			copy(q[j], o.andDf[j])
+			for i := 0; i < j; i++ {
+				R[i][j] = la.VecDot(q[i], q[j])
+				la.VecAdd(q[j], -R[i][j], q[i])
+			}
+			R[j][j] = la.VecNorm(q[j])
+			if R[j][j] <= 1e-10*la.VecNorm(o.andDf[j]) {
+				ok = false
+				break
+			}
+			la.VecCopy(q[j], 1.0/R[j][j], q[j])
+		}
+		if ok {
+			return
+		}
+		o.andDf, o.andDx = append(o.andDf[:0], o.andDf[1:]...), append(o.andDx[:0], o.andDx[1:]...)
+	}
+}
+
+// error messages
+var (
+	_nlsand_err1 = "nlsolver_anderson.go: NlSolver.Solve(Anderson): residual is NaN or Inf at iteration %d"
+)
@@ -0,0 +1,202 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// solveBroyden solves f(x) = 0 with Broyden's ("good") quasi-Newton method. The inverse of the
+// Jacobian is updated with the Sherman-Morrison formula in product form; i.e. only the steps sₖ
+// are stored and the factorisation of the initial Jacobian J₀ is reused:
+//   z = -J₀⁻¹ f(xₙ₊₁)
+//   z ← z + sⱼ₊₁ (sⱼ・z) / ‖sⱼ‖²   for j = 0 ... n-1
+//   sₙ₊₁ = z / (1 - sₙ・z / ‖sₙ‖²)
+// The method is restarted with a new Jacobian when ‖f‖ increases (if ChkConv is true) or when
+// BroMax steps have been stored. An error is returned if ‖f‖ increases twice in a row after steps
+// computed with new Jacobians
+//  NOTE: line-search is not available for this method
+//   Reference:
+//   [1] Kelley CT (1995) Iterative Methods for Linear and Nonlinear Equations. SIAM. 165p
+func (o *NlSolver) solveBroyden(x []float64, silent bool) (err error) {
+
+	// compute scaling vector
+	la.VecScaleAbs(o.scal, o.atol, o.rtol, x) // scal := Atol + Rtol*abs(x)
+
+	// evaluate function @ x
+	err = o.Ffcn(o.fx, x) // fx := f(x)
+	o.NFeval, o.NJeval, o.NLinIt, o.NRestart = 1, 0, 0, 0
+	if err != nil {
+		return
+	}
+
+	// show message
+	if !silent {
+		o.msgMethod("", "nstored", 0, 0, 0, 0, true, false)
+	}
+
+	// workspace
+	z := make([]float64, o.neq)
+	o.broS = o.broS[:0]
+
+	// iterations
+	var Ldx, fx_max float64
+	fnrm := la.VecNorm(o.fx)
+	restart, diverging := true, false
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		// check convergence on f(x)
+		fx_max = la.VecLargest(o.fx, 1.0) // den = 1.0
+		if fx_max < o.ftol {
+			if !silent {
+				o.msgMethod("fx_max", "", o.It, Ldx, fx_max, float64(len(o.broS)), false, true)
+			}
+			break
+		}
+
+		// show message
+		if !silent {
+			o.msgMethod("", "", o.It, Ldx, fx_max, float64(len(o.broS)), false, false)
+		}
+
+		// output
+		if o.Out != nil {
+			o.Out(x)
+		}
+
+		// new step
+		if restart {
+			err = o.broFactJ(x, o.It == 0)
+			if err != nil {
+				return
+			}
+			o.broS = o.broS[:0]
+			restart = false
+		}
+		err = o.broSolveJ(z, o.fx) // z := J₀⁻¹ f
+		if err != nil {
+			return
+		}
+		la.VecScale(z, 0, -1, z) // z := -z
+		if len(o.broS) > 0 {
+			nsto := len(o.broS)
+			for j := 0; j < nsto-1; j++ {
+				sj := o.broS[j]
+				la.VecAdd(z, la.VecDot(sj, z)/la.VecDot(sj, sj), o.broS[j+1])
+			}
+			sn := o.broS[nsto-1]
+			la.VecScale(z, 0, 1.0/(1.0-la.VecDot(sn, z)/la.VecDot(sn, sn)), z)
+		}
+		s := la.VecClone(z)
+		o.broS = append(o.broS, s)
+
+		// update x
+		copy(o.x0, x)
+		la.VecAdd(x, 1, s) // x += s
+		Ldx = nlsRms(s, o.scal)
+
+		// calculate fx := f(x) @ update x
+		err = o.Ffcn(o.fx, x)
+		o.NFeval++
+		if err != nil {
+			return
+		}
+
+		// check convergence on f(x)
+		fx_max = la.VecLargest(o.fx, 1.0) // den = 1.0
+		if fx_max < o.ftol {
+			if !silent {
+				o.msgMethod("fx_max", "", o.It, Ldx, fx_max, float64(len(o.broS)), false, true)
+			}
+			break
+		}
+
+		// check convergence on Ldx
+		if Ldx < o.fnewt {
+			if !silent {
+				o.msgMethod("Ldx", "", o.It, Ldx, fx_max, float64(len(o.broS)), false, true)
+			}
+			break
+		}
+
+		// monitor residual
+		fnrm_prev := fnrm
+		fnrm = la.VecNorm(o.fx)
+		increased := o.ChkConv && fnrm > fnrm_prev
+		if increased && diverging {
+			return chk.Err(_nlsbro_err1, fnrm, fnrm_prev)
+		}
+		diverging = increased && len(o.broS) == 1 // ‖f‖ increased after a step with a new Jacobian
+		if increased || len(o.broS) == o.BroMax {
+			restart = true
+			o.NRestart++
+		}
+	}
+
+	// output
+	if o.Out != nil {
+		o.Out(x)
+	}
+
+	// check convergence
+	if o.It == o.MaxIt {
+		err = chk.Err(_nls_err4, o.It)
+	}
+	return
+}
+
+// broFactJ computes and factorises the Jacobian @ x
+func (o *NlSolver) broFactJ(x []float64, first bool) (err error) {
+
+	// evaluate Jacobian
+	if o.useDn {
+		err = o.JfcnDn(o.J, x)
+	} else {
+		if o.numJ {
+			err = Jacobian(&o.Jtri, o.Ffcn, x, o.fx, o.w)
+			o.NFeval += o.neq
+		} else {
+			err = o.JfcnSp(&o.Jtri, x)
+		}
+	}
+	o.NJeval++
+	if err != nil {
+		return
+	}
+
+	// dense: invert matrix
+	if o.useDn {
+		err = la.MatInvG(o.Ji, o.J, 1e-10)
+		if err != nil {
+			return chk.Err(_nls_err1, err.Error())
+		}
+		return
+	}
+
+	// sparse: factorisation
+	if first {
+		symmetric, verbose, timing := false, false, false
+		err = o.lis.InitR(&o.Jtri, symmetric, verbose, timing)
+		if err != nil {
+			return chk.Err(_nls_err9, err.Error())
+		}
+	}
+	return o.lis.Fact()
+}
+
+// broSolveJ solves J₀ z = b using the factorisation computed by broFactJ
+func (o *NlSolver) broSolveJ(z, b []float64) (err error) {
+	if o.useDn {
+		la.MatVecMul(z, 1, o.Ji, b)
+		return
+	}
+	return o.lis.SolveR(z, b, false)
+}
+
+// error messages
+var (
+	_nlsbro_err1 = "nlsolver_broyden.go: NlSolver.Solve(Broyden) is diverging even with new Jacobians: ‖f‖ = %g > ‖f_prev‖ = %g"
+)
@@ -0,0 +1,206 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// nlsJfnkOp implements the la.LinOp interface with the directional difference
+//   J(x) u ≈ (f(x + h u) - f(x)) / h
+type nlsJfnkOp struct {
+	o   *NlSolver // solver holding Ffcn, NFeval and f(x)
+	x   []float64 // current x
+	xp  []float64 // perturbed x
+	fp  []float64 // f(x + h u)
+	err error     // error from the last call to Ffcn
+}
+
+// MatVec computes v := J(x) * u with a directional finite difference
+func (op *nlsJfnkOp) MatVec(v, u []float64) {
+	unrm := la.VecNorm(u)
+	if unrm == 0 || op.err != nil {
+		la.VecFill(v, 0)
+		return
+	}
+	h := math.Sqrt(MACHEPS) * max(la.VecNorm(op.x), 1.0) / unrm
+	for i := 0; i < len(u); i++ {
+		op.xp[i] = op.x[i] + h*u[i]
+	}
+	op.err = op.o.Ffcn(op.fp, op.xp)
+	op.o.NFeval++
+	for i := 0; i < len(u); i++ {
+		v[i] = (op.fp[i] - op.o.fx[i]) / h
+	}
+}
+
+// solveJfnk solves f(x) = 0 with the Jacobian-free Newton-Krylov method. The linear systems
+// J dx = -f are solved inexactly by GMRES with the relative tolerance η (forcing term) chosen by
+// the second method of Eisenstat and Walker. The Jacobian-vector products are computed by
+// directional finite differences; thus, one call to Ffcn is needed for each GMRES iteration
+//   Reference:
+//   [1] Kelley CT (2003) Solving Nonlinear Equations with Newton's Method. SIAM. 104p
+//   [2] Eisenstat SC, Walker HF (1996) Choosing the forcing terms in an inexact Newton method.
+//       SIAM Journal on Scientific Computing, 17(1):16-32
+func (o *NlSolver) solveJfnk(x []float64, silent bool) (err error) {
+
+	// compute scaling vector
+	la.VecScaleAbs(o.scal, o.atol, o.rtol, x) // scal := Atol + Rtol*abs(x)
+
+	// evaluate function @ x
+	err = o.Ffcn(o.fx, x) // fx := f(x)
+	o.NFeval, o.NJeval, o.NLinIt, o.NRestart = 1, 0, 0, 0
+	if err != nil {
+		return
+	}
+
+	// show message
+	if !silent {
+		o.msgMethod("", "η", 0, 0, 0, 0, true, false)
+	}
+
+	// iterations
+	const γ = 0.9
+	var Ldx, Θ, Θ_prev, fx_max float64
+	η, fnrm, fnrm_prev := o.EtaMax, la.VecNorm(o.fx), 0.0
+	op := o.jfnk
+	op.x = x
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		// check convergence on f(x)
+		fx_max = la.VecLargest(o.fx, 1.0) // den = 1.0
+		if fx_max < o.ftol {
+			if !silent {
+				o.msgMethod("fx_max(ini)", "", o.It, Ldx, fx_max, η, false, true)
+			}
+			break
+		}
+
+		// forcing term
+		if o.It > 0 {
+			ηold := η
+			η = γ * (fnrm * fnrm) / (fnrm_prev * fnrm_prev)
+			if γ*ηold*ηold > 0.1 {
+				η = max(η, γ*ηold*ηold)
+			}
+			η = min(o.EtaMax, max(η, 0.5*o.ftol/fnrm))
+		}
+
+		// show message
+		if !silent {
+			o.msgMethod("", "", o.It, Ldx, fx_max, η, false, false)
+		}
+
+		// output
+		if o.Out != nil {
+			o.Out(x)
+		}
+
+		// solve J mdx = fx with GMRES
+		la.VecFill(o.mdx, 0)
+		o.Kry.Tol = η
+		op.err = nil
+		err = o.Kry.Gmres(o.mdx, o.fx, op, o.Prec)
+		o.NLinIt += o.Kry.NumIt
+		if op.err != nil {
+			return op.err
+		}
+		if err != nil {
+			if o.Kry.Resid >= 1 { // no progress at all
+				return chk.Err(_nlsjfnk_err1, err.Error())
+			}
+			err = nil // accept inexact step
+		}
+
+		// update x
+		copy(o.x0, x)
+		la.VecAdd(x, -1, o.mdx) // x -= mdx
+		Ldx = nlsRms(o.mdx, o.scal)
+
+		// calculate fx := f(x) @ update x
+		err = o.Ffcn(o.fx, x)
+		o.NFeval++
+		if err != nil {
+			return
+		}
+		fnrm_prev, fnrm = fnrm, la.VecNorm(o.fx)
+
+		// line-search with backtracking (Armijo rule)
+		if o.Lsearch {
+			λ := 1.0
+			for k := 0; k < o.LsMaxIt && fnrm > (1.0-1e-4*λ)*fnrm_prev; k++ {
+				λ *= 0.5
+				la.VecAdd2(x, 1, o.x0, -λ, o.mdx) // x := x0 - λ mdx
+				err = o.Ffcn(o.fx, x)
+				o.NFeval++
+				if err != nil {
+					return
+				}
+				fnrm = la.VecNorm(o.fx)
+			}
+			if fnrm > (1.0-1e-4*λ)*fnrm_prev {
+				return chk.Err(_nlsjfnk_err2, o.LsMaxIt)
+			}
+			la.VecScale(o.mdx, 0, λ, o.mdx) // mdx := λ mdx
+			Ldx = λ * Ldx
+		}
+
+		// check convergence on f(x)
+		fx_max = la.VecLargest(o.fx, 1.0) // den = 1.0
+		if fx_max < o.ftol {
+			if !silent {
+				o.msgMethod("fx_max", "", o.It, Ldx, fx_max, η, false, true)
+			}
+			break
+		}
+
+		// check convergence on Ldx
+		if Ldx < o.fnewt {
+			if !silent {
+				o.msgMethod("Ldx", "", o.It, Ldx, fx_max, η, false, true)
+			}
+			break
+		}
+
+		// check convergence rate. NOTE: the first inexact steps may be much smaller than the
+		// following ones; thus, the rate is measured with ‖f‖ and two increases are allowed
+		if o.ChkConv {
+			Θ = fnrm / fnrm_prev
+			if Θ > 1 && Θ_prev > 1 {
+				return chk.Err(_nlsjfnk_err3, Θ, fnrm, fnrm_prev)
+			}
+			Θ_prev = Θ
+		}
+	}
+
+	// output
+	if o.Out != nil {
+		o.Out(x)
+	}
+
+	// check convergence
+	if o.It == o.MaxIt {
+		err = chk.Err(_nls_err4, o.It)
+	}
+	return
+}
+
+// nlsRms computes the scaled RMS norm sqrt(Σ (dx[i]/scal[i])² / n)
+func nlsRms(dx, scal []float64) (res float64) {
+	for i := 0; i < len(dx); i++ {
+		res += (dx[i] / scal[i]) * (dx[i] / scal[i])
+	}
+	return math.Sqrt(res / float64(len(dx)))
+}
+
+// error messages
+var (
+	_nlsjfnk_err1 = "nlsolver_jfnk.go: NlSolver.Solve(JFNK): GMRES failed:\n%v"
+	_nlsjfnk_err2 = "nlsolver_jfnk.go: NlSolver.Solve(JFNK): line-search failed after %d backtracking steps"
+	_nlsjfnk_err3 = "nlsolver_jfnk.go: NlSolver.Solve(JFNK) is diverging with Θ = %g (‖f‖=%g, ‖f_prev‖=%g)"
+)
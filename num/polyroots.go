@@ -0,0 +1,154 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// PolyRoots computes all (complex) roots of the polynomial with real coefficients
+//   p(x) = c[0] + c[1] x + c[2] x² + ... + c[n] xⁿ
+//  The roots are first computed as the eigenvalues of the (balanced) companion matrix using the
+//  Francis double-shift QR algorithm and then refined by the Aberth-Ehrlich simultaneous iteration.
+//  NOTE: (1) zero leading coefficients c[n], c[n-1], ... are ignored
+//        (2) imaginary parts smaller than the accuracy of the roots are set to zero
+//        (3) the roots are sorted by their real and then imaginary parts
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//   [2] Bini DA (1996) Numerical computation of polynomial zeros by means of Aberth's method.
+//       Numerical Algorithms, 13:179-200.
+func PolyRoots(c []float64) (roots []complex128, err error) {
+
+	// degree
+	n := len(c) - 1
+	for n >= 0 && c[n] == 0 {
+		n--
+	}
+	if n < 1 {
+		return nil, chk.Err(_polyroots_err1, len(c))
+	}
+
+	// zero roots
+	nz := 0
+	for c[nz] == 0 {
+		nz++
+	}
+	a := c[nz : n+1]
+	m := n - nz
+	roots = make([]complex128, 0, n)
+	for i := 0; i < nz; i++ {
+		roots = append(roots, 0)
+	}
+	if m == 0 {
+		return
+	}
+
+	// companion matrix (upper Hessenberg)
+	H := make([][]float64, m)
+	for i := 0; i < m; i++ {
+		H[i] = make([]float64, m)
+		if i > 0 {
+			H[i][i-1] = 1
+		}
+	}
+	for j := 0; j < m; j++ {
+		H[0][j] = -a[m-j-1] / a[m]
+	}
+
+	// eigenvalues
+	z, err := la.HessenbergEigen(H)
+	if err != nil {
+		return nil, err
+	}
+
+	// refinement
+	acc := polyAberth(a, z)
+	for i, zi := range z {
+		if math.Abs(imag(zi)) <= acc[i] {
+			z[i] = complex(real(zi), 0)
+		}
+	}
+	roots = append(roots, z...)
+	sort.Slice(roots, func(i, j int) bool {
+		if real(roots[i]) == real(roots[j]) {
+			return imag(roots[i]) < imag(roots[j])
+		}
+		return real(roots[i]) < real(roots[j])
+	})
+	return
+}
+
+// PolyEval evaluates the polynomial p(z) = c[0] + c[1] z + ... + c[n] zⁿ and its derivative
+func PolyEval(c []float64, z complex128) (p, dp complex128) {
+	for i := len(c) - 1; i >= 0; i-- {
+		dp = dp*z + p
+		p = p*z + complex(c[i], 0)
+	}
+	return
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// polyAberth refines the roots z of p(x) = Σ a[i] xⁱ using the Aberth-Ehrlich iteration and
+// returns estimates of the accuracy of each root
+func polyAberth(a []float64, z []complex128) (acc []float64) {
+	m := len(z)
+	acc = make([]float64, m)
+	done := make([]bool, m)
+	nmax := 50
+	for it := 0; it < nmax; it++ {
+		ndone := 0
+		for k := 0; k < m; k++ {
+			if done[k] {
+				ndone++
+				continue
+			}
+			p, dp := PolyEval(a, z[k])
+			if p == 0 {
+				done[k] = true
+				continue
+			}
+			if dp == 0 {
+				continue
+			}
+			N := p / dp
+			s := complex(0, 0)
+			for j := 0; j < m; j++ {
+				if j != k && z[k] != z[j] {
+					s += 1.0 / (z[k] - z[j])
+				}
+			}
+			w := N / (1.0 - N*s)
+			if cmplx.IsNaN(w) || cmplx.IsInf(w) {
+				continue
+			}
+			z[k] -= w
+			acc[k] = cmplx.Abs(w)
+			if acc[k] <= 4*MACHEPS*cmplx.Abs(z[k]) {
+				done[k] = true
+			}
+		}
+		if ndone == m {
+			break
+		}
+	}
+
+	// accuracy: at least a few ulps of the magnitude of the root
+	for k := 0; k < m; k++ {
+		acc[k] = math.Max(acc[k], 16*MACHEPS*cmplx.Abs(z[k]))
+	}
+	return
+}
+
+// error messages
+var (
+	_polyroots_err1 = "polyroots.go: PolyRoots: polynomial must have degree ≥ 1; %d coefficients were given but all leading coefficients are zero"
+)
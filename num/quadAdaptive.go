@@ -0,0 +1,536 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// Gauss-Kronrod rules
+const (
+	QuadGK15 = 15 // 7-point Gauss and 15-point Kronrod rule
+	QuadGK21 = 21 // 10-point Gauss and 21-point Kronrod rule
+)
+
+// QuadAdaptive implements adaptive quadrature algorithms based on QUADPACK [1]: global adaptive
+// subdivision with Gauss-Kronrod rules (QAG), transformation of (semi-)infinite intervals (QAGI) and
+// integration of oscillatory functions (QAWO/QAWF). It also implements the double-exponential
+// (tanh-sinh) rule [2] which is robust for integrands with endpoint singularities.
+//  NOTE: zero values of the parameters are replaced by the default values
+//   Reference:
+//   [1] Piessens R, de Doncker-Kapenga E, Überhuber CW, Kahaner DK (1983) QUADPACK: A Subroutine
+//       Package for Automatic Integration. Springer. 301p.
+//   [2] Takahasi H, Mori M (1974) Double exponential formulas for numerical integration.
+//       Publications of the Research Institute for Mathematical Sciences, 9(3):721-741.
+type QuadAdaptive struct {
+	Rule   int     // Gauss-Kronrod rule: QuadGK15 or QuadGK21 [default = QuadGK21]
+	AbsTol float64 // absolute tolerance [default = 1e-10]
+	RelTol float64 // relative tolerance [default = 1e-10]
+	Limit  int     // maximum number of subintervals (or cycles in Oscillatory with b = +∞) [default = 200]
+	MaxLev int     // maximum number of levels (step halvings) of the double-exponential rule [default = 10]
+}
+
+// Integrate computes the integral of f(x) in [a, b] using the adaptive Gauss-Kronrod rule.
+// The interval with the largest error is bisected until the error estimate satisfies
+//  abserr ≤ max(AbsTol, RelTol * |res|)
+// or the number of subintervals reaches Limit.
+//  NOTE: (1) a and/or b may be infinite (math.Inf). In this case, the integral is transformed
+//            into one over (0, 1] with x = a + (1 - t) / t
+//        (2) the function is never evaluated at the limits of integration
+//  OUTPUT:
+//   res    -- the result
+//   abserr -- estimate of the absolute error
+//   neval  -- number of function evaluations
+func (o *QuadAdaptive) Integrate(f fun.Ss, a, b float64) (res, abserr float64, neval int, err error) {
+
+	// check
+	o.defaults()
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, 0, 0, chk.Err(_quadAdaptive_err1, a, b)
+	}
+	if a == b {
+		return
+	}
+	sgn := 1.0
+	if a > b {
+		a, b, sgn = b, a, -1
+	}
+
+	// counter
+	g := func(x float64) (float64, error) {
+		neval++
+		return f(x)
+	}
+
+	// finite interval
+	ainf, binf := math.IsInf(a, 0), math.IsInf(b, 0)
+	if !ainf && !binf {
+		res, abserr, err = o.adapt(g, []float64{a, b})
+		return sgn * res, abserr, neval, err
+	}
+
+	// transformation of (semi-)infinite interval
+	h := func(t float64) (res float64, err error) {
+		x := (1.0 - t) / t
+		var fp, fm float64
+		switch {
+		case ainf && binf:
+			fp, err = g(x)
+			if err != nil {
+				return
+			}
+			fm, err = g(-x)
+			res = fp + fm
+		case binf:
+			res, err = g(a + x)
+		default:
+			res, err = g(b - x)
+		}
+		return res / (t * t), err
+	}
+	res, abserr, err = o.adapt(h, []float64{0, 1})
+	return sgn * res, abserr, neval, err
+}
+
+// TanhSinh computes the integral of f(x) in [a, b] using the double-exponential rule; i.e. the
+// trapezoidal rule applied after the change of variables:
+//  x = (a+b)/2 + (b-a)/2 tanh(π/2 sinh(t))  for finite intervals      (tanh-sinh)
+//  x = a + exp(π/2 sinh(t))                 for [a, +∞)               (exp-sinh)
+//  x = sinh(π/2 sinh(t))                    for (-∞, +∞)              (sinh-sinh)
+// The step size is halved until the difference between two levels satisfies the tolerances.
+//  NOTE: (1) the transformed integrand decays double-exponentially; thus integrable singularities
+//            at the limits of integration are handled well
+//        (2) the function is never evaluated at the limits of integration
+//        (3) the distance to the limits is computed accurately. Nonetheless, the nodes are rounded to
+//            x = a + δ; thus singularities should be moved to x = 0 for full precision
+func (o *QuadAdaptive) TanhSinh(f fun.Ss, a, b float64) (res, abserr float64, neval int, err error) {
+
+	// check
+	o.defaults()
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, 0, 0, chk.Err(_quadAdaptive_err1, a, b)
+	}
+	if a == b {
+		return
+	}
+	sgn := 1.0
+	if a > b {
+		a, b, sgn = b, a, -1
+	}
+
+	// change of variables: x(t) and w(t) = dx/dt; ok = false when the tail is exhausted
+	hpi := math.Pi / 2.0
+	ainf, binf := math.IsInf(a, 0), math.IsInf(b, 0)
+	var node func(t float64) (x, w float64, ok bool)
+	switch {
+	case !ainf && !binf:
+		h := (b - a) / 2.0
+		node = func(t float64) (x, w float64, ok bool) {
+			u := hpi * math.Sinh(t)
+			cu := math.Cosh(u)
+			w = h * hpi * math.Cosh(t) / (cu * cu)
+			if t < 0 {
+				x = a + 2.0*h/(math.Exp(-2.0*u)+1.0)
+			} else {
+				x = b - 2.0*h/(math.Exp(2.0*u)+1.0)
+			}
+			ok = w > 0 && x > a && x < b
+			return
+		}
+	case ainf && binf:
+		node = func(t float64) (x, w float64, ok bool) {
+			u := hpi * math.Sinh(t)
+			x = math.Sinh(u)
+			w = math.Cosh(u) * hpi * math.Cosh(t)
+			ok = !math.IsInf(x, 0) && !math.IsInf(w, 0)
+			return
+		}
+	default:
+		node = func(t float64) (x, w float64, ok bool) {
+			e := math.Exp(hpi * math.Sinh(t))
+			w = e * hpi * math.Cosh(t)
+			if binf {
+				x = a + e
+			} else {
+				x = b - e
+			}
+			ok = !math.IsInf(x, 0) && !math.IsInf(w, 0) && x != a && x != b
+			return
+		}
+	}
+
+	// evaluates term at t
+	term := func(t float64) (res float64, ok bool, err error) {
+		var x, w, fx float64
+		x, w, ok = node(t)
+		if !ok {
+			return
+		}
+		neval++
+		fx, err = f(x)
+		return w * fx, ok, err
+	}
+
+	// level 0 (h = 1): find truncation limits tl ≤ t ≤ tr
+	var sum, s float64
+	var ok bool
+	sum, _, err = term(0)
+	if err != nil {
+		return
+	}
+	lims := []float64{0, 0}
+	for k, dir := range []float64{-1, 1} {
+		for t := dir; ; t += dir {
+			s, ok, err = term(t)
+			if err != nil {
+				return
+			}
+			if !ok {
+				break
+			}
+			sum += s
+			lims[k] = t
+			if math.Abs(s) <= MACHEPS*MACHEPS*math.Abs(sum) {
+				break
+			}
+		}
+	}
+	tl, tr := lims[0], lims[1]
+
+	// refinement
+	old := sum
+	for lev := 1; lev <= o.MaxLev; lev++ {
+		h := math.Pow(2, -float64(lev))
+		for t := h; t <= tr; t += 2 * h {
+			s, ok, err = term(t)
+			if err != nil {
+				return
+			}
+			if !ok {
+				break
+			}
+			sum += s
+		}
+		for t := -h; t >= tl; t -= 2 * h {
+			s, ok, err = term(t)
+			if err != nil {
+				return
+			}
+			if !ok {
+				break
+			}
+			sum += s
+		}
+		res = sum * h
+		abserr = math.Abs(res - old)
+		old = res
+		if lev > 1 && abserr <= math.Max(o.AbsTol, o.RelTol*math.Abs(res)) {
+			return sgn * res, abserr, neval, nil
+		}
+	}
+	return sgn * res, abserr, neval, chk.Err(_quadAdaptive_err2, o.MaxLev, abserr)
+}
+
+// Oscillatory computes the integral of f(x) cos(ω x) or f(x) sin(ω x) in [a, b]
+//  INPUT:
+//   useSin -- use sin(ω x) as weight function; otherwise use cos(ω x)
+//  NOTE: (1) for finite b, the interval is initially divided into half-periods of the weight
+//            function before the adaptive Gauss-Kronrod subdivision
+//        (2) b may be +∞ (but not a). In this case, the integrals over half-periods form an
+//            alternating series whose limit is found by the epsilon algorithm of Wynn (QAWF)
+func (o *QuadAdaptive) Oscillatory(f fun.Ss, a, b, ω float64, useSin bool) (res, abserr float64, neval int, err error) {
+
+	// check
+	o.defaults()
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || a > b {
+		return 0, 0, 0, chk.Err(_quadAdaptive_err3, a, b)
+	}
+	if a == b {
+		return
+	}
+
+	// integrand
+	g := func(x float64) (res float64, err error) {
+		neval++
+		res, err = f(x)
+		if useSin {
+			return res * math.Sin(ω*x), err
+		}
+		return res * math.Cos(ω*x), err
+	}
+	ω = math.Abs(ω)
+	hp := math.Pi / ω // half-period
+
+	// finite interval
+	if !math.IsInf(b, 0) {
+		n := 1
+		if ω > 0 {
+			n = imin(int(math.Ceil((b-a)/hp)), o.Limit/2)
+		}
+		if n < 1 {
+			n = 1
+		}
+		pts := make([]float64, n+1)
+		for i := 0; i <= n; i++ {
+			pts[i] = a + float64(i)*(b-a)/float64(n)
+		}
+		pts[n] = b
+		res, abserr, err = o.adapt(g, pts)
+		return res, abserr, neval, err
+	}
+
+	// semi-infinite interval: sequence of partial sums over half-periods
+	if ω == 0 {
+		return 0, 0, 0, chk.Err(_quadAdaptive_err4)
+	}
+	inner := *o
+	inner.AbsTol = o.AbsTol / 10.0
+	sums := make([]float64, 0, o.Limit)
+	var total, prev, pprev, r, e float64
+	x0 := a
+	for k := 0; k < o.Limit; k++ {
+		x1 := a + float64(k+1)*hp
+		r, e, err = inner.adapt(g, []float64{x0, x1})
+		if err != nil {
+			return
+		}
+		total += r
+		abserr += e
+		sums = append(sums, total)
+		x0 = x1
+		res = wynnEpsilon(sums)
+		if k > 3 {
+			d := math.Abs(res-prev) + math.Abs(prev-pprev)
+			if d <= math.Max(o.AbsTol, o.RelTol*math.Abs(res)) {
+				return res, d + abserr, neval, nil
+			}
+		}
+		pprev, prev = prev, res
+	}
+	return res, abserr, neval, chk.Err(_quadAdaptive_err5, o.Limit)
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// quadInterval holds a subinterval of the adaptive algorithm
+type quadInterval struct {
+	a, b   float64 // limits
+	res    float64 // integral
+	abserr float64 // error estimate
+}
+
+// defaults sets the default values of parameters
+func (o *QuadAdaptive) defaults() {
+	if o.Rule != QuadGK15 {
+		o.Rule = QuadGK21
+	}
+	if o.AbsTol <= 0 && o.RelTol <= 0 {
+		o.AbsTol, o.RelTol = 1e-10, 1e-10
+	}
+	if o.Limit < 1 {
+		o.Limit = 200
+	}
+	if o.MaxLev < 1 {
+		o.MaxLev = 10
+	}
+}
+
+// adapt performs the global adaptive subdivision starting with the intervals defined by pts
+func (o *QuadAdaptive) adapt(g fun.Ss, pts []float64) (res, abserr float64, err error) {
+
+	// initial intervals
+	list := make([]quadInterval, len(pts)-1)
+	for i := 0; i < len(list); i++ {
+		list[i].a, list[i].b = pts[i], pts[i+1]
+		list[i].res, list[i].abserr, err = o.kronrod(g, pts[i], pts[i+1])
+		if err != nil {
+			return
+		}
+	}
+
+	// subdivision
+	for {
+		res, abserr = 0, 0
+		imax := 0
+		for i, iv := range list {
+			res += iv.res
+			abserr += iv.abserr
+			if iv.abserr > list[imax].abserr {
+				imax = i
+			}
+		}
+		if abserr <= math.Max(o.AbsTol, o.RelTol*math.Abs(res)) {
+			return
+		}
+		if len(list) >= o.Limit {
+			return res, abserr, chk.Err(_quadAdaptive_err6, o.Limit, abserr)
+		}
+		iv := list[imax]
+		m := (iv.a + iv.b) / 2.0
+		if m <= iv.a || m >= iv.b {
+			return res, abserr, chk.Err(_quadAdaptive_err7, iv.a, iv.b, abserr)
+		}
+		var left, right quadInterval
+		left.a, left.b, right.a, right.b = iv.a, m, m, iv.b
+		left.res, left.abserr, err = o.kronrod(g, iv.a, m)
+		if err != nil {
+			return
+		}
+		right.res, right.abserr, err = o.kronrod(g, m, iv.b)
+		if err != nil {
+			return
+		}
+		list[imax] = left
+		list = append(list, right)
+	}
+}
+
+// kronrod applies the Gauss-Kronrod rule in [a, b] and estimates the error as in QUADPACK
+func (o *QuadAdaptive) kronrod(g fun.Ss, a, b float64) (res, abserr float64, err error) {
+
+	// rule
+	xgk, wgk, wg := _quadAdaptive_xgk21, _quadAdaptive_wgk21, _quadAdaptive_wg10
+	if o.Rule == QuadGK15 {
+		xgk, wgk, wg = _quadAdaptive_xgk15, _quadAdaptive_wgk15, _quadAdaptive_wg7
+	}
+	nk := len(xgk)
+	centr := (a + b) / 2.0
+	hlgth := (b - a) / 2.0
+
+	// centre
+	fv := make([]float64, 2*nk-1)
+	fc, err := g(centr)
+	if err != nil {
+		return
+	}
+	fv[2*nk-2] = fc
+	resk := wgk[nk-1] * fc
+	resg := 0.0
+	if len(wg) > (nk-1)/2 {
+		resg = wg[len(wg)-1] * fc
+	}
+	resabs := math.Abs(resk)
+
+	// other nodes
+	var f1, f2 float64
+	for i := 0; i < nk-1; i++ {
+		dx := hlgth * xgk[i]
+		f1, err = g(centr - dx)
+		if err != nil {
+			return
+		}
+		f2, err = g(centr + dx)
+		if err != nil {
+			return
+		}
+		fv[2*i], fv[2*i+1] = f1, f2
+		resk += wgk[i] * (f1 + f2)
+		resabs += wgk[i] * (math.Abs(f1) + math.Abs(f2))
+		if i%2 == 1 {
+			resg += wg[i/2] * (f1 + f2)
+		}
+	}
+
+	// error estimate
+	reskh := resk / 2.0
+	resasc := wgk[nk-1] * math.Abs(fc-reskh)
+	for i := 0; i < nk-1; i++ {
+		resasc += wgk[i] * (math.Abs(fv[2*i]-reskh) + math.Abs(fv[2*i+1]-reskh))
+	}
+	h := math.Abs(hlgth)
+	res = resk * hlgth
+	resabs *= h
+	resasc *= h
+	abserr = math.Abs((resk - resg) * hlgth)
+	if resasc != 0 && abserr != 0 {
+		abserr = resasc * math.Min(1, math.Pow(200*abserr/resasc, 1.5))
+	}
+	if resabs > math.SmallestNonzeroFloat64/(50*MACHEPS) {
+		abserr = math.Max(50*MACHEPS*resabs, abserr)
+	}
+	return
+}
+
+// wynnEpsilon returns the limit of the sequence of partial sums s using the epsilon algorithm
+func wynnEpsilon(s []float64) float64 {
+	n := len(s)
+	if n < 3 {
+		return s[n-1]
+	}
+	prev := make([]float64, n+1) // column k-1
+	curr := make([]float64, n)   // column k
+	copy(curr, s)
+	best := s[n-1]
+	for k := 1; k < n; k++ {
+		next := make([]float64, n-k)
+		for i := 0; i < n-k; i++ {
+			d := curr[i+1] - curr[i]
+			if d == 0 {
+				return best
+			}
+			next[i] = prev[i+1] + 1.0/d
+		}
+		prev, curr = curr, next
+		if k%2 == 0 {
+			best = curr[n-k-1]
+		}
+	}
+	return best
+}
+
+// nodes and weights of Gauss-Kronrod rules (from QUADPACK). The centre is the last entry
+var (
+	_quadAdaptive_xgk15 = []float64{
+		0.991455371120812639206854697526329, 0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926, 0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013, 0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245, 0.000000000000000000000000000000000,
+	}
+	_quadAdaptive_wgk15 = []float64{
+		0.022935322010529224963732008058970, 0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518, 0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550, 0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649, 0.209482141084727828012999174891714,
+	}
+	_quadAdaptive_wg7 = []float64{
+		0.129484966168869693270611432679082, 0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975, 0.417959183673469387755102040816327,
+	}
+	_quadAdaptive_xgk21 = []float64{
+		0.995657163025808080735527280689003, 0.973906528517171720077964012084452,
+		0.930157491355708226001207180059508, 0.865063366688984510732096688423493,
+		0.780817726586416897063717578345042, 0.679409568299024406234327365114874,
+		0.562757134668604683339000099272694, 0.433395394129247190799265943165784,
+		0.294392862701460198131126603103866, 0.148874338981631210884826001129720,
+		0.000000000000000000000000000000000,
+	}
+	_quadAdaptive_wgk21 = []float64{
+		0.011694638867371874278064396062192, 0.032558162307964727478818972459390,
+		0.054755896574351996031381300244580, 0.075039674810919952767043140916190,
+		0.093125454583697605535065465083366, 0.109387158802297641899210590325805,
+		0.123491976262065851077208980220289, 0.134709217311473325928054001771707,
+		0.142775938577060080797094273138717, 0.147739104901338491374841515972068,
+		0.149445554002916905664936468389821,
+	}
+	_quadAdaptive_wg10 = []float64{
+		0.066671344308688137593568809893332, 0.149451349150580593145776339657697,
+		0.219086362515982043995534934228163, 0.269266719309996355091226921569469,
+		0.295524224714752870173892994651338,
+	}
+)
+
+// error messages
+var (
+	_quadAdaptive_err1 = "quadAdaptive.go: limits of integration are invalid: a=%g, b=%g"
+	_quadAdaptive_err2 = "quadAdaptive.go: TanhSinh: did not converge after %d levels. abserr = %g"
+	_quadAdaptive_err3 = "quadAdaptive.go: Oscillatory: limits of integration must satisfy -∞ < a ≤ b. a=%g, b=%g is invalid"
+	_quadAdaptive_err4 = "quadAdaptive.go: Oscillatory: ω must be non-zero if b = +∞"
+	_quadAdaptive_err5 = "quadAdaptive.go: Oscillatory: extrapolation did not converge after %d cycles"
+	_quadAdaptive_err6 = "quadAdaptive.go: maximum number of subintervals (%d) reached. abserr = %g"
+	_quadAdaptive_err7 = "quadAdaptive.go: roundoff error: interval [%g, %g] cannot be bisected. abserr = %g"
+)
@@ -0,0 +1,283 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// The algorithms below work with the recurrence coefficients of monic orthogonal polynomials
+//   p_{k+1}(x) = (x - a_k) p_k(x) - b_k p_{k-1}(x),   p_{-1} = 0,   p_0 = 1
+// with respect to a weight function w(x). By convention, b_0 = ∫ w(x) dx.
+//   Reference:
+//   [1] Golub GH, Welsch JH (1969) Calculation of Gauss quadrature rules. Mathematics of
+//       Computation, 23(106):221-230.
+//   [2] Golub GH (1973) Some modified matrix eigenvalue problems. SIAM Review, 15(2):318-334.
+//   [3] Gautschi W (2004) Orthogonal Polynomials: Computation and Approximation. Oxford
+//       University Press. 301p.
+
+// GolubWelschXW computes the positions (xi) and weights (wi) of the n-point Gauss rule associated
+// with the recurrence coefficients a and b (n = len(a)). The positions are the eigenvalues of the
+// symmetric tridiagonal (Jacobi) matrix with diagonal a_k and off-diagonal √b_k; the weights are
+// b_0 times the squares of the first components of the normalised eigenvectors.
+//  NOTE: (1) b must have at least n entries; b_0 = ∫ w(x) dx
+//        (2) the eigenvalue problem is solved by la.Jacobi
+//        (3) the positions are sorted in ascending order
+func GolubWelschXW(a, b []float64) (x, w []float64, err error) {
+	n := len(a)
+	if n < 1 || len(b) < n {
+		return nil, nil, chk.Err(_golubWelsch_err1, len(a), len(b))
+	}
+	for k := 0; k < n; k++ {
+		if b[k] <= 0 {
+			return nil, nil, chk.Err(_golubWelsch_err2, k, b[k])
+		}
+	}
+
+	// Jacobi matrix (scaled because la.Jacobi uses an absolute tolerance)
+	A := la.MatAlloc(n, n)
+	scale := 0.0
+	for k := 0; k < n; k++ {
+		scale = math.Max(scale, math.Abs(a[k]))
+		if k > 0 {
+			scale = math.Max(scale, math.Sqrt(b[k]))
+		}
+	}
+	for k := 0; k < n; k++ {
+		A[k][k] = a[k] / scale
+		if k > 0 {
+			A[k][k-1] = math.Sqrt(b[k]) / scale
+			A[k-1][k] = A[k][k-1]
+		}
+	}
+
+	// eigenvalues and eigenvectors
+	Q := la.MatAlloc(n, n)
+	x = make([]float64, n)
+	_, err = la.Jacobi(Q, x, A)
+	if err != nil {
+		return nil, nil, chk.Err(_golubWelsch_err3, err)
+	}
+	w = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] *= scale
+		w[i] = b[0] * Q[0][i] * Q[0][i]
+	}
+	utl.Qsort2(x, w)
+	return
+}
+
+// GaussRadauXW computes the positions (xi) and weights (wi) of the n-point Gauss-Radau rule
+// associated with the recurrence coefficients a and b, with one position fixed at x0 (n = len(a))
+//  NOTE: (1) x0 must be outside the open interval of orthogonality; e.g. an end point
+//        (2) only a[0:n-1] and b[0:n] are used; the last diagonal entry is modified as in [2]
+func GaussRadauXW(a, b []float64, x0 float64) (x, w []float64, err error) {
+	n := len(a)
+	if n < 2 || len(b) < n {
+		return nil, nil, chk.Err(_golubWelsch_err1, len(a), len(b))
+	}
+	δ, err := golubTriSolve(a[:n-1], b[:n-1], x0)
+	if err != nil {
+		return
+	}
+	ar := make([]float64, n)
+	copy(ar, a[:n-1])
+	ar[n-1] = x0 + b[n-1]*δ
+	return GolubWelschXW(ar, b)
+}
+
+// GaussLobattoXW computes the positions (xi) and weights (wi) of the n-point Gauss-Lobatto rule
+// associated with the recurrence coefficients a and b, with positions fixed at xl and xr
+// (n = len(a)); i.e. the end points of the interval of orthogonality are included
+//  NOTE: only a[0:n-1] and b[0:n-1] are used; the last entries of a and b are modified as in [2]
+func GaussLobattoXW(a, b []float64, xl, xr float64) (x, w []float64, err error) {
+	n := len(a)
+	if n < 3 || len(b) < n-1 || xl >= xr {
+		return nil, nil, chk.Err(_golubWelsch_err4, len(a), len(b), xl, xr)
+	}
+	γl, err := golubTriSolve(a[:n-1], b[:n-1], xl)
+	if err != nil {
+		return
+	}
+	γr, err := golubTriSolve(a[:n-1], b[:n-1], xr)
+	if err != nil {
+		return
+	}
+	ar, br := make([]float64, n), make([]float64, n)
+	copy(ar, a[:n-1])
+	copy(br, b[:n-1])
+	br[n-1] = (xl - xr) / (γr - γl)
+	ar[n-1] = xl + γl*br[n-1]
+	return GolubWelschXW(ar, br)
+}
+
+// GaussHermiteXW computes positions (xi) and weights (wi) to perform Gauss-Hermite integrations;
+// i.e. with the weight function exp(-x²) in (-∞, ∞)
+func GaussHermiteXW(n int) (x, w []float64) {
+	a, b := RecurrenceHermite(n)
+	x, w, err := GolubWelschXW(a, b)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	return
+}
+
+// GaussLaguerreXW computes positions (xi) and weights (wi) to perform generalised Gauss-Laguerre
+// integrations; i.e. with the weight function x^alf exp(-x) in [0, ∞) and alf > -1
+func GaussLaguerreXW(alf float64, n int) (x, w []float64) {
+	a, b := RecurrenceLaguerre(n, alf)
+	x, w, err := GolubWelschXW(a, b)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	return
+}
+
+// GaussLegendreLobattoXW computes positions (xi) and weights (wi) to perform Gauss-Lobatto-Legendre
+// integrations in [-1, 1]; i.e. the end points are included in the n positions (n ≥ 3)
+func GaussLegendreLobattoXW(n int) (x, w []float64) {
+	a, b := RecurrenceJacobi(n, 0, 0)
+	x, w, err := GaussLobattoXW(a, b, -1, 1)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	x[0], x[n-1] = -1, 1
+	return
+}
+
+// RecurrenceJacobi returns the recurrence coefficients (n terms) of the monic Jacobi polynomials;
+// i.e. orthogonal with respect to the weight function (1-x)^alf (1+x)^bet in [-1, 1]
+//  NOTE: alf, bet > -1. Legendre: alf = bet = 0. Chebyshev (first kind): alf = bet = -1/2.
+//        Chebyshev (second kind): alf = bet = 1/2
+func RecurrenceJacobi(n int, alf, bet float64) (a, b []float64) {
+	a, b = make([]float64, n), make([]float64, n)
+	if n < 1 {
+		return
+	}
+	ab := alf + bet
+	l1, _ := math.Lgamma(alf + 1)
+	l2, _ := math.Lgamma(bet + 1)
+	l3, _ := math.Lgamma(ab + 2)
+	a[0] = (bet - alf) / (ab + 2)
+	b[0] = math.Exp((ab+1)*math.Ln2 + l1 + l2 - l3)
+	for k := 1; k < n; k++ {
+		K := float64(k)
+		c := 2*K + ab
+		a[k] = (bet*bet - alf*alf) / (c * (c + 2))
+		if k == 1 {
+			b[k] = 4 * (1 + alf) * (1 + bet) / ((2 + ab) * (2 + ab) * (3 + ab))
+			continue
+		}
+		b[k] = 4 * K * (K + alf) * (K + bet) * (K + ab) / (c * c * (c + 1) * (c - 1))
+	}
+	return
+}
+
+// RecurrenceHermite returns the recurrence coefficients (n terms) of the monic Hermite polynomials;
+// i.e. orthogonal with respect to the weight function exp(-x²) in (-∞, ∞)
+func RecurrenceHermite(n int) (a, b []float64) {
+	a, b = make([]float64, n), make([]float64, n)
+	for k := 0; k < n; k++ {
+		b[k] = float64(k) / 2.0
+	}
+	if n > 0 {
+		b[0] = math.Sqrt(math.Pi)
+	}
+	return
+}
+
+// RecurrenceLaguerre returns the recurrence coefficients (n terms) of the monic generalised
+// Laguerre polynomials; i.e. orthogonal with respect to the weight function x^alf exp(-x) in
+// [0, ∞) with alf > -1
+func RecurrenceLaguerre(n int, alf float64) (a, b []float64) {
+	a, b = make([]float64, n), make([]float64, n)
+	for k := 0; k < n; k++ {
+		K := float64(k)
+		a[k] = 2*K + alf + 1
+		b[k] = K * (K + alf)
+	}
+	if n > 0 {
+		b[0] = math.Gamma(alf + 1)
+	}
+	return
+}
+
+// ModifiedChebyshev computes the recurrence coefficients (n terms) of the monic polynomials
+// orthogonal with respect to a weight function w(x) given its 2n modified moments
+//   mom[k] = ∫ π_k(x) w(x) dx,   k = 0...2n-1
+// where π_k are monic polynomials with known recurrence coefficients (at, bt) (2n-1 terms).
+// With at = bt = 0, π_k(x) = xᵏ and mom are the ordinary moments; however, the problem is then
+// severely ill-conditioned for large n. See Section 2.1.7 of [3]
+func ModifiedChebyshev(mom, at, bt []float64) (a, b []float64, err error) {
+	n := len(mom) / 2
+	if n < 1 || len(mom) != 2*n || len(at) < 2*n-1 || len(bt) < 2*n-1 {
+		return nil, nil, chk.Err(_golubWelsch_err5, len(mom), len(at), len(bt))
+	}
+	if mom[0] <= 0 {
+		return nil, nil, chk.Err(_golubWelsch_err6, 0, mom[0])
+	}
+	a, b = make([]float64, n), make([]float64, n)
+	a[0] = at[0] + mom[1]/mom[0]
+	b[0] = mom[0]
+	sigm := make([]float64, 2*n) // σ_{k-2,l}
+	sig0 := make([]float64, 2*n) // σ_{k-1,l}
+	sig1 := make([]float64, 2*n) // σ_{k,l}
+	copy(sig0, mom)
+	for k := 1; k < n; k++ {
+		for l := k; l < 2*n-k; l++ {
+			sig1[l] = sig0[l+1] - (a[k-1]-at[l])*sig0[l] - b[k-1]*sigm[l] + bt[l]*sig0[l-1]
+		}
+		if sig1[k] <= 0 {
+			return nil, nil, chk.Err(_golubWelsch_err6, k, sig1[k])
+		}
+		a[k] = at[k] + sig1[k+1]/sig1[k] - sig0[k]/sig0[k-1]
+		b[k] = sig1[k] / sig0[k-1]
+		sigm, sig0, sig1 = sig0, sig1, sigm
+	}
+	return
+}
+
+// GaussMomentsXW computes the positions (xi) and weights (wi) of the n-point Gauss rule for an
+// arbitrary weight function given its 2n modified moments; see ModifiedChebyshev
+func GaussMomentsXW(mom, at, bt []float64) (x, w []float64, err error) {
+	a, b, err := ModifiedChebyshev(mom, at, bt)
+	if err != nil {
+		return
+	}
+	return GolubWelschXW(a, b)
+}
+
+// golubTriSolve solves (J - x0 I) δ = e_{n-1} and returns the last component of δ, where J is the
+// Jacobi matrix defined by a and b
+func golubTriSolve(a, b []float64, x0 float64) (δ float64, err error) {
+	n := len(a)
+	var d, prev float64 // pivot and previous pivot (Gaussian elimination without pivoting)
+	for k := 0; k < n; k++ {
+		d = a[k] - x0
+		if k > 0 {
+			d -= b[k] / prev
+		}
+		if d == 0 {
+			return 0, chk.Err(_golubWelsch_err7, x0)
+		}
+		prev = d
+	}
+	return 1.0 / d, nil
+}
+
+// error messages
+var (
+	_golubWelsch_err1 = "quadGolubWelsch.go: the number of recurrence coefficients is invalid. len(a)=%d, len(b)=%d"
+	_golubWelsch_err2 = "quadGolubWelsch.go: recurrence coefficient b[%d]=%g must be positive"
+	_golubWelsch_err3 = "quadGolubWelsch.go: eigenvalue problem failed:\n%v"
+	_golubWelsch_err4 = "quadGolubWelsch.go: GaussLobattoXW: len(a)=%d, len(b)=%d, xl=%g, xr=%g are invalid"
+	_golubWelsch_err5 = "quadGolubWelsch.go: ModifiedChebyshev: an even number of moments and 2n-1 coefficients are required. len(mom)=%d, len(at)=%d, len(bt)=%d"
+	_golubWelsch_err6 = "quadGolubWelsch.go: ModifiedChebyshev: moments are not positive-definite: σ(%d)=%g"
+	_golubWelsch_err7 = "quadGolubWelsch.go: x0=%g is an eigenvalue of the Jacobi matrix"
+)
@@ -0,0 +1,111 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// EqQuarticSolve solves a quartic equation in closed form (Ferrari's method).
+//  The equation is specified by:
+//   x⁴ + a x³ + b x² + c x + d = 0
+//  Notes:
+//   1) the resolvent cubic is solved with EqCubicSolveReal
+//   2) the roots are polished with two Newton iterations
+//  Output:
+//   x[i] -- the four (complex) roots
+func EqQuarticSolve(a, b, c, d float64) (x [4]complex128) {
+
+	// depressed quartic: y⁴ + p y² + q y + r = 0 with x = y - a/4
+	aa := a * a
+	p := b - 3.0*aa/8.0
+	q := c - a*b/2.0 + aa*a/8.0
+	r := d - a*c/4.0 + aa*b/16.0 - 3.0*aa*aa/256.0
+
+	// biquadratic equation
+	scale := math.Max(1, math.Max(math.Abs(p), math.Sqrt(math.Abs(r))))
+	if math.Abs(q) <= 1e-14*scale*math.Sqrt(scale) {
+		z1, z2 := eqQuadraticComplex(complex(p, 0), complex(r, 0))
+		s1, s2 := cmplx.Sqrt(z1), cmplx.Sqrt(z2)
+		x = [4]complex128{s1, -s1, s2, -s2}
+	} else {
+
+		// largest root of the resolvent cubic: m³ + p m² + (p²/4 - r) m - q²/8 = 0
+		m1, m2, m3, nm := EqCubicSolveReal(p, p*p/4.0-r, -q*q/8.0)
+		m := m1
+		if nm > 1 {
+			m = math.Max(m, m2)
+		}
+		if nm > 2 {
+			m = math.Max(m, m3)
+		}
+
+		// factorisation into two quadratics
+		s := math.Sqrt(2.0 * m)
+		y1, y2 := eqQuadraticComplex(complex(s, 0), complex(p/2.0+m-q/(2.0*s), 0))
+		y3, y4 := eqQuadraticComplex(complex(-s, 0), complex(p/2.0+m+q/(2.0*s), 0))
+		x = [4]complex128{y1, y2, y3, y4}
+	}
+
+	// shift and polish
+	for i := 0; i < 4; i++ {
+		x[i] -= complex(a/4.0, 0)
+		for k := 0; k < 2; k++ {
+			f, df := PolyEval([]float64{d, c, b, a, 1}, x[i])
+			if df == 0 {
+				break
+			}
+			x[i] -= f / df
+		}
+	}
+	return
+}
+
+// EqQuarticSolveReal solves a quartic equation, ignoring the complex answers.
+//  The equation is specified by:
+//   x⁴ + a x³ + b x² + c x + d = 0
+//  Notes:
+//   1) roots with |imag(x)| ≤ 1e-7 (1 + |x|) are considered real; i.e. close conjugate pairs
+//      (such as those from a double root) are reported as two real roots
+//   2) the real roots are sorted in ascending order
+//  Output:
+//   x[i] -- roots
+//   nx   -- number of real roots: 0, 1, 2, 3 or 4
+func EqQuarticSolveReal(a, b, c, d float64) (x1, x2, x3, x4 float64, nx int) {
+	z := EqQuarticSolve(a, b, c, d)
+	x := make([]float64, 0, 4)
+	for _, zi := range z {
+		if math.Abs(imag(zi)) <= 1e-7*(1+cmplx.Abs(zi)) {
+			x = append(x, real(zi))
+		}
+	}
+	nx = len(x)
+	for len(x) < 4 {
+		x = append(x, math.Inf(1))
+	}
+	for i := 1; i < nx; i++ { // insertion sort
+		for j := i; j > 0 && x[j] < x[j-1]; j-- {
+			x[j], x[j-1] = x[j-1], x[j]
+		}
+	}
+	for i := nx; i < 4; i++ {
+		x[i] = 0
+	}
+	return x[0], x[1], x[2], x[3], nx
+}
+
+// eqQuadraticComplex solves z² + B z + C = 0 avoiding cancellation
+func eqQuadraticComplex(B, C complex128) (z1, z2 complex128) {
+	δ := cmplx.Sqrt(B*B - 4.0*C)
+	if real(cmplx.Conj(B)*δ) < 0 {
+		δ = -δ
+	}
+	w := -(B + δ) / 2.0
+	if w == 0 {
+		return 0, 0
+	}
+	return w, C / w
+}
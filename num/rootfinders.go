@@ -0,0 +1,588 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// RootFinder implements bracketing methods (Ridders, Illinois and TOMS748) and safeguarded
+// Newton/Halley methods for finding the roots of scalar functions y(x) = 0 in [xa, xb] with
+// y(xa) * y(xb) < 0. It also finds all roots in an interval by recursive bracketing.
+//  NOTE: zero values of the parameters are replaced by the default values
+//   Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//   [2] Alefeld GE, Potra FA, Shi Y (1995) Algorithm 748: enclosing zeros of continuous functions.
+//       ACM Transactions on Mathematical Software, 21(3):327-344.
+type RootFinder struct {
+	MaxIt  int     // max iterations [default = 100]
+	Tol    float64 // tolerance on x [default = 1e-14]
+	NFeval int     // number of calls to y(x) (function evaluations) in the last call
+	It     int     // number of iterations in the last call
+}
+
+// Ridders finds the root of y(x) in [xa, xb] using Ridders' method
+func (o *RootFinder) Ridders(f fun.Ss, xa, xb float64) (res float64, err error) {
+	fl, fh, done, err := o.start(f, xa, xb, &res)
+	if done || err != nil {
+		return
+	}
+	xl, xh := xa, xb
+	res = math.NaN()
+	var xm, fm, s, xnew, fnew float64
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		xm = (xl + xh) / 2.0
+		fm, err = o.eval(f, xm)
+		if err != nil || fm == 0 {
+			return xm, err
+		}
+		s = math.Sqrt(fm*fm - fl*fh)
+		if s == 0 {
+			return xm, nil
+		}
+		if fl >= fh {
+			xnew = xm + (xm-xl)*fm/s
+		} else {
+			xnew = xm - (xm-xl)*fm/s
+		}
+		if math.Abs(xnew-res) <= o.Tol {
+			return xnew, nil
+		}
+		res = xnew
+		fnew, err = o.eval(f, res)
+		if err != nil || fnew == 0 {
+			return
+		}
+		switch {
+		case math.Copysign(fm, fnew) != fm:
+			xl, fl, xh, fh = xm, fm, res, fnew
+		case math.Copysign(fl, fnew) != fl:
+			xh, fh = res, fnew
+		default:
+			xl, fl = res, fnew
+		}
+		if math.Abs(xh-xl) <= o.Tol {
+			return
+		}
+	}
+	return res, chk.Err(_rootfinders_err3, "Ridders", o.It)
+}
+
+// Illinois finds the root of y(x) in [xa, xb] using the Illinois variant of the regula falsi
+// (false position) method
+func (o *RootFinder) Illinois(f fun.Ss, xa, xb float64) (res float64, err error) {
+	fa, fb, done, err := o.start(f, xa, xb, &res)
+	if done || err != nil {
+		return
+	}
+	a, b := xa, xb
+	side := 0
+	res = a
+	var c, fc float64
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		c = (fa*b - fb*a) / (fa - fb)
+		if math.Abs(c-res) <= o.Tol || math.Abs(b-a) <= o.Tol {
+			return c, nil
+		}
+		res = c
+		fc, err = o.eval(f, c)
+		if err != nil || fc == 0 {
+			return
+		}
+		if fc*fb > 0 {
+			b, fb = c, fc
+			if side == -1 {
+				fa /= 2.0
+			}
+			side = -1
+		} else {
+			a, fa = c, fc
+			if side == 1 {
+				fb /= 2.0
+			}
+			side = 1
+		}
+	}
+	return res, chk.Err(_rootfinders_err3, "Illinois", o.It)
+}
+
+// Toms748 finds the root of y(x) in [xa, xb] using Algorithm 748 of Alefeld, Potra and Shi [2];
+// i.e. a combination of inverse cubic and quadratic interpolation, double-length secant steps
+// and bisection. The number of function evaluations is usually smaller than with Brent's method
+func (o *RootFinder) Toms748(f fun.Ss, xa, xb float64) (res float64, err error) {
+
+	// check
+	fa, fb, done, err := o.start(f, xa, xb, &res)
+	if done || err != nil {
+		return
+	}
+	a, b := xa, xb
+	if a > b {
+		a, b, fa, fb = b, a, fb, fa
+	}
+	converged := func() bool {
+		return fa == 0 || b-a <= o.Tol+4*MACHEPS*math.Min(math.Abs(a), math.Abs(b))
+	}
+
+	// initial steps: secant and quadratic interpolation
+	var c, d, e, fd, fe float64
+	c = toms748Secant(a, b, fa, fb)
+	err = o.toms748Bracket(f, &a, &b, c, &fa, &fb, &d, &fd)
+	o.It = 1
+	if err == nil && !converged() {
+		c = toms748Quadratic(a, b, d, fa, fb, fd, 2)
+		e, fe = d, fd
+		err = o.toms748Bracket(f, &a, &b, c, &fa, &fb, &d, &fd)
+		o.It++
+	}
+
+	// iterations
+	for ; err == nil && !converged(); o.It++ {
+		if o.It >= o.MaxIt {
+			return (a + b) / 2.0, chk.Err(_rootfinders_err3, "Toms748", o.It)
+		}
+		a0, b0 := a, b
+
+		// two interpolation steps
+		for k := 2; k <= 3; k++ {
+			if toms748Close(fa, fb, fd, fe) {
+				c = toms748Quadratic(a, b, d, fa, fb, fd, k)
+			} else {
+				c = toms748Cubic(a, b, d, e, fa, fb, fd, fe)
+			}
+			e, fe = d, fd
+			err = o.toms748Bracket(f, &a, &b, c, &fa, &fb, &d, &fd)
+			if err != nil || converged() {
+				break
+			}
+		}
+		if err != nil || converged() {
+			break
+		}
+
+		// double-length secant step
+		u, fu := a, fa
+		if math.Abs(fb) < math.Abs(fa) {
+			u, fu = b, fb
+		}
+		c = u - 2.0*(fu/(fb-fa))*(b-a)
+		if math.Abs(c-u) > (b-a)/2.0 {
+			c = a + (b-a)/2.0
+		}
+		e, fe = d, fd
+		err = o.toms748Bracket(f, &a, &b, c, &fa, &fb, &d, &fd)
+		if err != nil || converged() {
+			break
+		}
+
+		// bisection if the reduction is not sufficient
+		if b-a < 0.5*(b0-a0) {
+			continue
+		}
+		e, fe = d, fd
+		err = o.toms748Bracket(f, &a, &b, a+(b-a)/2.0, &fa, &fb, &d, &fd)
+	}
+	if fa == 0 || math.Abs(fa) < math.Abs(fb) {
+		return a, err
+	}
+	return b, err
+}
+
+// Newton finds the root of y(x) in [xa, xb] using Newton's method safeguarded by bisection; i.e.
+// a bisection step is taken whenever the Newton step leaves the bracket or does not reduce the
+// bracket fast enough
+//  INPUT:
+//   f, df -- function and its derivative
+//   x0    -- initial guess (the midpoint is used if x0 is outside [xa, xb])
+func (o *RootFinder) Newton(f, df fun.Ss, xa, xb, x0 float64) (res float64, err error) {
+	return o.safeguarded(f, df, nil, xa, xb, x0)
+}
+
+// Halley finds the root of y(x) in [xa, xb] using Halley's method safeguarded by bisection
+//  INPUT:
+//   f, df, d2f -- function and its first and second derivatives
+//   x0         -- initial guess (the midpoint is used if x0 is outside [xa, xb])
+func (o *RootFinder) Halley(f, df, d2f fun.Ss, xa, xb, x0 float64) (res float64, err error) {
+	return o.safeguarded(f, df, d2f, xa, xb, x0)
+}
+
+// AllRoots finds all roots of y(x) in [xa, xb] by recursive bracketing: the interval is divided into
+// nsub subintervals and those showing a change of sign are solved with Toms748. Subintervals
+// without change of sign are recursively bisected (up to depth levels) in order to find pairs of
+// close roots: both halves are searched if the value at the midpoint is closer to zero than both
+// end values or if the parabola through the end points and the midpoint crosses zero within the
+// subinterval; otherwise, only the half next to the end point closer to zero is searched.
+//  NOTE: (1) roots where y(x) touches zero without changing sign (e.g. double roots) are only found
+//            if y(x) is exactly zero at a sampled point
+//        (2) each subinterval without change of sign costs at least depth function evaluations
+//        (3) the roots are sorted in ascending order
+//        (4) if y(x) is zero at an end point of a subinterval, the root is recorded and the end point
+//            is moved inwards by √ε⋅(b-a) to continue the search within the subinterval
+func (o *RootFinder) AllRoots(f fun.Ss, xa, xb float64, nsub, depth int) (roots []float64, err error) {
+
+	// check
+	o.defaults()
+	if xa >= xb || nsub < 1 {
+		return nil, chk.Err(_rootfinders_err4, xa, xb, nsub)
+	}
+	nfeval := 0
+	g := func(x float64) (float64, error) {
+		nfeval++
+		return f(x)
+	}
+
+	// recursive search
+	var search func(a, b, fa, fb float64, lev int) error
+	search = func(a, b, fa, fb float64, lev int) error {
+		if fb == 0 {
+			roots = append(roots, b)
+		}
+		if fa == 0 || fb == 0 { // roots at end points are already recorded; thus, move them inwards
+			var err error
+			δ := math.Sqrt(MACHEPS) * (b - a)
+			if fa == 0 {
+				a += δ
+				if fa, err = g(a); err != nil {
+					return err
+				}
+			}
+			if fb == 0 {
+				b -= δ
+				if fb, err = g(b); err != nil {
+					return err
+				}
+			}
+			if fa == 0 || fb == 0 { // e.g. y(x) = 0 in [a, b]
+				return nil
+			}
+		}
+		if fa*fb < 0 {
+			x, err := o.Toms748(g, a, b)
+			if err != nil {
+				return err
+			}
+			roots = append(roots, x)
+			return nil
+		}
+		if lev >= depth {
+			return nil
+		}
+		m := (a + b) / 2.0
+		fm, err := g(m)
+		if err != nil {
+			return err
+		}
+		left, right := true, true
+		if fm*fa > 0 {
+			left, right = allRootsHidden(a, b, fa, fm, fb)
+		}
+		if left {
+			if err = search(a, m, fa, fm, lev+1); err != nil {
+				return err
+			}
+		}
+		if right {
+			return search(m, b, fm, fb, lev+1)
+		}
+		return nil
+	}
+
+	// subintervals
+	fa, err := g(xa)
+	if err != nil {
+		return
+	}
+	if fa == 0 {
+		roots = append(roots, xa)
+	}
+	a := xa
+	for i := 1; i <= nsub; i++ {
+		b := xa + float64(i)*(xb-xa)/float64(nsub)
+		if i == nsub {
+			b = xb
+		}
+		var fb float64
+		fb, err = g(b)
+		if err != nil {
+			return
+		}
+		err = search(a, b, fa, fb, 0)
+		if err != nil {
+			return
+		}
+		a, fa = b, fb
+	}
+	sort.Float64s(roots)
+	o.NFeval = nfeval
+	return
+}
+
+// auxiliary ////////////////////////////////////////////////////////////////////////////////////////
+
+// allRootsHidden indicates which halves of [a, b] may hide a pair of roots when fa, fm and fb have
+// the same sign. Both halves are selected if fm is closer to zero than fa and fb or if the parabola
+// through the three points has its vertex inside [a, b] with a value of opposite sign. Otherwise,
+// only the half next to the end point closer to zero is selected
+func allRootsHidden(a, b, fa, fm, fb float64) (left, right bool) {
+	if math.Abs(fm) < math.Abs(fa) && math.Abs(fm) < math.Abs(fb) {
+		return true, true
+	}
+	if fa < 0 {
+		fa, fm, fb = -fa, -fm, -fb
+	}
+	den := fa - 2.0*fm + fb
+	if den > 0 {
+		h := (b - a) / 2.0
+		xv := -h * (fb - fa) / (2.0 * den) // vertex relative to the midpoint
+		fv := fm - (fb-fa)*(fb-fa)/(8.0*den)
+		if math.Abs(xv) <= h && fv <= 0 {
+			return true, true
+		}
+	}
+	if fa < fb {
+		return true, false
+	}
+	return false, true
+}
+
+// defaults sets the default values of parameters
+func (o *RootFinder) defaults() {
+	if o.MaxIt < 1 {
+		o.MaxIt = 100
+	}
+	if o.Tol <= 0 {
+		o.Tol = 1e-14
+	}
+}
+
+// eval evaluates f and increments the counter
+func (o *RootFinder) eval(f fun.Ss, x float64) (float64, error) {
+	o.NFeval++
+	return f(x)
+}
+
+// start sets defaults, evaluates the function at the end points and checks the bracket.
+// done is true if one of the end points is a root
+func (o *RootFinder) start(f fun.Ss, xa, xb float64, res *float64) (fa, fb float64, done bool, err error) {
+	o.defaults()
+	o.NFeval, o.It = 0, 0
+	fa, err = o.eval(f, xa)
+	if err != nil {
+		return 0, 0, false, chk.Err(_rootfinders_err1, xa, err)
+	}
+	fb, err = o.eval(f, xb)
+	if err != nil {
+		return 0, 0, false, chk.Err(_rootfinders_err1, xb, err)
+	}
+	if fa == 0 {
+		*res = xa
+		return fa, fb, true, nil
+	}
+	if fb == 0 {
+		*res = xb
+		return fa, fb, true, nil
+	}
+	if fa*fb > 0 {
+		return 0, 0, false, chk.Err(_rootfinders_err2, xa, xb, fa, fb)
+	}
+	return
+}
+
+// safeguarded implements the Newton (d2f == nil) and Halley methods with bisection safeguard
+func (o *RootFinder) safeguarded(f, df, d2f fun.Ss, xa, xb, x0 float64) (res float64, err error) {
+
+	// bracket
+	fa, _, done, err := o.start(f, xa, xb, &res)
+	if done || err != nil {
+		return
+	}
+	xl, xh := xa, xb // f(xl) < 0 and f(xh) > 0
+	if fa > 0 {
+		xl, xh = xb, xa
+	}
+	lo, hi := math.Min(xa, xb), math.Max(xa, xb)
+	if !(x0 > lo && x0 < hi) {
+		x0 = (xa + xb) / 2.0
+	}
+
+	// derivatives
+	var fx, dfx, d2fx float64
+	derivs := func(x float64) (err error) {
+		fx, err = o.eval(f, x)
+		if err != nil {
+			return
+		}
+		dfx, err = df(x)
+		if err != nil || d2f == nil {
+			return
+		}
+		d2fx, err = d2f(x)
+		return
+	}
+
+	// iterations
+	res = x0
+	dxold := math.Abs(xb - xa)
+	dx := dxold
+	if err = derivs(res); err != nil {
+		return
+	}
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		// Newton or Halley step
+		step := math.Inf(1)
+		if dfx != 0 {
+			step = fx / dfx
+			if d2f != nil {
+				den := 1.0 - step*d2fx/(2.0*dfx)
+				if den > 0.5 { // otherwise, keep the Newton step
+					step /= den
+				}
+			}
+		}
+		xnew := res - step
+		if xnew == res { // step is below the resolution of x
+			return
+		}
+		inside := (xnew-xl)*(xnew-xh) < 0
+		if !inside || math.Abs(2.0*fx) > math.Abs(dxold*dfx) {
+			dxold = dx
+			dx = (xh - xl) / 2.0
+			xnew = xl + dx
+		} else {
+			dxold = dx
+			dx = step
+		}
+		if xnew == res {
+			return
+		}
+		res = xnew
+		if math.Abs(dx) < o.Tol {
+			return
+		}
+		if err = derivs(res); err != nil {
+			return
+		}
+		if fx == 0 {
+			return
+		}
+		if fx < 0 {
+			xl = res
+		} else {
+			xh = res
+		}
+	}
+	return res, chk.Err(_rootfinders_err3, "Newton/Halley", o.It)
+}
+
+// toms748Bracket evaluates f(c) and updates the bracket [a, b]. d receives the discarded end point
+func (o *RootFinder) toms748Bracket(f fun.Ss, a, b *float64, c float64, fa, fb, d, fd *float64) (err error) {
+	δ := 2.0 * MACHEPS * math.Max(math.Abs(*a), math.Abs(*b))
+	if *b-*a < 4.0*δ {
+		c = *a + (*b-*a)/2.0
+	} else if c <= *a+δ {
+		c = *a + δ
+	} else if c >= *b-δ {
+		c = *b - δ
+	}
+	fc, err := o.eval(f, c)
+	if err != nil {
+		return
+	}
+	if fc == 0 {
+		*a, *fa, *d, *fd = c, 0, 0, 0
+		return
+	}
+	if math.Signbit(*fa) != math.Signbit(fc) {
+		*d, *fd, *b, *fb = *b, *fb, c, fc
+	} else {
+		*d, *fd, *a, *fa = *a, *fa, c, fc
+	}
+	return
+}
+
+// toms748Close returns true if any pair of function values is too close for cubic interpolation
+func toms748Close(fa, fb, fd, fe float64) bool {
+	tiny := 32 * math.SmallestNonzeroFloat64
+	v := []float64{fa, fb, fd, fe}
+	for i := 0; i < 4; i++ {
+		for j := i + 1; j < 4; j++ {
+			if math.Abs(v[i]-v[j]) < tiny {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toms748SafeDiv returns num/den or r if the division would overflow
+func toms748SafeDiv(num, den, r float64) float64 {
+	if math.Abs(den) < 1 && math.Abs(den*math.MaxFloat64) <= math.Abs(num) {
+		return r
+	}
+	return num / den
+}
+
+// toms748Secant performs a secant step; the midpoint is returned if the result is too close to a or b
+func toms748Secant(a, b, fa, fb float64) (c float64) {
+	c = a - (fa/(fb-fa))*(b-a)
+	tol := 5 * MACHEPS
+	if c <= a+math.Abs(a)*tol || c >= b-math.Abs(b)*tol {
+		return (a + b) / 2.0
+	}
+	return
+}
+
+// toms748Quadratic performs count Newton steps on the quadratic interpolating a, b and d
+func toms748Quadratic(a, b, d, fa, fb, fd float64, count int) (c float64) {
+	B := toms748SafeDiv(fb-fa, b-a, math.MaxFloat64)
+	A := toms748SafeDiv(fd-fb, d-b, math.MaxFloat64)
+	A = toms748SafeDiv(A-B, d-a, 0)
+	if A == 0 {
+		return toms748Secant(a, b, fa, fb)
+	}
+	c = b
+	if math.Signbit(A) == math.Signbit(fa) {
+		c = a
+	}
+	for i := 0; i < count; i++ {
+		c -= toms748SafeDiv(fa+(B+A*(c-b))*(c-a), B+A*(2*c-a-b), 1+c-a)
+	}
+	if c <= a || c >= b {
+		c = toms748Secant(a, b, fa, fb)
+	}
+	return
+}
+
+// toms748Cubic performs the inverse cubic interpolation of a, b, d and e
+func toms748Cubic(a, b, d, e, fa, fb, fd, fe float64) (c float64) {
+	q11 := (d - e) * fd / (fe - fd)
+	q21 := (b - d) * fb / (fd - fb)
+	q31 := (a - b) * fa / (fb - fa)
+	d21 := (b - d) * fd / (fd - fb)
+	d31 := (a - b) * fb / (fb - fa)
+	q22 := (d21 - q11) * fb / (fe - fb)
+	q32 := (d31 - q21) * fa / (fd - fa)
+	d32 := (d31 - q21) * fd / (fd - fa)
+	q33 := (d32 - q22) * fa / (fe - fa)
+	c = q31 + q32 + q33 + a
+	if c <= a || c >= b || math.IsNaN(c) {
+		c = toms748Quadratic(a, b, d, fa, fb, fd, 3)
+	}
+	return
+}
+
+// error messages
+var (
+	_rootfinders_err1 = "rootfinders.go: RootFinder: f(%g) failed:\n%v"
+	_rootfinders_err2 = "rootfinders.go: RootFinder: root must be bracketed: xa=%g, xb=%g, fa=%g, fb=%g => fa * fb > 0"
+	_rootfinders_err3 = "rootfinders.go: RootFinder.%s: did not converge after %d iterations"
+	_rootfinders_err4 = "rootfinders.go: RootFinder.AllRoots: xa=%g must be smaller than xb=%g and nsub=%d must be positive"
+)
@@ -0,0 +1,149 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_bvpspectral01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bvpspectral01. linear BVP: u'' = exp(4x) with Dirichlet conditions")
+
+	// Trefethen (2000) Spectral Methods in MATLAB. Program 13
+	o, err := NewSpectralBvp(24, fun.ChebyGaussLobattoGridKind, -1, 1, BvpBc{1, 0, 0}, BvpBc{1, 0, 0})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	one := func(x float64) (float64, error) { return 1, nil }
+	rhs := func(x float64) (float64, error) { return math.Exp(4 * x), nil }
+	u, err := o.SolveLinear(one, nil, nil, rhs)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	ana := func(x float64) float64 {
+		return (math.Exp(4*x) - x*math.Sinh(4) - math.Cosh(4)) / 16.0
+	}
+	for i, x := range o.X {
+		chk.Scalar(tst, "u(X)", 1e-13, u[i], ana(x))
+	}
+	for _, x := range utl.LinSpace(-1, 1, 21) {
+		chk.Scalar(tst, "U(x)", 1e-13, o.U(u, x), ana(x))
+	}
+}
+
+func Test_bvpspectral02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bvpspectral02. linear BVP with Neumann and Robin conditions")
+
+	// u'' - u = 0 on [0, 1] with u'(0) = 1 and u(1) + u'(1) = 2e  =>  u = exp(x)
+	for _, grid := range []io.Enum{fun.ChebyGaussLobattoGridKind, fun.LegendreGaussLobattoGridKind} {
+		o, err := NewSpectralBvp(14, grid, 0, 1, BvpBc{0, 1, 1}, BvpBc{1, 1, 2 * math.E})
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		u, err := o.SolveLinear(
+			func(x float64) (float64, error) { return 1, nil },
+			nil,
+			func(x float64) (float64, error) { return -1, nil },
+			func(x float64) (float64, error) { return 0, nil },
+		)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		for i, x := range o.X {
+			chk.Scalar(tst, io.Sf("%s: u(X)", grid), 1e-12, u[i], math.Exp(x))
+		}
+	}
+
+	// u'' + x u' + u = s on [0, 2] with u(0) = u(2) = 0  =>  u = sin(π x)
+	π := math.Pi
+	o, err := NewSpectralBvp(24, fun.LegendreGaussLobattoGridKind, 0, 2, BvpBc{1, 0, 0}, BvpBc{1, 0, 0})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	u, err := o.SolveLinear(
+		func(x float64) (float64, error) { return 1, nil },
+		func(x float64) (float64, error) { return x, nil },
+		func(x float64) (float64, error) { return 1, nil },
+		func(x float64) (float64, error) {
+			return (1-π*π)*math.Sin(π*x) + π*x*math.Cos(π*x), nil
+		},
+	)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for _, x := range utl.LinSpace(0, 2, 11) {
+		chk.Scalar(tst, "U(x)", 1e-11, o.U(u, x), math.Sin(π*x))
+	}
+
+	// errors
+	_, err = NewSpectralBvp(8, fun.UniformGridKind, 0, 1, BvpBc{1, 0, 0}, BvpBc{1, 0, 0})
+	if err == nil {
+		tst.Errorf("NewSpectralBvp should have failed with uniform grid\n")
+	}
+	_, err = NewSpectralBvp(8, fun.ChebyGaussLobattoGridKind, 0, 1, BvpBc{0, 0, 0}, BvpBc{1, 0, 0})
+	if err == nil {
+		tst.Errorf("NewSpectralBvp should have failed with invalid boundary condition\n")
+	}
+}
+
+func Test_bvpspectral03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bvpspectral03. nonlinear BVP: Bratu problem")
+
+	// u'' + λ exp(u) = 0 on [0, 1] with u(0) = u(1) = 0
+	//   u = -2 log(cosh((x - 1/2) θ/2) / cosh(θ/4))  with  θ = sqrt(2λ) cosh(θ/4)  (lower branch)
+	λ := 1.0
+	θ := 1.0
+	for i := 0; i < 100; i++ {
+		θ = math.Sqrt(2*λ) * math.Cosh(θ/4)
+	}
+	ana := func(x float64) float64 {
+		return -2 * math.Log(math.Cosh((x-0.5)*θ/2)/math.Cosh(θ/4))
+	}
+
+	o, err := NewSpectralBvp(20, fun.ChebyGaussLobattoGridKind, 0, 1, BvpBc{1, 0, 0}, BvpBc{1, 0, 0})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	o.NlsPrms = map[string]float64{"atol": 1e-12, "rtol": 1e-12, "ftol": 1e-12}
+	u := make([]float64, len(o.X)) // initial guess: u = 0
+	err = o.Solve(u, func(x, u, du float64) (f, dfdu, dfddu float64, err error) {
+		f = -λ * math.Exp(u)
+		dfdu = f
+		return
+	}, !chk.Verbose)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("θ = %v\n", θ)
+	for i, x := range o.X {
+		chk.Scalar(tst, "u(X)", 1e-12, u[i], ana(x))
+	}
+
+	// errors
+	err = o.Solve([]float64{0}, nil, true)
+	if err == nil {
+		tst.Errorf("Solve should have failed with wrong size of u\n")
+	}
+}
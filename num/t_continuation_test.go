@@ -0,0 +1,192 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+)
+
+func Test_cont01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cont01. S-curve with two folds: λ = x³ - 3x")
+
+	// F(x, λ) = x³ - 3x - λ
+	ffcn := func(f, u []float64) error {
+		f[0] = u[0]*u[0]*u[0] - 3*u[0] - u[1]
+		return nil
+	}
+	jfcn := func(J *la.Triplet, u []float64) error {
+		J.Start()
+		J.Put(0, 0, 3*u[0]*u[0]-3)
+		J.Put(0, 1, -1)
+		return nil
+	}
+
+	// analytical and numerical Jacobians
+	for _, analytical := range []bool{true, false} {
+		var o Continuation
+		if analytical {
+			o.Init(1, ffcn, jfcn, map[string]float64{"lamMin": -5, "lamMax": 5, "ds": 0.2})
+		} else {
+			o.Init(1, ffcn, nil, map[string]float64{"lamMin": -5, "lamMax": 5, "ds": 0.2})
+		}
+		path, err := o.Trace([]float64{-2}, -4)
+		if err != nil {
+			tst.Errorf("Trace failed:\n%v\n", err)
+			return
+		}
+		io.Pforan("analytical=%v: npts = %d  nFeval = %d  nJeval = %d\n", analytical, len(path), o.NFeval, o.NJeval)
+
+		// all points are on the curve
+		for _, p := range path {
+			x := p.U[0]
+			chk.Scalar(tst, "λ - x³ + 3x", 1e-10, p.Lam-x*x*x+3*x, 0)
+		}
+
+		// folds at x = -1 (λ = 2) and x = 1 (λ = -2)
+		folds := path.Find(ContFold)
+		chk.Int(tst, "number of folds", len(folds), 2)
+		chk.Int(tst, "number of branch points", len(path.Find(ContBranch)), 0)
+		if len(folds) == 2 {
+			chk.Scalar(tst, "x(fold0)", 1e-7, path[folds[0]].U[0], -1)
+			chk.Scalar(tst, "λ(fold0)", 1e-10, path[folds[0]].Lam, 2)
+			chk.Scalar(tst, "x(fold1)", 1e-7, path[folds[1]].U[0], 1)
+			chk.Scalar(tst, "λ(fold1)", 1e-10, path[folds[1]].Lam, -2)
+			chk.Scalar(tst, "detJ(fold0)", 1e-6, path[folds[0]].DetJ, 0)
+		}
+
+		// end of path
+		last := path[len(path)-1]
+		if last.Lam < 5 {
+			tst.Errorf("path should reach λ = 5. last λ = %g\n", last.Lam)
+		}
+
+		// plot
+		if chk.Verbose && analytical {
+			lam, nrm := path.LamNorm()
+			plt.Reset(false, nil)
+			plt.Plot(lam, nrm, &plt.A{C: "r", M: ".", NoClip: true})
+			plt.Gll("$\\lambda$", "$||x||$", nil)
+			plt.Save("/tmp/gosl/num", "cont01")
+			path.Save("/tmp/gosl/num", "cont01")
+		}
+	}
+}
+
+func Test_cont02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cont02. pitchfork: x (λ - x²) = 0")
+
+	// F(x, λ) = x (λ - x²)
+	ffcn := func(f, u []float64) error {
+		x, λ := u[0], u[1]
+		f[0] = x * (λ - x*x)
+		return nil
+	}
+	jfcn := func(J *la.Triplet, u []float64) error {
+		x, λ := u[0], u[1]
+		J.Start()
+		J.Put(0, 0, λ-3*x*x)
+		J.Put(0, 1, x)
+		return nil
+	}
+
+	// trivial branch
+	var o Continuation
+	o.Init(1, ffcn, jfcn, map[string]float64{"lamMin": -1, "lamMax": 1, "ds": 0.15})
+	path, err := o.Trace([]float64{0}, -1)
+	if err != nil {
+		tst.Errorf("Trace failed:\n%v\n", err)
+		return
+	}
+	bps := path.Find(ContBranch)
+	chk.Int(tst, "number of branch points", len(bps), 1)
+	chk.Int(tst, "number of folds", len(path.Find(ContFold)), 0)
+	if len(bps) != 1 {
+		return
+	}
+	bp := path[bps[0]]
+	io.Pforan("branch point: λ = %v  x = %v\n", bp.Lam, bp.U[0])
+	chk.Scalar(tst, "λ(branch)", 1e-10, bp.Lam, 0)
+	chk.Scalar(tst, "x(branch)", 1e-15, bp.U[0], 0)
+
+	// switch branch: λ = x²
+	for _, sgn := range []float64{1, -1} {
+		o.LamMin, o.LamMax = -1, 0.5
+		branch, err := o.SwitchBranch(bp, sgn)
+		if err != nil {
+			tst.Errorf("SwitchBranch failed:\n%v\n", err)
+			return
+		}
+		io.Pforan("sgn = %+g: npts = %d\n", sgn, len(branch))
+		if len(branch) < 5 {
+			tst.Errorf("new branch has too few points\n")
+			return
+		}
+		for _, p := range branch[1:] {
+			x := p.U[0]
+			chk.Scalar(tst, "λ - x²", 1e-10, p.Lam-x*x, 0)
+			if x*sgn <= 0 {
+				tst.Errorf("x = %g is on the wrong side of the new branch\n", x)
+				return
+			}
+		}
+		last := branch[len(branch)-1]
+		chk.Scalar(tst, "‖x‖²(last)", 1e-10, last.Norm*last.Norm, last.Lam)
+	}
+
+	// SwitchBranch requires a branch point
+	_, err = o.SwitchBranch(path[0], 1)
+	if err == nil {
+		tst.Errorf("SwitchBranch should have failed\n")
+	}
+}
+
+func Test_cont03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cont03. snap-through of shallow truss with spring")
+
+	// two-dof model with deflection w of the truss and displacement v of the spring; load λ
+	//   F₀ = w³ - 3w² + 2w + (w - v) - λ
+	//   F₁ = 2v - (w - v) - λ/10
+	// => λ = (w³ - 3w² + 8w/3) / (1 + 1/30) with folds at w = 2/3 and w = 4/3
+	ffcn := func(f, u []float64) error {
+		w, v, λ := u[0], u[1], u[2]
+		f[0] = w*w*w - 3*w*w + 2*w + (w - v) - λ
+		f[1] = 2*v - (w - v) - 0.1*λ
+		return nil
+	}
+
+	// trace without Jacobian; Newton with fixed λ would jump at the limit points
+	var o Continuation
+	o.Init(2, ffcn, nil, map[string]float64{"lamMin": -1, "lamMax": 3, "ds": 0.1, "dsMax": 0.2})
+	path, err := o.Trace([]float64{0, 0}, 0)
+	if err != nil {
+		tst.Errorf("Trace failed:\n%v\n", err)
+		return
+	}
+	folds := path.Find(ContFold)
+	io.Pforan("npts = %d  folds = %v\n", len(path), folds)
+	chk.Int(tst, "number of folds", len(folds), 2)
+	for _, i := range folds {
+		p := path[i]
+		chk.Scalar(tst, "detJ(fold)", 1e-7, p.DetJ, 0)
+		chk.Scalar(tst, "tan_λ(fold)", 1e-7, p.Tan[2], 0)
+	}
+	for _, p := range path {
+		f := []float64{0, 0}
+		ffcn(f, p.U)
+		chk.Scalar(tst, "max|F|", 1e-10, math.Max(math.Abs(f[0]), math.Abs(f[1])), 0)
+	}
+}
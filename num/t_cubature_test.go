@@ -0,0 +1,187 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// cubatureGauss returns f(x) = Π exp(-x_i²) and the integral over [0, 1]ᵈ
+func cubatureGauss(d int) (f func(x []float64) (float64, error), a, b []float64, ref float64) {
+	f = func(x []float64) (float64, error) {
+		s := 0.0
+		for _, v := range x {
+			s += v * v
+		}
+		return math.Exp(-s), nil
+	}
+	a, b = make([]float64, d), make([]float64, d)
+	for i := 0; i < d; i++ {
+		b[i] = 1
+	}
+	ref = math.Pow(math.Sqrt(math.Pi)/2.0*math.Erf(1), float64(d))
+	return
+}
+
+func Test_cubature01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cubature01. Genz-Malik")
+
+	var o Cubature
+	o.AbsTol, o.RelTol = 1e-10, 1e-10
+
+	// polynomial of degree 5 => exact with one region (the error estimate vanishes)
+	poly5 := func(x []float64) (float64, error) {
+		return math.Pow(x[0], 5) + x[0]*x[0]*x[1]*x[1]*x[2] + x[1]*x[2], nil
+	}
+	lo, up := []float64{0, -1, 0}, []float64{1, 2, 2}
+	A, e, n, err := o.GenzMalik(poly5, lo, up)
+	if err != nil {
+		tst.Errorf("GenzMalik failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("poly5: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(poly5)", 1e-13, A, 6.0/6.0+(1.0/3.0)*3.0*2.0+(3.0/2.0)*2.0)
+	chk.Int(tst, "neval", n, 8+18+6+1)
+
+	// polynomial of degree 7 => exact with one region (the degree 5 rule is not)
+	poly7 := func(x []float64) (float64, error) {
+		return math.Pow(x[0], 7) + x[0]*x[0]*x[1]*x[1]*x[1]*x[1]*x[2] + x[1]*x[2], nil
+	}
+	o.MaxEval = n
+	A, e, _, err = o.GenzMalik(poly7, lo, up)
+	if err == nil {
+		tst.Errorf("GenzMalik should have failed\n")
+		return
+	}
+	io.Pforan("poly7: A = %v  abserr = %v\n", A, e)
+	chk.Scalar(tst, "A(poly7)", 1e-13, A, 6.0/8.0+(1.0/3.0)*(33.0/5.0)*2.0+(3.0/2.0)*2.0)
+
+	// Gaussian in 2, 3 and 5 dimensions
+	o.AbsTol, o.RelTol, o.MaxEval = 1e-7, 1e-7, 0
+	for _, d := range []int{2, 3, 5} {
+		f, a, b, ref := cubatureGauss(d)
+		A, e, n, err = o.GenzMalik(f, a, b)
+		if err != nil {
+			tst.Errorf("GenzMalik failed:\n%v\n", err)
+			return
+		}
+		io.Pforan("gauss%d: A = %v  abserr = %v  neval = %d\n", d, A, e, n)
+		chk.Scalar(tst, io.Sf("A(gauss%d)", d), 1e-7, A, ref)
+	}
+
+	// peak at corner: requires many subdivisions
+	corner := func(x []float64) (float64, error) {
+		return 1.0 / math.Pow(1.0+x[0]+x[1], 3), nil
+	}
+	A, e, n, err = o.GenzMalik(corner, []float64{0, 0}, []float64{1, 1})
+	if err != nil {
+		tst.Errorf("GenzMalik failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("corner: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(corner)", 1e-7, A, 1.0/6.0)
+
+	// maximum number of evaluations
+	o.MaxEval = 100
+	_, _, n, err = o.GenzMalik(corner, []float64{0, 0}, []float64{1, 1})
+	if err == nil {
+		tst.Errorf("GenzMalik should have failed\n")
+		return
+	}
+	io.Pforan("%v\n", err)
+	if n > 100 {
+		tst.Errorf("number of evaluations %d exceeds MaxEval\n", n)
+	}
+}
+
+func Test_cubature02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cubature02. Smolyak sparse grids")
+
+	// weights sum to volume; small grids
+	x, w := SmolyakGrid([]float64{0, 0}, []float64{2, 3}, 1)
+	chk.Int(tst, "npts(level=1)", len(w), 1)
+	chk.Vector(tst, "x(level=1)", 1e-15, x[0], []float64{1, 1.5})
+	chk.Scalar(tst, "w(level=1)", 1e-15, w[0], 6)
+	x, w = SmolyakGrid([]float64{0, 0, 0}, []float64{1, 1, 1}, 2)
+	chk.Int(tst, "npts(level=2)", len(w), 7) // centre + 2 points along each axis
+	sum := 0.0
+	for _, v := range w {
+		sum += v
+	}
+	chk.Scalar(tst, "Σw", 1e-14, sum, 1)
+
+	// exactness for total degree 4 level - 3
+	var o Cubature
+	poly := func(x []float64) (float64, error) {
+		return x[0]*x[0]*x[0]*x[0]*x[1]*x[1]*x[2] + x[3]*x[3]*x[3]*x[3]*x[3]*x[3]*x[3]*x[3]*x[3], nil
+	}
+	lo, up := []float64{0, 0, 0, 0}, []float64{1, 1, 1, 1}
+	A, e, n, err := o.Smolyak(poly, lo, up, 3)
+	if err != nil {
+		tst.Errorf("Smolyak failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("poly: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(poly)", 1e-14, A, 1.0/5.0/3.0/2.0+1.0/10.0)
+
+	// Gaussian in 8 dimensions
+	f, a, b, ref := cubatureGauss(8)
+	A, e, n, err = o.Smolyak(f, a, b, 5)
+	if err != nil {
+		tst.Errorf("Smolyak failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("gauss8: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(gauss8)", 1e-6, A, ref)
+	if math.Abs(A-ref) > 10*e {
+		tst.Errorf("error estimate is too small: %g. error = %g\n", e, math.Abs(A-ref))
+	}
+}
+
+func Test_cubature03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cubature03. quasi-Monte Carlo")
+
+	rnd.Init(1234)
+	var o Cubature
+	for _, d := range []int{3, 10, 20} {
+		f, a, b, ref := cubatureGauss(d)
+		for _, sobol := range []bool{false, true} {
+			var u [][]float64
+			if sobol {
+				u = rnd.SobolPoints(d, 4096)
+			} else {
+				u = rnd.HaltonPoints(d, 4096)
+			}
+			A, e, n, err := o.QuasiMonteCarlo(f, a, b, u, 16)
+			if err != nil {
+				tst.Errorf("QuasiMonteCarlo failed:\n%v\n", err)
+				return
+			}
+			io.Pforan("d=%2d sobol=%5v: A = %v  abserr = %.2e  error = %.2e  neval = %d\n", d, sobol, A, e, math.Abs(A-ref), n)
+			chk.Scalar(tst, io.Sf("A(d=%d)", d), 5e-4, A, ref)
+			if math.Abs(A-ref) > 5*e {
+				tst.Errorf("error estimate is too small: %g. error = %g\n", e, math.Abs(A-ref))
+			}
+		}
+	}
+
+	// number of points does not match the dimension
+	f, a, b, _ := cubatureGauss(4)
+	_, _, _, err := o.QuasiMonteCarlo(f, a, b, rnd.HaltonPoints(3, 16), 2)
+	if err == nil {
+		tst.Errorf("QuasiMonteCarlo should have failed\n")
+	}
+}
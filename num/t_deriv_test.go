@@ -11,6 +11,7 @@ import (
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
 )
 
 func Test_deriv01(tst *testing.T) {
@@ -185,3 +186,226 @@ func Test_deriv02(tst *testing.T) {
 		}
 	}
 }
+
+func Test_deriv03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("deriv03. Ridders")
+
+	// the same functions as in deriv01 with fixed and automatic stepsizes
+	fcns := []fun.Ss{
+		func(x float64) (float64, error) { return x * x, nil },
+		func(x float64) (float64, error) { return math.Exp(x), nil },
+		func(x float64) (float64, error) { return math.Exp(-x * x), nil },
+		func(x float64) (float64, error) { return 1.0 / x, nil },
+		func(x float64) (float64, error) { return x * math.Sqrt(x), nil },
+		func(x float64) (float64, error) { return math.Sin(1.0 / x), nil },
+	}
+	danas := []func(x float64) float64{
+		func(x float64) float64 { return 2 * x },
+		func(x float64) float64 { return math.Exp(x) },
+		func(x float64) float64 { return -2 * x * math.Exp(-x*x) },
+		func(x float64) float64 { return -1.0 / (x * x) },
+		func(x float64) float64 { return 1.5 * math.Sqrt(x) },
+		func(x float64) float64 { return -math.Cos(1.0/x) / (x * x) },
+	}
+	d2anas := []func(x float64) float64{
+		func(x float64) float64 { return 2 },
+		func(x float64) float64 { return math.Exp(x) },
+		func(x float64) float64 { return (4*x*x - 2) * math.Exp(-x*x) },
+		func(x float64) float64 { return 2.0 / (x * x * x) },
+		func(x float64) float64 { return 0.75 / math.Sqrt(x) },
+		func(x float64) float64 {
+			return (2*x*math.Cos(1.0/x) - math.Sin(1.0/x)) / (x * x * x * x)
+		},
+	}
+	xvals := [][]float64{
+		{-0.5, 0, 0.5},
+		{-0.5, 0, 0.5},
+		{-0.5, 0, 0.5},
+		{-0.5, 0.2, 0.5},
+		{0.2, 0.5, 1.0},
+		{-0.5, 0.2, 0.5},
+	}
+	hs := []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.01}
+	for _, auto := range []bool{false, true} {
+		io.Pf("\nautomatic stepsize = %v\n", auto)
+		for i, f := range fcns {
+			for _, x := range xvals[i] {
+				h := hs[i]
+				if auto {
+					h = 0
+				}
+				d, e, err := DerivRidders(x, h, f)
+				if err != nil {
+					tst.Errorf("%v\n", err)
+					return
+				}
+				io.Pforan("x = %+.1f  df/dx:    err = %.2e  errEst = %.2e\n", x, math.Abs(d-danas[i](x)), e)
+				chk.Scalar(tst, "df/dx", 1e-9*max(1, math.Abs(d)), d, danas[i](x))
+				if math.Abs(d-danas[i](x)) > 10*e+1e-13 {
+					tst.Errorf("error estimate is too small\n")
+					return
+				}
+				d2, e2, err := Deriv2Ridders(x, h, f)
+				if err != nil {
+					tst.Errorf("%v\n", err)
+					return
+				}
+				io.Pforan("x = %+.1f  d²f/dx²:  err = %.2e  errEst = %.2e\n", x, math.Abs(d2-d2anas[i](x)), e2)
+				chk.Scalar(tst, "d²f/dx²", 1e-6*max(1, math.Abs(d2)), d2, d2anas[i](x))
+			}
+		}
+	}
+}
+
+func Test_deriv04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("deriv04. Ridders: gradient, Hessian and Jacobian")
+
+	// scalar field: f(x) = x0² x1 + sin(x1 x2) + exp(x0)
+	f := func(x []float64) (float64, error) {
+		return x[0]*x[0]*x[1] + math.Sin(x[1]*x[2]) + math.Exp(x[0]), nil
+	}
+	x := []float64{0.5, -1.2, 2.0}
+	gAna := []float64{
+		2*x[0]*x[1] + math.Exp(x[0]),
+		x[0]*x[0] + x[2]*math.Cos(x[1]*x[2]),
+		x[1] * math.Cos(x[1]*x[2]),
+	}
+	c, s := math.Cos(x[1]*x[2]), math.Sin(x[1]*x[2])
+	Hana := [][]float64{
+		{2*x[1] + math.Exp(x[0]), 2 * x[0], 0},
+		{2 * x[0], -x[2] * x[2] * s, c - x[1]*x[2]*s},
+		{0, c - x[1]*x[2]*s, -x[1] * x[1] * s},
+	}
+
+	// gradient and Hessian
+	for _, h := range []float64{0.1, 0} {
+		g := make([]float64, 3)
+		e, err := GradRidders(g, x, h, f)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("h = %g: gradient errEst = %.2e\n", h, e)
+		chk.Vector(tst, "g", 1e-10, g, gAna)
+		H := la.MatAlloc(3, 3)
+		e, err = HessRidders(H, x, h, f)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("h = %g: Hessian errEst = %.2e\n", h, e)
+		chk.Matrix(tst, "H", 1e-8, H, Hana)
+	}
+	chk.Vector(tst, "x is restored", 1e-17, x, []float64{0.5, -1.2, 2.0})
+
+	// Jacobian of f(x) = {x0 x1², exp(x0 - x1), log(x1)}
+	ffcn := func(fx, x []float64) error {
+		fx[0] = x[0] * x[1] * x[1]
+		fx[1] = math.Exp(x[0] - x[1])
+		fx[2] = math.Log(x[1])
+		return nil
+	}
+	y := []float64{1.5, 0.7}
+	Jana := [][]float64{
+		{y[1] * y[1], 2 * y[0] * y[1]},
+		{math.Exp(y[0] - y[1]), -math.Exp(y[0] - y[1])},
+		{0, 1.0 / y[1]},
+	}
+	J := la.MatAlloc(3, 2)
+	e, err := JacRidders(J, y, 0, ffcn)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("Jacobian errEst = %.2e\n", e)
+	chk.Matrix(tst, "J", 1e-10, J, Jana)
+	chk.Vector(tst, "y is restored", 1e-17, y, []float64{1.5, 0.7})
+
+	// wrong size
+	_, err = JacRidders(la.MatAlloc(3, 3), y, 0, ffcn)
+	if err == nil {
+		tst.Errorf("JacRidders should have failed\n")
+	}
+}
+
+func Test_deriv05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("deriv05. checking derivatives with Ridders' method")
+
+	// function with large higher-order derivatives
+	f := func(x float64) (float64, error) { return math.Sin(1.0 / x), nil }
+	xAt := 0.2
+	dfdxAna := -math.Cos(1.0/xAt) / (xAt * xAt)
+
+	// automatic stepsize
+	t1 := new(testing.T)
+	DerivScaScaRidders(t1, "dfdx", 1e-9, dfdxAna, xAt, 0, chk.Verbose, f)
+	if t1.Failed() {
+		tst.Errorf("t1 should not have failed\n")
+		return
+	}
+
+	// given initial stepsize
+	t2 := new(testing.T)
+	DerivScaScaRidders(t2, "dfdx", 1e-9, dfdxAna, xAt, 0.01, chk.Verbose, f)
+	if t2.Failed() {
+		tst.Errorf("t2 should not have failed\n")
+		return
+	}
+
+	// wrong derivative
+	t3 := new(testing.T)
+	DerivScaScaRidders(t3, "dfdx", 1e-9, dfdxAna+1e-6, xAt, 0, chk.Verbose, f)
+	if !t3.Failed() {
+		tst.Errorf("t3 should have failed\n")
+		return
+	}
+
+	// vector w.r.t scalar
+	fvs := func(f []float64, x float64) error {
+		f[0] = math.Sin(1.0 / x)
+		f[1] = x * x * x
+		return nil
+	}
+	t4 := new(testing.T)
+	DerivVecScaRidders(t4, "dfdx", 1e-9, []float64{dfdxAna, 3 * xAt * xAt}, xAt, 0, chk.Verbose, fvs)
+	if t4.Failed() {
+		tst.Errorf("t4 should not have failed\n")
+		return
+	}
+
+	// scalar w.r.t vector
+	fsv := func(x []float64) (float64, error) {
+		return x[0]*x[0]*x[1] + math.Exp(x[1]), nil
+	}
+	x := []float64{0.5, 0.5}
+	t5 := new(testing.T)
+	DerivScaVecRidders(t5, "dfdx", 1e-10, []float64{2 * x[0] * x[1], x[0]*x[0] + math.Exp(x[1])}, x, 0, chk.Verbose, fsv)
+	if t5.Failed() {
+		tst.Errorf("t5 should not have failed\n")
+		return
+	}
+
+	// vector w.r.t vector
+	fcn := func(f, x []float64) (err error) {
+		f[0] = x[0]*x[0]*x[0] + x[1]*x[1] + x[0]*x[1] + x[0] - x[1]
+		f[1] = math.Cos(math.Pi*x[0]/2.0) * math.Sin(math.Pi*x[1]/2.0)
+		return
+	}
+	dfdxAna2 := [][]float64{
+		{3.0*x[0]*x[0] + x[1] + 1.0, 2.0*x[1] + x[0] - 1.0},
+		{-0.5 * math.Pi * math.Sin(math.Pi*x[0]/2.0) * math.Sin(math.Pi*x[1]/2.0), 0.5 * math.Pi * math.Cos(math.Pi*x[0]/2.0) * math.Cos(math.Pi*x[1]/2.0)},
+	}
+	t6 := new(testing.T)
+	DerivVecVecRidders(t6, "dfdx", 1e-12, dfdxAna2, x, 0, chk.Verbose, fcn)
+	if t6.Failed() {
+		tst.Errorf("t6 should not have failed\n")
+		return
+	}
+	chk.Vector(tst, "x is not modified", 1e-17, x, []float64{0.5, 0.5})
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/mpi"
+	"github.com/cpmech/gosl/num"
+)
+
+func main() {
+
+	mpi.Start(false)
+	defer func() {
+		mpi.Stop(false)
+	}()
+
+	if mpi.Rank() == 0 {
+		chk.PrintTitle("TestJacobian 03b (MPI). coloured sparse Jacobian")
+	}
+	n := 12
+	if mpi.Size() > n {
+		io.Pf("this tests works with %d or less MPI processors\n", n)
+		return
+	}
+
+	// tridiagonal: Bratu problem
+	h := 1.0 / float64(n+1)
+	λ := 2.0
+	ffcn := func(fx, x []float64) error {
+		for i := 0; i < n; i++ {
+			fx[i] = 2.0*x[i] - h*h*λ*math.Exp(x[i])
+			if i > 0 {
+				fx[i] -= x[i-1]
+			}
+			if i < n-1 {
+				fx[i] -= x[i+1]
+			}
+		}
+		return nil
+	}
+	id, sz := mpi.Rank(), mpi.Size()
+	start, endp1 := (id*n)/sz, ((id+1)*n)/sz
+	Jfcn := func(dfdx *la.Triplet, x []float64, start, endp1 int) {
+		dfdx.Start()
+		for i := start; i < endp1; i++ {
+			dfdx.Put(i, i, 2.0-h*h*λ*math.Exp(x[i]))
+			if i > 0 {
+				dfdx.Put(i, i-1, -1.0)
+			}
+			if i < n-1 {
+				dfdx.Put(i, i+1, -1.0)
+			}
+		}
+	}
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = math.Sin(float64(i+1) * math.Pi / float64(n+1))
+	}
+
+	// colouring of the whole sparsity pattern
+	var pattern la.Triplet
+	pattern.Init(n, n, 3*n)
+	Jfcn(&pattern, x, 0, n)
+	var col num.JacColouring
+	col.Init(&pattern)
+
+	// coloured Jacobian: rows in the range of this processor
+	neval := 0
+	fcount := func(fx, x []float64) error {
+		neval++
+		return ffcn(fx, x)
+	}
+	fx, w := make([]float64, n), make([]float64, n)
+	ffcn(fx, x)
+	var J la.Triplet
+	J.Init(n, n, 3*n)
+	err := num.JacobianColouredMpi(&J, fcount, x, fx, w, &col, true)
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+	var tst testing.T
+	chk.Int(&tst, io.Sf("number of evaluations @ proc %d", id), neval, col.Ncolours)
+
+	// compare with analytical Jacobian and with JacobianMpi
+	var Jana, Jmpi la.Triplet
+	Jana.Init(n, n, 3*n)
+	Jfcn(&Jana, x, start, endp1)
+	Jmpi.Init(n, n, n*n)
+	num.JacobianMpi(&Jmpi, ffcn, x, fx, w, true)
+	chk.Matrix(&tst, io.Sf("J(ana) @ proc %d", id), 1e-7, J.ToMatrix(nil).ToDense(), Jana.ToMatrix(nil).ToDense())
+	chk.Matrix(&tst, io.Sf("J(mpi) @ proc %d", id), 1e-15, J.ToMatrix(nil).ToDense(), Jmpi.ToMatrix(nil).ToDense())
+}
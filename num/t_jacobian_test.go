@@ -10,6 +10,7 @@ import (
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fdm"
+	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/utl"
@@ -178,3 +179,162 @@ func TestJacobian03(tst *testing.T) {
 		io.Pf("min/max fU1 = %v\n", min, max)
 	}
 }
+
+func TestJacobian04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TestJacobian 04. exact Jacobian with dual numbers")
+
+	// same system as in TestJacobian02a
+	ffcn := func(f, x []fun.Dual) error {
+		sq := func(a fun.Dual) fun.Dual { return a.Mul(a) }
+		f[0] = x[0].MulS(2).Sub(x[1]).Add(x[2].Sin()).Sub(x[3].Cos()).Sub(sq(x[5])).AddS(-1)
+		f[1] = x[0].Neg().Add(x[1].MulS(2)).Add(x[2].Cos()).Sub(x[3].Sin()).Add(x[5]).AddS(-1)
+		f[2] = x[0].Add(x[1].MulS(3)).Add(x[3].Sin()).Sub(x[4].Cos()).Sub(sq(x[5])).AddS(-1)
+		f[3] = x[0].MulS(2).Add(x[1].MulS(4)).Add(x[3].Cos()).Sub(x[4].Cos()).Add(x[5]).AddS(-1)
+		f[4] = x[0].Add(x[1].MulS(5)).Sub(x[2].Sin()).Add(x[4].Sin()).Sub(x[5].Pow(3)).AddS(-1)
+		f[5] = x[0].Add(x[1].MulS(6)).Sub(x[2].Cos()).Add(x[4].Cos()).Add(x[5]).AddS(-1)
+		return nil
+	}
+	x := []float64{5.0, 5.0, pi, pi, pi, 5.0}
+	CompareJac(tst, fun.DualResidual(6, ffcn), fun.DualJacobian(6, ffcn), x, 1e-6)
+
+	// exact and analytical Jacobians
+	Jfcn := fun.DualJacobian(6, ffcn)
+	var J la.Triplet
+	J.Init(6, 6, 36)
+	err := Jfcn(&J, x)
+	if err != nil {
+		tst.Errorf("DualJacobian failed:\n%v\n", err)
+		return
+	}
+	D := J.ToMatrix(nil).ToDense()
+	chk.Vector(tst, "row 0", 1e-15, D[0], []float64{2, -1, cos(x[2]), sin(x[3]), 0, -2 * x[5]})
+	chk.Vector(tst, "row 4", 1e-15, D[4], []float64{1, 5, -cos(x[2]), 0, cos(x[4]), -3 * x[5] * x[5]})
+}
+
+func TestJacobian05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TestJacobian 05. coloured sparse Jacobian")
+
+	// tridiagonal: Bratu problem
+	n := 50
+	ffcn, Jfcn, _ := bratu(n, 2)
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = math.Sin(float64(i+1) * pi / float64(n+1))
+	}
+	var pattern la.Triplet
+	pattern.Init(n, n, 3*n)
+	Jfcn(&pattern, x)
+	var col JacColouring
+	col.Init(&pattern)
+	io.Pforan("tridiagonal: nColours = %d  nnz = %d\n", col.Ncolours, col.Nnz)
+	chk.Int(tst, "nColours(tridiagonal)", col.Ncolours, 3)
+	chk.Int(tst, "nnz(tridiagonal)", col.Nnz, 3*n-2)
+
+	// count function evaluations
+	neval := 0
+	fcount := func(f, x []float64) error {
+		neval++
+		return ffcn(f, x)
+	}
+	fx, w := make([]float64, n), make([]float64, n)
+	ffcn(fx, x)
+	var J la.Triplet
+	err := JacobianColoured(&J, fcount, x, fx, w, &col)
+	if err != nil {
+		tst.Errorf("JacobianColoured failed:\n%v\n", err)
+		return
+	}
+	chk.Int(tst, "number of evaluations", neval, 3)
+	var Jana la.Triplet
+	Jana.Init(n, n, 3*n)
+	Jfcn(&Jana, x)
+	chk.Matrix(tst, "J(coloured)", 1e-7, J.ToMatrix(nil).ToDense(), Jana.ToMatrix(nil).ToDense())
+
+	// 5-point stencil: 2D Poisson problem with nonlinear source
+	nx := 8
+	N := nx * nx
+	idx := func(i, j int) int { return i + j*nx }
+	gfcn := func(f, u []float64) error {
+		for j := 0; j < nx; j++ {
+			for i := 0; i < nx; i++ {
+				k := idx(i, j)
+				f[k] = 4*u[k] - math.Exp(u[k])
+				if i > 0 {
+					f[k] -= u[idx(i-1, j)]
+				}
+				if i < nx-1 {
+					f[k] -= u[idx(i+1, j)]
+				}
+				if j > 0 {
+					f[k] -= u[idx(i, j-1)]
+				}
+				if j < nx-1 {
+					f[k] -= u[idx(i, j+1)]
+				}
+			}
+		}
+		return nil
+	}
+	pattern.Init(N, N, 6*N)
+	for j := 0; j < nx; j++ {
+		for i := 0; i < nx; i++ {
+			k := idx(i, j)
+			pattern.Put(k, k, 1)
+			pattern.Put(k, k, 1) // repeated entries are allowed
+			if i > 0 {
+				pattern.Put(k, idx(i-1, j), 1)
+			}
+			if i < nx-1 {
+				pattern.Put(k, idx(i+1, j), 1)
+			}
+			if j > 0 {
+				pattern.Put(k, idx(i, j-1), 1)
+			}
+			if j < nx-1 {
+				pattern.Put(k, idx(i, j+1), 1)
+			}
+		}
+	}
+	col.Init(&pattern)
+	io.Pforan("5-point stencil: nColours = %d  nnz = %d\n", col.Ncolours, col.Nnz)
+	if col.Ncolours < 5 || col.Ncolours > 8 {
+		tst.Errorf("number of colours of 5-point stencil should be between 5 and 8. nColours = %d\n", col.Ncolours)
+	}
+
+	// structurally independent columns
+	D := pattern.ToMatrix(nil).ToDense()
+	for _, group := range col.Groups {
+		for r := 0; r < N; r++ {
+			count := 0
+			for _, c := range group {
+				if D[r][c] != 0 {
+					count++
+				}
+			}
+			if count > 1 {
+				tst.Errorf("row %d has %d non-zeros in columns with the same colour\n", r, count)
+				return
+			}
+		}
+	}
+
+	// compare with Jacobian computed column by column
+	u := make([]float64, N)
+	for k := 0; k < N; k++ {
+		u[k] = 0.1 * float64(k%5)
+	}
+	fu, wu := make([]float64, N), make([]float64, N)
+	gfcn(fu, u)
+	var Jcol, Jref la.Triplet
+	err = JacobianColoured(&Jcol, gfcn, u, fu, wu, &col)
+	if err != nil {
+		tst.Errorf("JacobianColoured failed:\n%v\n", err)
+		return
+	}
+	Jacobian(&Jref, gfcn, u, fu, wu)
+	chk.Matrix(tst, "J(5-point)", 1e-15, Jcol.ToMatrix(nil).ToDense(), Jref.ToMatrix(nil).ToDense())
+}
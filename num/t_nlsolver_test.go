@@ -10,6 +10,7 @@ import (
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fdm"
+	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/utl"
@@ -90,7 +91,11 @@ func Test_nls01(tst *testing.T) {
 	ffcn(fx, xx)
 	io.Pf("xx    = %v  expected = %v\n", xx, []float64{1.0, 0.0})
 	io.Pf("f(xx) = %v\n", fx)
-	chk.Vector(tst, "f(x) = 0? ", 1e-16, fx, []float64{})
+	tol := 1e-16
+	if la.DefaultSolver == "native" { // roundoff differences in the native solvers
+		tol = 1e-15
+	}
+	chk.Vector(tst, "f(x) = 0? ", tol, fx, []float64{})
 	chk.Vector(tst, "x == xx", 1e-15, x, xx)
 
 	// check Jacobian
@@ -428,7 +433,11 @@ func Test_nls04(tst *testing.T) {
 
 	// check
 	fdm.JoinVecs(Uden, U1den, U2, &e)
-	chk.Vector(tst, "Uden", 1e-14, Uden, Uc)
+	tol := 1e-14
+	if la.DefaultSolver == "native" { // roundoff differences in the native solvers
+		tol = 1e-13
+	}
+	chk.Vector(tst, "Uden", tol, Uden, Uc)
 
 	io.PfYel("\n---- sparse -------- Numerical Jacobian -------------------\n")
 
@@ -442,3 +451,222 @@ func Test_nls04(tst *testing.T) {
 	fdm.JoinVecs(Unum, U1num, U2, &e)
 	chk.Vector(tst, "Unum", 1e-14, Unum, Uc)
 }
+
+// bratu returns the residual and Jacobian of the discrete Bratu problem
+//   u'' + λ exp(u) = 0  with  u(0) = u(1) = 0
+func bratu(n int, λ float64) (ffcn fun.Vv, Jfcn fun.Tv, JfcnD fun.Mv) {
+	h := 1.0 / float64(n+1)
+	ffcn = func(fx, x []float64) error {
+		for i := 0; i < n; i++ {
+			fx[i] = 2*x[i] - h*h*λ*math.Exp(x[i])
+			if i > 0 {
+				fx[i] -= x[i-1]
+			}
+			if i < n-1 {
+				fx[i] -= x[i+1]
+			}
+		}
+		return nil
+	}
+	Jfcn = func(dfdx *la.Triplet, x []float64) error {
+		dfdx.Start()
+		for i := 0; i < n; i++ {
+			dfdx.Put(i, i, 2-h*h*λ*math.Exp(x[i]))
+			if i > 0 {
+				dfdx.Put(i, i-1, -1)
+			}
+			if i < n-1 {
+				dfdx.Put(i, i+1, -1)
+			}
+		}
+		return nil
+	}
+	JfcnD = func(dfdx [][]float64, x []float64) error {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				dfdx[i][j] = 0
+			}
+			dfdx[i][i] = 2 - h*h*λ*math.Exp(x[i])
+			if i > 0 {
+				dfdx[i][i-1] = -1
+			}
+			if i < n-1 {
+				dfdx[i][i+1] = -1
+			}
+		}
+		return nil
+	}
+	return
+}
+
+func Test_nls05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("nls05. Jacobian-free Newton-Krylov")
+
+	// reference solution with Newton's method
+	n := 60
+	ffcn, Jfcn, _ := bratu(n, 1)
+	prms := map[string]float64{"atol": 1e-10, "rtol": 1e-10, "ftol": 1e-12}
+	var nls NlSolver
+	nls.Init(n, ffcn, Jfcn, nil, false, false, prms)
+	defer nls.Free()
+	xref := make([]float64, n)
+	err := nls.Solve(xref, true)
+	if err != nil {
+		tst.Errorf("Newton failed:\n%v\n", err)
+		return
+	}
+
+	// JFNK
+	for _, lsearch := range []float64{0, 1} {
+		prms["method"] = NlsJFNK
+		prms["lSearch"] = lsearch
+		var jfnk NlSolver
+		jfnk.Init(n, ffcn, nil, nil, false, false, prms)
+		x := make([]float64, n)
+		err = jfnk.Solve(x, chk.Verbose == false)
+		if err != nil {
+			tst.Errorf("JFNK failed:\n%v\n", err)
+			return
+		}
+		io.Pforan("lSearch=%v: it = %d  nFeval = %d  nLinIt = %d  nJeval = %d\n", lsearch, jfnk.It, jfnk.NFeval, jfnk.NLinIt, jfnk.NJeval)
+		chk.Vector(tst, "x(JFNK)", 1e-9, x, xref)
+		chk.Int(tst, "nJeval", jfnk.NJeval, 0)
+		if jfnk.NLinIt == 0 {
+			tst.Errorf("GMRES iterations should have been recorded\n")
+		}
+	}
+
+	// system with exp function (nls02)
+	ffcn2 := func(fx, x []float64) error {
+		fx[0] = 2.0*x[0] - x[1] - math.Exp(-x[0])
+		fx[1] = -x[0] + 2.0*x[1] - math.Exp(-x[1])
+		return nil
+	}
+	var jfnk NlSolver
+	jfnk.Init(2, ffcn2, nil, nil, false, false, map[string]float64{"method": NlsJFNK, "ftol": 1e-14, "lSearch": 1})
+	x := []float64{5, 5}
+	err = jfnk.Solve(x, true)
+	if err != nil {
+		tst.Errorf("JFNK failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x(exp)", 1e-12, x, []float64{0.5671432904097838, 0.5671432904097838})
+}
+
+func Test_nls06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("nls06. Anderson acceleration")
+
+	// system with exp function (nls02) => g(x) = x - ω f(x) is a contraction
+	ffcn := func(fx, x []float64) error {
+		fx[0] = 2.0*x[0] - x[1] - math.Exp(-x[0])
+		fx[1] = -x[0] + 2.0*x[1] - math.Exp(-x[1])
+		return nil
+	}
+	prms := map[string]float64{"method": NlsAnderson, "ftol": 1e-13, "andOmega": 0.3}
+	var nIt [2]int
+	for k, m := range []float64{0, 3} {
+		prms["andM"] = m
+		var nls NlSolver
+		nls.Init(2, ffcn, nil, nil, false, false, prms)
+		x := []float64{5, 5}
+		err := nls.Solve(x, chk.Verbose == false)
+		if err != nil {
+			tst.Errorf("Anderson(m=%v) failed:\n%v\n", m, err)
+			return
+		}
+		io.Pforan("m = %v: it = %d  nFeval = %d  nRestart = %d\n", m, nls.It, nls.NFeval, nls.NRestart)
+		chk.Vector(tst, "x", 1e-12, x, []float64{0.5671432904097838, 0.5671432904097838})
+		nIt[k] = nls.It
+	}
+	if nIt[1] >= nIt[0]/2 {
+		tst.Errorf("Anderson acceleration should reduce the number of iterations: %d vs %d\n", nIt[1], nIt[0])
+	}
+
+	// Bratu problem: compare with Newton's method
+	n := 20
+	bfcn, Jfcn, _ := bratu(n, 1)
+	var newton NlSolver
+	newton.Init(n, bfcn, Jfcn, nil, false, false, map[string]float64{"ftol": 1e-12})
+	defer newton.Free()
+	xref := make([]float64, n)
+	err := newton.Solve(xref, true)
+	if err != nil {
+		tst.Errorf("Newton failed:\n%v\n", err)
+		return
+	}
+	var nls NlSolver
+	nls.Init(n, bfcn, nil, nil, false, false, map[string]float64{"method": NlsAnderson, "ftol": 1e-12, "andM": 10, "andOmega": 0.25})
+	x := make([]float64, n)
+	err = nls.Solve(x, chk.Verbose == false)
+	if err != nil {
+		tst.Errorf("Anderson failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Bratu: it = %d  nRestart = %d\n", nls.It, nls.NRestart)
+	chk.Vector(tst, "x(Bratu)", 1e-10, x, xref)
+}
+
+func Test_nls07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("nls07. Broyden's method")
+
+	// Bratu problem
+	n := 40
+	ffcn, Jfcn, JfcnD := bratu(n, 3)
+	prms := map[string]float64{"atol": 1e-10, "rtol": 1e-10, "ftol": 1e-12}
+	var newton NlSolver
+	newton.Init(n, ffcn, Jfcn, nil, false, false, prms)
+	defer newton.Free()
+	xref := make([]float64, n)
+	err := newton.Solve(xref, true)
+	if err != nil {
+		tst.Errorf("Newton failed:\n%v\n", err)
+		return
+	}
+
+	// sparse, numerical and dense Jacobians
+	prms["method"] = NlsBroyden
+	for _, kind := range []string{"sparse", "numerical", "dense"} {
+		var nls NlSolver
+		switch kind {
+		case "sparse":
+			nls.Init(n, ffcn, Jfcn, nil, false, false, prms)
+		case "numerical":
+			nls.Init(n, ffcn, nil, nil, false, true, prms)
+		case "dense":
+			nls.Init(n, ffcn, nil, JfcnD, true, false, prms)
+		}
+		x := make([]float64, n)
+		err = nls.Solve(x, chk.Verbose == false)
+		nls.Free()
+		if err != nil {
+			tst.Errorf("Broyden(%s) failed:\n%v\n", kind, err)
+			return
+		}
+		io.Pforan("%-9s: it = %d  nFeval = %d  nJeval = %d  nRestart = %d\n", kind, nls.It, nls.NFeval, nls.NJeval, nls.NRestart)
+		chk.Vector(tst, "x(Broyden,"+kind+")", 1e-9, x, xref)
+		if nls.NJeval >= newton.NJeval {
+			tst.Errorf("Broyden should need fewer Jacobian evaluations than Newton: %d vs %d\n", nls.NJeval, newton.NJeval)
+		}
+	}
+
+	// restart after BroMax steps
+	prms["broMax"] = 2
+	var nls NlSolver
+	nls.Init(n, ffcn, nil, JfcnD, true, false, prms)
+	x := make([]float64, n)
+	err = nls.Solve(x, chk.Verbose == false)
+	if err != nil {
+		tst.Errorf("Broyden failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "x(Broyden,broMax=2)", 1e-9, x, xref)
+	if nls.NRestart == 0 {
+		tst.Errorf("Broyden should have been restarted\n")
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_polyroots01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("polyroots01. simple polynomials")
+
+	// (x - 1) (x - 2) (x - 3) = x³ - 6x² + 11x - 6
+	roots, err := PolyRoots([]float64{-6, 11, -6, 1})
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.VectorC(tst, "cubic", 1e-14, roots, []complex128{1, 2, 3})
+
+	// x² + 1 with zero leading coefficients
+	roots, err = PolyRoots([]float64{1, 0, 1, 0, 0})
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	chk.VectorC(tst, "x²+1", 1e-15, roots, []complex128{-1i, 1i})
+
+	// x² (x + 2) = x³ + 2x² => zero roots
+	roots, err = PolyRoots([]float64{0, 0, 2, 1})
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	chk.VectorC(tst, "x²(x+2)", 1e-15, roots, []complex128{-2, 0, 0})
+
+	// x⁵ - 1 => roots of unity
+	roots, err = PolyRoots([]float64{-1, 0, 0, 0, 0, 1})
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	for _, r := range roots {
+		chk.Scalar(tst, "|root|", 1e-15, cmplx.Abs(r), 1)
+		chk.ScalarC(tst, "root⁵", 1e-14, cmplx.Pow(r, 5), 1)
+	}
+
+	// constant polynomial
+	_, err = PolyRoots([]float64{3, 0})
+	if err == nil {
+		tst.Errorf("PolyRoots should have failed\n")
+	}
+}
+
+func Test_polyroots02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("polyroots02. Wilkinson polynomial")
+
+	// (x - 1) (x - 2) ... (x - n)
+	n := 12
+	c := []float64{1}
+	for k := 1; k <= n; k++ {
+		next := make([]float64, len(c)+1)
+		for i, v := range c {
+			next[i+1] += v
+			next[i] -= float64(k) * v
+		}
+		c = next
+	}
+	roots, err := PolyRoots(c)
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	for k := 0; k < n; k++ {
+		chk.Scalar(tst, io.Sf("Re(x%d)", k), 1e-8, real(roots[k]), float64(k+1))
+		chk.Scalar(tst, io.Sf("Im(x%d)", k), 1e-15, imag(roots[k]), 0)
+	}
+
+	// Chebyshev polynomial T₁₀: cos((2k+1)π/20)
+	c = []float64{-1, 0, 50, 0, -400, 0, 1120, 0, -1280, 0, 512}
+	roots, err = PolyRoots(c)
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	for k := 0; k < 10; k++ {
+		chk.ScalarC(tst, io.Sf("x%d", k), 1e-14, roots[k], complex(-math.Cos(float64(2*k+1)*math.Pi/20), 0))
+	}
+}
+
+func Test_quarticeq01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quarticeq01. closed-form quartic")
+
+	// (x - 1) (x + 2) (x - 3) (x + 4) = x⁴ + 2x³ - 13x² - 14x + 24
+	x1, x2, x3, x4, nx := EqQuarticSolveReal(2, -13, -14, 24)
+	io.Pforan("x = %v %v %v %v  nx = %d\n", x1, x2, x3, x4, nx)
+	chk.Int(tst, "nx", nx, 4)
+	chk.Vector(tst, "x", 1e-14, []float64{x1, x2, x3, x4}, []float64{-4, -2, 1, 3})
+
+	// biquadratic: x⁴ - 5x² + 4 = (x² - 1) (x² - 4)
+	x1, x2, x3, x4, nx = EqQuarticSolveReal(0, -5, 0, 4)
+	chk.Int(tst, "nx", nx, 4)
+	chk.Vector(tst, "x(biquadratic)", 1e-15, []float64{x1, x2, x3, x4}, []float64{-2, -1, 1, 2})
+
+	// (x² + 1) (x - 2) (x - 5) = x⁴ - 7x³ + 11x² - 7x + 10 => two complex roots
+	x1, x2, _, _, nx = EqQuarticSolveReal(-7, 11, -7, 10)
+	chk.Int(tst, "nx", nx, 2)
+	chk.Vector(tst, "x(two real)", 1e-14, []float64{x1, x2}, []float64{2, 5})
+
+	// double root: (x - 1)² (x + 3)²
+	x1, x2, x3, x4, nx = EqQuarticSolveReal(4, -2, -12, 9)
+	chk.Int(tst, "nx", nx, 4)
+	chk.Vector(tst, "x(double)", 1e-7, []float64{x1, x2, x3, x4}, []float64{-3, -3, 1, 1})
+
+	// complex roots: compare with PolyRoots
+	a, b, c, d := 0.3, 2.1, -0.7, 4.5
+	z := EqQuarticSolve(a, b, c, d)
+	ref, err := PolyRoots([]float64{d, c, b, a, 1})
+	if err != nil {
+		tst.Errorf("PolyRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("z   = %v\n", z)
+	io.Pforan("ref = %v\n", ref)
+	for _, zi := range z {
+		dmin := math.Inf(1)
+		for _, r := range ref {
+			dmin = math.Min(dmin, cmplx.Abs(zi-r))
+		}
+		chk.Scalar(tst, "|z - ref|", 1e-14, dmin, 0)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_quadGK01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadGK01. Gauss-Kronrod nodes and weights")
+
+	// integrates polynomials exactly: degree 3n+1 (Kronrod) and 2m-1 (Gauss)
+	for _, rule := range []int{QuadGK15, QuadGK21} {
+		xgk, wgk, wg := _quadAdaptive_xgk21, _quadAdaptive_wgk21, _quadAdaptive_wg10
+		if rule == QuadGK15 {
+			xgk, wgk, wg = _quadAdaptive_xgk15, _quadAdaptive_wgk15, _quadAdaptive_wg7
+		}
+		nk := len(xgk)
+		degK, degG := 3*(rule-1)/2+1, 2*(rule-1)/2-1
+		for p := 0; p <= degK; p += 2 {
+			ref := 2.0 / float64(p+1)
+			sk := wgk[nk-1] * math.Pow(0, float64(p))
+			sg := 0.0
+			if len(wg) > (nk-1)/2 {
+				sg = wg[len(wg)-1] * math.Pow(0, float64(p))
+			}
+			for i := 0; i < nk-1; i++ {
+				sk += 2 * wgk[i] * math.Pow(xgk[i], float64(p))
+				if i%2 == 1 {
+					sg += 2 * wg[i/2] * math.Pow(xgk[i], float64(p))
+				}
+			}
+			chk.Scalar(tst, io.Sf("K%d: x^%d", rule, p), 1e-15, sk, ref)
+			if p <= degG {
+				chk.Scalar(tst, io.Sf("G%d: x^%d", rule/2, p), 1e-15, sg, ref)
+			}
+		}
+	}
+}
+
+func Test_quadGK02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadGK02. adaptive Gauss-Kronrod")
+
+	y := func(x float64) (res float64, err error) {
+		res = math.Sqrt(1.0 + math.Pow(math.Sin(x), 3.0))
+		return
+	}
+	Acor := 1.08268158558
+
+	var o QuadAdaptive
+	for _, rule := range []int{QuadGK15, QuadGK21} {
+		o.Rule = rule
+		A, e, n, err := o.Integrate(y, 0, 1)
+		if err != nil {
+			tst.Errorf("Integrate failed:\n%v\n", err)
+			return
+		}
+		io.Pforan("GK%d: A = %v  abserr = %v  neval = %d\n", rule, A, e, n)
+		chk.Scalar(tst, "A", 1e-11, A, Acor)
+	}
+
+	// reversed limits
+	A, _, _, err := o.Integrate(y, 1, 0)
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "A(reversed)", 1e-11, A, -Acor)
+
+	// peaks: ∫ 1/((x-0.3)²+0.01) + 1/((x-0.9)²+0.04) - 6
+	peaks := func(x float64) (float64, error) {
+		return 1.0/((x-0.3)*(x-0.3)+0.01) + 1.0/((x-0.9)*(x-0.9)+0.04) - 6.0, nil
+	}
+	Fpeaks := func(x float64) float64 {
+		return 10*math.Atan(10*(x-0.3)) + 5*math.Atan(5*(x-0.9)) - 6*x
+	}
+	o.Rule, o.AbsTol, o.RelTol = QuadGK21, 1e-13, 1e-13
+	A, e, n, err := o.Integrate(peaks, 0, 2)
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("peaks: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(peaks)", 1e-12, A, Fpeaks(2)-Fpeaks(0))
+	if e > 1e-13*math.Abs(A) {
+		tst.Errorf("error estimate is too large: %g\n", e)
+	}
+
+	// integrable singularity with GK: needs many subdivisions
+	sing := func(x float64) (float64, error) { return math.Log(x), nil }
+	o.AbsTol, o.RelTol = 1e-10, 1e-10
+	A, e, n, err = o.Integrate(sing, 0, 1)
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("log(x): A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(log)", 1e-10, A, -1)
+
+	// subdivision limit
+	o.Limit = 3
+	_, _, n, err = o.Integrate(sing, 0, 1)
+	if err == nil {
+		tst.Errorf("Integrate should have failed due to subdivision limit\n")
+		return
+	}
+	io.Pforan("%v\n", err)
+	chk.Int(tst, "neval", n, 5*21)
+}
+
+func Test_quadGK03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadGK03. infinite intervals")
+
+	var o QuadAdaptive
+
+	// ∫_0^∞ exp(-x) dx = 1
+	A, e, n, err := o.Integrate(func(x float64) (float64, error) { return math.Exp(-x), nil }, 0, math.Inf(1))
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("exp(-x): A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(exp)", 1e-10, A, 1)
+
+	// ∫_-∞^0 1/(1+x²) dx = π/2
+	A, _, _, err = o.Integrate(func(x float64) (float64, error) { return 1.0 / (1.0 + x*x), nil }, math.Inf(-1), 0)
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "A(atan)", 1e-10, A, math.Pi/2.0)
+
+	// ∫_-∞^∞ exp(-x²) dx = √π
+	A, _, _, err = o.Integrate(func(x float64) (float64, error) { return math.Exp(-x * x), nil }, math.Inf(-1), math.Inf(1))
+	if err != nil {
+		tst.Errorf("Integrate failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "A(gauss)", 1e-10, A, math.Sqrt(math.Pi))
+}
+
+func Test_quadTanhSinh01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadTanhSinh01. double-exponential rules")
+
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+	}{
+		{"smooth", func(x float64) float64 { return math.Sqrt(1.0 + math.Pow(math.Sin(x), 3.0)) }, 0, 1, 1.08268158558},
+		{"1/√x", func(x float64) float64 { return 1.0 / math.Sqrt(x) }, 0, 1, 2},
+		{"log(x)", func(x float64) float64 { return math.Log(x) }, 0, 1, -1},
+		{"1/√(1-x²)", func(x float64) float64 { return 1.0 / math.Sqrt(1.0-x*x) }, 0, 0.5, math.Pi / 6.0},
+		{"x^-0.9", func(x float64) float64 { return math.Pow(x, -0.9) }, 0, 1, 10},
+		{"exp(-x)/√x", func(x float64) float64 { return math.Exp(-x) / math.Sqrt(x) }, 0, math.Inf(1), math.Sqrt(math.Pi)},
+		{"1/(1+x²)", func(x float64) float64 { return 1.0 / (1.0 + x*x) }, math.Inf(-1), 0, math.Pi / 2.0},
+		{"exp(-x²)", func(x float64) float64 { return math.Exp(-x * x) }, math.Inf(-1), math.Inf(1), math.Sqrt(math.Pi)},
+		{"reversed", func(x float64) float64 { return 1.0 / math.Sqrt(x) }, 4, 0, -4},
+	}
+
+	var o QuadAdaptive
+	o.AbsTol, o.RelTol = 1e-12, 1e-12
+	for _, t := range tests {
+		f := t.f
+		A, e, n, err := o.TanhSinh(func(x float64) (float64, error) { return f(x), nil }, t.a, t.b)
+		if err != nil {
+			tst.Errorf("TanhSinh failed (%s):\n%v\n", t.name, err)
+			return
+		}
+		io.Pforan("%-12s A = %23.15e  abserr = %9.2e  neval = %d\n", t.name, A, e, n)
+		tol := 1e-12
+		if t.name == "x^-0.9" {
+			tol = 1e-9 // strong singularity near the limit of double precision
+		}
+		chk.Scalar(tst, t.name, tol, A, t.ref)
+	}
+}
+
+func Test_quadOsc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadOsc01. oscillatory integrands")
+
+	var o QuadAdaptive
+
+	// ∫_0^1 x cos(100x) dx
+	ω := 100.0
+	A, e, n, err := o.Oscillatory(func(x float64) (float64, error) { return x, nil }, 0, 1, ω, false)
+	if err != nil {
+		tst.Errorf("Oscillatory failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("x cos(100x): A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(cos)", 1e-12, A, math.Sin(ω)/ω+(math.Cos(ω)-1)/(ω*ω))
+
+	// ∫_0^2π exp(x) sin(50x) dx
+	ω = 50.0
+	A, _, _, err = o.Oscillatory(func(x float64) (float64, error) { return math.Exp(x), nil }, 0, 2*math.Pi, ω, true)
+	if err != nil {
+		tst.Errorf("Oscillatory failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "A(sin)", 1e-9, A, -ω*(math.Exp(2*math.Pi)-1)/(1+ω*ω))
+
+	// ∫_0^∞ sin(x)/x dx = π/2
+	A, e, n, err = o.Oscillatory(func(x float64) (float64, error) { return 1.0 / x, nil }, 0, math.Inf(1), 1, true)
+	if err != nil {
+		tst.Errorf("Oscillatory failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("sin(x)/x: A = %v  abserr = %v  neval = %d\n", A, e, n)
+	chk.Scalar(tst, "A(sinc)", 1e-9, A, math.Pi/2.0)
+
+	// ∫_0^∞ cos(2x)/(1+x²) dx = π/(2 e²)
+	A, _, _, err = o.Oscillatory(func(x float64) (float64, error) { return 1.0 / (1.0 + x*x), nil }, 0, math.Inf(1), 2, false)
+	if err != nil {
+		tst.Errorf("Oscillatory failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "A(cos/(1+x²))", 1e-9, A, math.Pi/(2*math.Exp(2)))
+}
@@ -0,0 +1,205 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_golubWelsch01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("golubWelsch01. Legendre and Jacobi")
+
+	// Legendre
+	a, b := RecurrenceJacobi(10, 0, 0)
+	x, w, err := GolubWelschXW(a, b)
+	if err != nil {
+		tst.Errorf("GolubWelschXW failed:\n%v\n", err)
+		return
+	}
+	xL, wL := GaussLegendreXW(-1, 1, 10)
+	io.Pforan("x = %v\n", x)
+	chk.Vector(tst, "x(Legendre)", 1e-14, x, xL)
+	chk.Vector(tst, "w(Legendre)", 1e-14, w, wL)
+
+	// Jacobi
+	alf, bet := 0.5, -0.3
+	a, b = RecurrenceJacobi(8, alf, bet)
+	x, w, err = GolubWelschXW(a, b)
+	if err != nil {
+		tst.Errorf("GolubWelschXW failed:\n%v\n", err)
+		return
+	}
+	xJ, wJ := GaussJacobiXW(alf, bet, 8)
+	chk.Vector(tst, "x(Jacobi)", 1e-14, x, xJ)
+	chk.Vector(tst, "w(Jacobi)", 1e-14, w, wJ)
+
+	// Chebyshev (first kind)
+	n := 6
+	a, b = RecurrenceJacobi(n, -0.5, -0.5)
+	x, w, err = GolubWelschXW(a, b)
+	if err != nil {
+		tst.Errorf("GolubWelschXW failed:\n%v\n", err)
+		return
+	}
+	for i := 0; i < n; i++ {
+		chk.Scalar(tst, io.Sf("x%d(Chebyshev)", i), 1e-15, x[i], -math.Cos(math.Pi*(float64(i)+0.5)/float64(n)))
+		chk.Scalar(tst, io.Sf("w%d(Chebyshev)", i), 1e-15, w[i], math.Pi/float64(n))
+	}
+}
+
+func Test_golubWelsch02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("golubWelsch02. Hermite and Laguerre")
+
+	// Hermite
+	x, w := GaussHermiteXW(5)
+	chk.Vector(tst, "x(Hermite)", 1e-14, x, []float64{-2.0201828704560856, -0.9585724646138185, 0, 0.9585724646138185, 2.0201828704560856})
+	chk.Vector(tst, "w(Hermite)", 1e-14, w, []float64{0.01995324205904591, 0.3936193231522412, 0.9453087204829419, 0.3936193231522412, 0.01995324205904591})
+
+	// Hermite: ∫ x²ᵏ exp(-x²) dx = Γ(k+1/2) for 2k ≤ 2n-1
+	n := 12
+	x, w = GaussHermiteXW(n)
+	for k := 0; 2*k < 2*n; k++ {
+		s := 0.0
+		for i := 0; i < n; i++ {
+			s += w[i] * math.Pow(x[i], float64(2*k))
+		}
+		ref := math.Gamma(float64(k) + 0.5)
+		chk.Scalar(tst, io.Sf("Hermite: x^%d", 2*k), 1e-13*ref, s, ref)
+	}
+
+	// Laguerre: ∫ xᵏ x^alf exp(-x) dx = Γ(k+alf+1) for k ≤ 2n-1
+	for _, alf := range []float64{0, 1.5} {
+		n = 6
+		x, w = GaussLaguerreXW(alf, n)
+		for k := 0; k < 2*n; k++ {
+			s := 0.0
+			for i := 0; i < n; i++ {
+				s += w[i] * math.Pow(x[i], float64(k))
+			}
+			ref := math.Gamma(float64(k) + alf + 1)
+			chk.Scalar(tst, io.Sf("Laguerre(%g): x^%d", alf, k), 1e-12*ref, s, ref)
+		}
+	}
+}
+
+func Test_golubWelsch03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("golubWelsch03. Lobatto and Radau")
+
+	// Lobatto
+	x, w := GaussLegendreLobattoXW(4)
+	s5 := 1.0 / math.Sqrt(5)
+	chk.Vector(tst, "x(Lobatto4)", 1e-15, x, []float64{-1, -s5, s5, 1})
+	chk.Vector(tst, "w(Lobatto4)", 1e-15, w, []float64{1.0 / 6.0, 5.0 / 6.0, 5.0 / 6.0, 1.0 / 6.0})
+	x, w = GaussLegendreLobattoXW(5)
+	s37 := math.Sqrt(3.0 / 7.0)
+	chk.Vector(tst, "x(Lobatto5)", 1e-15, x, []float64{-1, -s37, 0, s37, 1})
+	chk.Vector(tst, "w(Lobatto5)", 1e-15, w, []float64{0.1, 49.0 / 90.0, 32.0 / 45.0, 49.0 / 90.0, 0.1})
+
+	// Radau
+	a, b := RecurrenceJacobi(3, 0, 0)
+	x, w, err := GaussRadauXW(a, b, -1)
+	if err != nil {
+		tst.Errorf("GaussRadauXW failed:\n%v\n", err)
+		return
+	}
+	s6 := math.Sqrt(6)
+	chk.Vector(tst, "x(Radau3)", 1e-15, x, []float64{-1, (1 - s6) / 5, (1 + s6) / 5})
+	chk.Vector(tst, "w(Radau3)", 1e-15, w, []float64{2.0 / 9.0, (16 + s6) / 18, (16 - s6) / 18})
+
+	// Radau-Laguerre: x = 0 is included and ∫ xᵏ exp(-x) dx = k! for k ≤ 2n-2
+	n := 5
+	a, b = RecurrenceLaguerre(n, 0)
+	x, w, err = GaussRadauXW(a, b, 0)
+	if err != nil {
+		tst.Errorf("GaussRadauXW failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "x0(Radau-Laguerre)", 1e-14, x[0], 0)
+	for k := 0; k < 2*n-1; k++ {
+		s := 0.0
+		for i := 0; i < n; i++ {
+			s += w[i] * math.Pow(x[i], float64(k))
+		}
+		ref := math.Gamma(float64(k) + 1)
+		chk.Scalar(tst, io.Sf("Radau-Laguerre: x^%d", k), 1e-12*ref, s, ref)
+	}
+}
+
+func Test_golubWelsch04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("golubWelsch04. modified moments")
+
+	// weight: -log(x) in [0, 1] => ∫ xʲ (-log x) dx = 1/(j+1)²
+	check := func(n int, x, w []float64) {
+		for j := 0; j < 2*n; j++ {
+			s := 0.0
+			for i := 0; i < n; i++ {
+				s += w[i] * math.Pow(x[i], float64(j))
+			}
+			chk.Scalar(tst, io.Sf("n=%d: x^%d", n, j), 1e-13, s, 1.0/float64((j+1)*(j+1)))
+		}
+	}
+
+	// ordinary moments
+	n := 5
+	mom, at, bt := make([]float64, 2*n), make([]float64, 2*n-1), make([]float64, 2*n-1)
+	for k := 0; k < 2*n; k++ {
+		mom[k] = 1.0 / float64((k+1)*(k+1))
+	}
+	x, w, err := GaussMomentsXW(mom, at, bt)
+	if err != nil {
+		tst.Errorf("GaussMomentsXW failed:\n%v\n", err)
+		return
+	}
+	check(n, x, w)
+
+	// modified moments with respect to the monic shifted Legendre polynomials in [0, 1]:
+	//  m_0 = 1 and m_k = (-1)ᵏ (k!)² / (k (k+1) (2k)!)
+	n = 20
+	mom, at, bt = make([]float64, 2*n), make([]float64, 2*n-1), make([]float64, 2*n-1)
+	mom[0] = 1
+	for k := 1; k < 2*n; k++ {
+		K := float64(k)
+		l1, _ := math.Lgamma(K + 1)
+		l2, _ := math.Lgamma(2*K + 1)
+		mom[k] = math.Pow(-1, K) * math.Exp(2*l1-l2) / (K * (K + 1))
+	}
+	for k := 0; k < 2*n-1; k++ {
+		K := float64(k)
+		at[k] = 0.5
+		if k > 0 {
+			bt[k] = 1.0 / (4.0 * (4.0 - 1.0/(K*K)))
+		}
+	}
+	x, w, err = GaussMomentsXW(mom, at, bt)
+	if err != nil {
+		tst.Errorf("GaussMomentsXW failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("x = %v\n", x)
+	check(n, x, w)
+
+	// ill-conditioned ordinary moments are detected
+	n = 40
+	mom, at, bt = make([]float64, 2*n), make([]float64, 2*n-1), make([]float64, 2*n-1)
+	for k := 0; k < 2*n; k++ {
+		mom[k] = 1.0 / float64((k+1)*(k+1))
+	}
+	_, _, err = GaussMomentsXW(mom, at, bt)
+	if err == nil {
+		tst.Errorf("GaussMomentsXW should have failed\n")
+	}
+}
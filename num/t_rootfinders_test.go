@@ -0,0 +1,196 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_rootfinders01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rootfinders01. bracketing methods")
+
+	// functions
+	type problem struct {
+		name   string
+		f      func(x float64) (float64, error)
+		xa, xb float64
+		root   float64
+	}
+	problems := []problem{
+		{"cubic", func(x float64) (float64, error) { return x*x*x - 2*x - 5, nil }, 2, 3, 2.0945514815423265},
+		{"cos", func(x float64) (float64, error) { return math.Cos(x) - x, nil }, 0, 1, 0.7390851332151607},
+		{"exp", func(x float64) (float64, error) { return math.Exp(x) - 1e4, nil }, 0, 20, math.Log(1e4)},
+		{"flat", func(x float64) (float64, error) { return math.Pow(x-1, 9), nil }, 0, 1.7, 1},
+		{"steep", func(x float64) (float64, error) { return math.Atan(1e3 * (x - 0.3)), nil }, -2, 5, 0.3},
+	}
+
+	// solve
+	var o RootFinder
+	for _, p := range problems {
+		for _, method := range []string{"Ridders", "Illinois", "Toms748"} {
+			if method == "Illinois" && p.name == "flat" {
+				continue // regula falsi variants are too slow for roots of high multiplicity
+			}
+			var x float64
+			var err error
+			switch method {
+			case "Ridders":
+				x, err = o.Ridders(p.f, p.xa, p.xb)
+			case "Illinois":
+				x, err = o.Illinois(p.f, p.xa, p.xb)
+			case "Toms748":
+				x, err = o.Toms748(p.f, p.xa, p.xb)
+			}
+			if err != nil {
+				tst.Errorf("%s failed on %q:\n%v\n", method, p.name, err)
+				return
+			}
+			io.Pforan("%-6s %-8s: x = %.16f  nfeval = %3d\n", p.name, method, x, o.NFeval)
+			tol := 1e-13
+			if p.name == "flat" {
+				tol = 1e-2 // very flat function: any x with |x-1| < 0.01 gives f(x) < 1e-18
+			}
+			chk.Scalar(tst, io.Sf("%s: %s", p.name, method), tol, x, p.root)
+		}
+	}
+
+	// reversed bracket
+	x, err := o.Toms748(problems[1].f, 1, 0)
+	if err != nil {
+		tst.Errorf("Toms748 failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "cos: reversed", 1e-15, x, problems[1].root)
+
+	// bad bracket
+	_, err = o.Ridders(problems[1].f, 1, 2)
+	if err == nil {
+		tst.Errorf("Ridders should have failed\n")
+	}
+}
+
+func Test_rootfinders02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rootfinders02. safeguarded Newton and Halley")
+
+	f := func(x float64) (float64, error) { return math.Atan(x), nil }
+	df := func(x float64) (float64, error) { return 1.0 / (1.0 + x*x), nil }
+	d2f := func(x float64) (float64, error) { return -2.0 * x / math.Pow(1.0+x*x, 2), nil }
+
+	// pure Newton diverges from x0 = 2 (|x0| > 1.39); the safeguard prevents that
+	var o RootFinder
+	x, err := o.Newton(f, df, -3, 10, 2)
+	if err != nil {
+		tst.Errorf("Newton failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Newton: x = %v  it = %d\n", x, o.It)
+	chk.Scalar(tst, "Newton", 1e-15, x, 0)
+	x, err = o.Halley(f, df, d2f, -3, 10, 2)
+	if err != nil {
+		tst.Errorf("Halley failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Halley: x = %v  it = %d\n", x, o.It)
+	chk.Scalar(tst, "Halley", 1e-15, x, 0)
+
+	// convergence rate
+	g := func(x float64) (float64, error) { return x*x*x - 2*x - 5, nil }
+	dg := func(x float64) (float64, error) { return 3*x*x - 2, nil }
+	d2g := func(x float64) (float64, error) { return 6 * x, nil }
+	x, err = o.Newton(g, dg, 2, 3, 2.5)
+	if err != nil {
+		tst.Errorf("Newton failed:\n%v\n", err)
+		return
+	}
+	itNewton := o.It
+	chk.Scalar(tst, "cubic: Newton", 1e-15, x, 2.0945514815423265)
+	x, err = o.Halley(g, dg, d2g, 2, 3, 2.5)
+	if err != nil {
+		tst.Errorf("Halley failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("cubic: it(Newton) = %d  it(Halley) = %d\n", itNewton, o.It)
+	chk.Scalar(tst, "cubic: Halley", 1e-15, x, 2.0945514815423265)
+	if o.It > itNewton {
+		tst.Errorf("Halley should not need more iterations than Newton\n")
+	}
+}
+
+func Test_rootfinders03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rootfinders03. all roots in interval")
+
+	// sin(x) in [-1, 10] => 0, π, 2π and 3π
+	var o RootFinder
+	roots, err := o.AllRoots(func(x float64) (float64, error) { return math.Sin(x), nil }, -1, 10, 20, 0)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.Vector(tst, "sin", 1e-14, roots, []float64{0, math.Pi, 2 * math.Pi, 3 * math.Pi})
+
+	// two close roots (x = 0.5 ± 0.001) hidden in one subinterval
+	f := func(x float64) (float64, error) { return (x - 0.499) * (x - 0.501) * (2 - x), nil }
+	roots, err = o.AllRoots(f, 0.01, 1.9, 2, 0)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots(depth=0) = %v\n", roots)
+	chk.Int(tst, "number of roots (depth=0)", len(roots), 0)
+	roots, err = o.AllRoots(f, 0.01, 1.9, 2, 10)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots(depth=10) = %v\n", roots)
+	chk.Int(tst, "number of roots (depth=10)", len(roots), 2)
+	for _, r := range roots {
+		fr, _ := f(r)
+		chk.Scalar(tst, "f(root)", 1e-15, fr, 0)
+	}
+
+	// root at sampled point
+	roots, err = o.AllRoots(func(x float64) (float64, error) { return x * (x - 1) * (x + 1), nil }, -2, 2, 4, 0)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "cubic", 1e-15, roots, []float64{-1, 0, 1})
+
+	// roots at sampled points and inside the same subintervals
+	roots, err = o.AllRoots(func(x float64) (float64, error) { return x * (x - 1) * (x - 2) * (x - 3), nil }, 0, 4, 2, 0)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots(quartic) = %v\n", roots)
+	chk.Vector(tst, "quartic", 1e-14, roots, []float64{0, 1, 2, 3})
+	roots, err = o.AllRoots(func(x float64) (float64, error) { return (x - 0.5) * (x - 0.7), nil }, 0, 1, 2, 0)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("roots(quadratic) = %v\n", roots)
+	chk.Vector(tst, "quadratic", 1e-14, roots, []float64{0.5, 0.7})
+
+	// zero function
+	roots, err = o.AllRoots(func(x float64) (float64, error) { return 0, nil }, 0, 1, 2, 3)
+	if err != nil {
+		tst.Errorf("AllRoots failed:\n%v\n", err)
+		return
+	}
+	chk.Vector(tst, "zero function", 1e-15, roots, []float64{0, 0.5, 1})
+}
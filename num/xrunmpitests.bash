@@ -2,3 +2,4 @@
 
 go build -o /tmp/gosl/t_jacobian01b_main t_jacobian01b_main.go && mpirun -np 2 /tmp/gosl/t_jacobian01b_main
 go build -o /tmp/gosl/t_jacobian02b_main t_jacobian02b_main.go && mpirun -np 4 /tmp/gosl/t_jacobian02b_main
+go build -o /tmp/gosl/t_jacobian03b_main t_jacobian03b_main.go && mpirun -np 3 /tmp/gosl/t_jacobian03b_main
@@ -312,7 +312,7 @@ func (o *Solver) Solve(y []float64, x, xb, Δx float64, fixstp bool) (err error)
 	var rerr float64
 
 	// linear solver
-	lsname := "umfpack"
+	lsname := la.DefaultSolver
 	if o.Distr {
 		lsname = "mumps"
 	}
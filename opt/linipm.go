@@ -109,7 +109,7 @@ func (o *LinIpm) Init(A *la.CCMatrix, b, c []float64, prms dbf.Params) {
 	o.J.Init(o.Ny, o.Ny, nnz)
 
 	// linear solver
-	o.Lis = la.GetSolver("umfpack")
+	o.Lis = la.GetSolver(la.DefaultSolver)
 }
 
 // Solve solves linear programming problem
@@ -4,6 +4,12 @@
 
 package rnd
 
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
 // auxiliary functions
 func imin(a, b int) int {
 	if a < b {
@@ -29,3 +35,53 @@ func max(a, b float64) float64 {
 	}
 	return b
 }
+
+// xlogy returns x log(y) or zero if x == 0
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+// discreteQuantile finds the smallest integer k ≤ kmax such that cdf(k) ≥ p, starting at the
+// normal approximation k ≈ μ + σ Φ⁻¹(p)
+func discreteQuantile(cdf func(x float64) float64, p, μ, σ, kmax float64) float64 {
+	k := math.Floor(μ + σ*stdQuantile(p))
+	k = math.Max(0, math.Min(kmax, k))
+	for k < kmax && cdf(k) < p {
+		k++
+	}
+	for k > 0 && cdf(k-1) >= p {
+		k--
+	}
+	return k
+}
+
+// bisect finds the root of a continuous function f in [a,b] by bisection
+//  NOTE: f(a) and f(b) must have opposite signs; otherwise an error is returned
+func bisect(f func(x float64) float64, a, b float64) (x float64, err error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if fa*fb > 0 {
+		return 0, chk.Err("root is not bracketed in [%g, %g]: f(a)=%g, f(b)=%g", a, b, fa, fb)
+	}
+	for it := 0; it < 200; it++ {
+		x = (a + b) / 2.0
+		fx := f(x)
+		if fx == 0 || x == a || x == b {
+			return
+		}
+		if fx*fa < 0 {
+			b = x
+		} else {
+			a, fa = x, fx
+		}
+	}
+	return
+}
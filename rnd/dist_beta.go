@@ -0,0 +1,91 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// DistBeta implements the beta distribution
+//  f(x) = x^(α-1) (1-x)^(β-1) / B(α,β)  with  0 ≤ x ≤ 1
+type DistBeta struct {
+	Alp float64 // α: first shape
+	Bet float64 // β: second shape
+
+	// auxiliary
+	lnb float64 // log(B(α,β))
+}
+
+// set factory
+func init() {
+	distallocators[D_Beta] = func() Distribution { return new(DistBeta) }
+}
+
+// Init initialises beta distribution
+//  NOTE: if the shapes (A and B) are not given, the mean (M) and standard deviation (S) are used
+//        to compute them. Otherwise, the mean and standard deviation are computed.
+func (o *DistBeta) Init(p *VarData) error {
+	if p.A > 0 && p.B > 0 {
+		o.Alp, o.Bet = p.A, p.B
+		s := o.Alp + o.Bet
+		p.M = o.Alp / s
+		p.S = math.Sqrt(o.Alp * o.Bet / (s * s * (s + 1.0)))
+	} else {
+		μ, v := p.M, p.S*p.S
+		if μ <= 0 || μ >= 1 || v <= 0 || v >= μ*(1.0-μ) {
+			return chk.Err("beta distribution requires 0 < M < 1 and 0 < S² < M (1 - M). M=%g, S=%g", p.M, p.S)
+		}
+		c := μ*(1.0-μ)/v - 1.0
+		o.Alp, o.Bet = μ*c, (1.0-μ)*c
+	}
+	la, _ := math.Lgamma(o.Alp)
+	lb, _ := math.Lgamma(o.Bet)
+	lab, _ := math.Lgamma(o.Alp + o.Bet)
+	o.lnb = la + lb - lab
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistBeta) Pdf(x float64) float64 {
+	if x < 0 || x > 1 {
+		return 0
+	}
+	return math.Exp(o.LogPdf(x))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistBeta) Cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	return fun.BetaInc(o.Alp, o.Bet, x)
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistBeta) LogPdf(x float64) float64 {
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+	return xlogy(o.Alp-1.0, x) + xlogy(o.Bet-1.0, 1.0-x) - o.lnb
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistBeta) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, 0, 1); done {
+		return x
+	}
+	return fun.BetaIncInv(o.Alp, o.Bet, p)
+}
+
+// Sample generates a random number with this distribution
+func (o DistBeta) Sample() float64 {
+	return o.Quantile(unitRand())
+}
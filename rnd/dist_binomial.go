@@ -0,0 +1,81 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// DistBinomial implements the binomial distribution (discrete)
+//  P(X = k) = C(n,k) p^k (1-p)^(n-k)  with  k = 0, 1, ..., n
+type DistBinomial struct {
+	N int     // number of trials
+	P float64 // probability of success in each trial
+}
+
+// set factory
+func init() {
+	distallocators[D_Binomial] = func() Distribution { return new(DistBinomial) }
+}
+
+// Init initialises binomial distribution
+//  NOTE: the mean (M) and standard deviation (S) are computed
+func (o *DistBinomial) Init(p *VarData) error {
+	o.N, o.P = p.N, p.P
+	if o.N < 0 || o.P < 0 || o.P > 1 {
+		return chk.Err("binomial distribution requires N ≥ 0 and 0 ≤ P ≤ 1. N=%d, P=%g", o.N, o.P)
+	}
+	n := float64(o.N)
+	p.M = n * o.P
+	p.S = math.Sqrt(n * o.P * (1.0 - o.P))
+	return nil
+}
+
+// Pdf computes the probability mass function @ x; i.e. zero if x is not an integer in [0, N]
+func (o DistBinomial) Pdf(x float64) float64 {
+	return math.Exp(o.LogPdf(x))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistBinomial) Cdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	k := math.Floor(x)
+	n := float64(o.N)
+	if k >= n {
+		return 1
+	}
+	return fun.BetaInc(n-k, k+1.0, 1.0-o.P)
+}
+
+// LogPdf computes the natural logarithm of the probability mass function @ x
+func (o DistBinomial) LogPdf(x float64) float64 {
+	n := float64(o.N)
+	if x < 0 || x > n || x != math.Floor(x) {
+		return math.Inf(-1)
+	}
+	ln, _ := math.Lgamma(n + 1.0)
+	lk, _ := math.Lgamma(x + 1.0)
+	lnk, _ := math.Lgamma(n - x + 1.0)
+	return ln - lk - lnk + xlogy(x, o.P) + xlogy(n-x, 1.0-o.P)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistBinomial) Quantile(p float64) float64 {
+	n := float64(o.N)
+	if x, done := quantileBounds(p, 0, n); done {
+		return x
+	}
+	return discreteQuantile(o.Cdf, p, n*o.P, math.Sqrt(n*o.P*(1.0-o.P)), n)
+}
+
+// Sample generates a random number with this distribution
+func (o DistBinomial) Sample() float64 {
+	return o.Quantile(unitRand())
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistChiSquared implements the chi-squared distribution with ν degrees of freedom
+//  NOTE: this is a gamma distribution with shape ν/2 and scale 2
+type DistChiSquared struct {
+	Nu float64 // ν: degrees of freedom
+
+	// auxiliary
+	gam DistGamma // equivalent gamma distribution
+}
+
+// set factory
+func init() {
+	distallocators[D_ChiSquared] = func() Distribution { return new(DistChiSquared) }
+}
+
+// Init initialises chi-squared distribution
+//  NOTE: the mean (M) and standard deviation (S) are computed
+func (o *DistChiSquared) Init(p *VarData) error {
+	o.Nu = p.Nu
+	if o.Nu <= 0 {
+		return chk.Err("chi-squared distribution requires positive degrees of freedom (Nu). Nu=%g", o.Nu)
+	}
+	o.gam.Init(&VarData{A: o.Nu / 2.0, C: 2.0})
+	p.M = o.Nu
+	p.S = math.Sqrt(2.0 * o.Nu)
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistChiSquared) Pdf(x float64) float64 {
+	return o.gam.Pdf(x)
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistChiSquared) Cdf(x float64) float64 {
+	return o.gam.Cdf(x)
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistChiSquared) LogPdf(x float64) float64 {
+	return o.gam.LogPdf(x)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistChiSquared) Quantile(p float64) float64 {
+	return o.gam.Quantile(p)
+}
+
+// Sample generates a random number with this distribution
+func (o DistChiSquared) Sample() float64 {
+	return o.gam.Sample()
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistExponential implements the exponential distribution
+//  f(x) = λ exp(-λ (x-L))  with  x ≥ L
+type DistExponential struct {
+	L   float64 // location. default = 0
+	Lam float64 // λ: rate = 1 / (μ - L)
+}
+
+// set factory
+func init() {
+	distallocators[D_Exponential] = func() Distribution { return new(DistExponential) }
+}
+
+// Init initialises exponential distribution
+//  NOTE: the rate is computed from the mean (M) and location (L); the standard deviation is set
+func (o *DistExponential) Init(p *VarData) error {
+	if p.M <= p.L {
+		return chk.Err("exponential distribution requires the mean (M) to be greater than the location (L). M=%g, L=%g", p.M, p.L)
+	}
+	o.L = p.L
+	o.Lam = 1.0 / (p.M - p.L)
+	p.S = p.M - p.L
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistExponential) Pdf(x float64) float64 {
+	if x < o.L {
+		return 0
+	}
+	return o.Lam * math.Exp(-o.Lam*(x-o.L))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistExponential) Cdf(x float64) float64 {
+	if x < o.L {
+		return 0
+	}
+	return -math.Expm1(-o.Lam * (x - o.L))
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistExponential) LogPdf(x float64) float64 {
+	if x < o.L {
+		return math.Inf(-1)
+	}
+	return math.Log(o.Lam) - o.Lam*(x-o.L)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistExponential) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.L, math.Inf(1)); done {
+		return x
+	}
+	return o.L - math.Log1p(-p)/o.Lam
+}
+
+// Sample generates a random number with this distribution
+func (o DistExponential) Sample() float64 {
+	return o.L + rand.ExpFloat64()/o.Lam
+}
@@ -53,6 +53,28 @@ func (o DistFrechet) Cdf(x float64) float64 {
 	return math.Exp(-math.Pow(z, -o.A))
 }
 
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistFrechet) LogPdf(x float64) float64 {
+	if x-o.L < ZERO {
+		return math.Inf(-1)
+	}
+	z := (x - o.L) / o.C
+	return math.Log(o.A/o.C) - (1.0+o.A)*math.Log(z) - math.Pow(z, -o.A)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistFrechet) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.L, math.Inf(1)); done {
+		return x
+	}
+	return o.L + o.C*math.Pow(-math.Log(p), -1.0/o.A)
+}
+
+// Sample generates a random number with this distribution
+func (o DistFrechet) Sample() float64 {
+	return o.Quantile(unitRand())
+}
+
 // Mean returns the expected value
 func (o DistFrechet) Mean() float64 {
 	if o.A > 1.0 {
@@ -0,0 +1,100 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// DistGamma implements the gamma distribution
+//  f(x) = x^(k-1) exp(-x/θ) / (Γ(k) θ^k)  with  x ≥ 0
+type DistGamma struct {
+	K  float64 // shape
+	Th float64 // θ: scale
+
+	// auxiliary
+	lnc float64 // -log(Γ(k)) - k log(θ)
+}
+
+// set factory
+func init() {
+	distallocators[D_Gamma] = func() Distribution { return new(DistGamma) }
+}
+
+// Init initialises gamma distribution
+//  NOTE: if the shape (A) is not given, the mean (M) and standard deviation (S) are used to
+//        compute the shape and scale. Otherwise, the shape (A) and scale (C) are used, with C = 1
+//        by default, and the mean and standard deviation are computed.
+func (o *DistGamma) Init(p *VarData) error {
+	if p.A > 0 {
+		o.K, o.Th = p.A, p.C
+		if math.Abs(o.Th) < ZERO {
+			o.Th = 1
+		}
+		p.M = o.K * o.Th
+		p.S = math.Sqrt(o.K) * o.Th
+	} else {
+		if p.M <= 0 || p.S <= 0 {
+			return chk.Err("gamma distribution requires positive mean and standard deviation. M=%g, S=%g", p.M, p.S)
+		}
+		o.K = p.M * p.M / (p.S * p.S)
+		o.Th = p.S * p.S / p.M
+	}
+	if o.Th < 0 {
+		return chk.Err("gamma distribution requires a positive scale (C). C=%g", o.Th)
+	}
+	lg, _ := math.Lgamma(o.K)
+	o.lnc = -lg - o.K*math.Log(o.Th)
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistGamma) Pdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return math.Exp(o.LogPdf(x))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistGamma) Cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return fun.GammaP(o.K, x/o.Th)
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistGamma) LogPdf(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case o.K < 1:
+			return math.Inf(1)
+		case o.K > 1:
+			return math.Inf(-1)
+		}
+		return o.lnc
+	}
+	return o.lnc + (o.K-1.0)*math.Log(x) - x/o.Th
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistGamma) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, 0, math.Inf(1)); done {
+		return x
+	}
+	return o.Th * fun.GammaPinv(o.K, p)
+}
+
+// Sample generates a random number with this distribution
+func (o DistGamma) Sample() float64 {
+	return o.Quantile(unitRand())
+}
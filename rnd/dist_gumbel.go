@@ -36,3 +36,22 @@ func (o DistGumbel) Cdf(x float64) float64 {
 	mz := (o.U - x) / o.B
 	return math.Exp(-math.Exp(mz))
 }
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistGumbel) LogPdf(x float64) float64 {
+	mz := (o.U - x) / o.B
+	return mz - math.Exp(mz) - math.Log(o.B)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistGumbel) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, math.Inf(-1), math.Inf(1)); done {
+		return x
+	}
+	return o.U - o.B*math.Log(-math.Log(p))
+}
+
+// Sample generates a random number with this distribution
+func (o DistGumbel) Sample() float64 {
+	return o.Quantile(unitRand())
+}
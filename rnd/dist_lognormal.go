@@ -65,5 +65,23 @@ func (o DistLogNormal) Cdf(x float64) float64 {
 	if x < ZERO {
 		return 0
 	}
-	return (1.0 + math.Erf((math.Log(x)-o.N)/(o.Z*math.Sqrt2))) / 2.0
+	return math.Erfc(-(math.Log(x)-o.N)/(o.Z*math.Sqrt2)) / 2.0
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistLogNormal) LogPdf(x float64) float64 {
+	if x < ZERO {
+		return math.Inf(-1)
+	}
+	return math.Log(o.A/x) + o.B*math.Pow(math.Log(x)-o.N, 2.0)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistLogNormal) Quantile(p float64) float64 {
+	return math.Exp(o.N + o.Z*stdQuantile(p))
+}
+
+// Sample generates a random number with this distribution
+func (o DistLogNormal) Sample() float64 {
+	return math.Exp(o.N + o.Z*rand.NormFloat64())
 }
@@ -29,6 +29,19 @@ func StdInvPhi(x float64) float64 {
 	return ltqnorm(x)
 }
 
+// stdQuantile implements Φ⁻¹(p) with full precision (StdInvPhi uses a rational approximation)
+//  NOTE: math.Erfcinv loses relative precision for small arguments; thus, one step of Halley's
+//        method is applied to correct the lower tail
+func stdQuantile(p float64) float64 {
+	z := -math.Sqrt2 * math.Erfcinv(2.0*p)
+	if math.IsInf(z, 0) || math.IsNaN(z) {
+		return z
+	}
+	e := math.Erfc(-z/math.Sqrt2)/2.0 - p
+	u := e * math.Sqrt2 * math.SqrtPi * math.Exp(z*z/2.0)
+	return z - u/(1.0+z*u/2.0)
+}
+
 // DistNormal implements the normal distribution
 type DistNormal struct {
 
@@ -66,5 +79,20 @@ func (o DistNormal) Pdf(x float64) float64 {
 
 // Cdf computes the cumulative probability function @ x
 func (o DistNormal) Cdf(x float64) float64 {
-	return (1.0 + math.Erf((x-o.Mu)/(o.Sig*math.Sqrt2))) / 2.0
+	return math.Erfc(-(x-o.Mu)/(o.Sig*math.Sqrt2)) / 2.0
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistNormal) LogPdf(x float64) float64 {
+	return math.Log(o.a) + o.b*math.Pow(x-o.Mu, 2.0)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistNormal) Quantile(p float64) float64 {
+	return o.Mu + o.Sig*stdQuantile(p)
+}
+
+// Sample generates a random number with this distribution
+func (o DistNormal) Sample() float64 {
+	return Normal(o.Mu, o.Sig)
 }
@@ -0,0 +1,87 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistPareto implements the Pareto (Type I) distribution
+//  f(x) = α xm^α / x^(α+1)  with  x ≥ xm
+type DistPareto struct {
+	Xm  float64 // scale = min value
+	Alp float64 // α: shape (tail index)
+}
+
+// set factory
+func init() {
+	distallocators[D_Pareto] = func() Distribution { return new(DistPareto) }
+}
+
+// Init initialises Pareto distribution
+//  NOTE: if the shape (A) is not given, the mean (M) and standard deviation (S) are used to compute
+//        the shape and scale. Otherwise, the shape (A) and scale (C) are used and the mean and
+//        standard deviation are computed (they are +Inf if they do not exist)
+func (o *DistPareto) Init(p *VarData) error {
+	if p.A > 0 {
+		o.Alp, o.Xm = p.A, p.C
+		if o.Xm <= 0 {
+			return chk.Err("Pareto distribution requires a positive scale (C). C=%g", o.Xm)
+		}
+		p.M, p.S = math.Inf(1), math.Inf(1)
+		if o.Alp > 1 {
+			p.M = o.Alp * o.Xm / (o.Alp - 1.0)
+		}
+		if o.Alp > 2 {
+			p.S = o.Xm / (o.Alp - 1.0) * math.Sqrt(o.Alp/(o.Alp-2.0))
+		}
+		return nil
+	}
+	if p.M <= 0 || p.S <= 0 {
+		return chk.Err("Pareto distribution requires positive mean and standard deviation. M=%g, S=%g", p.M, p.S)
+	}
+	o.Alp = 1.0 + math.Sqrt(1.0+p.M*p.M/(p.S*p.S))
+	o.Xm = p.M * (o.Alp - 1.0) / o.Alp
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistPareto) Pdf(x float64) float64 {
+	if x < o.Xm {
+		return 0
+	}
+	return o.Alp * math.Pow(o.Xm/x, o.Alp) / x
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistPareto) Cdf(x float64) float64 {
+	if x < o.Xm {
+		return 0
+	}
+	return -math.Expm1(o.Alp * math.Log(o.Xm/x))
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistPareto) LogPdf(x float64) float64 {
+	if x < o.Xm {
+		return math.Inf(-1)
+	}
+	return math.Log(o.Alp) + o.Alp*math.Log(o.Xm/x) - math.Log(x)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistPareto) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.Xm, math.Inf(1)); done {
+		return x
+	}
+	return o.Xm * math.Exp(-math.Log1p(-p)/o.Alp)
+}
+
+// Sample generates a random number with this distribution
+func (o DistPareto) Sample() float64 {
+	return o.Quantile(unitRand())
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// DistPoisson implements the Poisson distribution (discrete)
+//  P(X = k) = λ^k exp(-λ) / k!  with  k = 0, 1, 2, ...
+type DistPoisson struct {
+	Lam float64 // λ: rate = mean
+}
+
+// set factory
+func init() {
+	distallocators[D_Poisson] = func() Distribution { return new(DistPoisson) }
+}
+
+// Init initialises Poisson distribution
+//  NOTE: the rate is given by the mean (M); the standard deviation is set
+func (o *DistPoisson) Init(p *VarData) error {
+	o.Lam = p.M
+	if o.Lam <= 0 {
+		return chk.Err("Poisson distribution requires a positive mean (M). M=%g", o.Lam)
+	}
+	p.S = math.Sqrt(o.Lam)
+	return nil
+}
+
+// Pdf computes the probability mass function @ x; i.e. zero if x is not a non-negative integer
+func (o DistPoisson) Pdf(x float64) float64 {
+	return math.Exp(o.LogPdf(x))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistPoisson) Cdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+	return fun.GammaQ(math.Floor(x)+1.0, o.Lam)
+}
+
+// LogPdf computes the natural logarithm of the probability mass function @ x
+func (o DistPoisson) LogPdf(x float64) float64 {
+	if x < 0 || x != math.Floor(x) || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	lf, _ := math.Lgamma(x + 1.0)
+	return x*math.Log(o.Lam) - o.Lam - lf
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistPoisson) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, 0, math.Inf(1)); done {
+		return x
+	}
+	return discreteQuantile(o.Cdf, p, o.Lam, math.Sqrt(o.Lam), math.Inf(1))
+}
+
+// Sample generates a random number with this distribution
+func (o DistPoisson) Sample() float64 {
+	return o.Quantile(unitRand())
+}
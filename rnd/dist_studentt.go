@@ -0,0 +1,107 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// DistStudentT implements the (location-scale) Student's t distribution
+//  f(x) = Γ((ν+1)/2) / (Γ(ν/2) sqrt(ν π) C) (1 + z²/ν)^(-(ν+1)/2)  with  z = (x-L)/C
+type DistStudentT struct {
+	Nu float64 // ν: degrees of freedom
+	L  float64 // location. default = 0
+	C  float64 // scale. default = 1
+
+	// auxiliary
+	lnc float64 // log of normalising constant
+}
+
+// set factory
+func init() {
+	distallocators[D_StudentT] = func() Distribution { return new(DistStudentT) }
+}
+
+// Init initialises Student's t distribution
+//  NOTE: the mean (M) and standard deviation (S) are computed; they are +Inf or NaN if they do
+//        not exist (ν ≤ 1 or ν ≤ 2, respectively)
+func (o *DistStudentT) Init(p *VarData) error {
+	o.Nu, o.L, o.C = p.Nu, p.L, p.C
+	if math.Abs(o.C) < ZERO {
+		o.C = 1
+	}
+	if o.Nu <= 0 || o.C < 0 {
+		return chk.Err("Student's t distribution requires positive degrees of freedom (Nu) and scale (C). Nu=%g, C=%g", o.Nu, o.C)
+	}
+	l1, _ := math.Lgamma((o.Nu + 1.0) / 2.0)
+	l2, _ := math.Lgamma(o.Nu / 2.0)
+	o.lnc = l1 - l2 - 0.5*math.Log(o.Nu*math.Pi) - math.Log(o.C)
+	p.M, p.S = math.NaN(), math.NaN()
+	if o.Nu > 1 {
+		p.M = o.L
+	}
+	switch {
+	case o.Nu > 2:
+		p.S = o.C * math.Sqrt(o.Nu/(o.Nu-2.0))
+	case o.Nu > 1:
+		p.S = math.Inf(1)
+	}
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistStudentT) Pdf(x float64) float64 {
+	return math.Exp(o.LogPdf(x))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistStudentT) Cdf(x float64) float64 {
+	z := (x - o.L) / o.C
+	if math.IsInf(z, 0) {
+		if z < 0 {
+			return 0
+		}
+		return 1
+	}
+	h := 0.5 * fun.BetaInc(o.Nu/2.0, 0.5, o.Nu/(o.Nu+z*z))
+	if z < 0 {
+		return h
+	}
+	return 1.0 - h
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistStudentT) LogPdf(x float64) float64 {
+	z := (x - o.L) / o.C
+	return o.lnc - (o.Nu+1.0)/2.0*math.Log1p(z*z/o.Nu)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistStudentT) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, math.Inf(-1), math.Inf(1)); done {
+		return x
+	}
+	q := math.Min(p, 1.0-p) // probability in one tail
+	var z2 float64          // z²
+	if q < 0.25 {
+		y := fun.BetaIncInv(o.Nu/2.0, 0.5, 2.0*q) // y = ν / (ν + z²)
+		z2 = o.Nu * (1.0 - y) / y
+	} else {
+		y := fun.BetaIncInv(0.5, o.Nu/2.0, 1.0-2.0*q) // y = z² / (ν + z²); avoids cancellation
+		z2 = o.Nu * y / (1.0 - y)
+	}
+	if p < 0.5 {
+		return o.L - o.C*math.Sqrt(z2)
+	}
+	return o.L + o.C*math.Sqrt(z2)
+}
+
+// Sample generates a random number with this distribution
+func (o DistStudentT) Sample() float64 {
+	return o.Quantile(unitRand())
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistTriangular implements the triangular distribution
+type DistTriangular struct {
+	A float64 // min value
+	C float64 // mode
+	B float64 // max value
+}
+
+// set factory
+func init() {
+	distallocators[D_Triangular] = func() Distribution { return new(DistTriangular) }
+}
+
+// Init initialises triangular distribution
+//  NOTE: the mean (M) and standard deviation (S) are computed
+func (o *DistTriangular) Init(p *VarData) error {
+	o.A, o.C, o.B = p.Min, p.Mode, p.Max
+	if o.A >= o.B || o.C < o.A || o.C > o.B {
+		return chk.Err("triangular distribution requires Min ≤ Mode ≤ Max and Min < Max. Min=%g, Mode=%g, Max=%g", o.A, o.C, o.B)
+	}
+	a, b, c := o.A, o.B, o.C
+	p.M = (a + b + c) / 3.0
+	p.S = math.Sqrt((a*a + b*b + c*c - a*b - a*c - b*c) / 18.0)
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistTriangular) Pdf(x float64) float64 {
+	if x < o.A || x > o.B {
+		return 0
+	}
+	if x <= o.C && o.C > o.A {
+		return 2.0 * (x - o.A) / ((o.B - o.A) * (o.C - o.A))
+	}
+	return 2.0 * (o.B - x) / ((o.B - o.A) * (o.B - o.C))
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistTriangular) Cdf(x float64) float64 {
+	if x <= o.A {
+		return 0
+	}
+	if x >= o.B {
+		return 1
+	}
+	if x <= o.C {
+		return (x - o.A) * (x - o.A) / ((o.B - o.A) * (o.C - o.A))
+	}
+	return 1.0 - (o.B-x)*(o.B-x)/((o.B-o.A)*(o.B-o.C))
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistTriangular) LogPdf(x float64) float64 {
+	return math.Log(o.Pdf(x))
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistTriangular) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.A, o.B); done {
+		return x
+	}
+	if p < (o.C-o.A)/(o.B-o.A) {
+		return o.A + math.Sqrt(p*(o.B-o.A)*(o.C-o.A))
+	}
+	return o.B - math.Sqrt((1.0-p)*(o.B-o.A)*(o.B-o.C))
+}
+
+// Sample generates a random number with this distribution
+func (o DistTriangular) Sample() float64 {
+	return o.Quantile(unitRand())
+}
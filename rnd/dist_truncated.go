@@ -0,0 +1,101 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistTruncated implements the truncated version of another distribution; i.e. the distribution
+// of X conditioned on Min < X ≤ Max
+//  NOTE: (1) for discrete distributions, the value Min is excluded; e.g. the zero-truncated
+//            Poisson distribution is obtained with Min = 0 and Max = +Inf
+//        (2) uniform and triangular distributions cannot be truncated because they use
+//            Min and Max as their own parameters
+type DistTruncated struct {
+	Base Distribution // distribution being truncated
+	Lo   float64      // lower limit
+	Hi   float64      // upper limit
+
+	// auxiliary
+	flo float64 // Cdf(Lo) of base distribution
+	fhi float64 // Cdf(Hi) of base distribution
+	z   float64 // normalising factor = Cdf(Hi) - Cdf(Lo)
+}
+
+// set factory
+func init() {
+	distallocators[D_Truncated] = func() Distribution { return new(DistTruncated) }
+}
+
+// Init initialises truncated distribution
+//  NOTE: the base distribution of type p.Base is initialised with p as well
+func (o *DistTruncated) Init(p *VarData) (err error) {
+	if p.Base == D_Truncated || p.Base == D_Uniform || p.Base == D_Triangular {
+		return chk.Err("cannot truncate distribution %q", GetDistrName(p.Base))
+	}
+	o.Base, err = GetDistrib(p.Base)
+	if err != nil {
+		return
+	}
+	err = o.Base.Init(p)
+	if err != nil {
+		return
+	}
+	o.Lo, o.Hi = p.Min, p.Max
+	if o.Lo >= o.Hi {
+		return chk.Err("truncated distribution requires Min < Max. Min=%g, Max=%g", o.Lo, o.Hi)
+	}
+	o.flo = o.Base.Cdf(o.Lo)
+	o.fhi = o.Base.Cdf(o.Hi)
+	o.z = o.fhi - o.flo
+	if o.z <= 0 {
+		return chk.Err("truncated distribution has zero probability within [%g, %g]", o.Lo, o.Hi)
+	}
+	return
+}
+
+// Pdf computes the probability density function @ x
+func (o DistTruncated) Pdf(x float64) float64 {
+	if x <= o.Lo || x > o.Hi {
+		return 0
+	}
+	return o.Base.Pdf(x) / o.z
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistTruncated) Cdf(x float64) float64 {
+	if x <= o.Lo {
+		return 0
+	}
+	if x >= o.Hi {
+		return 1
+	}
+	return (o.Base.Cdf(x) - o.flo) / o.z
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistTruncated) LogPdf(x float64) float64 {
+	if x <= o.Lo || x > o.Hi {
+		return math.Inf(-1)
+	}
+	return o.Base.LogPdf(x) - math.Log(o.z)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistTruncated) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.Lo, o.Hi); done {
+		return x
+	}
+	x := o.Base.Quantile(o.flo + p*o.z)
+	return math.Max(o.Lo, math.Min(o.Hi, x))
+}
+
+// Sample generates a random number with this distribution
+func (o DistTruncated) Sample() float64 {
+	return o.Quantile(unitRand())
+}
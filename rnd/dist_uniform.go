@@ -4,7 +4,10 @@
 
 package rnd
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+)
 
 // Uniform returns a random number belonging to a uniform distribution
 func Uniform(min, max float64) float64 {
@@ -51,3 +54,24 @@ func (o DistUniform) Cdf(x float64) float64 {
 	}
 	return (x - o.A) / (o.B - o.A)
 }
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistUniform) LogPdf(x float64) float64 {
+	if x < o.A || x > o.B {
+		return math.Inf(-1)
+	}
+	return -math.Log(o.B - o.A)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistUniform) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.A, o.B); done {
+		return x
+	}
+	return o.A + p*(o.B-o.A)
+}
+
+// Sample generates a random number with this distribution
+func (o DistUniform) Sample() float64 {
+	return Uniform(o.A, o.B)
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// DistWeibull implements the Weibull / Type III Extreme Value Distribution (smallest value)
+//  f(x) = (A/C) z^(A-1) exp(-z^A)  with  z = (x-L)/C  and  x ≥ L
+type DistWeibull struct {
+	L float64 // location. default = 0
+	C float64 // scale. default = 1
+	A float64 // shape
+}
+
+// set factory
+func init() {
+	distallocators[D_Weibull] = func() Distribution { return new(DistWeibull) }
+}
+
+// Init initialises Weibull distribution
+func (o *DistWeibull) Init(p *VarData) error {
+	o.L, o.C, o.A = p.L, p.C, p.A
+	if math.Abs(o.C) < ZERO {
+		o.C = 1
+	}
+	if o.A <= 0 || o.C < 0 {
+		return chk.Err("Weibull distribution requires positive shape (A) and scale (C). A=%g, C=%g", o.A, o.C)
+	}
+	p.M = o.Mean()
+	p.S = math.Sqrt(o.Variance())
+	return nil
+}
+
+// Pdf computes the probability density function @ x
+func (o DistWeibull) Pdf(x float64) float64 {
+	if x < o.L {
+		return 0
+	}
+	z := (x - o.L) / o.C
+	return o.A * math.Pow(z, o.A-1.0) * math.Exp(-math.Pow(z, o.A)) / o.C
+}
+
+// Cdf computes the cumulative probability function @ x
+func (o DistWeibull) Cdf(x float64) float64 {
+	if x < o.L {
+		return 0
+	}
+	z := (x - o.L) / o.C
+	return -math.Expm1(-math.Pow(z, o.A))
+}
+
+// LogPdf computes the natural logarithm of the probability density function @ x
+func (o DistWeibull) LogPdf(x float64) float64 {
+	if x < o.L {
+		return math.Inf(-1)
+	}
+	z := (x - o.L) / o.C
+	return math.Log(o.A/o.C) + (o.A-1.0)*math.Log(z) - math.Pow(z, o.A)
+}
+
+// Quantile computes the inverse cumulative probability function @ p
+func (o DistWeibull) Quantile(p float64) float64 {
+	if x, done := quantileBounds(p, o.L, math.Inf(1)); done {
+		return x
+	}
+	return o.L + o.C*math.Pow(-math.Log1p(-p), 1.0/o.A)
+}
+
+// Sample generates a random number with this distribution
+func (o DistWeibull) Sample() float64 {
+	return o.Quantile(unitRand())
+}
+
+// Mean returns the expected value
+func (o DistWeibull) Mean() float64 {
+	return o.L + o.C*math.Gamma(1.0+1.0/o.A)
+}
+
+// Variance returns the variance
+func (o DistWeibull) Variance() float64 {
+	return o.C * o.C * (math.Gamma(1.0+2.0/o.A) - math.Pow(math.Gamma(1.0+1.0/o.A), 2.0))
+}
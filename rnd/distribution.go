@@ -4,13 +4,23 @@
 
 package rnd
 
-import "github.com/cpmech/gosl/chk"
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cpmech/gosl/chk"
+)
 
 // Distribution defines a probability distribution
+//  NOTE: for discrete distributions, Pdf returns the probability mass function; i.e. the probability
+//        of x being exactly equal to an integer value (zero otherwise)
 type Distribution interface {
 	Init(prms *VarData) error
 	Pdf(x float64) float64
 	Cdf(x float64) float64
+	LogPdf(x float64) float64   // log(Pdf(x)), computed directly to avoid under/overflow
+	Quantile(p float64) float64 // inverse of Cdf: smallest x such that Cdf(x) ≥ p
+	Sample() float64            // generates a random number with this distribution
 }
 
 // factory
@@ -24,3 +34,31 @@ func GetDistrib(dtype DistType) (d Distribution, err error) {
 	}
 	return allocator(), nil
 }
+
+// unitRand returns a random number in the open interval (0,1); e.g. to be used in inverse
+// transform sampling where the values 0 and 1 may correspond to infinite quantiles
+func unitRand() (u float64) {
+	for u == 0 {
+		u = rand.Float64()
+	}
+	return
+}
+
+// quantileBounds handles the out-of-range and limit values of probabilities in Quantile functions
+//  Input:
+//   p        -- probability
+//   xlo, xhi -- lower and upper limits of the support of the distribution
+//  Output:
+//   x  -- quantile if done == true
+//   done -- p is outside (0,1) and x has been set accordingly
+func quantileBounds(p, xlo, xhi float64) (x float64, done bool) {
+	switch {
+	case p < 0 || p > 1 || math.IsNaN(p):
+		return math.NaN(), true
+	case p == 0:
+		return xlo, true
+	case p == 1:
+		return xhi, true
+	}
+	return 0, false
+}
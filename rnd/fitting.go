@@ -0,0 +1,513 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// FitMoments fits the parameters of a distribution to data using the method of moments
+//  Input:
+//   dtype -- type of distribution; the truncated distribution is not supported
+//   x     -- data (sample)
+//  Output:
+//   prms -- parameters of the fitted distribution; prms.Distr holds the initialised distribution
+//  NOTE: (1) the location (L) of the Frechet, Weibull and exponential distributions is zero
+//        (2) Student's t requires positive excess kurtosis (heavier tails than the normal)
+//        (3) the triangular distribution uses the range of the data as support and the mean
+//            to compute the mode
+//        (4) the binomial distribution requires a variance smaller than the mean
+func FitMoments(dtype DistType, x []float64) (prms *VarData, err error) {
+
+	// statistics
+	if len(x) < 2 {
+		return nil, chk.Err("at least 2 data points are required for fitting. n=%d", len(x))
+	}
+	μ, σ := StatAveDev(x, true)
+	δ2 := σ * σ / (μ * μ) // squared coefficient of variation
+
+	// parameters
+	prms = &VarData{D: dtype}
+	switch dtype {
+
+	case D_Normal, D_Lognormal, D_Gumbel, D_Gamma, D_Beta, D_Pareto:
+		prms.M, prms.S = μ, σ
+
+	case D_Frechet:
+		var u float64 // u = 1/α
+		u, err = bisect(func(u float64) float64 {
+			g1 := math.Gamma(1.0 - u)
+			return math.Gamma(1.0-2.0*u)/(g1*g1) - 1.0 - δ2
+		}, 0, 0.5-1e-12)
+		if err != nil {
+			return nil, chk.Err("cannot fit Frechet distribution:\n%v", err)
+		}
+		prms.A, prms.C = 1.0/u, μ/math.Gamma(1.0-u)
+
+	case D_Uniform:
+		prms.Min, prms.Max = μ-math.Sqrt(3.0)*σ, μ+math.Sqrt(3.0)*σ
+
+	case D_Weibull:
+		var u float64 // u = 1/k
+		u, err = bisect(func(u float64) float64 {
+			g1 := math.Gamma(1.0 + u)
+			return math.Gamma(1.0+2.0*u)/(g1*g1) - 1.0 - δ2
+		}, 0, 20)
+		if err != nil {
+			return nil, chk.Err("cannot fit Weibull distribution:\n%v", err)
+		}
+		prms.A, prms.C = 1.0/u, μ/math.Gamma(1.0+u)
+
+	case D_Exponential, D_Poisson:
+		prms.M = μ
+
+	case D_StudentT:
+		_, _, _, _, _, _, kurt, e := StatMoments(x)
+		if e != nil {
+			return nil, e
+		}
+		if kurt <= 0 {
+			return nil, chk.Err("cannot fit Student's t distribution with non-positive excess kurtosis. kurt=%g", kurt)
+		}
+		prms.Nu = 4.0 + 6.0/kurt
+		prms.L, prms.C = μ, σ*math.Sqrt((prms.Nu-2.0)/prms.Nu)
+
+	case D_ChiSquared:
+		prms.Nu = μ
+
+	case D_Triangular:
+		a, b := minMax(x)
+		prms.Min, prms.Max = a, b
+		prms.Mode = math.Max(a, math.Min(b, 3.0*μ-a-b))
+
+	case D_Binomial:
+		p := 1.0 - σ*σ/μ
+		if p <= 0 {
+			return nil, chk.Err("cannot fit binomial distribution to data with variance greater than the mean. μ=%g, σ²=%g", μ, σ*σ)
+		}
+		_, xmax := minMax(x)
+		prms.N = int(math.Max(math.Floor(μ/p+0.5), xmax))
+		prms.P = μ / float64(prms.N)
+
+	default:
+		return nil, chk.Err("cannot fit distribution %q using the method of moments", distName(dtype))
+	}
+	err = prms.initDistr()
+	return
+}
+
+// FitMle fits the parameters of a distribution to data using the maximum likelihood method
+//  Input:
+//   dtype -- type of distribution; the truncated distribution is not supported
+//   x     -- data (sample)
+//  Output:
+//   prms -- parameters of the fitted distribution; prms.Distr holds the initialised distribution
+//  NOTE: (1) the location (L) of the Frechet, Weibull and exponential distributions is zero
+//        (2) the support of the uniform and triangular distributions is the range of the data;
+//            the mode of the triangular distribution is the data point that maximises the
+//            likelihood of the points inside the support
+//        (3) the number of trials (N) of the binomial distribution is estimated by the method
+//            of moments (or set to max(x) for under-dispersed data)
+//        (4) Student's t is fitted with the ECME algorithm of Liu and Rubin (1995)
+//  Reference:
+//   Liu C and Rubin DB (1995) ML estimation of the t distribution using EM and its extensions,
+//   ECM and ECME, Statistica Sinica, 5:19-39
+func FitMle(dtype DistType, x []float64) (prms *VarData, err error) {
+
+	// check
+	n := len(x)
+	if n < 2 {
+		return nil, chk.Err("at least 2 data points are required for fitting. n=%d", n)
+	}
+	N := float64(n)
+	xave := StatAve(x)
+
+	// parameters
+	prms = &VarData{D: dtype}
+	switch dtype {
+
+	case D_Normal:
+		prms.M, prms.S = xave, math.Sqrt(sumSqDev(x, xave)/N)
+
+	case D_Lognormal:
+		var y []float64
+		y, err = logData(x, "lognormal")
+		if err != nil {
+			return
+		}
+		yave := StatAve(y)
+		v := sumSqDev(y, yave) / N
+		prms.M = math.Exp(yave + v/2.0)
+		prms.S = prms.M * math.Sqrt(math.Expm1(v))
+
+	case D_Gumbel:
+		xmin, _ := minMax(x)
+		σ := StatDev(x, true)
+		wave := func(β float64) (sw, swx float64) { // weighted averages with w = exp(-(x-xmin)/β)
+			for _, xi := range x {
+				w := math.Exp(-(xi - xmin) / β)
+				sw += w
+				swx += w * xi
+			}
+			return sw / N, swx / N
+		}
+		var β float64
+		β, err = bisect(func(β float64) float64 {
+			sw, swx := wave(β)
+			return xave - swx/sw - β
+		}, 1e-3*σ, 10.0*σ)
+		if err != nil {
+			return nil, chk.Err("cannot fit Gumbel distribution:\n%v", err)
+		}
+		sw, _ := wave(β)
+		u := xmin - β*math.Log(sw)
+		prms.M, prms.S = u+EULER*β, β*math.Pi/math.Sqrt(6.0)
+
+	case D_Frechet:
+		y := make([]float64, n) // 1/x has a Weibull distribution with shape α and scale 1/C
+		for i, xi := range x {
+			if xi <= 0 {
+				return nil, chk.Err("cannot fit Frechet distribution to non-positive data. x[%d]=%g", i, xi)
+			}
+			y[i] = 1.0 / xi
+		}
+		var k, λ float64
+		k, λ, err = weibullMle(y)
+		if err != nil {
+			return nil, chk.Err("cannot fit Frechet distribution:\n%v", err)
+		}
+		prms.A, prms.C = k, 1.0/λ
+
+	case D_Uniform:
+		prms.Min, prms.Max = minMax(x)
+
+	case D_Weibull:
+		prms.A, prms.C, err = weibullMle(x)
+		if err != nil {
+			return nil, chk.Err("cannot fit Weibull distribution:\n%v", err)
+		}
+
+	case D_Gamma:
+		var y []float64
+		y, err = logData(x, "gamma")
+		if err != nil {
+			return
+		}
+		s := math.Log(xave) - StatAve(y)
+		prms.A, err = bisect(func(k float64) float64 {
+			return math.Log(k) - fun.Digamma(k) - s
+		}, 1e-6, 1e8)
+		if err != nil {
+			return nil, chk.Err("cannot fit gamma distribution:\n%v", err)
+		}
+		prms.C = xave / prms.A
+
+	case D_Beta:
+		prms.A, prms.B, err = betaMle(x)
+		if err != nil {
+			return nil, chk.Err("cannot fit beta distribution:\n%v", err)
+		}
+
+	case D_Exponential, D_Poisson:
+		prms.M = xave
+
+	case D_StudentT:
+		prms.Nu, prms.L, prms.C, err = studentTMle(x)
+		if err != nil {
+			return nil, chk.Err("cannot fit Student's t distribution:\n%v", err)
+		}
+
+	case D_ChiSquared:
+		var y []float64
+		y, err = logData(x, "chi-squared")
+		if err != nil {
+			return
+		}
+		g := StatAve(y) - math.Ln2
+		prms.Nu, err = bisect(func(ν float64) float64 {
+			return fun.Digamma(ν/2.0) - g
+		}, 1e-6, 1e8)
+		if err != nil {
+			return nil, chk.Err("cannot fit chi-squared distribution:\n%v", err)
+		}
+
+	case D_Triangular:
+		prms.Min, prms.Mode, prms.Max = triangularMle(x)
+
+	case D_Pareto:
+		xm, _ := minMax(x)
+		if xm <= 0 {
+			return nil, chk.Err("cannot fit Pareto distribution to non-positive data. min(x)=%g", xm)
+		}
+		var sum float64
+		for _, xi := range x {
+			sum += math.Log(xi / xm)
+		}
+		prms.A, prms.C = N/sum, xm
+
+	case D_Binomial:
+		_, σ := StatAveDev(x, true)
+		_, xmax := minMax(x)
+		nt := xmax
+		if p := 1.0 - σ*σ/xave; p > 0 {
+			nt = math.Max(math.Floor(xave/p+0.5), xmax)
+		}
+		prms.N = int(nt)
+		prms.P = xave / nt
+
+	default:
+		return nil, chk.Err("cannot fit distribution %q using the maximum likelihood method", distName(dtype))
+	}
+	err = prms.initDistr()
+	return
+}
+
+// initDistr allocates and initialises the distribution in Distr
+func (o *VarData) initDistr() (err error) {
+	o.Distr, err = GetDistrib(o.D)
+	if err != nil {
+		return
+	}
+	return o.Distr.Init(o)
+}
+
+// distName returns the name of distribution or its number if unknown
+func distName(dtype DistType) string {
+	if dtype < D_Normal || dtype > D_Truncated {
+		return io.Sf("%d", dtype)
+	}
+	return GetDistrName(dtype)
+}
+
+// minMax returns the min and max values in x
+func minMax(x []float64) (xmin, xmax float64) {
+	xmin, xmax = x[0], x[0]
+	for _, xi := range x {
+		xmin = math.Min(xmin, xi)
+		xmax = math.Max(xmax, xi)
+	}
+	return
+}
+
+// sumSqDev returns Σ (x - xave)²
+func sumSqDev(x []float64, xave float64) (sum float64) {
+	for _, xi := range x {
+		sum += (xi - xave) * (xi - xave)
+	}
+	return
+}
+
+// logData returns log(x) for positive data
+func logData(x []float64, name string) (y []float64, err error) {
+	y = make([]float64, len(x))
+	for i, xi := range x {
+		if xi <= 0 {
+			return nil, chk.Err("cannot fit %s distribution to non-positive data. x[%d]=%g", name, i, xi)
+		}
+		y[i] = math.Log(xi)
+	}
+	return
+}
+
+// weibullMle computes the shape k and scale λ of the (two-parameter) Weibull distribution by
+// solving Σ xᵏ log(x) / Σ xᵏ - 1/k - ave(log(x)) = 0
+func weibullMle(x []float64) (k, λ float64, err error) {
+	y, err := logData(x, "Weibull")
+	if err != nil {
+		return
+	}
+	N := float64(len(x))
+	yave := StatAve(y)
+	_, xmax := minMax(x)
+	ymax := math.Log(xmax)
+	moment := func(k float64) (sr, sry float64) { // r = (x/xmax)ᵏ avoids overflow
+		for _, yi := range y {
+			r := math.Exp(k * (yi - ymax))
+			sr += r
+			sry += r * yi
+		}
+		return
+	}
+	k, err = bisect(func(k float64) float64 {
+		sr, sry := moment(k)
+		return sry/sr - 1.0/k - yave
+	}, 1e-3, 1e3)
+	if err != nil {
+		return
+	}
+	sr, _ := moment(k)
+	λ = xmax * math.Pow(sr/N, 1.0/k)
+	return
+}
+
+// betaMle computes the shapes α and β of the beta distribution by solving
+// ψ(α) - ψ(α+β) = ave(log(x)) and ψ(β) - ψ(α+β) = ave(log(1-x)) with Newton's method
+// starting at the method of moments estimate
+func betaMle(x []float64) (α, β float64, err error) {
+
+	// statistics
+	N := float64(len(x))
+	var g1, g2 float64
+	for i, xi := range x {
+		if xi <= 0 || xi >= 1 {
+			return 0, 0, chk.Err("data must be in (0,1). x[%d]=%g", i, xi)
+		}
+		g1 += math.Log(xi)
+		g2 += math.Log1p(-xi)
+	}
+	g1 /= N
+	g2 /= N
+
+	// initial values
+	μ, σ := StatAveDev(x, true)
+	c := μ*(1.0-μ)/(σ*σ) - 1.0
+	if c <= 0 {
+		c = 1
+	}
+	α, β = μ*c, (1.0-μ)*c
+
+	// Newton's iterations
+	for it := 0; it < 100; it++ {
+		ψs, ts := fun.Digamma(α+β), fun.Polygamma(1, α+β)
+		r1 := fun.Digamma(α) - ψs - g1
+		r2 := fun.Digamma(β) - ψs - g2
+		j11, j22 := fun.Polygamma(1, α)-ts, fun.Polygamma(1, β)-ts
+		det := j11*j22 - ts*ts
+		dα := -(j22*r1 + ts*r2) / det
+		dβ := -(ts*r1 + j11*r2) / det
+		for α+dα <= 0 || β+dβ <= 0 { // keep shapes positive
+			dα /= 2.0
+			dβ /= 2.0
+		}
+		α += dα
+		β += dβ
+		if math.Abs(dα) < 1e-13*α && math.Abs(dβ) < 1e-13*β {
+			return
+		}
+	}
+	return α, β, chk.Err("Newton's method did not converge. α=%g, β=%g", α, β)
+}
+
+// studentTMle computes the degrees of freedom ν, location and scale of Student's t distribution
+// using the ECME algorithm
+func studentTMle(x []float64) (ν, loc, scale float64, err error) {
+
+	// initial values
+	n := len(x)
+	N := float64(n)
+	loc, scale = StatAveDev(x, true)
+	ν = 10.0
+	w := make([]float64, n)
+
+	// iterations
+	for it := 0; it < 1000; it++ {
+
+		// E-step: weights
+		for i, xi := range x {
+			z := (xi - loc) / scale
+			w[i] = (ν + 1.0) / (ν + z*z)
+		}
+
+		// CM-step: location and scale
+		var sw, swx, swd float64
+		for i, xi := range x {
+			sw += w[i]
+			swx += w[i] * xi
+		}
+		locNew := swx / sw
+		for i, xi := range x {
+			swd += w[i] * (xi - locNew) * (xi - locNew)
+		}
+		scaleNew := math.Sqrt(swd / N)
+
+		// CM-step: degrees of freedom (maximising the actual likelihood)
+		lnew := func(ν float64) (sum float64) {
+			l1, _ := math.Lgamma((ν + 1.0) / 2.0)
+			l2, _ := math.Lgamma(ν / 2.0)
+			for _, xi := range x {
+				z := (xi - locNew) / scaleNew
+				sum -= (ν + 1.0) / 2.0 * math.Log1p(z*z/ν)
+			}
+			return sum + N*(l1-l2-0.5*math.Log(ν))
+		}
+		dlnew := func(ν float64) (sum float64) { // d(lnew)/dν
+			for _, xi := range x {
+				z2 := (xi - locNew) * (xi - locNew) / (scaleNew * scaleNew)
+				sum += -0.5*math.Log1p(z2/ν) + (ν+1.0)/2.0*z2/(ν*(ν+z2))
+			}
+			return sum + N*0.5*(fun.Digamma((ν+1.0)/2.0)-fun.Digamma(ν/2.0)-1.0/ν)
+		}
+		νmin, νmax := 1e-3, 1e6
+		νNew := νmax
+		if dlnew(νmax) < 0 {
+			if dlnew(νmin) <= 0 {
+				νNew = νmin
+			} else {
+				νNew, err = bisect(dlnew, νmin, νmax)
+				if err != nil {
+					return
+				}
+			}
+		}
+		if lnew(νNew) < lnew(νmax) {
+			νNew = νmax
+		}
+
+		// check convergence
+		conv := math.Abs(locNew-loc) <= 1e-12*(1.0+math.Abs(loc)) &&
+			math.Abs(scaleNew-scale) <= 1e-12*scale &&
+			math.Abs(νNew-ν) <= 1e-10*ν
+		ν, loc, scale = νNew, locNew, scaleNew
+		if conv {
+			return
+		}
+	}
+	return ν, loc, scale, chk.Err("ECME algorithm did not converge. ν=%g, loc=%g, scale=%g", ν, loc, scale)
+}
+
+// triangularMle computes the parameters of the triangular distribution. The support is the range
+// of the data and the mode is the data point maximising the likelihood of the interior points
+func triangularMle(x []float64) (a, c, b float64) {
+
+	// sorted interior points
+	a, b = minMax(x)
+	u := make([]float64, 0, len(x))
+	for _, xi := range x {
+		if xi > a && xi < b {
+			u = append(u, (xi-a)/(b-a))
+		}
+	}
+	if len(u) == 0 {
+		return a, (a + b) / 2.0, b
+	}
+	sort.Float64s(u)
+
+	// cumulated sums: sl[r] = Σ_{i<r} log(uᵢ) and sr[r] = Σ_{i>r} log(1-uᵢ)
+	m := len(u)
+	sl := make([]float64, m)
+	sr := make([]float64, m)
+	for r := 1; r < m; r++ {
+		sl[r] = sl[r-1] + math.Log(u[r-1])
+	}
+	for r := m - 2; r >= 0; r-- {
+		sr[r] = sr[r+1] + math.Log1p(-u[r+1])
+	}
+
+	// best mode
+	rbest, lbest := 0, math.Inf(-1)
+	for r := 0; r < m; r++ {
+		l := sl[r] - float64(r)*math.Log(u[r]) + sr[r] - float64(m-1-r)*math.Log1p(-u[r])
+		if l > lbest {
+			rbest, lbest = r, l
+		}
+	}
+	c = a + u[rbest]*(b-a)
+	return
+}
@@ -0,0 +1,412 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// LimitStateFunc defines the limit state function g(x); failure corresponds to g ≤ 0
+type LimitStateFunc func(x []float64) (g float64, err error)
+
+// LimitStateGrad computes the gradient of the limit state function dg/dx @ x
+type LimitStateGrad func(dgdx, x []float64) (err error)
+
+// Reliability implements the first and second order reliability methods (FORM and SORM) to
+// estimate the probability of failure Pf = P[g(x) ≤ 0]. The design point (most probable point of
+// failure) u* is the point on G(u) = g(x(u)) = 0 closest to the origin of the standard normal space
+//
+//   β = |u*|   and   Pf ≈ Φ(-β)   (FORM)
+//
+//  NOTE: (1) the derivatives of G(u) are computed with dg/dx and J = dx/du if Dgdx is given;
+//            otherwise, central finite differences in the u-space are employed
+//        (2) the sensitivity factors α = -∇G/|∇G| @ u* are such that u* = β α
+//  Reference:
+//   [1] Hasofer AM and Lind NC (1974) Exact and invariant second-moment code format, Journal of
+//       the Engineering Mechanics Division, 100(1):111-121
+//   [2] Rackwitz R and Fiessler B (1978) Structural reliability under combined random load
+//       sequences, Computers & Structures, 9(5):489-494
+//   [3] Zhang Y and Der Kiureghian A (1995) Two improved algorithms for reliability analysis,
+//       Reliability and Optimization of Structural Systems, Springer, pp 297-304
+//   [4] Breitung K (1984) Asymptotic approximations for multinormal integrals, Journal of
+//       Engineering Mechanics, 110(3):357-366
+//   [5] Hohenbichler M, Gollwitzer S, Kruse W and Rackwitz R (1987) New light on first- and
+//       second-order reliability methods, Structural Safety, 4(4):267-284
+type Reliability struct {
+
+	// input
+	Trans IsoTransform   // transformation x ↔ u
+	Gfcn  LimitStateFunc // limit state function
+	Dgdx  LimitStateGrad // gradient of limit state function [optional]
+
+	// settings
+	Improved bool    // use the improved HL-RF (iHL-RF) algorithm with line search
+	TolU     float64 // tolerance on the change of u between iterations
+	TolG     float64 // tolerance on |G(u)| / |G(u0)|
+	MaxIt    int     // max number of iterations
+	Hfd      float64 // step for finite differences in the u-space
+	Verbose  bool    // show messages
+
+	// FORM results
+	Beta  float64   // reliability index β
+	Pf    float64   // probability of failure (FORM)
+	Ustar []float64 // design point in u-space
+	Xstar []float64 // design point in x-space
+	Alpha []float64 // sensitivity factors α = -∇G/|∇G| @ u*; i.e. importance of uᵢ = α²ᵢ
+	Gamma []float64 // sensitivity factors w.r.t the original variables (= α if uncorrelated)
+	NumIt int       // number of iterations
+	NumG  int       // number of evaluations of g(x) by Form and Sorm
+
+	// SORM results
+	Kappa      []float64 // main curvatures of the limit state surface @ u*
+	PfBreitung float64   // probability of failure (SORM: Breitung)
+	PfHR       float64   // probability of failure (SORM: Hohenbichler-Rackwitz)
+
+	// auxiliary
+	n    int         // dimension
+	x    []float64   // physical variables
+	u    []float64   // standard normal variables
+	dgdx []float64   // gradient of g w.r.t x
+	J    [][]float64 // Jacobian dx/du
+}
+
+// NewReliability returns a new reliability analysis structure
+//  Input:
+//   trans -- isoprobabilistic transformation
+//   gfcn  -- limit state function
+//   dgdx  -- gradient of limit state function [may be nil]
+func NewReliability(trans IsoTransform, gfcn LimitStateFunc, dgdx LimitStateGrad) (o *Reliability) {
+	n := trans.Ndim()
+	o = &Reliability{Trans: trans, Gfcn: gfcn, Dgdx: dgdx}
+	o.TolU = 1e-8
+	o.TolG = 1e-8
+	o.MaxIt = 100
+	o.Hfd = 1e-6
+	o.n = n
+	o.x = make([]float64, n)
+	o.u = make([]float64, n)
+	o.dgdx = make([]float64, n)
+	o.J = la.MatAlloc(n, n)
+	return
+}
+
+// G computes the limit state function in the u-space
+func (o *Reliability) G(u []float64) (g float64, err error) {
+	err = o.Trans.UtoX(o.x, u)
+	if err != nil {
+		return
+	}
+	o.NumG++
+	return o.Gfcn(o.x)
+}
+
+// GradG computes the limit state function G and its gradient dGdu @ u
+func (o *Reliability) GradG(dGdu, u []float64) (g float64, err error) {
+	g, err = o.G(u)
+	if err != nil {
+		return
+	}
+	if o.Dgdx != nil {
+		err = o.Dgdx(o.dgdx, o.x)
+		if err != nil {
+			return
+		}
+		err = o.Trans.Jacobian(o.J, u)
+		if err != nil {
+			return
+		}
+		la.MatTrVecMul(dGdu, 1, o.J, o.dgdx) // dG/du = Jᵀ dg/dx
+		return
+	}
+	copy(o.u, u)
+	for i := 0; i < o.n; i++ {
+		o.u[i] = u[i] + o.Hfd
+		gp, e := o.G(o.u)
+		if e != nil {
+			return g, e
+		}
+		o.u[i] = u[i] - o.Hfd
+		gm, e := o.G(o.u)
+		if e != nil {
+			return g, e
+		}
+		o.u[i] = u[i]
+		dGdu[i] = (gp - gm) / (2.0 * o.Hfd)
+	}
+	return
+}
+
+// Form finds the design point using the HL-RF or the iHL-RF algorithm and computes β, Pf and the
+// sensitivity factors
+//  Input:
+//   u0 -- starting point in u-space; may be nil for u0 = 0 (which corresponds to the medians of
+//         the marginal distributions in x-space)
+//  NOTE: the HL-RF step is d = [(∇G·u - G) / |∇G|²] ∇G - u; the iHL-RF algorithm finds the step
+//        size λ ≤ 1 with the Armijo rule applied to the merit function m(u) = ½|u|² + c|G(u)|
+func (o *Reliability) Form(u0 []float64) (err error) {
+
+	// initial values
+	n := o.n
+	u := make([]float64, n)
+	if u0 != nil {
+		copy(u, u0)
+	}
+	o.NumG = 0
+	dGdu := make([]float64, n)
+	d := make([]float64, n)
+	unew := make([]float64, n)
+	g, err := o.GradG(dGdu, u)
+	if err != nil {
+		return
+	}
+	g0 := math.Abs(g)
+	if g0 == 0 {
+		g0 = 1
+	}
+
+	// iterations
+	var converged bool
+	for o.NumIt = 0; o.NumIt < o.MaxIt; o.NumIt++ {
+
+		// HL-RF direction
+		ng2 := la.VecDot(dGdu, dGdu)
+		if ng2 == 0 {
+			return chk.Err("gradient of the limit state function is zero @ u = %v\n", u)
+		}
+		s := (la.VecDot(dGdu, u) - g) / ng2
+		for i := 0; i < n; i++ {
+			d[i] = s*dGdu[i] - u[i]
+		}
+
+		// step size
+		λ := 1.0
+		if o.Improved {
+			c := la.VecNorm(u) / math.Sqrt(ng2) // c > |u| / |∇G| ensures that d is a descent direction
+			if math.Abs(g) > 0 {
+				c = math.Max(c, 0.5*s*s*ng2/math.Abs(g)) // |u+d|² = s² |∇G|²
+			}
+			c *= 2.0
+			m0 := 0.5*la.VecDot(u, u) + c*math.Abs(g)
+			dm := la.VecDot(u, d) - c*math.Abs(g) // directional derivative of m (upper bound)
+			for k := 0; k < 30; k++ {
+				for i := 0; i < n; i++ {
+					unew[i] = u[i] + λ*d[i]
+				}
+				gnew, e := o.G(unew)
+				if e == nil {
+					m := 0.5*la.VecDot(unew, unew) + c*math.Abs(gnew)
+					if m <= m0+0.5*λ*dm {
+						break
+					}
+				}
+				λ *= 0.5
+			}
+		}
+
+		// update
+		for i := 0; i < n; i++ {
+			unew[i] = u[i] + λ*d[i]
+		}
+		var du float64
+		for i := 0; i < n; i++ {
+			du = math.Max(du, math.Abs(unew[i]-u[i]))
+		}
+		copy(u, unew)
+		g, err = o.GradG(dGdu, u)
+		if err != nil {
+			return
+		}
+		if o.Verbose {
+			io.Pf("%4d : β = %23.15e  G = %23.15e  λ = %g\n", o.NumIt, la.VecNorm(u), g, λ)
+		}
+
+		// check convergence
+		if du < o.TolU*(1+la.VecNorm(u)) && math.Abs(g)/g0 < o.TolG {
+			converged = true
+			o.NumIt++
+			break
+		}
+	}
+	if !converged {
+		return chk.Err("FORM did not converge after %d iterations\n", o.MaxIt)
+	}
+
+	// results
+	ng := la.VecNorm(dGdu)
+	o.Ustar = u
+	o.Xstar = make([]float64, n)
+	err = o.Trans.UtoX(o.Xstar, u)
+	if err != nil {
+		return
+	}
+	o.Alpha = make([]float64, n)
+	for i := 0; i < n; i++ {
+		o.Alpha[i] = -dGdu[i] / ng
+	}
+	o.Beta = la.VecDot(o.Alpha, u) // signed: negative if the origin is in the failure domain
+	o.Pf = StdPhi(-o.Beta)
+	return o.calcGamma(u)
+}
+
+// calcGamma computes the sensitivity factors w.r.t the original variables: γ = αᵀ J⁻¹ D / |αᵀ J⁻¹ D|,
+// where D = diag(sqrt(J Jᵀ)) contains the standard deviations of the equivalent normal variables
+//  Reference: Der Kiureghian A (2005) First- and second-order reliability methods. In: Nikolaidis E,
+//             Ghiocel DM and Singhal S (eds) Engineering Design Reliability Handbook, CRC Press
+func (o *Reliability) calcGamma(u []float64) (err error) {
+	n := o.n
+	err = o.Trans.Jacobian(o.J, u)
+	if err != nil {
+		return
+	}
+	Ji := la.MatAlloc(n, n)
+	_, err = la.MatInv(Ji, o.J, 0)
+	if err != nil {
+		return chk.Err("cannot compute sensitivity factors: Jacobian is singular:\n%v", err)
+	}
+	o.Gamma = make([]float64, n)
+	for j := 0; j < n; j++ {
+		var dj float64
+		for k := 0; k < n; k++ {
+			dj += o.J[j][k] * o.J[j][k]
+		}
+		for i := 0; i < n; i++ {
+			o.Gamma[j] += o.Alpha[i] * Ji[i][j]
+		}
+		o.Gamma[j] *= math.Sqrt(dj)
+	}
+	nγ := la.VecNorm(o.Gamma)
+	for j := 0; j < n; j++ {
+		o.Gamma[j] /= nγ
+	}
+	return
+}
+
+// Sorm computes the main curvatures of the limit state surface at the design point and the
+// probability of failure with the Breitung and Hohenbichler-Rackwitz formulae
+//
+//   Breitung: Pf ≈ Φ(-β) Π (1 + β κᵢ)^(-½)
+//   Hohenbichler-Rackwitz: Pf ≈ Φ(-β) Π (1 + ψ κᵢ)^(-½)   with   ψ = φ(β) / Φ(-β)
+//
+//  NOTE: (1) Form must be called first
+//        (2) the Hessian of G is computed with finite differences of the gradient
+//        (3) positive curvatures correspond to a limit state surface curving away from the origin
+func (o *Reliability) Sorm() (err error) {
+
+	// check
+	if o.Ustar == nil {
+		return chk.Err("Form must be called before Sorm\n")
+	}
+	n := o.n
+	o.Kappa = make([]float64, n-1)
+	o.PfBreitung, o.PfHR = o.Pf, o.Pf
+	if n == 1 {
+		return
+	}
+
+	// Hessian of G @ u*
+	H := la.MatAlloc(n, n)
+	gp, gm := make([]float64, n), make([]float64, n)
+	up := make([]float64, n)
+	copy(up, o.Ustar)
+	h := math.Max(1e-4, math.Sqrt(o.Hfd))
+	for j := 0; j < n; j++ {
+		up[j] = o.Ustar[j] + h
+		_, err = o.GradG(gp, up)
+		if err != nil {
+			return
+		}
+		up[j] = o.Ustar[j] - h
+		_, err = o.GradG(gm, up)
+		if err != nil {
+			return
+		}
+		up[j] = o.Ustar[j]
+		for i := 0; i < n; i++ {
+			H[i][j] = (gp[i] - gm[i]) / (2.0 * h)
+		}
+	}
+	dGdu := make([]float64, n)
+	_, err = o.GradG(dGdu, o.Ustar)
+	if err != nil {
+		return
+	}
+	ng := la.VecNorm(dGdu)
+
+	// rotation matrix with α as the last row (Gram-Schmidt)
+	R := o.rotation()
+
+	// A = R H Rᵀ / |∇G| restricted to the first n-1 rows and columns
+	A := la.MatAlloc(n-1, n-1)
+	for i := 0; i < n-1; i++ {
+		for j := 0; j < n-1; j++ {
+			for k := 0; k < n; k++ {
+				for l := 0; l < n; l++ {
+					A[i][j] += R[i][k] * (H[k][l] + H[l][k]) / 2.0 * R[j][l]
+				}
+			}
+			A[i][j] /= ng
+		}
+	}
+
+	// main curvatures
+	Q := la.MatAlloc(n-1, n-1)
+	_, err = la.Jacobi(Q, o.Kappa, A)
+	if err != nil {
+		return chk.Err("cannot compute main curvatures:\n%v", err)
+	}
+
+	// probabilities
+	ψ := Stdphi(o.Beta) / StdPhi(-o.Beta)
+	for _, κ := range o.Kappa {
+		if 1+o.Beta*κ <= 0 || 1+ψ*κ <= 0 {
+			return chk.Err("SORM approximation is not valid: 1 + β κ ≤ 0 with κ = %g and β = %g\n", κ, o.Beta)
+		}
+		o.PfBreitung /= math.Sqrt(1 + o.Beta*κ)
+		o.PfHR /= math.Sqrt(1 + ψ*κ)
+	}
+	return
+}
+
+// rotation computes an orthonormal matrix R whose last row is α
+func (o *Reliability) rotation() (R [][]float64) {
+	n := o.n
+	R = la.MatAlloc(n, n)
+	copy(R[n-1], o.Alpha)
+
+	// start with the identity matrix and skip the row most aligned with α
+	imax := 0
+	for i := 1; i < n; i++ {
+		if math.Abs(o.Alpha[i]) > math.Abs(o.Alpha[imax]) {
+			imax = i
+		}
+	}
+	row := 0
+	for k := 0; k < n; k++ {
+		if k == imax {
+			continue
+		}
+		R[row][k] = 1
+		row++
+	}
+
+	// Gram-Schmidt starting from the last row
+	for i := n - 2; i >= 0; i-- {
+		for j := n - 1; j > i; j-- {
+			c := la.VecDot(R[i], R[j])
+			for k := 0; k < n; k++ {
+				R[i][k] -= c * R[j][k]
+			}
+		}
+		nr := la.VecNorm(R[i])
+		for k := 0; k < n; k++ {
+			R[i][k] /= nr
+		}
+	}
+	return
+}
@@ -0,0 +1,253 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// SimResults holds the results of simulation methods to estimate the probability of failure
+//  NOTE: the evaluations of g(x) during simulations are not added to Reliability.NumG
+type SimResults struct {
+	Method string  // name of method
+	Pf     float64 // probability of failure
+	Cov    float64 // coefficient of variation of the estimate of Pf
+	Beta   float64 // generalised reliability index β = -Φ⁻¹(Pf)
+	NumG   int     // number of evaluations of g(x)
+}
+
+// newSimResults returns a new structure and computes β
+func newSimResults(method string, pf, cov float64, ng int) *SimResults {
+	return &SimResults{Method: method, Pf: pf, Cov: cov, Beta: -stdQuantile(pf), NumG: ng}
+}
+
+// MonteCarlo estimates the probability of failure by crude Monte Carlo simulation in the u-space
+//  Input:
+//   nmax      -- max number of samples
+//   covTarget -- stop when the coefficient of variation of Pf is smaller than this value
+//                (checked every 1000 samples); use 0 to draw all nmax samples
+func (o *Reliability) MonteCarlo(nmax int, covTarget float64) (res *SimResults, err error) {
+	ng0 := o.NumG
+	u := make([]float64, o.n)
+	var nf int
+	var pf, cov float64
+	for k := 1; k <= nmax; k++ {
+		for i := 0; i < o.n; i++ {
+			u[i] = Normal(0, 1)
+		}
+		g, err := o.G(u)
+		if err != nil {
+			return nil, err
+		}
+		if g <= 0 {
+			nf++
+		}
+		if k%1000 == 0 || k == nmax {
+			pf = float64(nf) / float64(k)
+			cov = math.Inf(1)
+			if nf > 0 {
+				cov = math.Sqrt((1 - pf) / (float64(k) * pf))
+			}
+			if cov < covTarget {
+				break
+			}
+		}
+	}
+	ng := o.NumG - ng0
+	o.NumG = ng0
+	return newSimResults("Monte Carlo", pf, cov, ng), nil
+}
+
+// ImportanceSampling estimates the probability of failure by sampling around the design point
+// with the density h(v) = φₙ(v - u*); thus, each sample has the weight φₙ(v) / h(v) = exp(½|u*|² - v·u*)
+//  NOTE: Form must be called first
+//  Input:
+//   n -- number of samples
+func (o *Reliability) ImportanceSampling(n int) (res *SimResults, err error) {
+	if o.Ustar == nil {
+		return nil, chk.Err("Form must be called before ImportanceSampling\n")
+	}
+	ng0 := o.NumG
+	v := make([]float64, o.n)
+	b2 := 0.0
+	for _, us := range o.Ustar {
+		b2 += us * us
+	}
+	var sum, sum2 float64
+	for k := 0; k < n; k++ {
+		var vu float64
+		for i := 0; i < o.n; i++ {
+			v[i] = o.Ustar[i] + Normal(0, 1)
+			vu += v[i] * o.Ustar[i]
+		}
+		g, err := o.G(v)
+		if err != nil {
+			return nil, err
+		}
+		if g <= 0 {
+			w := math.Exp(0.5*b2 - vu)
+			sum += w
+			sum2 += w * w
+		}
+	}
+	N := float64(n)
+	pf := sum / N
+	cov := math.Inf(1)
+	if pf > 0 {
+		cov = math.Sqrt((sum2/N-pf*pf)/N) / pf
+	}
+	ng := o.NumG - ng0
+	o.NumG = ng0
+	return newSimResults("Importance sampling", pf, cov, ng), nil
+}
+
+// SubsetSimulation estimates (small) probabilities of failure as a product of larger conditional
+// probabilities P[G ≤ bⱼ | G ≤ bⱼ₋₁], where the intermediate thresholds bⱼ are chosen such that each
+// conditional probability is equal to p0. The samples at each level are generated by Markov chains
+// (modified Metropolis algorithm) starting from the failure samples of the previous level
+//  Input:
+//   n         -- number of samples per level; n p0 and 1/p0 must be integers
+//   p0        -- conditional probability of intermediate levels; e.g. 0.1
+//   maxLevels -- max number of levels
+//  Reference:
+//   Au SK and Beck JL (2001) Estimation of small failure probabilities in high dimensions by
+//   subset simulation, Probabilistic Engineering Mechanics, 16(4):263-277
+func (o *Reliability) SubsetSimulation(n int, p0 float64, maxLevels int) (res *SimResults, err error) {
+
+	// check
+	nc := int(p0*float64(n) + 0.5) // number of chains
+	if p0 <= 0 || p0 >= 1 || nc < 1 || n%nc != 0 {
+		return nil, chk.Err("n p0 and 1/p0 must be integers. n=%d, p0=%g\n", n, p0)
+	}
+	ns := n / nc // number of states per chain
+
+	// level 0: Monte Carlo
+	ng0 := o.NumG
+	U := make([][]float64, n)
+	G := make([]float64, n)
+	for k := 0; k < n; k++ {
+		U[k] = make([]float64, o.n)
+		for i := 0; i < o.n; i++ {
+			U[k][i] = Normal(0, 1)
+		}
+		G[k], err = o.G(U[k])
+		if err != nil {
+			return
+		}
+	}
+
+	// levels
+	idx := make([]int, n)
+	Unew := make([][]float64, n)
+	for k := 0; k < n; k++ {
+		Unew[k] = make([]float64, o.n)
+	}
+	Gnew := make([]float64, n)
+	cand := make([]float64, o.n)
+	prob, cov2 := 1.0, 0.0
+	chains := false // samples are organised in nc chains of length ns
+	for level := 0; level < maxLevels; level++ {
+
+		// sort samples by G
+		for k := 0; k < n; k++ {
+			idx[k] = k
+		}
+		sort.Slice(idx, func(a, b int) bool { return G[idx[a]] < G[idx[b]] })
+
+		// threshold
+		b := (G[idx[nc-1]] + G[idx[nc]]) / 2.0
+		final := b <= 0
+		if final {
+			b = 0
+		}
+		var nf int
+		for k := 0; k < n; k++ {
+			if G[k] <= b {
+				nf++
+			}
+		}
+		pj := float64(nf) / float64(n)
+		δ2 := (1 - pj) / (float64(n) * pj)
+		if chains {
+			δ2 *= 1 + subsetGamma(G, b, nc, ns, pj)
+		}
+		cov2 += δ2
+		prob *= pj
+		if o.Verbose {
+			io.Pf("level %d: b = %g  P = %g\n", level, b, pj)
+		}
+		if final {
+			ng := o.NumG - ng0
+			o.NumG = ng0
+			return newSimResults("Subset simulation", prob, math.Sqrt(cov2), ng), nil
+		}
+
+		// Markov chains starting at the nc seeds with G ≤ b
+		for c := 0; c < nc; c++ {
+			k := c * ns
+			copy(Unew[k], U[idx[c]])
+			Gnew[k] = G[idx[c]]
+			for s := 1; s < ns; s++ {
+				k = c*ns + s
+				moved := false
+				for i := 0; i < o.n; i++ {
+					cand[i] = Unew[k-1][i]
+					ξ := cand[i] + 2.0*rand.Float64() - 1.0 // uniform proposal with unit half-width
+					if rand.Float64() < math.Exp((cand[i]*cand[i]-ξ*ξ)/2.0) {
+						cand[i] = ξ
+						moved = true
+					}
+				}
+				copy(Unew[k], Unew[k-1])
+				Gnew[k] = Gnew[k-1]
+				if moved {
+					g, err := o.G(cand)
+					if err != nil {
+						return nil, err
+					}
+					if g <= b {
+						copy(Unew[k], cand)
+						Gnew[k] = g
+					}
+				}
+			}
+		}
+		U, Unew = Unew, U
+		G, Gnew = Gnew, G
+		chains = true
+	}
+	return nil, chk.Err("subset simulation did not reach the failure domain after %d levels\n", maxLevels)
+}
+
+// subsetGamma computes the factor γ accounting for the correlation between states in Markov chains
+//
+//   γ = 2 Σ (1 - k/ns) ρ(k)   with   k = 1...ns-1
+//
+// where ρ(k) is the correlation coefficient between the indicators I(G ≤ b) of states k steps apart
+func subsetGamma(G []float64, b float64, nc, ns int, p float64) (γ float64) {
+	n := nc * ns
+	R0 := p * (1 - p)
+	if R0 <= 0 {
+		return 0
+	}
+	for k := 1; k < ns; k++ {
+		var sum float64
+		for c := 0; c < nc; c++ {
+			for s := 0; s < ns-k; s++ {
+				if G[c*ns+s] <= b && G[c*ns+s+k] <= b {
+					sum++
+				}
+			}
+		}
+		Rk := sum/float64(n-k*nc) - p*p
+		γ += 2.0 * (1.0 - float64(k)/float64(ns)) * Rk / R0
+	}
+	return
+}
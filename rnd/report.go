@@ -63,7 +63,9 @@ name & var & $\mu$ & $\sigma$ & D$^{\star}$ & min & max \\ \hline
 	io.Ff(buf, `
 \multicolumn{7}{p{7cm}}{
 	\scriptsize
-	$^{\star}$N:Normal, L:Lognormal, G:Gumbel, F:Frechet, U:Uniform
+	$^{\star}$N:Normal, L:Lognormal, G:Gumbel, F:Frechet, U:Uniform, W:Weibull, Ga:Gamma,
+	Be:Beta, E:Exponential, T:Student-t, X:Chi-squared, Tr:Triangular, P:Pareto, Po:Poisson,
+	Bi:Binomial, Tc:Truncated
 } \\
 
 \bottomrule
@@ -72,6 +74,85 @@ name & var & $\mu$ & $\sigma$ & D$^{\star}$ & min & max \\ \hline
 \end{table}
 `, fnkey)
 
+	// write table
+	writeReport(dirout, fnkey, "Random Variables", buf, genPDF)
+}
+
+// ReportReliability generates TeX report with the results of a reliability analysis
+//  Input:
+//   vars -- random variables; may be nil if the transformation is not Nataf
+//   rel  -- reliability analysis after Form (and optionally Sorm)
+//   sims -- results of simulations [may be nil]
+func ReportReliability(dirout, fnkey string, vars Variables, rel *Reliability, sims []*SimResults, genPDF bool) {
+
+	// design point and sensitivity factors
+	buf := new(bytes.Buffer)
+	io.Ff(buf, `
+\begin{table} \centering
+\caption{Design point and sensitivity factors.}
+
+\scriptsize
+
+\begin{tabular}[c]{cccccccc} \toprule
+var & D & $\mu$ & $\sigma$ & $x^*$ & $u^*$ & $\alpha$ & $\gamma$ \\ \hline
+`)
+	for i := range rel.Ustar {
+		txtD, txtM, txtS := "-", "-", "-"
+		if i < len(vars) {
+			txtD = GetDistrKey(vars[i].D)
+			if vars[i].D != D_Uniform {
+				txtM = "$" + io.TexNum("", vars[i].M, true) + "$"
+				txtS = "$" + io.TexNum("", vars[i].S, true) + "$"
+			}
+		}
+		io.Ff(buf, `$x_{%d}$ & %s & %s & %s & $%s$ & $%s$ & $%s$ & $%s$ \\`, i, txtD, txtM, txtS,
+			io.TexNum("%.4f", rel.Xstar[i], true), io.TexNum("%.4f", rel.Ustar[i], true),
+			io.TexNum("%.4f", rel.Alpha[i], true), io.TexNum("%.4f", rel.Gamma[i], true))
+		io.Ff(buf, "\n")
+	}
+	io.Ff(buf, `
+\bottomrule
+\end{tabular}
+\label{tab:dp%s}
+\end{table}
+`, fnkey)
+
+	// probabilities of failure
+	io.Ff(buf, `
+\begin{table} \centering
+\caption{Reliability index and probability of failure.}
+
+\scriptsize
+
+\begin{tabular}[c]{ccccc} \toprule
+method & $\beta$ & $P_f$ & c.o.v & $n_g$ \\ \hline
+`)
+	io.Ff(buf, `FORM & $%s$ & $%s$ & - & %d \\`, io.TexNum("%.4f", rel.Beta, true), io.TexNum("%.4e", rel.Pf, true), rel.NumG)
+	io.Ff(buf, "\n")
+	if rel.Kappa != nil {
+		io.Ff(buf, `SORM (Breitung) & $%s$ & $%s$ & - & - \\`, io.TexNum("%.4f", -stdQuantile(rel.PfBreitung), true), io.TexNum("%.4e", rel.PfBreitung, true))
+		io.Ff(buf, "\n")
+		io.Ff(buf, `SORM (HR) & $%s$ & $%s$ & - & - \\`, io.TexNum("%.4f", -stdQuantile(rel.PfHR), true), io.TexNum("%.4e", rel.PfHR, true))
+		io.Ff(buf, "\n")
+	}
+	for _, s := range sims {
+		io.Ff(buf, `%s & $%s$ & $%s$ & $%s$ & %d \\`, s.Method, io.TexNum("%.4f", s.Beta, true), io.TexNum("%.4e", s.Pf, true), io.TexNum("%.4f", s.Cov, true), s.NumG)
+		io.Ff(buf, "\n")
+	}
+	io.Ff(buf, `
+\bottomrule
+\end{tabular}
+\label{tab:pf%s}
+\end{table}
+`, fnkey)
+
+	// write tables
+	writeReport(dirout, fnkey, "Reliability Analysis", buf, genPDF)
+}
+
+// writeReport writes TeX file and generates PDF if requested
+func writeReport(dirout, fnkey, title string, buf *bytes.Buffer, genPDF bool) {
+
 	// write table
 	tex := fnkey + ".tex"
 	io.WriteFileVD(dirout, tex, buf)
@@ -89,10 +170,10 @@ name & var & $\mu$ & $\sigma$ & D$^{\star}$ & min & max \\ \hline
 
 \usepackage[margin=1.5cm,footskip=0.5cm]{geometry}
 
-\title{Gosl-rnd Report: Random Variables}
+\title{Gosl-rnd Report: %s}
 \author{The Author}
 
-\begin{document}`)
+\begin{document}`, title)
 
 		// footer
 		footer := new(bytes.Buffer)
@@ -0,0 +1,96 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// SobolPoints generates points of the Sobol low-discrepancy sequence in [0, 1)
+//   x -- [dim][n] points
+//  NOTE: (1) the first point is the origin
+//        (2) the maximum dimension is 21; see SobolMaxDim
+//        (3) the Gray code ordering and the direction numbers of [1] are used
+//   Reference:
+//   [1] Joe S, Kuo FY (2008) Constructing Sobol sequences with better two-dimensional projections.
+//       SIAM Journal on Scientific Computing, 30(5):2635-2654.
+func SobolPoints(dim, n int) (x [][]float64) {
+	if dim > SobolMaxDim {
+		chk.Panic("SobolPoints can only handle maximum dimension = %d", SobolMaxDim)
+	}
+	x = utl.Alloc(dim, n)
+	const nbits = 32
+	const scale = 1.0 / (1 << nbits)
+	v := make([]uint64, nbits+1)
+	for j := 0; j < dim; j++ {
+
+		// direction numbers
+		if j == 0 {
+			for k := 1; k <= nbits; k++ {
+				v[k] = 1 << uint(nbits-k)
+			}
+		} else {
+			s, a, m := _sobol_s[j-1], _sobol_a[j-1], _sobol_m[j-1]
+			for k := 1; k <= nbits; k++ {
+				if k <= s {
+					v[k] = uint64(m[k-1]) << uint(nbits-k)
+					continue
+				}
+				v[k] = v[k-s] ^ (v[k-s] >> uint(s))
+				for l := 1; l < s; l++ {
+					if (a>>uint(s-1-l))&1 == 1 {
+						v[k] ^= v[k-l]
+					}
+				}
+			}
+		}
+
+		// points (Gray code)
+		var y uint64
+		for i := 1; i < n; i++ {
+			c, b := 1, i-1
+			for b&1 == 1 {
+				b >>= 1
+				c++
+			}
+			y ^= v[c]
+			x[j][i] = float64(y) * scale
+		}
+	}
+	return
+}
+
+// SobolMaxDim is the maximum dimension of SobolPoints
+const SobolMaxDim = 21
+
+// degrees (s), coefficients (a) of primitive polynomials and initial direction numbers (m) of
+// dimensions 2 to 21 from [1] (file new-joe-kuo-6.21201)
+var (
+	_sobol_s = []int{1, 2, 3, 3, 4, 4, 5, 5, 5, 5, 5, 5, 6, 6, 6, 6, 6, 6, 7, 7}
+	_sobol_a = []int{0, 1, 1, 2, 1, 4, 2, 4, 7, 11, 13, 14, 1, 13, 16, 19, 22, 25, 1, 4}
+	_sobol_m = [][]int{
+		{1},
+		{1, 3},
+		{1, 3, 1},
+		{1, 1, 1},
+		{1, 1, 3, 3},
+		{1, 3, 5, 13},
+		{1, 1, 5, 5, 17},
+		{1, 1, 5, 5, 5},
+		{1, 1, 7, 11, 19},
+		{1, 1, 5, 1, 1},
+		{1, 1, 1, 3, 11},
+		{1, 3, 5, 5, 31},
+		{1, 3, 3, 9, 7, 49},
+		{1, 1, 1, 15, 21, 21},
+		{1, 3, 1, 13, 27, 49},
+		{1, 1, 1, 15, 7, 5},
+		{1, 3, 1, 15, 13, 25},
+		{1, 1, 5, 5, 19, 61},
+		{1, 3, 7, 11, 23, 15, 103},
+		{1, 3, 7, 13, 13, 15, 69},
+	}
+)
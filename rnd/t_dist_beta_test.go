@@ -0,0 +1,51 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_beta_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_beta_01")
+
+	var dist DistBeta
+	p := &VarData{A: 2, B: 3}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 0.4)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 0.2)
+
+	// f(x) = 12 x (1-x)²  and  F(x) = 6x² - 8x³ + 3x⁴
+	X := []float64{0.1, 0.4, 0.7, 0.95}
+	Ypdf := []float64{0.972, 1.728, 0.756, 0.0285}
+	Ycdf := []float64{0.0523, 0.5248, 0.9163, 0.99951875}
+	for i, x := range X {
+		chk.Scalar(tst, "pdf", 1e-14, dist.Pdf(x), Ypdf[i])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[i])
+	}
+	chk.Scalar(tst, "pdf(1.1)", 1e-15, dist.Pdf(1.1), 0)
+	chk.Scalar(tst, "cdf(1.1)", 1e-15, dist.Cdf(1.1), 1)
+	checkDistrib(tst, &dist, []float64{0.05, 0.3, 0.5, 0.8, 0.99}, p.M, p.S, 1e-13, 1e-12, false)
+
+	// from mean and deviation
+	err = dist.Init(&VarData{M: 0.4, S: 0.2})
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "α", 1e-14, dist.Alp, 2)
+	chk.Scalar(tst, "β", 1e-14, dist.Bet, 3)
+	if dist.Init(&VarData{M: 0.5, S: 0.6}) == nil {
+		tst.Errorf("Init should have failed with too large variance\n")
+	}
+}
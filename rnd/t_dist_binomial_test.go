@@ -0,0 +1,48 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_binomial_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_binomial_01")
+
+	var dist DistBinomial
+	p := &VarData{N: 10, P: 0.3}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 3)
+	chk.Scalar(tst, "σ", 1e-15, p.S, math.Sqrt(2.1))
+
+	// P(X = k) and P(X ≤ k) for k = 0, 1, ..., 10
+	Ypmf := []float64{0.0282475249, 0.121060821, 0.2334744405, 0.266827932, 0.200120949, 0.1029193452,
+		0.036756909, 0.009001692, 0.0014467005, 0.000137781, 0.0000059049}
+	Ycdf := []float64{0.0282475249, 0.1493083459, 0.3827827864, 0.6496107184, 0.8497316674, 0.9526510126,
+		0.9894079216, 0.9984096136, 0.9998563141, 0.9999940951, 1}
+	for k := 0; k <= 10; k++ {
+		x := float64(k)
+		chk.Scalar(tst, "pmf", 1e-15, dist.Pdf(x), Ypmf[k])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[k])
+	}
+	chk.Scalar(tst, "pmf(11)", 1e-15, dist.Pdf(11), 0)
+	chk.Scalar(tst, "Q(1)", 1e-15, dist.Quantile(1), 10)
+	checkDistrib(tst, &dist, []float64{-1, 0, 1, 3, 6, 9, 10}, p.M, p.S, 0, 1e-15, true)
+
+	// degenerate case
+	dist.Init(&VarData{N: 5, P: 1})
+	chk.Scalar(tst, "pmf(5)", 1e-15, dist.Pdf(5), 1)
+	chk.Scalar(tst, "pmf(4)", 1e-15, dist.Pdf(4), 0)
+	chk.Scalar(tst, "Q(0.5)", 1e-15, dist.Quantile(0.5), 5)
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_chisquared_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_chisquared_01")
+
+	// ν = 1
+	var dist DistChiSquared
+	p := &VarData{Nu: 1}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 1)
+	chk.Scalar(tst, "σ", 1e-15, p.S, math.Sqrt2)
+	X := []float64{0.5, 1, 3, 20}
+	Ypdf := []float64{0.43939128946772243, 0.24197072451914337, 0.0513934432679231, 4.049955478044559e-06}
+	Ycdf := []float64{0.5204998778130465, 0.682689492137086, 0.9167354833364496, 0.999992255783569}
+	for i, x := range X {
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), Ypdf[i])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[i])
+	}
+	chk.Scalar(tst, "Q(0.95)", 1e-13, dist.Quantile(0.95), 3.841458820694124)
+
+	// ν = 2
+	dist.Init(&VarData{Nu: 2})
+	for _, x := range []float64{0.1, 1, 5, 30} {
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), 1-math.Exp(-x/2))
+	}
+	for _, P := range []float64{0.001, 0.5, 0.9} {
+		chk.Scalar(tst, "Q", 1e-13, dist.Quantile(P), -2*math.Log1p(-P))
+	}
+
+	// ν = 4
+	p = &VarData{Nu: 4}
+	dist.Init(p)
+	checkDistrib(tst, &dist, []float64{0.2, 1, 4, 10, 20}, p.M, p.S, 1e-13, 1e-10, false)
+}
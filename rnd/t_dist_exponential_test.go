@@ -0,0 +1,41 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_exponential_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_exponential_01")
+
+	var dist DistExponential
+	p := &VarData{L: 1, M: 3}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "λ", 1e-15, dist.Lam, 0.5)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 2)
+	for _, x := range []float64{0, 1, 2, 5} {
+		pdf, cdf := 0.0, 0.0
+		if x >= 1 {
+			pdf, cdf = 0.5*math.Exp(-0.5*(x-1)), 1-math.Exp(-0.5*(x-1))
+		}
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), pdf)
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), cdf)
+	}
+	chk.Scalar(tst, "median", 1e-15, dist.Quantile(0.5), 1+2*math.Ln2)
+	checkDistrib(tst, &dist, []float64{1.1, 2, 4, 10}, 3, 2, 1e-14, 1e-11, false)
+	if dist.Init(&VarData{L: 1, M: 0.5}) == nil {
+		tst.Errorf("Init should have failed with M < L\n")
+	}
+}
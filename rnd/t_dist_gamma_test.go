@@ -0,0 +1,54 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_gamma_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_gamma_01")
+
+	// from shape and scale
+	var dist DistGamma
+	p := &VarData{A: 3, C: 2}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 6)
+	chk.Scalar(tst, "σ", 1e-15, p.S, math.Sqrt(12))
+
+	X := []float64{1, 3, 6, 10}
+	Ypdf := []float64{0.03790816623203959, 0.12551071508349176, 0.11202090382769388, 0.04211216874428417}
+	Ycdf := []float64{0.014387677966970713, 0.19115316946194194, 0.5768099188731565, 0.8753479805169189}
+	for i, x := range X {
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), Ypdf[i])
+		chk.Scalar(tst, "cdf", 1e-14, dist.Cdf(x), Ycdf[i])
+	}
+	checkDistrib(tst, &dist, []float64{0.5, 2, 6, 10, 20}, p.M, p.S, 1e-13, 1e-10, false)
+
+	// from mean and deviation
+	err = dist.Init(&VarData{M: 6, S: math.Sqrt(12)})
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "k", 1e-14, dist.K, 3)
+	chk.Scalar(tst, "θ", 1e-15, dist.Th, 2)
+
+	// shape < 1
+	dist.Init(&VarData{A: 0.5, C: 1})
+	if !math.IsInf(dist.LogPdf(0), 1) {
+		tst.Errorf("LogPdf(0) should be +Inf\n")
+	}
+	checkDistrib(tst, &dist, []float64{0.01, 0.1, 1, 3}, 0.5, math.Sqrt(0.5), 1e-12, 1e-9, false)
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_pareto_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_pareto_01")
+
+	var dist DistPareto
+	p := &VarData{A: 5, C: 2}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 2.5)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 0.5*math.Sqrt(5.0/3.0))
+	for _, x := range []float64{1, 2, 3, 10} {
+		pdf, cdf := 0.0, 0.0
+		if x >= 2 {
+			pdf, cdf = 5*math.Pow(2, 5)/math.Pow(x, 6), 1-math.Pow(2/x, 5)
+		}
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), pdf)
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), cdf)
+	}
+	checkDistrib(tst, &dist, []float64{2.01, 2.5, 3, 6}, p.M, p.S, 1e-14, 1e-10, false)
+
+	// from mean and deviation
+	err = dist.Init(&VarData{M: p.M, S: p.S})
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "α", 1e-14, dist.Alp, 5)
+	chk.Scalar(tst, "xm", 1e-14, dist.Xm, 2)
+
+	// infinite moments
+	p = &VarData{A: 1, C: 1}
+	dist.Init(p)
+	if !math.IsInf(p.M, 1) || !math.IsInf(p.S, 1) {
+		tst.Errorf("mean and deviation should be +Inf for α = 1\n")
+	}
+}
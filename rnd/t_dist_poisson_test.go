@@ -0,0 +1,49 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_poisson_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_poisson_01")
+
+	var dist DistPoisson
+	p := &VarData{M: 3.5}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "σ", 1e-15, p.S, math.Sqrt(3.5))
+
+	// P(X = k) and P(X ≤ k) for k = 0, 1, ..., 8
+	Ypmf := []float64{0.0301973834223185, 0.10569084197811476, 0.1849589734617009, 0.2157854690386509,
+		0.18881228540881978, 0.13216859978617365, 0.07709834987526794, 0.038549174937634004, 0.016865264035214902}
+	Ycdf := []float64{0.0301973834223185, 0.13588822540043327, 0.3208471988621342, 0.5366326679007851,
+		0.7254449533096049, 0.8576135530957786, 0.9347119029710466, 0.9732610779086805, 0.9901263419438955}
+	for k := 0; k < len(Ypmf); k++ {
+		x := float64(k)
+		chk.Scalar(tst, "pmf", 1e-15, dist.Pdf(x), Ypmf[k])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[k])
+		chk.Scalar(tst, "cdf(x+½)", 1e-15, dist.Cdf(x+0.5), Ycdf[k])
+		chk.Scalar(tst, "pmf(x+½)", 1e-15, dist.Pdf(x+0.5), 0)
+	}
+	chk.Scalar(tst, "cdf(-1)", 1e-15, dist.Cdf(-1), 0)
+	chk.Scalar(tst, "Q(0)", 1e-15, dist.Quantile(0), 0)
+	chk.Scalar(tst, "Q(0.5)", 1e-15, dist.Quantile(0.5), 3)
+	checkDistrib(tst, &dist, []float64{-1, 0, 2, 3.5, 7, 15}, 3.5, p.S, 0, 1e-15, true)
+
+	// large rate
+	p = &VarData{M: 400}
+	dist.Init(p)
+	checkDistrib(tst, &dist, []float64{300, 350, 400, 410, 500}, 400, 20, 0, 1e-13, true)
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_dist_studentt_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_studentt_01. ν = 1 (Cauchy) and ν = 2")
+
+	// ν = 1: Cauchy distribution
+	var dist DistStudentT
+	p := &VarData{Nu: 1}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	if !math.IsNaN(p.M) || !math.IsNaN(p.S) {
+		tst.Errorf("mean and deviation should be NaN for ν = 1\n")
+	}
+	for _, x := range []float64{-30, -2, -0.1, 0, 0.5, 3, 100} {
+		chk.Scalar(tst, io.Sf("pdf(%g)", x), 1e-15, dist.Pdf(x), 1/(math.Pi*(1+x*x)))
+		chk.Scalar(tst, io.Sf("cdf(%g)", x), 1e-15, dist.Cdf(x), 0.5+math.Atan(x)/math.Pi)
+	}
+	for _, P := range []float64{1e-8, 0.01, 0.2, 0.3, 0.5, 0.7, 0.99} {
+		q := -1 / math.Tan(math.Pi*P) // = tan(π(P-½))
+		chk.AnaNum(tst, io.Sf("Q(%g)", P), 1e-14*(1+math.Abs(q)), dist.Quantile(P), q, chk.Verbose)
+	}
+
+	// ν = 2
+	dist.Init(&VarData{Nu: 2})
+	for _, x := range []float64{-30, -2, -0.1, 0, 0.5, 3, 100} {
+		chk.Scalar(tst, io.Sf("pdf(%g)", x), 1e-15, dist.Pdf(x), math.Pow(2+x*x, -1.5))
+		chk.Scalar(tst, io.Sf("cdf(%g)", x), 1e-15, dist.Cdf(x), 0.5+x/(2*math.Sqrt(2+x*x)))
+	}
+	for _, P := range []float64{1e-8, 0.01, 0.2, 0.3, 0.5, 0.7, 0.99} {
+		q := (2*P - 1) / math.Sqrt(2*P*(1-P))
+		chk.AnaNum(tst, io.Sf("Q(%g)", P), 1e-14*(1+math.Abs(q)), dist.Quantile(P), q, chk.Verbose)
+	}
+}
+
+func Test_dist_studentt_02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_studentt_02. location and scale")
+
+	var dist DistStudentT
+	p := &VarData{Nu: 5, L: 1, C: 2}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 1)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 2*math.Sqrt(5.0/3.0))
+	checkDistrib(tst, &dist, []float64{-8, -2, 0, 1, 1.5, 4, 12}, p.M, p.S, 1e-13, 1e-10, false)
+
+	// large ν ⇒ normal
+	var normal DistNormal
+	normal.Init(&VarData{M: 1, S: 2})
+	dist.Init(&VarData{Nu: 1e8, L: 1, C: 2})
+	for _, x := range []float64{-3, 0, 1, 2, 5} {
+		chk.Scalar(tst, io.Sf("cdf(%g)", x), 1e-8, dist.Cdf(x), normal.Cdf(x))
+	}
+}
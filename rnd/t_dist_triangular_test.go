@@ -0,0 +1,52 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_triangular_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_triangular_01")
+
+	var dist DistTriangular
+	p := &VarData{Min: 1, Mode: 2, Max: 4}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 7.0/3.0)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 0.6236095644623235)
+	X := []float64{0, 1.5, 2, 3, 5}
+	Ypdf := []float64{0, 1.0 / 3.0, 2.0 / 3.0, 1.0 / 3.0, 0}
+	Ycdf := []float64{0, 1.0 / 12.0, 1.0 / 3.0, 5.0 / 6.0, 1}
+	for i, x := range X {
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), Ypdf[i])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[i])
+	}
+	checkDistrib(tst, &dist, []float64{1.2, 1.8, 2, 2.5, 3.9}, p.M, p.S, 1e-14, 1e-13, false)
+
+	// mode at the limits
+	for _, mode := range []float64{1, 4} {
+		p = &VarData{Min: 1, Mode: mode, Max: 4}
+		dist.Init(p)
+		chk.Scalar(tst, "pdf(mode)", 1e-15, dist.Pdf(mode), 2.0/3.0)
+		checkDistrib(tst, &dist, []float64{1, 1.5, 3, 4}, p.M, p.S, 1e-14, 1e-13, false)
+	}
+
+	// errors
+	if dist.Init(&VarData{Min: 1, Mode: 5, Max: 4}) == nil {
+		tst.Errorf("Init should have failed with mode outside [min, max]\n")
+	}
+	if !math.IsInf(dist.LogPdf(0), -1) {
+		tst.Errorf("LogPdf(0) should be -Inf\n")
+	}
+}
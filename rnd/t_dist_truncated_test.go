@@ -0,0 +1,79 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_truncated_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_truncated_01. truncated normal")
+
+	var dist DistTruncated
+	p := &VarData{Base: D_Normal, M: 0, S: 1, Min: -1, Max: 2}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	z := StdPhi(2) - StdPhi(-1)
+	for _, x := range []float64{-2, -1, 0, 1.5, 2, 3} {
+		pdf, cdf := 0.0, 0.0
+		if x > -1 && x <= 2 {
+			pdf, cdf = Stdphi(x)/z, (StdPhi(x)-StdPhi(-1))/z
+		}
+		if x > 2 {
+			cdf = 1
+		}
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), pdf)
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), cdf)
+	}
+
+	// mean and deviation of truncated normal
+	a, b := -1.0, 2.0
+	μ := (Stdphi(a) - Stdphi(b)) / z
+	σ := math.Sqrt(1 + (a*Stdphi(a)-b*Stdphi(b))/z - μ*μ)
+	checkDistrib(tst, &dist, []float64{-0.99, -0.5, 0, 1, 1.99}, μ, σ, 1e-14, 1e-12, false)
+
+	// errors
+	if dist.Init(&VarData{Base: D_Uniform, Min: 0, Max: 1}) == nil {
+		tst.Errorf("Init should have failed with uniform distribution\n")
+	}
+	if dist.Init(&VarData{Base: D_Normal, M: 0, S: 1, Min: 1, Max: 1}) == nil {
+		tst.Errorf("Init should have failed with Min = Max\n")
+	}
+}
+
+func Test_dist_truncated_02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_truncated_02. zero-truncated Poisson")
+
+	var dist DistTruncated
+	λ := 1.5
+	p := &VarData{Base: D_Poisson, M: λ, Min: 0, Max: math.Inf(1)}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "pmf(0)", 1e-15, dist.Pdf(0), 0)
+	for k := 1; k < 8; k++ {
+		lf, _ := math.Lgamma(float64(k) + 1)
+		pmf := math.Exp(float64(k)*math.Log(λ)-λ-lf) / (1 - math.Exp(-λ))
+		chk.Scalar(tst, "pmf", 1e-15, dist.Pdf(float64(k)), pmf)
+	}
+	chk.Scalar(tst, "Q(1e-9)", 1e-15, dist.Quantile(1e-9), 1)
+
+	// mean and deviation of zero-truncated Poisson
+	μ := λ / (1 - math.Exp(-λ))
+	σ := math.Sqrt(μ * (1 + λ - μ))
+	checkDistrib(tst, &dist, []float64{0, 1, 2, 5, 10}, μ, σ, 0, 1e-15, true)
+}
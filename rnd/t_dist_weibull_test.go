@@ -0,0 +1,56 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dist_weibull_01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dist_weibull_01")
+
+	var dist DistWeibull
+	p := &VarData{C: 2, A: 1.5}
+	err := dist.Init(p)
+	if err != nil {
+		tst.Errorf("Init failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "μ", 1e-15, p.M, 1.8054905859018673)
+	chk.Scalar(tst, "σ", 1e-15, p.S, 1.2258715835093523)
+
+	X := []float64{0.5, 1, 2, 4}
+	Ypdf := []float64{0.3309363384692233, 0.37239168821942203, 0.27590958087858175, 0.06269111130157907}
+	Ycdf := []float64{0.11750309741540454, 0.29781149867344037, 0.6321205588285577, 0.9408942534380438}
+	for i, x := range X {
+		chk.Scalar(tst, "pdf", 1e-15, dist.Pdf(x), Ypdf[i])
+		chk.Scalar(tst, "cdf", 1e-15, dist.Cdf(x), Ycdf[i])
+	}
+	chk.Scalar(tst, "pdf(-1)", 1e-15, dist.Pdf(-1), 0)
+	chk.Scalar(tst, "cdf(-1)", 1e-15, dist.Cdf(-1), 0)
+	checkDistrib(tst, &dist, []float64{0.1, 1, 2, 3, 6}, p.M, p.S, 1e-14, 1e-10, false)
+
+	// shape = 1 ⇒ exponential
+	dist.Init(&VarData{L: 1, C: 2, A: 1})
+	var expo DistExponential
+	expo.Init(&VarData{L: 1, M: 3})
+	for _, x := range []float64{0, 1, 2, 5} {
+		chk.Scalar(tst, "pdf(exponential)", 1e-15, dist.Pdf(x), expo.Pdf(x))
+		chk.Scalar(tst, "cdf(exponential)", 1e-15, dist.Cdf(x), expo.Cdf(x))
+	}
+
+	// errors
+	if dist.Init(&VarData{A: -1}) == nil {
+		tst.Errorf("Init should have failed with negative shape\n")
+	}
+	if !math.IsInf(dist.Quantile(1), 1) {
+		tst.Errorf("Q(1) should be +Inf\n")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// checkDistrib checks LogPdf and Quantile against Pdf and Cdf, Cdf against the integral of Pdf
+// between consecutive points in X, and the mean and deviation of samples against μ and σ
+func checkDistrib(tst *testing.T, d Distribution, X []float64, μ, σ, tolQ, tolInt float64, discrete bool) {
+
+	// LogPdf and Quantile
+	for _, x := range X {
+		if f := d.Pdf(x); f > 0 {
+			chk.AnaNum(tst, io.Sf("log(f(%g))", x), 1e-13, d.LogPdf(x), math.Log(f), chk.Verbose)
+		}
+		F := d.Cdf(x)
+		if F <= 0 || F >= 1 {
+			continue
+		}
+		if discrete {
+			xx := math.Floor(x)
+			chk.Scalar(tst, io.Sf("Q(F(%g))", x), 1e-15, d.Quantile(F), xx)
+			chk.Scalar(tst, io.Sf("Q(F(%g)+)", x), 1e-15, d.Quantile(F*(1+1e-12)), xx+1)
+		} else {
+			chk.AnaNum(tst, io.Sf("Q(F(%g))", x), tolQ*(1+math.Abs(x)), d.Quantile(F), x, chk.Verbose)
+		}
+	}
+
+	// Cdf(b) - Cdf(a) = ∫ Pdf
+	for i := 1; i < len(X); i++ {
+		a, b := X[i-1], X[i]
+		var sum float64
+		if discrete {
+			for k := math.Floor(a) + 1; k <= b; k++ {
+				sum += d.Pdf(k)
+			}
+		} else { // Simpson's rule
+			n := 400
+			h := (b - a) / float64(n)
+			sum = d.Pdf(a) + d.Pdf(b)
+			for j := 1; j < n; j++ {
+				sum += float64(2+2*(j%2)) * d.Pdf(a+float64(j)*h)
+			}
+			sum *= h / 3.0
+		}
+		chk.AnaNum(tst, io.Sf("∫f [%g,%g]", a, b), tolInt, d.Cdf(b)-d.Cdf(a), sum, chk.Verbose)
+	}
+
+	// samples
+	if math.IsInf(σ, 0) || math.IsNaN(σ) {
+		return
+	}
+	n := 40000
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = d.Sample()
+	}
+	xave, xdev := StatAveDev(x, true)
+	chk.AnaNum(tst, "ave(samples)", 6*σ/math.Sqrt(float64(n)), xave, μ, chk.Verbose)
+	chk.AnaNum(tst, "dev(samples)", 0.05*σ, xdev, σ, chk.Verbose)
+}
+
+func Test_distribution01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("distribution01. LogPdf, Quantile and Sample")
+
+	var normal DistNormal
+	normal.Init(&VarData{M: 2, S: 0.5})
+	checkDistrib(tst, &normal, []float64{0, 1, 1.5, 2, 2.5, 3.5}, 2, 0.5, 1e-14, 1e-11, false)
+	if !math.IsInf(normal.Quantile(0), -1) {
+		tst.Errorf("Q(0) should be -Inf\n")
+	}
+	chk.Scalar(tst, "Q(0.975)", 1e-15, normal.Quantile(0.975), 2+0.5*1.959963984540054)
+
+	p := &VarData{M: 2, S: 0.5}
+	var lognormal DistLogNormal
+	lognormal.Init(p)
+	checkDistrib(tst, &lognormal, []float64{0.5, 1, 2, 3, 5}, 2, 0.5, 1e-12, 1e-10, false)
+	chk.Scalar(tst, "Q(0.5)", 1e-15, lognormal.Quantile(0.5), math.Exp(lognormal.N))
+
+	var gumbel DistGumbel
+	gumbel.Init(&VarData{M: 61.3, S: 7.52})
+	checkDistrib(tst, &gumbel, []float64{40, 50, 60, 70, 90}, 61.3, 7.52, 1e-14, 1e-10, false)
+
+	var frechet DistFrechet
+	p = &VarData{L: 8.782275, C: 1, A: 4}
+	frechet.Init(p)
+	checkDistrib(tst, &frechet, []float64{9, 9.5, 10, 11, 13}, p.M, p.S, 1e-14, 1e-10, false)
+
+	var uniform DistUniform
+	uniform.Init(&VarData{Min: -1, Max: 3})
+	checkDistrib(tst, &uniform, []float64{-0.5, 0, 1, 2.5}, 1, 4/math.Sqrt(12), 1e-15, 1e-15, false)
+	if !math.IsInf(uniform.LogPdf(4), -1) {
+		tst.Errorf("LogPdf(4) should be -Inf\n")
+	}
+	if !math.IsNaN(uniform.Quantile(1.1)) {
+		tst.Errorf("Q(1.1) should be NaN\n")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_fitting01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("fitting01. closed-form estimates")
+
+	x := []float64{1, 2, 3, 4, 5, 6}
+
+	// normal
+	p, err := FitMle(D_Normal, x)
+	if err != nil {
+		tst.Errorf("FitMle failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "normal(mle): μ", 1e-15, p.M, 3.5)
+	chk.Scalar(tst, "normal(mle): σ", 1e-15, p.S, math.Sqrt(17.5/6.0))
+	p, err = FitMoments(D_Normal, x)
+	if err != nil {
+		tst.Errorf("FitMoments failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "normal(mom): σ", 1e-15, p.S, math.Sqrt(17.5/5.0))
+	chk.Scalar(tst, "normal(mom): Distr.Sig", 1e-15, p.Distr.(*DistNormal).Sig, p.S)
+
+	// uniform
+	p, _ = FitMle(D_Uniform, x)
+	chk.Scalar(tst, "uniform(mle): min", 1e-15, p.Min, 1)
+	chk.Scalar(tst, "uniform(mle): max", 1e-15, p.Max, 6)
+	p, _ = FitMoments(D_Uniform, x)
+	chk.Scalar(tst, "uniform(mom): min+max", 1e-15, p.Min+p.Max, 7)
+
+	// exponential and Poisson
+	p, _ = FitMle(D_Exponential, x)
+	chk.Scalar(tst, "exponential(mle): λ", 1e-15, p.Distr.(*DistExponential).Lam, 1.0/3.5)
+	p, _ = FitMle(D_Poisson, x)
+	chk.Scalar(tst, "Poisson(mle): λ", 1e-15, p.Distr.(*DistPoisson).Lam, 3.5)
+
+	// Pareto
+	p, _ = FitMle(D_Pareto, x)
+	var sum float64
+	for _, xi := range x {
+		sum += math.Log(xi)
+	}
+	chk.Scalar(tst, "Pareto(mle): xm", 1e-15, p.C, 1)
+	chk.Scalar(tst, "Pareto(mle): α", 1e-15, p.A, 6/sum)
+
+	// gamma: ln(k) - ψ(k) = ln(ave(x)) - ave(ln(x))
+	p, _ = FitMle(D_Gamma, x)
+	chk.Scalar(tst, "gamma(mle): ln(k)-ψ(k)", 1e-14, math.Log(p.A)-fun.Digamma(p.A), math.Log(3.5)-sum/6)
+	chk.Scalar(tst, "gamma(mle): k θ", 1e-14, p.A*p.C, 3.5)
+
+	// beta: ψ(α) - ψ(α+β) = ave(ln(x)) and ψ(β) - ψ(α+β) = ave(ln(1-x))
+	y := []float64{0.1, 0.25, 0.3, 0.45, 0.5, 0.8}
+	p, err = FitMle(D_Beta, y)
+	if err != nil {
+		tst.Errorf("FitMle failed:\n%v\n", err)
+		return
+	}
+	var g1, g2 float64
+	for _, yi := range y {
+		g1 += math.Log(yi) / 6
+		g2 += math.Log(1-yi) / 6
+	}
+	chk.Scalar(tst, "beta(mle): g1", 1e-14, fun.Digamma(p.A)-fun.Digamma(p.A+p.B), g1)
+	chk.Scalar(tst, "beta(mle): g2", 1e-14, fun.Digamma(p.B)-fun.Digamma(p.A+p.B), g2)
+
+	// triangular
+	p, _ = FitMle(D_Triangular, []float64{0, 1, 1.5, 2, 2.2, 3, 5})
+	chk.Scalar(tst, "triangular(mle): min", 1e-15, p.Min, 0)
+	chk.Scalar(tst, "triangular(mle): max", 1e-15, p.Max, 5)
+	chk.Scalar(tst, "triangular(mle): mode", 1e-15, p.Mode, 1.5)
+
+	// errors
+	if _, err = FitMle(D_Gamma, []float64{1, -1, 2}); err == nil {
+		tst.Errorf("FitMle should have failed with negative data\n")
+	}
+	if _, err = FitMle(D_Truncated, x); err == nil {
+		tst.Errorf("FitMle should have failed with truncated distribution\n")
+	}
+	if _, err = FitMoments(D_Binomial, []float64{0, 10, 0, 10}); err == nil {
+		tst.Errorf("FitMoments should have failed with over-dispersed data\n")
+	}
+}
+
+func Test_fitting02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("fitting02. samples")
+
+	cases := []*VarData{
+		{D: D_Normal, M: 10, S: 2},
+		{D: D_Lognormal, M: 10, S: 2},
+		{D: D_Gumbel, M: 61.3, S: 7.52},
+		{D: D_Frechet, C: 3, A: 6},
+		{D: D_Uniform, Min: -1, Max: 3},
+		{D: D_Weibull, C: 2, A: 1.5},
+		{D: D_Gamma, A: 3, C: 2},
+		{D: D_Beta, A: 2, B: 5},
+		{D: D_Exponential, M: 4},
+		{D: D_StudentT, Nu: 10, L: 1, C: 2},
+		{D: D_ChiSquared, Nu: 4},
+		{D: D_Triangular, Min: 1, Mode: 2, Max: 4},
+		{D: D_Pareto, A: 5, C: 2},
+		{D: D_Poisson, M: 3.5},
+		{D: D_Binomial, N: 20, P: 0.3},
+	}
+
+	n := 20000
+	x := make([]float64, n)
+	for _, p := range cases {
+
+		// samples
+		err := p.initDistr()
+		if err != nil {
+			tst.Errorf("initDistr failed:\n%v\n", err)
+			return
+		}
+		for i := 0; i < n; i++ {
+			x[i] = p.Distr.Sample()
+		}
+
+		// fit and compare cumulative probabilities
+		name := GetDistrName(p.D)
+		for _, method := range []string{"mom", "mle"} {
+			fit := FitMoments
+			if method == "mle" {
+				fit = FitMle
+			}
+			q, err := fit(p.D, x)
+			if err != nil {
+				tst.Errorf("%s(%s) failed:\n%v\n", name, method, err)
+				return
+			}
+			for _, P := range []float64{0.1, 0.25, 0.5, 0.75, 0.9} {
+				xp := p.Distr.Quantile(P)
+				chk.AnaNum(tst, io.Sf("%s(%s): F(%g)", name, method, xp), 0.025, q.Distr.Cdf(xp), p.Distr.Cdf(xp), chk.Verbose)
+			}
+		}
+	}
+}
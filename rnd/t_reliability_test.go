@@ -0,0 +1,282 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_reliab01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("reliab01. FORM: linear limit state with normal variables")
+
+	// g = R - S
+	gfcn := func(x []float64) (float64, error) { return x[0] - x[1], nil }
+	dgdx := func(dgdx, x []float64) error {
+		dgdx[0], dgdx[1] = 1, -1
+		return nil
+	}
+
+	// uncorrelated
+	vars := Variables{
+		&VarData{D: D_Normal, M: 200, S: 20},
+		&VarData{D: D_Normal, M: 100, S: 30},
+	}
+	nataf, err := NewNataf(vars, nil)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	for _, grad := range []LimitStateGrad{nil, dgdx} {
+		rel := NewReliability(nataf, gfcn, grad)
+		rel.Verbose = chk.Verbose
+		err = rel.Form(nil)
+		if err != nil {
+			tst.Errorf("Form failed:\n%v\n", err)
+			return
+		}
+		β := 100 / math.Sqrt(1300)
+		chk.Scalar(tst, "β", 1e-9, rel.Beta, β)
+		chk.Scalar(tst, "Pf", 1e-12, rel.Pf, StdPhi(-β))
+		chk.Vector(tst, "α", 1e-9, rel.Alpha, []float64{-20 / math.Sqrt(1300), 30 / math.Sqrt(1300)})
+		chk.Vector(tst, "γ", 1e-9, rel.Gamma, rel.Alpha)
+		chk.Vector(tst, "x*", 1e-6, rel.Xstar, []float64{200 - 20*β*20/math.Sqrt(1300), 100 + 30*β*30/math.Sqrt(1300)})
+		err = rel.Sorm()
+		if err != nil {
+			tst.Errorf("Sorm failed:\n%v\n", err)
+			return
+		}
+		chk.Scalar(tst, "κ", 1e-5, rel.Kappa[0], 0)
+		chk.Scalar(tst, "Pf(Breitung)", 1e-7, rel.PfBreitung, rel.Pf)
+	}
+
+	// correlated: γ corresponds to the uncorrelated α
+	nataf, err = NewNataf(vars, [][]float64{{1, 0.5}, {0.5, 1}})
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	rel := NewReliability(nataf, gfcn, dgdx)
+	err = rel.Form(nil)
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "β(ρ=0.5)", 1e-8, rel.Beta, 100/math.Sqrt(700))
+	chk.Vector(tst, "γ(ρ=0.5)", 1e-8, rel.Gamma, []float64{-20 / math.Sqrt(1300), 30 / math.Sqrt(1300)})
+}
+
+func Test_reliab02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("reliab02. FORM: nonlinear limit state")
+
+	// g = x₀³ + x₁³ - 18
+	gfcn := func(x []float64) (float64, error) { return x[0]*x[0]*x[0] + x[1]*x[1]*x[1] - 18, nil }
+	dgdx := func(dgdx, x []float64) error {
+		dgdx[0], dgdx[1] = 3*x[0]*x[0], 3*x[1]*x[1]
+		return nil
+	}
+	vars := Variables{
+		&VarData{D: D_Normal, M: 10, S: 5},
+		&VarData{D: D_Normal, M: 9.9, S: 5},
+	}
+	nataf, err := NewNataf(vars, nil)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+
+	// reference: min distance from origin to G(u) = 0 by sweeping directions
+	rmin := func(θ float64) float64 {
+		r, _ := bisect(func(r float64) float64 {
+			g, _ := gfcn([]float64{10 + 5*r*math.Cos(θ), 9.9 + 5*r*math.Sin(θ)})
+			return g
+		}, 0, 10)
+		return r
+	}
+	θref, βref := 0.0, math.Inf(1)
+	for k := 0; k < 3600; k++ {
+		θ := math.Pi + float64(k)*math.Pi/2/3600 // third quadrant
+		if r := rmin(θ); r < βref {
+			θref, βref = θ, r
+		}
+	}
+	a, b := θref-math.Pi/3600, θref+math.Pi/3600
+	for k := 0; k < 100; k++ { // golden section
+		c := b - (b-a)*0.618033988749895
+		d := a + (b-a)*0.618033988749895
+		if rmin(c) < rmin(d) {
+			b = d
+		} else {
+			a = c
+		}
+	}
+	βref = rmin((a + b) / 2)
+	io.Pforan("βref = %v\n", βref)
+
+	// HL-RF does not converge for this problem
+	rel := NewReliability(nataf, gfcn, dgdx)
+	rel.MaxIt = 50
+	if err = rel.Form(nil); err == nil {
+		tst.Errorf("HL-RF should have failed\n")
+	}
+
+	// iHL-RF
+	rel = NewReliability(nataf, gfcn, dgdx)
+	rel.Improved = true
+	rel.Verbose = chk.Verbose
+	err = rel.Form(nil)
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("nit = %d  ng = %d\n", rel.NumIt, rel.NumG)
+	chk.Scalar(tst, "β", 1e-8, rel.Beta, βref)
+	g, _ := gfcn(rel.Xstar)
+	chk.Scalar(tst, "g(x*)", 1e-8, g, 0)
+
+	// finite differences
+	rel = NewReliability(nataf, gfcn, nil)
+	rel.Improved = true
+	err = rel.Form(nil)
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "β(fd)", 1e-7, rel.Beta, βref)
+}
+
+func Test_reliab03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("reliab03. SORM: paraboloid")
+
+	// G = β - u₂ + ½ (κ₀ u₀² + κ₁ u₁²)
+	β, κ0, κ1 := 3.0, 0.1, -0.2
+	gfcn := func(x []float64) (float64, error) {
+		return β - x[2] + (κ0*x[0]*x[0]+κ1*x[1]*x[1])/2, nil
+	}
+	vars := Variables{
+		&VarData{D: D_Normal, M: 0, S: 1},
+		&VarData{D: D_Normal, M: 0, S: 1},
+		&VarData{D: D_Normal, M: 0, S: 1},
+	}
+	nataf, err := NewNataf(vars, nil)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	rel := NewReliability(nataf, gfcn, nil)
+	err = rel.Form([]float64{0.1, 0.1, 0})
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "β", 1e-9, rel.Beta, β)
+	chk.Vector(tst, "α", 1e-7, rel.Alpha, []float64{0, 0, 1})
+	err = rel.Sorm()
+	if err != nil {
+		tst.Errorf("Sorm failed:\n%v\n", err)
+		return
+	}
+	sort.Float64s(rel.Kappa)
+	chk.Vector(tst, "κ", 1e-6, rel.Kappa, []float64{κ1, κ0})
+	pf := StdPhi(-β)
+	ψ := Stdphi(β) / pf
+	chk.Scalar(tst, "Pf(Breitung)", 1e-9, rel.PfBreitung, pf/math.Sqrt((1+β*κ0)*(1+β*κ1)))
+	chk.Scalar(tst, "Pf(HR)", 1e-9, rel.PfHR, pf/math.Sqrt((1+ψ*κ0)*(1+ψ*κ1)))
+
+	// reference: Pf = E[Φ(-β - ½ (κ₀ u₀² + κ₁ u₁²))] by the trapezoidal rule
+	var pfRef float64
+	h := 0.02
+	for i := -400; i <= 400; i++ {
+		for j := -400; j <= 400; j++ {
+			u0, u1 := float64(i)*h, float64(j)*h
+			pfRef += Stdphi(u0) * Stdphi(u1) * StdPhi(-β-(κ0*u0*u0+κ1*u1*u1)/2) * h * h
+		}
+	}
+	chk.AnaNum(tst, "Pf(Breitung) vs reference", 0.05*pfRef, rel.PfBreitung, pfRef, chk.Verbose)
+	chk.AnaNum(tst, "Pf(HR) vs reference", 0.1*pfRef, rel.PfHR, pfRef, chk.Verbose)
+
+	// importance sampling
+	res, err := rel.ImportanceSampling(20000)
+	if err != nil {
+		tst.Errorf("ImportanceSampling failed:\n%v\n", err)
+		return
+	}
+	io.Pforan("Pf: FORM = %g  Breitung = %g  HR = %g  IS = %g (cov = %g)  reference = %g\n", rel.Pf, rel.PfBreitung, rel.PfHR, res.Pf, res.Cov, pfRef)
+	chk.AnaNum(tst, "Pf(IS)", 4*res.Cov*pfRef, res.Pf, pfRef, chk.Verbose)
+}
+
+func Test_reliab04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("reliab04. simulations: lognormal variables")
+
+	// g = R - S  ⇒  failure ⇔ ln(R) - ln(S) ≤ 0; thus FORM is exact
+	vars := Variables{
+		&VarData{D: D_Lognormal, M: 200, S: 20},
+		&VarData{D: D_Lognormal, M: 100, S: 30},
+	}
+	nataf, err := NewNataf(vars, nil)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	rel := NewReliability(nataf, func(x []float64) (float64, error) { return x[0] - x[1], nil }, nil)
+	err = rel.Form(nil)
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	ζR, ζS := math.Sqrt(math.Log(1+0.01)), math.Sqrt(math.Log(1+0.09))
+	λR, λS := math.Log(200)-ζR*ζR/2, math.Log(100)-ζS*ζS/2
+	β := (λR - λS) / math.Sqrt(ζR*ζR+ζS*ζS)
+	chk.Scalar(tst, "β", 1e-8, rel.Beta, β)
+	chk.Vector(tst, "α", 1e-8, rel.Alpha, []float64{-ζR / math.Sqrt(ζR*ζR+ζS*ζS), ζS / math.Sqrt(ζR*ζR+ζS*ζS)})
+
+	// simulations
+	mc, err := rel.MonteCarlo(200000, 0.05)
+	if err != nil {
+		tst.Errorf("MonteCarlo failed:\n%v\n", err)
+		return
+	}
+	is, err := rel.ImportanceSampling(5000)
+	if err != nil {
+		tst.Errorf("ImportanceSampling failed:\n%v\n", err)
+		return
+	}
+	ss, err := rel.SubsetSimulation(2000, 0.1, 10)
+	if err != nil {
+		tst.Errorf("SubsetSimulation failed:\n%v\n", err)
+		return
+	}
+	for _, res := range []*SimResults{mc, is, ss} {
+		io.Pforan("%-20s: Pf = %.6e  cov = %.4f  β = %.4f  ng = %d\n", res.Method, res.Pf, res.Cov, res.Beta, res.NumG)
+		chk.AnaNum(tst, res.Method, 4*res.Cov*rel.Pf, res.Pf, rel.Pf, chk.Verbose)
+	}
+	if mc.Cov >= 0.05 {
+		tst.Errorf("Monte Carlo should have stopped with cov < 0.05. cov = %g\n", mc.Cov)
+	}
+	if is.Cov > 0.05 {
+		tst.Errorf("importance sampling should be efficient for linear limit states. cov = %g\n", is.Cov)
+	}
+
+	// errors
+	if _, err = rel.SubsetSimulation(1000, 0.3, 10); err == nil {
+		tst.Errorf("SubsetSimulation should have failed with 1/p0 not integer\n")
+	}
+	rel = NewReliability(nataf, func(x []float64) (float64, error) { return x[0] - x[1], nil }, nil)
+	if err = rel.Sorm(); err == nil {
+		tst.Errorf("Sorm should have failed before Form\n")
+	}
+}
@@ -59,3 +59,49 @@ func Test_report01(tst *testing.T) {
 		ReportVariables(dirout, fnkey, sets, genPDF)
 	}
 }
+
+func Test_report02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Report02. reliability analysis")
+
+	vars := Variables{
+		&VarData{D: D_Lognormal, M: 200, S: 20},
+		&VarData{D: D_Gumbel, M: 100, S: 30},
+	}
+	nataf, err := NewNataf(vars, [][]float64{{1, 0.3}, {0.3, 1}})
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	rel := NewReliability(nataf, func(x []float64) (float64, error) { return x[0] - x[1], nil }, nil)
+	err = rel.Form(nil)
+	if err != nil {
+		tst.Errorf("Form failed:\n%v\n", err)
+		return
+	}
+	err = rel.Sorm()
+	if err != nil {
+		tst.Errorf("Sorm failed:\n%v\n", err)
+		return
+	}
+
+	if chk.Verbose {
+
+		dirout := "/tmp"
+		fnkey := "gosl-report02"
+		genPDF := true
+
+		is, err := rel.ImportanceSampling(10000)
+		if err != nil {
+			tst.Errorf("ImportanceSampling failed:\n%v\n", err)
+			return
+		}
+		ss, err := rel.SubsetSimulation(1000, 0.1, 10)
+		if err != nil {
+			tst.Errorf("SubsetSimulation failed:\n%v\n", err)
+			return
+		}
+		ReportReliability(dirout, fnkey, vars, rel, []*SimResults{is, ss}, genPDF)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/plt"
+)
+
+func Test_sobol01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("sobol01. Sobol Points")
+
+	P := SobolPoints(3, 8)
+	chk.Vector(tst, "x0", 1e-17, P[0], []float64{0, 0.5, 0.75, 0.25, 0.375, 0.875, 0.625, 0.125})
+	chk.Vector(tst, "x1", 1e-17, P[1], []float64{0, 0.5, 0.25, 0.75, 0.375, 0.875, 0.125, 0.625})
+	chk.Vector(tst, "x2", 1e-17, P[2], []float64{0, 0.5, 0.25, 0.75, 0.625, 0.125, 0.875, 0.375})
+
+	// stratification: with n = 2ᵐ points, each interval [k/n, (k+1)/n) of each coordinate has one point
+	n := 1024
+	P = SobolPoints(SobolMaxDim, n)
+	for j := 0; j < SobolMaxDim; j++ {
+		cnt := make([]int, n)
+		for i := 0; i < n; i++ {
+			cnt[int(P[j][i]*float64(n))]++
+		}
+		for k := 0; k < n; k++ {
+			if cnt[k] != 1 {
+				tst.Errorf("dimension %d: interval %d has %d points\n", j, k, cnt[k])
+				return
+			}
+		}
+	}
+
+	if chk.Verbose {
+		plt.Reset(false, nil)
+		plt.Plot(P[0][:256], P[1][:256], &plt.A{C: "b", M: ".", Ls: "none"})
+		plt.Equal()
+		plt.Save("/tmp/gosl", "sobol01")
+	}
+}
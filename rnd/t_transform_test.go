@@ -0,0 +1,130 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// checkIsoTransform checks XtoU(UtoX(u)) = u and the Jacobian against finite differences
+func checkIsoTransform(tst *testing.T, trans IsoTransform, U [][]float64, tolJ float64) {
+	n := trans.Ndim()
+	x, u := make([]float64, n), make([]float64, n)
+	xp, xm, up := make([]float64, n), make([]float64, n), make([]float64, n)
+	J := la.MatAlloc(n, n)
+	for _, u0 := range U {
+		err := trans.UtoX(x, u0)
+		if err != nil {
+			tst.Errorf("UtoX failed:\n%v\n", err)
+			return
+		}
+		err = trans.XtoU(u, x)
+		if err != nil {
+			tst.Errorf("XtoU failed:\n%v\n", err)
+			return
+		}
+		chk.Vector(tst, io.Sf("u(x(%v))", u0), 1e-12, u, u0)
+		err = trans.Jacobian(J, u0)
+		if err != nil {
+			tst.Errorf("Jacobian failed:\n%v\n", err)
+			return
+		}
+		h := 1e-5
+		for j := 0; j < n; j++ {
+			copy(up, u0)
+			up[j] = u0[j] + h
+			trans.UtoX(xp, up)
+			up[j] = u0[j] - h
+			trans.UtoX(xm, up)
+			for i := 0; i < n; i++ {
+				chk.AnaNum(tst, io.Sf("dx%d/du%d @ %v", i, j, u0), tolJ, J[i][j], (xp[i]-xm[i])/(2*h), chk.Verbose)
+			}
+		}
+	}
+}
+
+func Test_transform01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("transform01. Nataf: equivalent correlation")
+
+	// normal variables: ρz = ρ
+	vars := Variables{
+		&VarData{D: D_Normal, M: 200, S: 20},
+		&VarData{D: D_Normal, M: 100, S: 30},
+	}
+	rho := [][]float64{{1, 0.5}, {0.5, 1}}
+	nataf, err := NewNataf(vars, rho)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "normal: ρz", 1e-9, nataf.Rz[0][1], 0.5)
+
+	// lognormal variables: ρz = ln(1 + ρ δ₀ δ₁) / sqrt(ln(1 + δ₀²) ln(1 + δ₁²))
+	vars = Variables{
+		&VarData{D: D_Lognormal, M: 10, S: 2},
+		&VarData{D: D_Lognormal, M: 5, S: 1.5},
+		&VarData{D: D_Gumbel, M: 20, S: 4},
+	}
+	rho = [][]float64{{1, 0.6, -0.3}, {0.6, 1, 0}, {-0.3, 0, 1}}
+	nataf, err = NewNataf(vars, rho)
+	if err != nil {
+		tst.Errorf("NewNataf failed:\n%v\n", err)
+		return
+	}
+	δ0, δ1 := 0.2, 0.3
+	chk.Scalar(tst, "lognormal: ρz", 1e-8, nataf.Rz[0][1], math.Log(1+0.6*δ0*δ1)/math.Sqrt(math.Log(1+δ0*δ0)*math.Log(1+δ1*δ1)))
+	chk.Scalar(tst, "ρz[1][2]", 1e-15, nataf.Rz[1][2], 0)
+	if math.Abs(nataf.Rz[0][2]) <= 0.3 {
+		tst.Errorf("|ρz| should be greater than |ρ| = 0.3 for lognormal-Gumbel. ρz = %g\n", nataf.Rz[0][2])
+	}
+	checkIsoTransform(tst, nataf, [][]float64{{0, 0, 0}, {1, -0.5, 2}, {-2, 1.5, -1}}, 1e-7)
+
+	// errors
+	if _, err = NewNataf(vars[:2], [][]float64{{1, 0.999}, {0.999, 1}}); err == nil {
+		tst.Errorf("NewNataf should have failed with unattainable correlation\n")
+	}
+	if _, err = NewNataf(vars[:2], [][]float64{{1, 0.5}, {0.4, 1}}); err == nil {
+		tst.Errorf("NewNataf should have failed with non-symmetric matrix\n")
+	}
+}
+
+func Test_transform02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("transform02. Rosenblatt")
+
+	// x₀ ~ Exponential(λ=1) and x₁ | x₀ ~ Normal(x₀, 1)
+	trans := NewRosenblatt(2, func(i int, x []float64) (d Distribution, err error) {
+		if i == 0 {
+			d = new(DistExponential)
+			err = d.Init(&VarData{M: 1})
+			return
+		}
+		d = new(DistNormal)
+		err = d.Init(&VarData{M: x[0], S: 1})
+		return
+	})
+	u := make([]float64, 2)
+	err := trans.XtoU(u, []float64{0.5, 2})
+	if err != nil {
+		tst.Errorf("XtoU failed:\n%v\n", err)
+		return
+	}
+	chk.Scalar(tst, "u0", 1e-15, u[0], stdQuantile(1-math.Exp(-0.5)))
+	chk.Scalar(tst, "u1", 1e-14, u[1], 1.5)
+	checkIsoTransform(tst, trans, [][]float64{{0, 0}, {1, -0.5}, {-1.5, 2}}, 1e-6)
+
+	// error: outside support
+	if err = trans.XtoU(u, []float64{-1, 0}); err == nil {
+		tst.Errorf("XtoU should have failed with x0 < 0\n")
+	}
+}
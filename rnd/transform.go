@@ -0,0 +1,311 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// IsoTransform defines an isoprobabilistic transformation between the physical space (x) and the
+// space of independent standard normal variables (u)
+type IsoTransform interface {
+	Ndim() int                                       // number of random variables
+	XtoU(u, x []float64) (err error)                 // computes u(x)
+	UtoX(x, u []float64) (err error)                 // computes x(u)
+	Jacobian(J [][]float64, u []float64) (err error) // computes J = dx/du @ u
+}
+
+// Nataf implements the Nataf transformation: the marginal distributions are transformed to
+// correlated standard normal variables z, which are then decorrelated by means of the Cholesky
+// factorisation of the equivalent correlation matrix Rz = L Lᵀ:
+//
+//   zᵢ = Φ⁻¹(Fᵢ(xᵢ))   and   u = L⁻¹ z
+//
+//  NOTE: with uncorrelated variables, this is the Rosenblatt transformation of independent variables
+//  Reference:
+//   Liu PL and Der Kiureghian A (1986) Multivariate distribution models with prescribed marginals
+//   and covariances, Probabilistic Engineering Mechanics, 1(2):105-112
+type Nataf struct {
+	Vars Variables   // random variables (marginal distributions)
+	Rho  [][]float64 // correlation matrix in physical space; nil means uncorrelated variables
+	Rz   [][]float64 // equivalent correlation matrix in the standard normal space
+	L    [][]float64 // lower triangular matrix: Rz = L Lᵀ
+
+	// auxiliary
+	z []float64 // correlated standard normal variables
+}
+
+// NewNataf returns a new Nataf transformation
+//  Input:
+//   vars -- random variables; they are initialised here if Distr == nil
+//   rho  -- correlation matrix of x; may be nil for uncorrelated variables
+func NewNataf(vars Variables, rho [][]float64) (o *Nataf, err error) {
+
+	// check variables
+	n := len(vars)
+	if n < 1 {
+		return nil, chk.Err("Nataf transformation requires at least one random variable\n")
+	}
+	for _, v := range vars {
+		if v.Distr == nil {
+			err = v.initDistr()
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// allocate
+	o = &Nataf{Vars: vars, Rho: rho, Rz: la.MatAlloc(n, n), L: la.MatAlloc(n, n), z: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		o.Rz[i][i] = 1
+	}
+
+	// equivalent correlation coefficients
+	if rho != nil {
+		if len(rho) != n {
+			return nil, chk.Err("correlation matrix must be %d x %d\n", n, n)
+		}
+		var grid *natafGrid
+		for i := 0; i < n; i++ {
+			if len(rho[i]) != n || math.Abs(rho[i][i]-1) > 1e-15 {
+				return nil, chk.Err("correlation matrix must be %d x %d with unit diagonal\n", n, n)
+			}
+			for j := 0; j < i; j++ {
+				if math.Abs(rho[i][j]-rho[j][i]) > 1e-15 || math.Abs(rho[i][j]) >= 1 {
+					return nil, chk.Err("correlation matrix must be symmetric with |ρij| < 1. ρ[%d][%d]=%g\n", i, j, rho[i][j])
+				}
+				if rho[i][j] == 0 {
+					continue
+				}
+				if grid == nil {
+					grid = newNatafGrid(vars)
+				}
+				o.Rz[i][j], err = grid.equivCorrelation(i, j, rho[i][j])
+				if err != nil {
+					return nil, chk.Err("cannot compute equivalent correlation between x%d and x%d:\n%v", i, j, err)
+				}
+				o.Rz[j][i] = o.Rz[i][j]
+			}
+		}
+	}
+
+	// factorisation
+	err = la.Cholesky(o.L, o.Rz)
+	if err != nil {
+		return nil, chk.Err("equivalent correlation matrix is not positive-definite:\n%v", err)
+	}
+	return
+}
+
+// Ndim returns the number of random variables
+func (o *Nataf) Ndim() int {
+	return len(o.Vars)
+}
+
+// XtoU computes u(x)
+func (o *Nataf) XtoU(u, x []float64) (err error) {
+	for i, v := range o.Vars {
+		F := v.Distr.Cdf(x[i])
+		if F <= 0 || F >= 1 {
+			return chk.Err("x%d = %g is outside the support of its distribution (F = %g)\n", i, x[i], F)
+		}
+		o.z[i] = stdQuantile(F)
+	}
+	for i := range u { // solve L u = z
+		u[i] = o.z[i]
+		for j := 0; j < i; j++ {
+			u[i] -= o.L[i][j] * u[j]
+		}
+		u[i] /= o.L[i][i]
+	}
+	return
+}
+
+// UtoX computes x(u)
+func (o *Nataf) UtoX(x, u []float64) (err error) {
+	la.MatVecMul(o.z, 1, o.L, u)
+	for i, v := range o.Vars {
+		x[i] = v.Distr.Quantile(openPhi(o.z[i]))
+		if math.IsInf(x[i], 0) || math.IsNaN(x[i]) {
+			return chk.Err("cannot compute x%d with z%d = %g\n", i, i, o.z[i])
+		}
+	}
+	return
+}
+
+// Jacobian computes J = dx/du = diag(φ(zᵢ) / fᵢ(xᵢ)) L @ u
+func (o *Nataf) Jacobian(J [][]float64, u []float64) (err error) {
+	la.MatVecMul(o.z, 1, o.L, u)
+	for i, v := range o.Vars {
+		x := v.Distr.Quantile(openPhi(o.z[i]))
+		f := v.Distr.Pdf(x)
+		if f <= 0 || math.IsInf(x, 0) {
+			return chk.Err("cannot compute Jacobian: density of x%d is zero @ x%d = %g\n", i, i, x)
+		}
+		d := Stdphi(o.z[i]) / f
+		for j := range u {
+			J[i][j] = d * o.L[i][j]
+		}
+	}
+	return
+}
+
+// natafGrid holds standardised variables at a grid of standard normal values z ∈ [-zmax, zmax]
+// to compute equivalent correlation coefficients with the trapezoidal rule; i.e. given
+//
+//   ρij = ∫∫ hᵢ(zᵢ) hⱼ(zⱼ) φ₂(zᵢ, zⱼ; ρz) dzᵢ dzⱼ   with   hᵢ(z) = (Fᵢ⁻¹(Φ(z)) - μᵢ) / σᵢ
+//
+// find ρz. The means and deviations are computed with the same rule for consistency
+type natafGrid struct {
+	z []float64   // grid
+	h [][]float64 // [nvars][ngrid] standardised variables
+}
+
+// newNatafGrid allocates a new grid
+func newNatafGrid(vars Variables) (o *natafGrid) {
+	zmax, dz := 8.0, 0.1
+	m := int(2*zmax/dz+0.5) + 1
+	o = &natafGrid{z: make([]float64, m), h: make([][]float64, len(vars))}
+	for a := 0; a < m; a++ {
+		o.z[a] = -zmax + float64(a)*dz
+	}
+	for i, v := range vars {
+		o.h[i] = make([]float64, m)
+		var μ, μ2, sw float64
+		for a, z := range o.z {
+			o.h[i][a] = v.Distr.Quantile(StdPhi(z))
+			w := Stdphi(z)
+			sw += w
+			μ += w * o.h[i][a]
+			μ2 += w * o.h[i][a] * o.h[i][a]
+		}
+		μ /= sw
+		σ := math.Sqrt(μ2/sw - μ*μ)
+		for a := range o.z {
+			o.h[i][a] = (o.h[i][a] - μ) / σ
+		}
+	}
+	return
+}
+
+// correlation computes ρij for a given ρz
+func (o *natafGrid) correlation(i, j int, ρz float64) float64 {
+	c := 1.0 - ρz*ρz
+	var sum, sw float64
+	for a, za := range o.z {
+		for b, zb := range o.z {
+			w := math.Exp(-(za*za - 2.0*ρz*za*zb + zb*zb) / (2.0 * c))
+			sw += w
+			sum += w * o.h[i][a] * o.h[j][b]
+		}
+	}
+	return sum / sw
+}
+
+// equivCorrelation computes ρz such that the correlation between xᵢ and xⱼ is equal to ρ
+func (o *natafGrid) equivCorrelation(i, j int, ρ float64) (ρz float64, err error) {
+	lim := 0.999
+	for _, v := range [][]float64{o.h[i], o.h[j]} {
+		for _, h := range v {
+			if math.IsInf(h, 0) || math.IsNaN(h) {
+				return 0, chk.Err("standardised variables cannot be computed (infinite moments?)\n")
+			}
+		}
+	}
+	return bisect(func(ρz float64) float64 {
+		return o.correlation(i, j, ρz) - ρ
+	}, -lim, lim)
+}
+
+// Rosenblatt implements the Rosenblatt transformation based on conditional distributions:
+//
+//   u₀ = Φ⁻¹(F₀(x₀)),  u₁ = Φ⁻¹(F₁(x₁|x₀)),  u₂ = Φ⁻¹(F₂(x₂|x₀,x₁)), ...
+//
+//  NOTE: the Jacobian is computed with finite differences
+//  Reference:
+//   Rosenblatt M (1952) Remarks on a multivariate transformation, The Annals of Mathematical
+//   Statistics, 23(3):470-472
+type Rosenblatt struct {
+	N    int                                            // number of random variables
+	Cond func(i int, x []float64) (Distribution, error) // distribution of xᵢ given x₀...xᵢ₋₁ (x[:i])
+	H    float64                                        // step for finite differences; default = 1e-6
+
+	// auxiliary
+	xp, xm []float64 // perturbed x
+	up     []float64 // perturbed u
+}
+
+// NewRosenblatt returns a new Rosenblatt transformation
+func NewRosenblatt(n int, cond func(i int, x []float64) (Distribution, error)) (o *Rosenblatt) {
+	return &Rosenblatt{N: n, Cond: cond, H: 1e-6, xp: make([]float64, n), xm: make([]float64, n), up: make([]float64, n)}
+}
+
+// Ndim returns the number of random variables
+func (o *Rosenblatt) Ndim() int {
+	return o.N
+}
+
+// XtoU computes u(x)
+func (o *Rosenblatt) XtoU(u, x []float64) (err error) {
+	for i := 0; i < o.N; i++ {
+		d, err := o.Cond(i, x[:i])
+		if err != nil {
+			return err
+		}
+		F := d.Cdf(x[i])
+		if F <= 0 || F >= 1 {
+			return chk.Err("x%d = %g is outside the support of its distribution (F = %g)\n", i, x[i], F)
+		}
+		u[i] = stdQuantile(F)
+	}
+	return
+}
+
+// UtoX computes x(u)
+func (o *Rosenblatt) UtoX(x, u []float64) (err error) {
+	for i := 0; i < o.N; i++ {
+		d, err := o.Cond(i, x[:i])
+		if err != nil {
+			return err
+		}
+		x[i] = d.Quantile(openPhi(u[i]))
+		if math.IsInf(x[i], 0) || math.IsNaN(x[i]) {
+			return chk.Err("cannot compute x%d with u%d = %g\n", i, i, u[i])
+		}
+	}
+	return
+}
+
+// Jacobian computes J = dx/du @ u with central differences
+func (o *Rosenblatt) Jacobian(J [][]float64, u []float64) (err error) {
+	copy(o.up, u)
+	for j := 0; j < o.N; j++ {
+		o.up[j] = u[j] + o.H
+		err = o.UtoX(o.xp, o.up)
+		if err != nil {
+			return
+		}
+		o.up[j] = u[j] - o.H
+		err = o.UtoX(o.xm, o.up)
+		if err != nil {
+			return
+		}
+		o.up[j] = u[j]
+		for i := 0; i < o.N; i++ {
+			J[i][j] = (o.xp[i] - o.xm[i]) / (2.0 * o.H)
+		}
+	}
+	return
+}
+
+// openPhi returns Φ(z) within the open interval (0,1); i.e. Φ(z) = 1 (rounding) is replaced by the
+// largest number smaller than 1 to keep the quantiles finite
+func openPhi(z float64) float64 {
+	return math.Max(math.SmallestNonzeroFloat64, math.Min(StdPhi(z), math.Nextafter(1, 0)))
+}
@@ -13,11 +13,22 @@ import (
 type DistType int
 
 const (
-	D_Normal    DistType = iota + 1 // normal
-	D_Lognormal                     // lognormal
-	D_Gumbel                        // Type I Extreme Value
-	D_Frechet                       // Type II Extreme Value
-	D_Uniform                       // uniform
+	D_Normal      DistType = iota + 1 // normal
+	D_Lognormal                       // lognormal
+	D_Gumbel                          // Type I Extreme Value
+	D_Frechet                         // Type II Extreme Value
+	D_Uniform                         // uniform
+	D_Weibull                         // Weibull / Type III Extreme Value (smallest value)
+	D_Gamma                           // gamma
+	D_Beta                            // beta
+	D_Exponential                     // exponential
+	D_StudentT                        // Student's t
+	D_ChiSquared                      // chi-squared
+	D_Triangular                      // triangular
+	D_Pareto                          // Pareto (Type I)
+	D_Poisson                         // Poisson (discrete)
+	D_Binomial                        // binomial (discrete)
+	D_Truncated                       // truncated version of another distribution
 )
 
 // VarData implements data defining one random variable
@@ -28,14 +39,24 @@ type VarData struct {
 	M float64  // mean
 	S float64  // standard deviation
 
-	// input: Frechet
+	// input: Frechet, Weibull, gamma, beta, Student's t, Pareto
 	L float64 // location
 	C float64 // scale
 	A float64 // shape
+	B float64 // second shape (beta)
 
-	// input: uniform
-	Min float64 // min value
-	Max float64 // max value
+	// input: uniform, triangular and truncated
+	Min  float64 // min value
+	Max  float64 // max value
+	Mode float64 // mode (triangular)
+
+	// input: Student's t, chi-squared and binomial
+	Nu float64 // degrees of freedom
+	N  int     // number of trials
+	P  float64 // probability of success in each trial
+
+	// input: truncated
+	Base DistType // type of distribution to be truncated within [Min, Max]
 
 	// optional
 	Key string // auxiliary indentifier
@@ -105,6 +126,28 @@ func GetDistribution(name string) DistType {
 		return D_Frechet
 	case "uniform":
 		return D_Uniform
+	case "weibull":
+		return D_Weibull
+	case "gamma":
+		return D_Gamma
+	case "beta":
+		return D_Beta
+	case "exponential":
+		return D_Exponential
+	case "studentt":
+		return D_StudentT
+	case "chisquared":
+		return D_ChiSquared
+	case "triangular":
+		return D_Triangular
+	case "pareto":
+		return D_Pareto
+	case "poisson":
+		return D_Poisson
+	case "binomial":
+		return D_Binomial
+	case "truncated":
+		return D_Truncated
 	default:
 		chk.Panic("cannot get distribution named %q", name)
 	}
@@ -124,6 +167,28 @@ func GetDistrName(typ DistType) (name string) {
 		return "frechet"
 	case D_Uniform:
 		return "uniform"
+	case D_Weibull:
+		return "weibull"
+	case D_Gamma:
+		return "gamma"
+	case D_Beta:
+		return "beta"
+	case D_Exponential:
+		return "exponential"
+	case D_StudentT:
+		return "studentt"
+	case D_ChiSquared:
+		return "chisquared"
+	case D_Triangular:
+		return "triangular"
+	case D_Pareto:
+		return "pareto"
+	case D_Poisson:
+		return "poisson"
+	case D_Binomial:
+		return "binomial"
+	case D_Truncated:
+		return "truncated"
 	default:
 		chk.Panic("cannot get distribution %v", typ)
 	}
@@ -143,6 +208,28 @@ func GetDistrKey(typ DistType) (name string) {
 		return "F"
 	case D_Uniform:
 		return "U"
+	case D_Weibull:
+		return "W"
+	case D_Gamma:
+		return "Ga"
+	case D_Beta:
+		return "Be"
+	case D_Exponential:
+		return "E"
+	case D_StudentT:
+		return "T"
+	case D_ChiSquared:
+		return "X"
+	case D_Triangular:
+		return "Tr"
+	case D_Pareto:
+		return "P"
+	case D_Poisson:
+		return "Po"
+	case D_Binomial:
+		return "Bi"
+	case D_Truncated:
+		return "Tc"
 	default:
 		chk.Panic("cannot get distribution %v", typ)
 	}